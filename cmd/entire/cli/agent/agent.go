@@ -89,6 +89,29 @@ type HookSupport interface {
 	GetSupportedHooks() []HookType
 }
 
+// StatusLineInstaller is implemented by agents that can show the output of
+// `entire statusline` in their UI. This is used by `entire enable --statusline`.
+type StatusLineInstaller interface {
+	HookSupport
+
+	// InstallStatusLine registers `entire statusline` as the agent's status
+	// line, unless the user configured one. If localDev is true, it points to
+	// the local development build. Returns true if the settings changed.
+	// UninstallHooks removes it again.
+	InstallStatusLine(localDev bool) (bool, error)
+}
+
+// HookCommandLister is implemented by agents whose installed hook commands
+// can be inspected, so `entire doctor` can check they run a binary that exists.
+type HookCommandLister interface {
+	HookSupport
+
+	// InstalledHookCommands returns the commands of the Entire hooks in the
+	// agent's settings file, without duplicates. Returns an error if the
+	// file exists but can't be parsed.
+	InstalledHookCommands() ([]string, error)
+}
+
 // HookHandler is implemented by agents that define their own hook vocabulary.
 // Each agent defines its own hook names (verbs) which become subcommands
 // under `entire hooks <agent>`. The actual handling is done by handlers
@@ -140,6 +163,45 @@ type TranscriptAnalyzer interface {
 	ExtractModifiedFilesFromOffset(path string, startOffset int) (files []string, currentPosition int, err error)
 }
 
+// ShellToolParser is implemented by agents with a shell tool whose calls can
+// be recorded as commands. Tool hooks see the tool's raw input and response,
+// and their format differs between agents.
+type ShellToolParser interface {
+	Agent
+
+	// ParseShellCommand returns the command line of a shell tool call.
+	// Returns false if toolName isn't the agent's shell tool.
+	ParseShellCommand(toolName string, toolInput []byte) (string, bool)
+
+	// ParseShellResult extracts the output and exit status from the shell
+	// tool's response.
+	ParseShellResult(toolResponse []byte) ShellResult
+}
+
+// FileEditToolParser is implemented by agents whose file-editing tool calls
+// can be checkpointed as they happen. Tool hooks see the tool's raw input,
+// and its format differs between agents.
+type FileEditToolParser interface {
+	Agent
+
+	// ParseEditedFile returns the path of the file a file-editing tool call
+	// wrote, as given by the agent (usually absolute).
+	// Returns false if toolName isn't one of the agent's file-editing tools.
+	ParseEditedFile(toolName string, toolInput []byte) (string, bool)
+}
+
+// TranscriptMerger is implemented by agents whose transcript format needs
+// format-aware merging. Agents that compact their context may rewrite the
+// live transcript, so the copy taken before compaction is merged with it.
+type TranscriptMerger interface {
+	Agent
+
+	// MergeTranscripts returns earlier followed by the entries of later that
+	// earlier doesn't already contain. earlier is always a prefix of the
+	// result, so positions in it stay valid.
+	MergeTranscripts(earlier, later []byte) ([]byte, error)
+}
+
 // TranscriptChunker is implemented by agents that support transcript chunking.
 // This allows agents to split large transcripts into chunks for storage (GitHub has
 // a 100MB blob limit) and reassemble them when reading.
@@ -115,6 +115,17 @@ func (c *ClaudeCodeAgent) ParseHookInput(hookType agent.HookType, reader io.Read
 		input.SessionID = raw.SessionID
 		input.SessionRef = raw.TranscriptPath
 
+	case agent.HookPreCompact:
+		var raw preCompactRaw
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse pre-compact input: %w", err)
+		}
+		input.SessionID = raw.SessionID
+		input.SessionRef = raw.TranscriptPath
+		if raw.Trigger != "" {
+			input.RawData["trigger"] = raw.Trigger
+		}
+
 	case agent.HookPreToolUse:
 		var raw taskHookInputRaw
 		if err := json.Unmarshal(data, &raw); err != nil {
@@ -122,6 +133,7 @@ func (c *ClaudeCodeAgent) ParseHookInput(hookType agent.HookType, reader io.Read
 		}
 		input.SessionID = raw.SessionID
 		input.SessionRef = raw.TranscriptPath
+		input.ToolName = raw.ToolName
 		input.ToolUseID = raw.ToolUseID
 		input.ToolInput = raw.ToolInput
 
@@ -132,11 +144,14 @@ func (c *ClaudeCodeAgent) ParseHookInput(hookType agent.HookType, reader io.Read
 		}
 		input.SessionID = raw.SessionID
 		input.SessionRef = raw.TranscriptPath
+		input.ToolName = raw.ToolName
 		input.ToolUseID = raw.ToolUseID
 		input.ToolInput = raw.ToolInput
+		input.ToolResponse = raw.ToolResponse
 		// Store agent ID in raw data for Task tool results
-		if raw.ToolResponse.AgentID != "" {
-			input.RawData["agent_id"] = raw.ToolResponse.AgentID
+		var taskResponse taskToolResponse
+		if json.Unmarshal(raw.ToolResponse, &taskResponse) == nil && taskResponse.AgentID != "" {
+			input.RawData["agent_id"] = taskResponse.AgentID
 		}
 	}
 
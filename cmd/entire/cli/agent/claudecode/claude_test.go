@@ -48,6 +48,28 @@ func TestParseHookInput_UserPromptSubmit(t *testing.T) {
 	}
 }
 
+func TestParseHookInput_PreCompact(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	input := `{"session_id":"sess-789","transcript_path":"/tmp/transcript.jsonl","hook_event_name":"PreCompact","trigger":"auto","custom_instructions":""}`
+
+	result, err := c.ParseHookInput(agent.HookPreCompact, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseHookInput() error = %v", err)
+	}
+
+	if result.SessionID != "sess-789" {
+		t.Errorf("SessionID = %q, want %q", result.SessionID, "sess-789")
+	}
+	if result.SessionRef != "/tmp/transcript.jsonl" {
+		t.Errorf("SessionRef = %q, want %q", result.SessionRef, "/tmp/transcript.jsonl")
+	}
+	if result.RawData["trigger"] != "auto" {
+		t.Errorf("RawData[trigger] = %v, want %q", result.RawData["trigger"], "auto")
+	}
+}
+
 func TestParseHookInput_SessionStart_NoPrompt(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,300 @@
+package claudecode
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/textutil"
+	"github.com/entireio/cli/cmd/entire/cli/transcript"
+
+	"github.com/google/uuid"
+)
+
+// Compile-time check that ClaudeCodeAgent supports session conversion.
+var _ agent.SessionConverter = (*ClaudeCodeAgent)(nil)
+
+// Claude tool names mapped to normalized tool names. Claude's tool inputs
+// already use the normalized argument names (file_path, command, ...).
+var toolNamesToNormalized = map[string]string{
+	"Read":      agent.ToolRead,
+	"Write":     agent.ToolWrite,
+	"Edit":      agent.ToolEdit,
+	"Bash":      agent.ToolShell,
+	"Glob":      agent.ToolGlob,
+	"Grep":      agent.ToolGrep,
+	"LS":        agent.ToolList,
+	"WebFetch":  agent.ToolWebFetch,
+	"WebSearch": agent.ToolWebSearch,
+}
+
+var toolNamesFromNormalized = func() map[string]string {
+	m := make(map[string]string, len(toolNamesToNormalized))
+	for native, normalized := range toolNamesToNormalized {
+		m[normalized] = native
+	}
+	return m
+}()
+
+// convertLine is a transcript line with the fields needed for conversion.
+type convertLine struct {
+	Type        string          `json:"type"`
+	UUID        string          `json:"uuid"`
+	Timestamp   time.Time       `json:"timestamp"`
+	IsMeta      bool            `json:"isMeta"`
+	IsSidechain bool            `json:"isSidechain"`
+	Message     json.RawMessage `json:"message"`
+}
+
+// convertBlock is a content block in a user or assistant message.
+type convertBlock struct {
+	Type      string          `json:"type"`
+	Text      string          `json:"text,omitempty"`
+	ID        string          `json:"id,omitempty"`
+	Name      string          `json:"name,omitempty"`
+	Input     json.RawMessage `json:"input,omitempty"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
+}
+
+// SessionToEntries parses the session's JSONL transcript into normalized entries.
+// Meta and sidechain (subagent) lines are skipped. Tool results are attached
+// to the tool entry of the matching tool_use block.
+func (c *ClaudeCodeAgent) SessionToEntries(session *agent.AgentSession) ([]agent.SessionEntry, error) {
+	if session == nil || len(session.NativeData) == 0 {
+		return nil, nil
+	}
+
+	var entries []agent.SessionEntry
+	toolIndex := make(map[string]int)
+
+	for _, raw := range bytes.Split(session.NativeData, []byte("\n")) {
+		if len(bytes.TrimSpace(raw)) == 0 {
+			continue
+		}
+		var line convertLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			// Skip malformed lines, matching ParseTranscript
+			continue
+		}
+		if line.IsMeta || line.IsSidechain || len(line.Message) == 0 {
+			continue
+		}
+
+		switch line.Type {
+		case transcript.TypeUser:
+			var msg struct {
+				Content json.RawMessage `json:"content"`
+			}
+			if err := json.Unmarshal(line.Message, &msg); err != nil {
+				continue
+			}
+			var texts []string
+			if text, ok := decodeString(msg.Content); ok {
+				texts = append(texts, text)
+			} else {
+				var blocks []convertBlock
+				if err := json.Unmarshal(msg.Content, &blocks); err != nil {
+					continue
+				}
+				for _, block := range blocks {
+					switch block.Type {
+					case transcript.ContentTypeText:
+						texts = append(texts, block.Text)
+					case "tool_result":
+						if i, ok := toolIndex[block.ToolUseID]; ok {
+							entries[i].ToolOutput = toolResultText(block.Content)
+						}
+					}
+				}
+			}
+			text := strings.TrimSpace(textutil.StripIDEContextTags(strings.Join(texts, "\n\n")))
+			if text != "" {
+				entries = append(entries, agent.SessionEntry{
+					UUID:      line.UUID,
+					Type:      agent.EntryUser,
+					Timestamp: line.Timestamp,
+					Content:   text,
+				})
+			}
+
+		case transcript.TypeAssistant:
+			var msg struct {
+				Content []convertBlock `json:"content"`
+			}
+			if err := json.Unmarshal(line.Message, &msg); err != nil {
+				continue
+			}
+			for _, block := range msg.Content {
+				switch block.Type {
+				case transcript.ContentTypeText:
+					if strings.TrimSpace(block.Text) == "" {
+						continue
+					}
+					entries = append(entries, agent.SessionEntry{
+						UUID:      line.UUID,
+						Type:      agent.EntryAssistant,
+						Timestamp: line.Timestamp,
+						Content:   block.Text,
+					})
+				case transcript.ContentTypeToolUse:
+					name := block.Name
+					if normalized, ok := toolNamesToNormalized[name]; ok {
+						name = normalized
+					}
+					input := agent.ToolInputMap(block.Input)
+					entry := agent.SessionEntry{
+						UUID:      block.ID,
+						Type:      agent.EntryTool,
+						Timestamp: line.Timestamp,
+						ToolName:  name,
+						ToolInput: input,
+					}
+					if path, ok := input["file_path"].(string); ok && path != "" {
+						entry.FilesAffected = []string{path}
+					}
+					toolIndex[block.ID] = len(entries)
+					entries = append(entries, entry)
+				}
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// SessionFromEntries builds a Claude JSONL transcript from normalized entries.
+// Tools Claude knows become tool_use/tool_result pairs; other tools are
+// rendered as assistant text so the context isn't lost.
+func (c *ClaudeCodeAgent) SessionFromEntries(sessionID, repoPath, sessionDir string, entries []agent.SessionEntry) (*agent.AgentSession, error) {
+	var buf bytes.Buffer
+	var parentUUID string
+	startTime := time.Now()
+	if len(entries) > 0 && !entries[0].Timestamp.IsZero() {
+		startTime = entries[0].Timestamp
+	}
+
+	writeLine := func(lineType string, ts time.Time, message interface{}) error {
+		if ts.IsZero() {
+			ts = time.Now()
+		}
+		lineUUID := uuid.NewString()
+		line := map[string]interface{}{
+			"parentUuid":  nil,
+			"isSidechain": false,
+			"userType":    "external",
+			"cwd":         repoPath,
+			"sessionId":   sessionID,
+			"type":        lineType,
+			"message":     message,
+			"uuid":        lineUUID,
+			"timestamp":   ts.UTC().Format(time.RFC3339Nano),
+		}
+		if parentUUID != "" {
+			line["parentUuid"] = parentUUID
+		}
+		data, err := json.Marshal(line)
+		if err != nil {
+			return fmt.Errorf("failed to marshal transcript line: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+		parentUUID = lineUUID
+		return nil
+	}
+	assistantText := func(ts time.Time, text string) error {
+		return writeLine(transcript.TypeAssistant, ts, map[string]interface{}{
+			"role":    "assistant",
+			"type":    "message",
+			"content": []map[string]interface{}{{"type": transcript.ContentTypeText, "text": text}},
+		})
+	}
+
+	for _, entry := range entries {
+		var err error
+		switch entry.Type {
+		case agent.EntryUser:
+			err = writeLine(transcript.TypeUser, entry.Timestamp, map[string]interface{}{
+				"role":    "user",
+				"content": entry.Content,
+			})
+		case agent.EntryAssistant:
+			err = assistantText(entry.Timestamp, entry.Content)
+		case agent.EntryTool:
+			name, ok := toolNamesFromNormalized[entry.ToolName]
+			if !ok {
+				err = assistantText(entry.Timestamp, agent.FormatToolNote(entry))
+				break
+			}
+			toolUseID := entry.UUID
+			if !strings.HasPrefix(toolUseID, "toolu_") {
+				toolUseID = "toolu_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+			}
+			err = writeLine(transcript.TypeAssistant, entry.Timestamp, map[string]interface{}{
+				"role": "assistant",
+				"type": "message",
+				"content": []map[string]interface{}{{
+					"type":  transcript.ContentTypeToolUse,
+					"id":    toolUseID,
+					"name":  name,
+					"input": agent.ToolInputMap(entry.ToolInput),
+				}},
+			})
+			if err == nil {
+				err = writeLine(transcript.TypeUser, entry.Timestamp, map[string]interface{}{
+					"role": "user",
+					"content": []map[string]interface{}{{
+						"type":        "tool_result",
+						"tool_use_id": toolUseID,
+						"content":     agent.ToolOutputText(entry.ToolOutput),
+					}},
+				})
+			}
+		case agent.EntrySystem:
+			// System entries are agent-specific and aren't carried over
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &agent.AgentSession{
+		SessionID:  sessionID,
+		AgentName:  c.Name(),
+		RepoPath:   repoPath,
+		SessionRef: c.ResolveSessionFile(sessionDir, sessionID),
+		StartTime:  startTime,
+		NativeData: buf.Bytes(),
+	}, nil
+}
+
+// decodeString returns raw as a string if it is a JSON string.
+func decodeString(raw json.RawMessage) (string, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err != nil {
+		return "", false
+	}
+	return s, true
+}
+
+// toolResultText flattens tool_result content, which is either a string or
+// an array of text blocks.
+func toolResultText(raw json.RawMessage) string {
+	if s, ok := decodeString(raw); ok {
+		return s
+	}
+	var blocks []convertBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return ""
+	}
+	var texts []string
+	for _, block := range blocks {
+		if block.Type == transcript.ContentTypeText {
+			texts = append(texts, block.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
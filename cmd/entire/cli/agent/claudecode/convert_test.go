@@ -0,0 +1,156 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+const convertTestTranscript = `{"type":"user","uuid":"u1","timestamp":"2026-01-02T10:00:00Z","message":{"role":"user","content":"add a README"}}
+{"type":"user","uuid":"m1","isMeta":true,"message":{"role":"user","content":"<command-name>/clear</command-name>"}}
+{"type":"assistant","uuid":"a1","timestamp":"2026-01-02T10:00:01Z","message":{"role":"assistant","content":[{"type":"thinking","thinking":"hmm"},{"type":"text","text":"I'll create it."}]}}
+{"type":"assistant","uuid":"a2","timestamp":"2026-01-02T10:00:02Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Write","input":{"file_path":"/repo/README.md","content":"# Hi"}}]}}
+{"type":"user","uuid":"u2","timestamp":"2026-01-02T10:00:03Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"File created"}]}}
+{"type":"assistant","uuid":"s1","isSidechain":true,"message":{"role":"assistant","content":[{"type":"text","text":"subagent chatter"}]}}
+{"type":"assistant","uuid":"a3","timestamp":"2026-01-02T10:00:04Z","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_2","name":"Task","input":{"prompt":"review"}}]}}
+{"type":"user","uuid":"u3","timestamp":"2026-01-02T10:00:05Z","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_2","content":[{"type":"text","text":"looks good"}]}]}}
+`
+
+func TestSessionToEntries(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	entries, err := c.SessionToEntries(&agent.AgentSession{NativeData: []byte(convertTestTranscript)})
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+
+	if len(entries) != 4 {
+		t.Fatalf("got %d entries, want 4: %+v", len(entries), entries)
+	}
+	if entries[0].Type != agent.EntryUser || entries[0].Content != "add a README" {
+		t.Errorf("entries[0] = %+v", entries[0])
+	}
+	if entries[0].Timestamp.IsZero() {
+		t.Error("entries[0] timestamp not parsed")
+	}
+	if entries[1].Type != agent.EntryAssistant || entries[1].Content != "I'll create it." {
+		t.Errorf("entries[1] = %+v", entries[1])
+	}
+
+	write := entries[2]
+	if write.Type != agent.EntryTool || write.ToolName != agent.ToolWrite {
+		t.Errorf("entries[2] = %+v, want normalized write tool", write)
+	}
+	if write.ToolOutput != "File created" {
+		t.Errorf("write output = %v", write.ToolOutput)
+	}
+	if len(write.FilesAffected) != 1 || write.FilesAffected[0] != "/repo/README.md" {
+		t.Errorf("write FilesAffected = %v", write.FilesAffected)
+	}
+
+	task := entries[3]
+	if task.ToolName != "Task" || task.ToolOutput != "looks good" {
+		t.Errorf("entries[3] = %+v, want unmapped Task tool with text output", task)
+	}
+}
+
+func TestSessionFromEntries(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	dir := t.TempDir()
+	entries := []agent.SessionEntry{
+		{Type: agent.EntryUser, Content: "list files"},
+		{Type: agent.EntryTool, ToolName: agent.ToolShell, ToolInput: map[string]interface{}{"command": "ls"}, ToolOutput: "a.go"},
+		{Type: agent.EntryTool, ToolName: "save_memory", ToolInput: map[string]interface{}{"fact": "x"}},
+		{Type: agent.EntryAssistant, Content: "There is one file."},
+	}
+
+	session, err := c.SessionFromEntries("new-id", "/repo", dir, entries)
+	if err != nil {
+		t.Fatalf("SessionFromEntries() error = %v", err)
+	}
+	if session.AgentName != agent.AgentNameClaudeCode || session.SessionID != "new-id" {
+		t.Errorf("session = %+v", session)
+	}
+	if session.SessionRef != filepath.Join(dir, "new-id.jsonl") {
+		t.Errorf("SessionRef = %q", session.SessionRef)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(session.NativeData)), "\n")
+	// user, tool_use, tool_result, note, assistant
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want 5:\n%s", len(lines), session.NativeData)
+	}
+
+	var prevUUID string
+	for i, raw := range lines {
+		var line map[string]interface{}
+		if err := json.Unmarshal([]byte(raw), &line); err != nil {
+			t.Fatalf("line %d is not JSON: %v", i, err)
+		}
+		if line["sessionId"] != "new-id" || line["cwd"] != "/repo" {
+			t.Errorf("line %d sessionId/cwd = %v/%v", i, line["sessionId"], line["cwd"])
+		}
+		if i == 0 && line["parentUuid"] != nil {
+			t.Errorf("first line parentUuid = %v, want null", line["parentUuid"])
+		}
+		if i > 0 && line["parentUuid"] != prevUUID {
+			t.Errorf("line %d parentUuid = %v, want %s", i, line["parentUuid"], prevUUID)
+		}
+		prevUUID, _ = line["uuid"].(string)
+	}
+	if !strings.Contains(lines[1], `"name":"Bash"`) || !strings.Contains(lines[2], `"tool_result"`) {
+		t.Errorf("shell tool not rendered as Bash tool_use/tool_result:\n%s\n%s", lines[1], lines[2])
+	}
+	if !strings.Contains(lines[3], "[Tool call: save_memory]") {
+		t.Errorf("unknown tool not rendered as text: %s", lines[3])
+	}
+
+	// The generated transcript parses with the regular transcript helpers
+	parsed, err := ParseTranscript(session.NativeData)
+	if err != nil {
+		t.Fatalf("ParseTranscript() error = %v", err)
+	}
+	if got := ExtractLastUserPrompt(parsed); got != "list files" {
+		t.Errorf("ExtractLastUserPrompt() = %q", got)
+	}
+}
+
+func TestSessionEntries_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	entries, err := c.SessionToEntries(&agent.AgentSession{NativeData: []byte(convertTestTranscript)})
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+	session, err := c.SessionFromEntries("rt", "/repo", t.TempDir(), entries)
+	if err != nil {
+		t.Fatalf("SessionFromEntries() error = %v", err)
+	}
+	again, err := c.SessionToEntries(session)
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+
+	// The unmapped Task call comes back as assistant text
+	if len(again) != len(entries) {
+		t.Fatalf("round trip has %d entries, want %d", len(again), len(entries))
+	}
+	for i := range 3 {
+		if again[i].Type != entries[i].Type || again[i].Content != entries[i].Content || again[i].ToolName != entries[i].ToolName {
+			t.Errorf("entry %d = %+v, want %+v", i, again[i], entries[i])
+		}
+	}
+	if again[2].ToolOutput != "File created" {
+		t.Errorf("tool output lost: %v", again[2].ToolOutput)
+	}
+	if again[3].Type != agent.EntryAssistant || !strings.Contains(again[3].Content, "[Tool call: Task]") {
+		t.Errorf("entry 3 = %+v", again[3])
+	}
+}
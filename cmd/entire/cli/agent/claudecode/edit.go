@@ -0,0 +1,28 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+// Ensure ClaudeCodeAgent implements FileEditToolParser
+var _ agent.FileEditToolParser = (*ClaudeCodeAgent)(nil)
+
+// ParseEditedFile returns the file a Write, Edit, MultiEdit or NotebookEdit
+// tool call wrote.
+func (c *ClaudeCodeAgent) ParseEditedFile(toolName string, rawInput []byte) (string, bool) {
+	if !slices.Contains(FileModificationTools, toolName) {
+		return "", false
+	}
+	var input toolInput
+	if err := json.Unmarshal(rawInput, &input); err != nil {
+		return "", false
+	}
+	file := input.FilePath
+	if file == "" {
+		file = input.NotebookPath
+	}
+	return file, file != ""
+}
@@ -0,0 +1,28 @@
+package claudecode
+
+import "testing"
+
+func TestParseEditedFile(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	tests := []struct {
+		toolName string
+		input    string
+		want     string
+		wantOK   bool
+	}{
+		{ToolWrite, `{"file_path":"/repo/main.go","content":"package main"}`, "/repo/main.go", true},
+		{ToolEdit, `{"file_path":"/repo/main.go","old_string":"a","new_string":"b"}`, "/repo/main.go", true},
+		{ToolMultiEdit, `{"file_path":"/repo/util.go","edits":[{"old_string":"a","new_string":"b"}]}`, "/repo/util.go", true},
+		{ToolNotebookEdit, `{"notebook_path":"/repo/nb.ipynb","new_source":"x"}`, "/repo/nb.ipynb", true},
+		{ToolBash, `{"command":"touch main.go"}`, "", false},
+		{ToolEdit, `not json`, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := c.ParseEditedFile(tt.toolName, []byte(tt.input))
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("ParseEditedFile(%s) = (%q, %v), want (%q, %v)", tt.toolName, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
@@ -13,10 +13,13 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 )
 
-// Ensure ClaudeCodeAgent implements HookSupport and HookHandler
+// Ensure ClaudeCodeAgent implements HookSupport, HookHandler and HookCommandLister
 var (
-	_ agent.HookSupport = (*ClaudeCodeAgent)(nil)
-	_ agent.HookHandler = (*ClaudeCodeAgent)(nil)
+	_ agent.HookSupport       = (*ClaudeCodeAgent)(nil)
+	_ agent.HookHandler       = (*ClaudeCodeAgent)(nil)
+	_ agent.HookCommandLister = (*ClaudeCodeAgent)(nil)
+
+	_ agent.StatusLineInstaller = (*ClaudeCodeAgent)(nil)
 )
 
 // Claude Code hook names - these become subcommands under `entire hooks claude-code`
@@ -28,6 +31,10 @@ const (
 	HookNamePreTask          = "pre-task"
 	HookNamePostTask         = "post-task"
 	HookNamePostTodo         = "post-todo"
+	HookNamePreCompact       = "pre-compact"
+	HookNamePreBash          = "pre-bash"
+	HookNamePostBash         = "post-bash"
+	HookNamePostEdit         = "post-edit"
 )
 
 // ClaudeSettingsFileName is the settings file used by Claude Code.
@@ -48,6 +55,10 @@ func (c *ClaudeCodeAgent) GetHookNames() []string {
 		HookNamePreTask,
 		HookNamePostTask,
 		HookNamePostTodo,
+		HookNamePreCompact,
+		HookNamePreBash,
+		HookNamePostBash,
+		HookNamePostEdit,
 	}
 }
 
@@ -61,19 +72,11 @@ var entireHookPrefixes = []string{
 // If force is true, removes existing Entire hooks before installing.
 // Returns the number of hooks installed.
 func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
-	// Use repo root instead of CWD to find .claude directory
-	// This ensures hooks are installed correctly when run from a subdirectory
-	repoRoot, err := paths.RepoRoot()
+	settingsPath, err := claudeSettingsPath()
 	if err != nil {
-		// Fallback to CWD if not in a git repo (e.g., during tests)
-		repoRoot, err = os.Getwd() //nolint:forbidigo // Intentional fallback when RepoRoot() fails (tests run outside git repos)
-		if err != nil {
-			return 0, fmt.Errorf("failed to get current directory: %w", err)
-		}
+		return 0, err
 	}
 
-	settingsPath := filepath.Join(repoRoot, ".claude", ClaudeSettingsFileName)
-
 	// Read existing settings if they exist
 	var rawSettings map[string]json.RawMessage
 
@@ -110,13 +113,14 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 	}
 
 	// Parse only the hook types we need to modify
-	var sessionStart, sessionEnd, stop, userPromptSubmit, preToolUse, postToolUse []ClaudeHookMatcher
+	var sessionStart, sessionEnd, stop, userPromptSubmit, preToolUse, postToolUse, preCompact []ClaudeHookMatcher
 	parseHookType(rawHooks, "SessionStart", &sessionStart)
 	parseHookType(rawHooks, "SessionEnd", &sessionEnd)
 	parseHookType(rawHooks, "Stop", &stop)
 	parseHookType(rawHooks, "UserPromptSubmit", &userPromptSubmit)
 	parseHookType(rawHooks, "PreToolUse", &preToolUse)
 	parseHookType(rawHooks, "PostToolUse", &postToolUse)
+	parseHookType(rawHooks, "PreCompact", &preCompact)
 
 	// If force is true, remove all existing Entire hooks first
 	if force {
@@ -126,10 +130,11 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 		userPromptSubmit = removeEntireHooks(userPromptSubmit)
 		preToolUse = removeEntireHooksFromMatchers(preToolUse)
 		postToolUse = removeEntireHooksFromMatchers(postToolUse)
+		preCompact = removeEntireHooks(preCompact)
 	}
 
 	// Define hook commands
-	var sessionStartCmd, sessionEndCmd, stopCmd, userPromptSubmitCmd, preTaskCmd, postTaskCmd, postTodoCmd string
+	var sessionStartCmd, sessionEndCmd, stopCmd, userPromptSubmitCmd, preTaskCmd, postTaskCmd, postTodoCmd, preCompactCmd, preBashCmd, postBashCmd, postEditCmd string
 	if localDev {
 		sessionStartCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code session-start"
 		sessionEndCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code session-end"
@@ -138,6 +143,10 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 		preTaskCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code pre-task"
 		postTaskCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code post-task"
 		postTodoCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code post-todo"
+		preCompactCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code pre-compact"
+		preBashCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code pre-bash"
+		postBashCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code post-bash"
+		postEditCmd = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code post-edit"
 	} else {
 		sessionStartCmd = "entire hooks claude-code session-start"
 		sessionEndCmd = "entire hooks claude-code session-end"
@@ -146,6 +155,10 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 		preTaskCmd = "entire hooks claude-code pre-task"
 		postTaskCmd = "entire hooks claude-code post-task"
 		postTodoCmd = "entire hooks claude-code post-todo"
+		preCompactCmd = "entire hooks claude-code pre-compact"
+		preBashCmd = "entire hooks claude-code pre-bash"
+		postBashCmd = "entire hooks claude-code post-bash"
+		postEditCmd = "entire hooks claude-code post-edit"
 	}
 
 	count := 0
@@ -179,6 +192,22 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 		postToolUse = addHookToMatcher(postToolUse, "TodoWrite", postTodoCmd)
 		count++
 	}
+	if !hookCommandExists(preCompact, preCompactCmd) {
+		preCompact = addHookToMatcher(preCompact, "", preCompactCmd)
+		count++
+	}
+	if !hookCommandExistsWithMatcher(preToolUse, ToolBash, preBashCmd) {
+		preToolUse = addHookToMatcher(preToolUse, ToolBash, preBashCmd)
+		count++
+	}
+	if !hookCommandExistsWithMatcher(postToolUse, ToolBash, postBashCmd) {
+		postToolUse = addHookToMatcher(postToolUse, ToolBash, postBashCmd)
+		count++
+	}
+	if !hookCommandExistsWithMatcher(postToolUse, FileEditToolMatcher, postEditCmd) {
+		postToolUse = addHookToMatcher(postToolUse, FileEditToolMatcher, postEditCmd)
+		count++
+	}
 
 	// Add permissions.deny rule if not present
 	permissionsChanged := false
@@ -209,6 +238,7 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 	marshalHookType(rawHooks, "UserPromptSubmit", userPromptSubmit)
 	marshalHookType(rawHooks, "PreToolUse", preToolUse)
 	marshalHookType(rawHooks, "PostToolUse", postToolUse)
+	marshalHookType(rawHooks, "PreCompact", preCompact)
 
 	// Marshal hooks and update raw settings
 	hooksJSON, err := json.Marshal(rawHooks)
@@ -241,6 +271,92 @@ func (c *ClaudeCodeAgent) InstallHooks(localDev bool, force bool) (int, error) {
 	return count, nil
 }
 
+// claudeSettingsPath returns the path of the project's .claude/settings.json.
+func claudeSettingsPath() (string, error) {
+	// Use repo root instead of CWD to find .claude directory
+	// This ensures hooks are installed correctly when run from a subdirectory
+	repoRoot, err := paths.RepoRoot()
+	if err != nil {
+		// Fallback to CWD if not in a git repo (e.g., during tests)
+		repoRoot, err = os.Getwd() //nolint:forbidigo // Intentional fallback when RepoRoot() fails (tests run outside git repos)
+		if err != nil {
+			return "", fmt.Errorf("failed to get current directory: %w", err)
+		}
+	}
+	return filepath.Join(repoRoot, ".claude", ClaudeSettingsFileName), nil
+}
+
+// InstallStatusLine registers `entire statusline` as Claude Code's status
+// line in .claude/settings.json, unless one is already configured.
+// Returns true if the settings changed.
+func (c *ClaudeCodeAgent) InstallStatusLine(localDev bool) (bool, error) {
+	settingsPath, err := claudeSettingsPath()
+	if err != nil {
+		return false, err
+	}
+
+	rawSettings := make(map[string]json.RawMessage)
+	existingData, readErr := os.ReadFile(settingsPath) //nolint:gosec // path is constructed from repo root + fixed path
+	if readErr == nil {
+		if err := json.Unmarshal(existingData, &rawSettings); err != nil {
+			return false, fmt.Errorf("failed to parse existing settings.json: %w", err)
+		}
+	}
+
+	command := "entire statusline"
+	if localDev {
+		command = "go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go statusline"
+	}
+	installed, err := installStatusLine(rawSettings, command)
+	if err != nil || !installed {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(settingsPath), 0o750); err != nil {
+		return false, fmt.Errorf("failed to create .claude directory: %w", err)
+	}
+	output, err := jsonutil.MarshalIndentWithNewline(rawSettings, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal settings: %w", err)
+	}
+	if err := os.WriteFile(settingsPath, output, 0o600); err != nil {
+		return false, fmt.Errorf("failed to write settings.json: %w", err)
+	}
+	return true, nil
+}
+
+// installStatusLine sets the statusLine command in rawSettings. A status line
+// the user configured themselves is left alone. Returns true if it changed.
+func installStatusLine(rawSettings map[string]json.RawMessage, command string) (bool, error) {
+	if existingRaw, ok := rawSettings["statusLine"]; ok {
+		var existing ClaudeStatusLine
+		if err := json.Unmarshal(existingRaw, &existing); err != nil {
+			return false, fmt.Errorf("failed to parse statusLine in settings.json: %w", err)
+		}
+		if existing.Command == command || !isEntireHook(existing.Command) {
+			return false, nil
+		}
+	}
+	data, err := json.Marshal(ClaudeStatusLine{Type: "command", Command: command})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal statusLine: %w", err)
+	}
+	rawSettings["statusLine"] = data
+	return true, nil
+}
+
+// removeStatusLine removes the statusLine from rawSettings if Entire installed it.
+func removeStatusLine(rawSettings map[string]json.RawMessage) {
+	existingRaw, ok := rawSettings["statusLine"]
+	if !ok {
+		return
+	}
+	var existing ClaudeStatusLine
+	if err := json.Unmarshal(existingRaw, &existing); err == nil && isEntireHook(existing.Command) {
+		delete(rawSettings, "statusLine")
+	}
+}
+
 // parseHookType parses a specific hook type from rawHooks into the target slice.
 // Silently ignores parse errors (leaves target unchanged).
 func parseHookType(rawHooks map[string]json.RawMessage, hookType string, target *[]ClaudeHookMatcher) {
@@ -294,13 +410,14 @@ func (c *ClaudeCodeAgent) UninstallHooks() error {
 	}
 
 	// Parse only the hook types we need to modify
-	var sessionStart, sessionEnd, stop, userPromptSubmit, preToolUse, postToolUse []ClaudeHookMatcher
+	var sessionStart, sessionEnd, stop, userPromptSubmit, preToolUse, postToolUse, preCompact []ClaudeHookMatcher
 	parseHookType(rawHooks, "SessionStart", &sessionStart)
 	parseHookType(rawHooks, "SessionEnd", &sessionEnd)
 	parseHookType(rawHooks, "Stop", &stop)
 	parseHookType(rawHooks, "UserPromptSubmit", &userPromptSubmit)
 	parseHookType(rawHooks, "PreToolUse", &preToolUse)
 	parseHookType(rawHooks, "PostToolUse", &postToolUse)
+	parseHookType(rawHooks, "PreCompact", &preCompact)
 
 	// Remove Entire hooks from all hook types
 	sessionStart = removeEntireHooks(sessionStart)
@@ -309,6 +426,7 @@ func (c *ClaudeCodeAgent) UninstallHooks() error {
 	userPromptSubmit = removeEntireHooks(userPromptSubmit)
 	preToolUse = removeEntireHooksFromMatchers(preToolUse)
 	postToolUse = removeEntireHooksFromMatchers(postToolUse)
+	preCompact = removeEntireHooks(preCompact)
 
 	// Marshal modified hook types back to rawHooks
 	marshalHookType(rawHooks, "SessionStart", sessionStart)
@@ -317,6 +435,9 @@ func (c *ClaudeCodeAgent) UninstallHooks() error {
 	marshalHookType(rawHooks, "UserPromptSubmit", userPromptSubmit)
 	marshalHookType(rawHooks, "PreToolUse", preToolUse)
 	marshalHookType(rawHooks, "PostToolUse", postToolUse)
+	marshalHookType(rawHooks, "PreCompact", preCompact)
+
+	removeStatusLine(rawSettings)
 
 	// Also remove the metadata deny rule from permissions
 	var rawPermissions map[string]json.RawMessage
@@ -420,6 +541,7 @@ func (c *ClaudeCodeAgent) GetSupportedHooks() []agent.HookType {
 		agent.HookStop,
 		agent.HookPreToolUse,
 		agent.HookPostToolUse,
+		agent.HookPreCompact,
 	}
 }
 
@@ -513,8 +635,54 @@ func removeEntireHooks(matchers []ClaudeHookMatcher) []ClaudeHookMatcher {
 }
 
 // removeEntireHooksFromMatchers removes Entire hooks from tool-use matchers (PreToolUse, PostToolUse)
-// This handles the nested structure where hooks are grouped by tool matcher (e.g., "Task", "TodoWrite")
+// This handles the nested structure where hooks are grouped by tool matcher (e.g., "Task", "TodoWrite", "Bash", "Write|Edit|MultiEdit")
 func removeEntireHooksFromMatchers(matchers []ClaudeHookMatcher) []ClaudeHookMatcher {
 	// Same logic as removeEntireHooks - both work on the same structure
 	return removeEntireHooks(matchers)
 }
+
+// InstalledHookCommands returns the commands of the Entire hooks and status
+// line in .claude/settings.json.
+func (c *ClaudeCodeAgent) InstalledHookCommands() ([]string, error) {
+	repoRoot, err := paths.RepoRoot()
+	if err != nil {
+		repoRoot = "."
+	}
+	data, err := os.ReadFile(filepath.Join(repoRoot, c.GetHookConfigPath())) //nolint:gosec // path is constructed from repo root + fixed path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read settings.json: %w", err)
+	}
+
+	var settings struct {
+		ClaudeSettings
+
+		StatusLine *ClaudeStatusLine `json:"statusLine,omitempty"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings.json: %w", err)
+	}
+
+	var commands []string
+	seen := make(map[string]bool)
+	add := func(command string) {
+		if isEntireHook(command) && !seen[command] {
+			seen[command] = true
+			commands = append(commands, command)
+		}
+	}
+	h := settings.Hooks
+	for _, matchers := range [][]ClaudeHookMatcher{h.SessionStart, h.SessionEnd, h.UserPromptSubmit, h.Stop, h.PreToolUse, h.PostToolUse, h.PreCompact} {
+		for _, matcher := range matchers {
+			for _, hook := range matcher.Hooks {
+				add(hook.Command)
+			}
+		}
+	}
+	if settings.StatusLine != nil {
+		add(settings.StatusLine.Command)
+	}
+	return commands, nil
+}
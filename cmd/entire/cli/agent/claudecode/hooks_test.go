@@ -295,6 +295,106 @@ func TestUninstallHooks(t *testing.T) {
 	}
 }
 
+func TestInstallHooks_PreCompact(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	agent := &ClaudeCodeAgent{}
+	if _, err := agent.InstallHooks(false, false); err != nil {
+		t.Fatalf("InstallHooks() error = %v", err)
+	}
+
+	commands, err := agent.InstalledHookCommands()
+	if err != nil {
+		t.Fatalf("InstalledHookCommands() error = %v", err)
+	}
+	if !slices.Contains(commands, "entire hooks claude-code pre-compact") {
+		t.Errorf("InstalledHookCommands() = %v, want the pre-compact hook", commands)
+	}
+
+	if err := agent.UninstallHooks(); err != nil {
+		t.Fatalf("UninstallHooks() error = %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(tempDir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatalf("failed to read settings.json: %v", err)
+	}
+	var settings ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("failed to parse settings.json: %v", err)
+	}
+	if len(settings.Hooks.PreCompact) != 0 {
+		t.Errorf("PreCompact hooks after uninstall = %v, want none", settings.Hooks.PreCompact)
+	}
+}
+
+func TestInstallHooks_Bash(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	agent := &ClaudeCodeAgent{}
+	if _, err := agent.InstallHooks(false, false); err != nil {
+		t.Fatalf("InstallHooks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatalf("failed to read settings.json: %v", err)
+	}
+	var settings ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("failed to parse settings.json: %v", err)
+	}
+	if !hookCommandExistsWithMatcher(settings.Hooks.PreToolUse, "Bash", "entire hooks claude-code pre-bash") {
+		t.Errorf("PreToolUse = %v, want a Bash matcher running pre-bash", settings.Hooks.PreToolUse)
+	}
+	if !hookCommandExistsWithMatcher(settings.Hooks.PostToolUse, "Bash", "entire hooks claude-code post-bash") {
+		t.Errorf("PostToolUse = %v, want a Bash matcher running post-bash", settings.Hooks.PostToolUse)
+	}
+
+	if err := agent.UninstallHooks(); err != nil {
+		t.Fatalf("UninstallHooks() error = %v", err)
+	}
+	commands, err := agent.InstalledHookCommands()
+	if err != nil {
+		t.Fatalf("InstalledHookCommands() error = %v", err)
+	}
+	if len(commands) != 0 {
+		t.Errorf("InstalledHookCommands() after uninstall = %v, want none", commands)
+	}
+}
+
+func TestInstallHooks_FileEdit(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	agent := &ClaudeCodeAgent{}
+	if _, err := agent.InstallHooks(false, false); err != nil {
+		t.Fatalf("InstallHooks() error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatalf("failed to read settings.json: %v", err)
+	}
+	var settings ClaudeSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("failed to parse settings.json: %v", err)
+	}
+	if !hookCommandExistsWithMatcher(settings.Hooks.PostToolUse, "Write|Edit|MultiEdit", "entire hooks claude-code post-edit") {
+		t.Errorf("PostToolUse = %v, want a Write|Edit|MultiEdit matcher running post-edit", settings.Hooks.PostToolUse)
+	}
+
+	// Installing again doesn't duplicate the hook
+	count, err := agent.InstallHooks(false, false)
+	if err != nil {
+		t.Fatalf("second InstallHooks() error = %v", err)
+	}
+	if count != 0 {
+		t.Errorf("second InstallHooks() count = %d, want 0", count)
+	}
+}
+
 func TestUninstallHooks_NoSettingsFile(t *testing.T) {
 	tempDir := t.TempDir()
 	t.Chdir(tempDir)
@@ -655,3 +755,116 @@ func TestUninstallHooks_PreservesUnknownHookTypes(t *testing.T) {
 		}
 	}
 }
+
+// readStatusLine reads the statusLine entry from .claude/settings.json, or nil if absent.
+func readStatusLine(t *testing.T, tempDir string) *ClaudeStatusLine {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(tempDir, ".claude", ClaudeSettingsFileName))
+	if err != nil {
+		t.Fatalf("failed to read settings.json: %v", err)
+	}
+	var settings struct {
+		StatusLine *ClaudeStatusLine `json:"statusLine"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatalf("failed to parse settings.json: %v", err)
+	}
+	return settings.StatusLine
+}
+
+func TestInstallHooks_StatusLine(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	if _, err := (&ClaudeCodeAgent{}).InstallHooks(false, false); err != nil {
+		t.Fatalf("InstallHooks() error = %v", err)
+	}
+	if got := readStatusLine(t, tempDir); got != nil {
+		t.Errorf("statusLine = %+v, want none unless requested", got)
+	}
+
+	ag := &ClaudeCodeAgent{}
+	installed, err := ag.InstallStatusLine(false)
+	if err != nil {
+		t.Fatalf("InstallStatusLine() error = %v", err)
+	}
+	if !installed {
+		t.Error("InstallStatusLine() = false, want true")
+	}
+	if got := readStatusLine(t, tempDir); got == nil || got.Type != "command" || got.Command != "entire statusline" {
+		t.Errorf("statusLine = %+v", got)
+	}
+	if installed, err := ag.InstallStatusLine(false); err != nil || installed {
+		t.Errorf("second InstallStatusLine() = %v, %v; want false", installed, err)
+	}
+	if count, err := ag.InstallHooks(false, false); err != nil || count != 0 {
+		t.Errorf("InstallHooks() after InstallStatusLine = %d, %v; want 0", count, err)
+	}
+	if got := readStatusLine(t, tempDir); got == nil || got.Command != "entire statusline" {
+		t.Errorf("statusLine = %+v after InstallHooks, want it kept", got)
+	}
+
+	if err := ag.UninstallHooks(); err != nil {
+		t.Fatalf("UninstallHooks() error = %v", err)
+	}
+	if got := readStatusLine(t, tempDir); got != nil {
+		t.Errorf("statusLine = %+v after uninstall, want removed", got)
+	}
+}
+
+func TestInstallHooks_StatusLinePreservesUserCommand(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	writeSettingsFile(t, tempDir, `{"statusLine": {"type": "command", "command": "~/bin/my-prompt"}}`)
+
+	ag := &ClaudeCodeAgent{}
+	if _, err := ag.InstallStatusLine(false); err != nil {
+		t.Fatalf("InstallStatusLine() error = %v", err)
+	}
+	if got := readStatusLine(t, tempDir); got == nil || got.Command != "~/bin/my-prompt" {
+		t.Errorf("statusLine = %+v, want the user's command kept", got)
+	}
+
+	if err := ag.UninstallHooks(); err != nil {
+		t.Fatalf("UninstallHooks() error = %v", err)
+	}
+	if got := readStatusLine(t, tempDir); got == nil || got.Command != "~/bin/my-prompt" {
+		t.Errorf("statusLine = %+v after uninstall, want the user's command kept", got)
+	}
+}
+
+func TestInstalledHookCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	agent := &ClaudeCodeAgent{}
+	commands, err := agent.InstalledHookCommands()
+	if err != nil || len(commands) != 0 {
+		t.Fatalf("InstalledHookCommands() without settings = %v, %v; want none", commands, err)
+	}
+
+	writeSettingsFile(t, tempDir, `{
+		"hooks": {
+			"Stop": [{"matcher": "", "hooks": [
+				{"type": "command", "command": "entire hooks claude-code stop"},
+				{"type": "command", "command": "my-linter --fix"}
+			]}],
+			"SessionStart": [{"matcher": "", "hooks": [{"type": "command", "command": "entire hooks claude-code stop"}]}]
+		},
+		"statusLine": {"type": "command", "command": "entire statusline"}
+	}`)
+	commands, err = agent.InstalledHookCommands()
+	if err != nil {
+		t.Fatalf("InstalledHookCommands() error = %v", err)
+	}
+	want := []string{"entire hooks claude-code stop", "entire statusline"}
+	if !slices.Equal(commands, want) {
+		t.Errorf("InstalledHookCommands() = %v, want %v", commands, want)
+	}
+
+	writeSettingsFile(t, tempDir, `{"hooks": [}`)
+	if _, err := agent.InstalledHookCommands(); err == nil {
+		t.Error("InstalledHookCommands() should fail on invalid JSON")
+	}
+}
@@ -0,0 +1,81 @@
+package claudecode
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+// Ensure ClaudeCodeAgent implements ShellToolParser
+var _ agent.ShellToolParser = (*ClaudeCodeAgent)(nil)
+
+// exitCodePattern matches the exit status Claude Code reports for a failed
+// command, e.g. "Exit code 1".
+var exitCodePattern = regexp.MustCompile(`(?i)exit code:?\s*(-?\d+)`)
+
+// ParseShellCommand returns the command line of a Bash tool call.
+func (c *ClaudeCodeAgent) ParseShellCommand(toolName string, toolInput []byte) (string, bool) {
+	if toolName != ToolBash {
+		return "", false
+	}
+	var input bashToolInput
+	if err := json.Unmarshal(toolInput, &input); err != nil || input.Command == "" {
+		return "", false
+	}
+	return input.Command, true
+}
+
+// ParseShellResult extracts the output and exit status from a Bash tool's
+// response. Claude Code only reports the exit status of failed commands, in
+// the output, so a command that wasn't interrupted and reports none exited 0.
+func (c *ClaudeCodeAgent) ParseShellResult(toolResponse []byte) agent.ShellResult {
+	var response bashToolResponse
+	if err := json.Unmarshal(toolResponse, &response); err != nil {
+		// Failed commands may be reported as a plain error string
+		var message string
+		if json.Unmarshal(toolResponse, &message) != nil {
+			return agent.ShellResult{}
+		}
+		return agent.ShellResult{Output: message, ExitCode: exitCodeFromOutput(message)}
+	}
+
+	output := strings.TrimRight(response.Stdout, "\n")
+	if stderr := strings.TrimRight(response.Stderr, "\n"); stderr != "" {
+		if output != "" {
+			output += "\n"
+		}
+		output += stderr
+	}
+
+	result := agent.ShellResult{Output: output, Interrupted: response.Interrupted}
+	switch {
+	case response.ExitCode != nil:
+		result.ExitCode = response.ExitCode
+	case response.ReturnCode != nil:
+		result.ExitCode = response.ReturnCode
+	default:
+		result.ExitCode = exitCodeFromOutput(response.Stderr)
+	}
+	if result.ExitCode == nil && !response.Interrupted {
+		zero := 0
+		result.ExitCode = &zero
+	}
+	return result
+}
+
+// exitCodeFromOutput returns the exit status reported in a Bash tool's
+// output, or nil if the output doesn't report one.
+func exitCodeFromOutput(output string) *int {
+	match := exitCodePattern.FindStringSubmatch(output)
+	if match == nil {
+		return nil
+	}
+	code, err := strconv.Atoi(match[1])
+	if err != nil {
+		return nil
+	}
+	return &code
+}
@@ -0,0 +1,116 @@
+package claudecode
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+func TestParseHookInput_PostBash(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	input := `{"session_id":"sess-1","transcript_path":"/tmp/t.jsonl","tool_name":"Bash","tool_use_id":"toolu_01","tool_input":{"command":"go test ./...","description":"Run tests"},"tool_response":{"stdout":"ok\n","stderr":"","interrupted":false,"isImage":false}}`
+
+	result, err := c.ParseHookInput(agent.HookPostToolUse, strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseHookInput() error = %v", err)
+	}
+	if result.ToolName != ToolBash {
+		t.Errorf("ToolName = %q, want %q", result.ToolName, ToolBash)
+	}
+	if result.ToolUseID != "toolu_01" {
+		t.Errorf("ToolUseID = %q, want %q", result.ToolUseID, "toolu_01")
+	}
+	if len(result.ToolResponse) == 0 {
+		t.Error("ToolResponse is empty, want the raw tool response")
+	}
+	if _, ok := result.RawData["agent_id"]; ok {
+		t.Error("RawData[agent_id] set for a Bash tool response")
+	}
+}
+
+func TestParseShellCommand(t *testing.T) {
+	t.Parallel()
+
+	c := &ClaudeCodeAgent{}
+	command, ok := c.ParseShellCommand(ToolBash, []byte(`{"command":"make build","description":"Build"}`))
+	if !ok || command != "make build" {
+		t.Errorf("ParseShellCommand(Bash) = (%q, %v), want (%q, true)", command, ok, "make build")
+	}
+	if _, ok := c.ParseShellCommand("Task", []byte(`{"command":"make build"}`)); ok {
+		t.Error("ParseShellCommand(Task) = true, want false")
+	}
+	if _, ok := c.ParseShellCommand(ToolBash, []byte(`{}`)); ok {
+		t.Error("ParseShellCommand() with no command = true, want false")
+	}
+}
+
+func TestParseShellResult(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name            string
+		response        string
+		wantOutput      string
+		wantExitCode    *int
+		wantInterrupted bool
+	}{
+		{
+			name:         "success",
+			response:     `{"stdout":"ok\n","stderr":"","interrupted":false}`,
+			wantOutput:   "ok",
+			wantExitCode: intPtr(0),
+		},
+		{
+			name:         "stdout and stderr",
+			response:     `{"stdout":"building\n","stderr":"warning: unused\n","interrupted":false}`,
+			wantOutput:   "building\nwarning: unused",
+			wantExitCode: intPtr(0),
+		},
+		{
+			name:         "exit code in error string",
+			response:     `"Error: Exit code 2\nmake: *** [build] Error 2"`,
+			wantOutput:   "Error: Exit code 2\nmake: *** [build] Error 2",
+			wantExitCode: intPtr(2),
+		},
+		{
+			name:         "structured exit code",
+			response:     `{"stdout":"","stderr":"boom","interrupted":false,"exitCode":1}`,
+			wantOutput:   "boom",
+			wantExitCode: intPtr(1),
+		},
+		{
+			name:            "interrupted",
+			response:        `{"stdout":"partial","stderr":"","interrupted":true}`,
+			wantOutput:      "partial",
+			wantInterrupted: true,
+		},
+	}
+
+	c := &ClaudeCodeAgent{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			result := c.ParseShellResult([]byte(tt.response))
+			if result.Output != tt.wantOutput {
+				t.Errorf("Output = %q, want %q", result.Output, tt.wantOutput)
+			}
+			if result.Interrupted != tt.wantInterrupted {
+				t.Errorf("Interrupted = %v, want %v", result.Interrupted, tt.wantInterrupted)
+			}
+			switch {
+			case tt.wantExitCode == nil && result.ExitCode != nil:
+				t.Errorf("ExitCode = %d, want nil", *result.ExitCode)
+			case tt.wantExitCode != nil && (result.ExitCode == nil || *result.ExitCode != *tt.wantExitCode):
+				t.Errorf("ExitCode = %v, want %d", result.ExitCode, *tt.wantExitCode)
+			}
+		})
+	}
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -15,6 +15,28 @@ type ClaudeHooks struct {
 	Stop             []ClaudeHookMatcher `json:"Stop,omitempty"`
 	PreToolUse       []ClaudeHookMatcher `json:"PreToolUse,omitempty"`
 	PostToolUse      []ClaudeHookMatcher `json:"PostToolUse,omitempty"`
+	PreCompact       []ClaudeHookMatcher `json:"PreCompact,omitempty"`
+}
+
+// ClaudeStatusLine is the statusLine entry in .claude/settings.json
+type ClaudeStatusLine struct {
+	Type    string `json:"type"`
+	Command string `json:"command"`
+	Padding int    `json:"padding,omitempty"`
+}
+
+// StatusLineInput is the JSON Claude Code writes to a status line command's stdin.
+type StatusLineInput struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	Cwd            string `json:"cwd"`
+	Model          struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"display_name"`
+	} `json:"model"`
+	Cost struct {
+		TotalCostUSD float64 `json:"total_cost_usd"`
+	} `json:"cost"`
 }
 
 // ClaudeHookMatcher matches hooks to specific patterns
@@ -35,6 +57,13 @@ type sessionInfoRaw struct {
 	TranscriptPath string `json:"transcript_path"`
 }
 
+// preCompactRaw is the JSON structure from PreCompact hooks
+type preCompactRaw struct {
+	SessionID      string `json:"session_id"`
+	TranscriptPath string `json:"transcript_path"`
+	Trigger        string `json:"trigger"` // manual or auto
+}
+
 // userPromptSubmitRaw is the JSON structure from UserPromptSubmit hooks.
 // Unlike other session hooks, this includes the user's prompt text.
 type userPromptSubmitRaw struct {
@@ -47,6 +76,7 @@ type userPromptSubmitRaw struct {
 type taskHookInputRaw struct {
 	SessionID      string          `json:"session_id"`
 	TranscriptPath string          `json:"transcript_path"`
+	ToolName       string          `json:"tool_name"`
 	ToolUseID      string          `json:"tool_use_id"`
 	ToolInput      json.RawMessage `json:"tool_input"`
 }
@@ -55,26 +85,52 @@ type taskHookInputRaw struct {
 type postToolHookInputRaw struct {
 	SessionID      string          `json:"session_id"`
 	TranscriptPath string          `json:"transcript_path"`
+	ToolName       string          `json:"tool_name"`
 	ToolUseID      string          `json:"tool_use_id"`
 	ToolInput      json.RawMessage `json:"tool_input"`
-	ToolResponse   struct {
-		AgentID string `json:"agentId"`
-	} `json:"tool_response"`
+	ToolResponse   json.RawMessage `json:"tool_response"`
+}
+
+// taskToolResponse is the part of a Task tool's response Entire reads
+type taskToolResponse struct {
+	AgentID string `json:"agentId"`
+}
+
+// bashToolInput is the tool_input of a Bash tool call
+type bashToolInput struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// bashToolResponse is the tool_response of a Bash tool call
+type bashToolResponse struct {
+	Stdout      string `json:"stdout"`
+	Stderr      string `json:"stderr"`
+	Interrupted bool   `json:"interrupted"`
+	ExitCode    *int   `json:"exitCode,omitempty"`
+	ReturnCode  *int   `json:"returnCode,omitempty"`
 }
 
 // Tool names used in Claude Code transcripts
 const (
 	ToolWrite        = "Write"
 	ToolEdit         = "Edit"
+	ToolMultiEdit    = "MultiEdit"
 	ToolNotebookEdit = "NotebookEdit"
 	ToolMCPWrite     = "mcp__acp__Write" //nolint:gosec // G101: This is a tool name, not a credential
 	ToolMCPEdit      = "mcp__acp__Edit"
+	ToolBash         = "Bash"
 )
 
+// FileEditToolMatcher is the PostToolUse matcher for the tools that write
+// files, used for fine-grained checkpoints
+const FileEditToolMatcher = ToolWrite + "|" + ToolEdit + "|" + ToolMultiEdit
+
 // FileModificationTools lists tools that create or modify files
 var FileModificationTools = []string{
 	ToolWrite,
 	ToolEdit,
+	ToolMultiEdit,
 	ToolNotebookEdit,
 	ToolMCPWrite,
 	ToolMCPEdit,
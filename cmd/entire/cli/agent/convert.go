@@ -0,0 +1,128 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SessionConverter is implemented by agents whose sessions can be converted
+// to and from the normalized SessionEntry form. Converting through entries
+// lets a session started in one agent be continued in another.
+type SessionConverter interface {
+	Agent
+
+	// SessionToEntries parses the session's NativeData into normalized entries.
+	// Tool names are mapped to the normalized Tool* names where known.
+	SessionToEntries(session *AgentSession) ([]SessionEntry, error)
+
+	// SessionFromEntries builds a new session in this agent's native format.
+	// The returned session has NativeData and SessionRef (a new file in
+	// sessionDir) set, ready to pass to WriteSession.
+	SessionFromEntries(sessionID, repoPath, sessionDir string, entries []SessionEntry) (*AgentSession, error)
+}
+
+// Normalized tool names used in SessionEntry.ToolName by session converters.
+// Tools without a normalized name keep their native name, and target agents
+// render them as plain-text notes.
+const (
+	ToolRead      = "read"
+	ToolWrite     = "write"
+	ToolEdit      = "edit"
+	ToolShell     = "shell"
+	ToolGlob      = "glob"
+	ToolGrep      = "grep"
+	ToolList      = "list"
+	ToolWebFetch  = "web_fetch"
+	ToolWebSearch = "web_search"
+)
+
+// ConvertSession converts a session into target's native format. The
+// session's AgentName selects the source agent, and both agents must
+// implement SessionConverter. The converted session gets newSessionID and a
+// SessionRef inside sessionDir.
+func ConvertSession(session *AgentSession, target Agent, newSessionID, sessionDir string) (*AgentSession, error) {
+	if session == nil {
+		return nil, errors.New("session is nil")
+	}
+	source, err := Get(session.AgentName)
+	if err != nil {
+		return nil, fmt.Errorf("unknown source agent: %w", err)
+	}
+	from, ok := source.(SessionConverter)
+	if !ok {
+		return nil, fmt.Errorf("agent %s does not support session conversion", source.Name())
+	}
+	to, ok := target.(SessionConverter)
+	if !ok {
+		return nil, fmt.Errorf("agent %s does not support session conversion", target.Name())
+	}
+
+	entries, err := from.SessionToEntries(session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s session: %w", source.Name(), err)
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("session has no messages to convert")
+	}
+
+	converted, err := to.SessionFromEntries(newSessionID, session.RepoPath, sessionDir, entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build %s session: %w", target.Name(), err)
+	}
+	converted.Entries = entries
+	return converted, nil
+}
+
+// ToolInputMap returns a tool entry's input as a map, decoding JSON if needed.
+// Returns an empty map when the input is missing or not an object.
+func ToolInputMap(input interface{}) map[string]interface{} {
+	switch v := input.(type) {
+	case map[string]interface{}:
+		return v
+	case json.RawMessage:
+		var m map[string]interface{}
+		if err := json.Unmarshal(v, &m); err == nil && m != nil {
+			return m
+		}
+	case []byte:
+		var m map[string]interface{}
+		if err := json.Unmarshal(v, &m); err == nil && m != nil {
+			return m
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// ToolOutputText returns a tool entry's output as text.
+func ToolOutputText(output interface{}) string {
+	switch v := output.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// FormatToolNote renders a tool call as plain text, for agents that can't
+// represent the tool natively.
+func FormatToolNote(entry SessionEntry) string {
+	var sb strings.Builder
+	sb.WriteString("[Tool call: " + entry.ToolName + "]")
+	if input := ToolInputMap(entry.ToolInput); len(input) > 0 {
+		if data, err := json.Marshal(input); err == nil {
+			sb.WriteString(" " + string(data))
+		}
+	}
+	if out := ToolOutputText(entry.ToolOutput); out != "" {
+		sb.WriteString("\n[Tool result]\n" + out)
+	}
+	return sb.String()
+}
@@ -0,0 +1,166 @@
+package agent
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// mockConverter is a SessionConverter whose native format is one user
+// prompt per line.
+type mockConverter struct {
+	mockAgent
+
+	name AgentName
+}
+
+func (m *mockConverter) Name() AgentName { return m.name }
+
+func (m *mockConverter) SessionToEntries(session *AgentSession) ([]SessionEntry, error) {
+	var entries []SessionEntry
+	for _, line := range strings.Split(strings.TrimSpace(string(session.NativeData)), "\n") {
+		if line != "" {
+			entries = append(entries, SessionEntry{Type: EntryUser, Content: line})
+		}
+	}
+	return entries, nil
+}
+
+func (m *mockConverter) SessionFromEntries(sessionID, repoPath, sessionDir string, entries []SessionEntry) (*AgentSession, error) {
+	lines := make([]string, 0, len(entries))
+	for _, e := range entries {
+		lines = append(lines, e.Content)
+	}
+	return &AgentSession{
+		SessionID:  sessionID,
+		AgentName:  m.name,
+		RepoPath:   repoPath,
+		SessionRef: filepath.Join(sessionDir, sessionID+".txt"),
+		NativeData: []byte(strings.Join(lines, "\n")),
+	}, nil
+}
+
+func withConverterRegistry(t *testing.T) {
+	t.Helper()
+
+	original := make(map[AgentName]Factory)
+	registryMu.Lock()
+	for k, v := range registry {
+		original[k] = v
+	}
+	registry = make(map[AgentName]Factory)
+	registryMu.Unlock()
+
+	t.Cleanup(func() {
+		registryMu.Lock()
+		registry = original
+		registryMu.Unlock()
+	})
+
+	Register("conv-a", func() Agent { return &mockConverter{name: "conv-a"} })
+	Register("conv-b", func() Agent { return &mockConverter{name: "conv-b"} })
+	Register("plain", func() Agent { return &mockAgent{} })
+}
+
+func TestConvertSession(t *testing.T) {
+	withConverterRegistry(t)
+
+	target, err := Get("conv-b")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	session := &AgentSession{
+		SessionID:  "old",
+		AgentName:  "conv-a",
+		RepoPath:   "/repo",
+		NativeData: []byte("first\nsecond\n"),
+	}
+
+	converted, err := ConvertSession(session, target, "new", "/sessions")
+	if err != nil {
+		t.Fatalf("ConvertSession() error = %v", err)
+	}
+	if converted.AgentName != "conv-b" || converted.SessionID != "new" || converted.RepoPath != "/repo" {
+		t.Errorf("converted session = %+v", converted)
+	}
+	if converted.SessionRef != filepath.Join("/sessions", "new.txt") {
+		t.Errorf("SessionRef = %q", converted.SessionRef)
+	}
+	if string(converted.NativeData) != "first\nsecond" {
+		t.Errorf("NativeData = %q", converted.NativeData)
+	}
+	if len(converted.Entries) != 2 {
+		t.Errorf("Entries = %d, want 2", len(converted.Entries))
+	}
+}
+
+func TestConvertSession_Errors(t *testing.T) {
+	withConverterRegistry(t)
+
+	convB, err := Get("conv-b")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+	plain, err := Get("plain")
+	if err != nil {
+		t.Fatalf("Get() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		session *AgentSession
+		target  Agent
+		wantErr string
+	}{
+		{"nil session", nil, convB, "session is nil"},
+		{"unknown source", &AgentSession{AgentName: "missing", NativeData: []byte("x")}, convB, "unknown source agent"},
+		{"source not a converter", &AgentSession{AgentName: "plain", NativeData: []byte("x")}, convB, "does not support session conversion"},
+		{"target not a converter", &AgentSession{AgentName: "conv-a", NativeData: []byte("x")}, plain, "does not support session conversion"},
+		{"empty session", &AgentSession{AgentName: "conv-a"}, convB, "no messages"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ConvertSession(tt.session, tt.target, "new", t.TempDir())
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ConvertSession() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestToolInputMap(t *testing.T) {
+	t.Parallel()
+
+	if got := ToolInputMap(map[string]interface{}{"a": "b"}); got["a"] != "b" {
+		t.Errorf("map input = %v", got)
+	}
+	if got := ToolInputMap(json.RawMessage(`{"file_path":"x.go"}`)); got["file_path"] != "x.go" {
+		t.Errorf("raw input = %v", got)
+	}
+	if got := ToolInputMap(json.RawMessage(`"not an object"`)); len(got) != 0 {
+		t.Errorf("non-object input = %v, want empty", got)
+	}
+	if got := ToolInputMap(nil); got == nil || len(got) != 0 {
+		t.Errorf("nil input = %v, want empty map", got)
+	}
+}
+
+func TestFormatToolNote(t *testing.T) {
+	t.Parallel()
+
+	note := FormatToolNote(SessionEntry{
+		Type:       EntryTool,
+		ToolName:   "Task",
+		ToolInput:  map[string]interface{}{"prompt": "explore"},
+		ToolOutput: "done",
+	})
+	for _, want := range []string{"[Tool call: Task]", `"prompt":"explore"`, "[Tool result]\ndone"} {
+		if !strings.Contains(note, want) {
+			t.Errorf("FormatToolNote() = %q, missing %q", note, want)
+		}
+	}
+	if got := ToolOutputText(map[string]interface{}{"k": 1}); got != `{"k":1}` {
+		t.Errorf("ToolOutputText() = %q", got)
+	}
+}
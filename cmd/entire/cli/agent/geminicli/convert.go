@@ -0,0 +1,330 @@
+package geminicli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+
+	"github.com/google/uuid"
+)
+
+// Compile-time check that GeminiCLIAgent supports session conversion.
+var _ agent.SessionConverter = (*GeminiCLIAgent)(nil)
+
+// Additional Gemini CLI tool names that have a normalized equivalent.
+const (
+	ToolReadFile          = "read_file"
+	ToolRunShellCommand   = "run_shell_command"
+	ToolGlob              = "glob"
+	ToolSearchFileContent = "search_file_content"
+	ToolListDirectory     = "list_directory"
+	ToolWebFetch          = "web_fetch"
+	ToolGoogleWebSearch   = "google_web_search"
+)
+
+// Gemini tool names mapped to normalized tool names.
+var toolNamesToNormalized = map[string]string{
+	ToolReadFile:          agent.ToolRead,
+	ToolWriteFile:         agent.ToolWrite,
+	ToolReplace:           agent.ToolEdit,
+	ToolRunShellCommand:   agent.ToolShell,
+	ToolGlob:              agent.ToolGlob,
+	ToolSearchFileContent: agent.ToolGrep,
+	ToolListDirectory:     agent.ToolList,
+	ToolWebFetch:          agent.ToolWebFetch,
+	ToolGoogleWebSearch:   agent.ToolWebSearch,
+}
+
+var toolNamesFromNormalized = func() map[string]string {
+	m := make(map[string]string, len(toolNamesToNormalized))
+	for native, normalized := range toolNamesToNormalized {
+		m[normalized] = native
+	}
+	return m
+}()
+
+// convertSession is the full Gemini session file, including the fields
+// GeminiTranscript doesn't need.
+type convertSession struct {
+	SessionID   string           `json:"sessionId"`
+	ProjectHash string           `json:"projectHash"`
+	StartTime   string           `json:"startTime"`
+	LastUpdated string           `json:"lastUpdated"`
+	Messages    []convertMessage `json:"messages"`
+}
+
+type convertMessage struct {
+	ID        string            `json:"id"`
+	Timestamp string            `json:"timestamp"`
+	Type      string            `json:"type"`
+	Content   json.RawMessage   `json:"content"`
+	ToolCalls []convertToolCall `json:"toolCalls,omitempty"`
+}
+
+type convertToolCall struct {
+	ID        string                 `json:"id"`
+	Name      string                 `json:"name"`
+	Args      map[string]interface{} `json:"args"`
+	Result    []convertToolResult    `json:"result,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+	Timestamp string                 `json:"timestamp,omitempty"`
+}
+
+type convertToolResult struct {
+	FunctionResponse struct {
+		ID       string                 `json:"id"`
+		Name     string                 `json:"name"`
+		Response map[string]interface{} `json:"response"`
+	} `json:"functionResponse"`
+}
+
+// SessionToEntries parses the session's JSON transcript into normalized entries.
+// Info and error messages are skipped. Each tool call becomes its own entry
+// after the text of the message that made it.
+func (g *GeminiCLIAgent) SessionToEntries(session *agent.AgentSession) ([]agent.SessionEntry, error) {
+	if session == nil || len(session.NativeData) == 0 {
+		return nil, nil
+	}
+
+	var file convertSession
+	if err := json.Unmarshal(session.NativeData, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+
+	var entries []agent.SessionEntry
+	for _, msg := range file.Messages {
+		ts := parseTimestamp(msg.Timestamp)
+		text := strings.TrimSpace(messageText(msg.Content))
+
+		switch msg.Type {
+		case MessageTypeUser:
+			if text != "" {
+				entries = append(entries, agent.SessionEntry{UUID: msg.ID, Type: agent.EntryUser, Timestamp: ts, Content: text})
+			}
+		case MessageTypeGemini:
+			if text != "" {
+				entries = append(entries, agent.SessionEntry{UUID: msg.ID, Type: agent.EntryAssistant, Timestamp: ts, Content: text})
+			}
+			for _, call := range msg.ToolCalls {
+				entries = append(entries, toolCallEntry(call, ts))
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// SessionFromEntries builds a Gemini session file from normalized entries.
+// Tool calls are attached to the preceding gemini message; tools Gemini
+// doesn't know are rendered as text.
+func (g *GeminiCLIAgent) SessionFromEntries(sessionID, repoPath, sessionDir string, entries []agent.SessionEntry) (*agent.AgentSession, error) {
+	now := time.Now()
+	startTime := now
+	if len(entries) > 0 && !entries[0].Timestamp.IsZero() {
+		startTime = entries[0].Timestamp
+	}
+	lastUpdated := startTime
+
+	projectHash := sha256.Sum256([]byte(repoPath))
+	file := convertSession{
+		SessionID:   sessionID,
+		ProjectHash: hex.EncodeToString(projectHash[:]),
+		StartTime:   startTime.UTC().Format(time.RFC3339Nano),
+	}
+
+	addMessage := func(msgType, content string, ts time.Time) {
+		data, err := json.Marshal(content)
+		if err != nil {
+			data = []byte(`""`)
+		}
+		file.Messages = append(file.Messages, convertMessage{
+			ID:        uuid.NewString(),
+			Timestamp: ts.UTC().Format(time.RFC3339Nano),
+			Type:      msgType,
+			Content:   data,
+		})
+	}
+
+	for _, entry := range entries {
+		ts := entry.Timestamp
+		if ts.IsZero() {
+			ts = now
+		}
+		if ts.After(lastUpdated) {
+			lastUpdated = ts
+		}
+
+		switch entry.Type {
+		case agent.EntryUser:
+			addMessage(MessageTypeUser, entry.Content, ts)
+		case agent.EntryAssistant:
+			addMessage(MessageTypeGemini, entry.Content, ts)
+		case agent.EntryTool:
+			name, ok := toolNamesFromNormalized[entry.ToolName]
+			if !ok {
+				addMessage(MessageTypeGemini, agent.FormatToolNote(entry), ts)
+				continue
+			}
+			last := len(file.Messages) - 1
+			if last < 0 || file.Messages[last].Type != MessageTypeGemini {
+				addMessage(MessageTypeGemini, "", ts)
+				last = len(file.Messages) - 1
+			}
+			callID := fmt.Sprintf("%s-%d-%s", name, ts.UnixMilli(), uuid.NewString()[:8])
+			call := convertToolCall{
+				ID:        callID,
+				Name:      name,
+				Args:      argsFromNormalized(name, agent.ToolInputMap(entry.ToolInput)),
+				Status:    "success",
+				Timestamp: ts.UTC().Format(time.RFC3339Nano),
+			}
+			var result convertToolResult
+			result.FunctionResponse.ID = callID
+			result.FunctionResponse.Name = name
+			result.FunctionResponse.Response = map[string]interface{}{"output": agent.ToolOutputText(entry.ToolOutput)}
+			call.Result = []convertToolResult{result}
+			file.Messages[last].ToolCalls = append(file.Messages[last].ToolCalls, call)
+		case agent.EntrySystem:
+			// System entries are agent-specific and aren't carried over
+		}
+	}
+	file.LastUpdated = lastUpdated.UTC().Format(time.RFC3339Nano)
+
+	data, err := json.MarshalIndent(file, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal transcript: %w", err)
+	}
+
+	return &agent.AgentSession{
+		SessionID:  sessionID,
+		AgentName:  g.Name(),
+		RepoPath:   repoPath,
+		SessionRef: filepath.Join(sessionDir, sessionFileName(sessionID, startTime)),
+		StartTime:  startTime,
+		NativeData: data,
+	}, nil
+}
+
+// sessionFileName returns Gemini's file name for a session:
+// session-<date>-<first 8 chars of id>.json.
+func sessionFileName(sessionID string, start time.Time) string {
+	shortID := sessionID
+	if len(shortID) > 8 {
+		shortID = shortID[:8]
+	}
+	return "session-" + start.UTC().Format("2006-01-02T15-04") + "-" + shortID + ".json"
+}
+
+// toolCallEntry converts a Gemini tool call to a normalized tool entry.
+func toolCallEntry(call convertToolCall, ts time.Time) agent.SessionEntry {
+	if callTS := parseTimestamp(call.Timestamp); !callTS.IsZero() {
+		ts = callTS
+	}
+	name := call.Name
+	if normalized, ok := toolNamesToNormalized[name]; ok {
+		name = normalized
+	}
+	input := argsToNormalized(call.Name, call.Args)
+
+	entry := agent.SessionEntry{
+		UUID:      call.ID,
+		Type:      agent.EntryTool,
+		Timestamp: ts,
+		ToolName:  name,
+		ToolInput: input,
+	}
+	if path, ok := input["file_path"].(string); ok && path != "" {
+		entry.FilesAffected = []string{path}
+	}
+
+	var outputs []string
+	for _, result := range call.Result {
+		resp := result.FunctionResponse.Response
+		if out, ok := resp["output"]; ok {
+			outputs = append(outputs, agent.ToolOutputText(out))
+		} else if errOut, ok := resp["error"]; ok {
+			outputs = append(outputs, agent.ToolOutputText(errOut))
+		}
+	}
+	if len(outputs) > 0 {
+		entry.ToolOutput = strings.Join(outputs, "\n")
+	}
+	return entry
+}
+
+// argsToNormalized renames Gemini-specific argument names to the normalized
+// names (Claude's), so file paths survive conversion.
+func argsToNormalized(name string, args map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(args))
+	for k, v := range args {
+		out[k] = v
+	}
+	if name == ToolReadFile {
+		if path, ok := out["absolute_path"]; ok {
+			out["file_path"] = path
+			delete(out, "absolute_path")
+		}
+	}
+	return out
+}
+
+// argsFromNormalized is the reverse of argsToNormalized.
+func argsFromNormalized(name string, input map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(input))
+	for k, v := range input {
+		out[k] = v
+	}
+	switch name {
+	case ToolReadFile:
+		if path, ok := out["file_path"]; ok {
+			out["absolute_path"] = path
+			delete(out, "file_path")
+		}
+	case ToolWebFetch:
+		// Gemini's web_fetch takes the URLs inside the prompt
+		if url, ok := out["url"].(string); ok && url != "" {
+			prompt, _ := out["prompt"].(string)
+			out["prompt"] = strings.TrimSpace(prompt + "\n" + url)
+			delete(out, "url")
+		}
+	}
+	return out
+}
+
+// messageText returns message content, which is a string or a list of parts.
+func messageText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var parts []struct {
+		Text string `json:"text"`
+	}
+	if err := json.Unmarshal(raw, &parts); err != nil {
+		return ""
+	}
+	texts := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p.Text != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
+
+func parseTimestamp(s string) time.Time {
+	ts, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return ts
+}
@@ -0,0 +1,161 @@
+package geminicli
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+const convertTestSession = `{
+  "sessionId": "0f7a2b3c-1111-2222-3333-444455556666",
+  "projectHash": "abc",
+  "startTime": "2026-01-02T10:00:00.000Z",
+  "lastUpdated": "2026-01-02T10:00:05.000Z",
+  "messages": [
+    {"id": "m1", "timestamp": "2026-01-02T10:00:00.000Z", "type": "user", "content": "read main.go"},
+    {"id": "m2", "timestamp": "2026-01-02T10:00:01.000Z", "type": "info", "content": "Switched model"},
+    {"id": "m3", "timestamp": "2026-01-02T10:00:02.000Z", "type": "gemini", "content": "Reading it now.",
+     "toolCalls": [
+       {"id": "read_file-1", "name": "read_file", "args": {"absolute_path": "/repo/main.go"},
+        "result": [{"functionResponse": {"id": "read_file-1", "name": "read_file", "response": {"output": "package main"}}}],
+        "status": "success", "timestamp": "2026-01-02T10:00:03.000Z"},
+       {"id": "save_memory-1", "name": "save_memory", "args": {"fact": "likes Go"},
+        "result": [{"functionResponse": {"id": "save_memory-1", "name": "save_memory", "response": {"error": "disabled"}}}],
+        "status": "error"}
+     ]},
+    {"id": "m4", "timestamp": "2026-01-02T10:00:04.000Z", "type": "user", "content": [{"text": "thanks"}]}
+  ]
+}`
+
+func TestSessionToEntries(t *testing.T) {
+	t.Parallel()
+
+	g := &GeminiCLIAgent{}
+	entries, err := g.SessionToEntries(&agent.AgentSession{NativeData: []byte(convertTestSession)})
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+
+	if len(entries) != 5 {
+		t.Fatalf("got %d entries, want 5: %+v", len(entries), entries)
+	}
+	if entries[0].Type != agent.EntryUser || entries[0].Content != "read main.go" {
+		t.Errorf("entries[0] = %+v", entries[0])
+	}
+	if entries[1].Type != agent.EntryAssistant || entries[1].Content != "Reading it now." {
+		t.Errorf("entries[1] = %+v", entries[1])
+	}
+
+	read := entries[2]
+	if read.ToolName != agent.ToolRead || read.ToolOutput != "package main" {
+		t.Errorf("entries[2] = %+v, want normalized read tool", read)
+	}
+	if input := agent.ToolInputMap(read.ToolInput); input["file_path"] != "/repo/main.go" {
+		t.Errorf("read input = %v, want absolute_path renamed to file_path", input)
+	}
+	if !read.Timestamp.Equal(time.Date(2026, 1, 2, 10, 0, 3, 0, time.UTC)) {
+		t.Errorf("read timestamp = %v", read.Timestamp)
+	}
+
+	if entries[3].ToolName != "save_memory" || entries[3].ToolOutput != "disabled" {
+		t.Errorf("entries[3] = %+v", entries[3])
+	}
+	if entries[4].Content != "thanks" {
+		t.Errorf("entries[4] = %+v, want text from parts", entries[4])
+	}
+}
+
+func TestSessionFromEntries(t *testing.T) {
+	t.Parallel()
+
+	g := &GeminiCLIAgent{}
+	dir := t.TempDir()
+	start := time.Date(2026, 3, 4, 5, 6, 0, 0, time.UTC)
+	entries := []agent.SessionEntry{
+		{Type: agent.EntryUser, Timestamp: start, Content: "fix the test"},
+		{Type: agent.EntryTool, Timestamp: start, ToolName: agent.ToolEdit, ToolInput: map[string]interface{}{
+			"file_path": "/repo/a_test.go", "old_string": "1", "new_string": "2",
+		}, ToolOutput: "ok"},
+		{Type: agent.EntryTool, Timestamp: start, ToolName: "TodoWrite", ToolInput: map[string]interface{}{"todos": []interface{}{}}},
+		{Type: agent.EntryAssistant, Timestamp: start, Content: "Fixed."},
+	}
+
+	session, err := g.SessionFromEntries("0123456789abcdef", "/repo", dir, entries)
+	if err != nil {
+		t.Fatalf("SessionFromEntries() error = %v", err)
+	}
+	if session.AgentName != agent.AgentNameGemini {
+		t.Errorf("AgentName = %q", session.AgentName)
+	}
+	if want := filepath.Join(dir, "session-2026-03-04T05-06-01234567.json"); session.SessionRef != want {
+		t.Errorf("SessionRef = %q, want %q", session.SessionRef, want)
+	}
+
+	var file convertSession
+	if err := json.Unmarshal(session.NativeData, &file); err != nil {
+		t.Fatalf("output is not JSON: %v", err)
+	}
+	if file.SessionID != "0123456789abcdef" || file.ProjectHash == "" || file.StartTime == "" {
+		t.Errorf("session header = %+v", file)
+	}
+	// user, gemini (holding the edit), note, gemini
+	if len(file.Messages) != 4 {
+		t.Fatalf("got %d messages, want 4", len(file.Messages))
+	}
+	edit := file.Messages[1]
+	if edit.Type != MessageTypeGemini || len(edit.ToolCalls) != 1 || edit.ToolCalls[0].Name != ToolReplace {
+		t.Errorf("messages[1] = %+v, want gemini message with replace call", edit)
+	}
+	if !strings.Contains(messageText(file.Messages[2].Content), "[Tool call: TodoWrite]") {
+		t.Errorf("unknown tool not rendered as text: %s", file.Messages[2].Content)
+	}
+
+	// The generated file works with the existing transcript helpers
+	if files, err := ExtractModifiedFiles(session.NativeData); err != nil || len(files) != 1 || files[0] != "/repo/a_test.go" {
+		t.Errorf("ExtractModifiedFiles() = %v, %v", files, err)
+	}
+	if got, err := ExtractLastUserPrompt(session.NativeData); err != nil || got != "fix the test" {
+		t.Errorf("ExtractLastUserPrompt() = %q, %v", got, err)
+	}
+
+	// ResolveSessionFile finds the converted file by session ID once written
+	if err := g.WriteSession(session); err != nil {
+		t.Fatalf("WriteSession() error = %v", err)
+	}
+	if got := g.ResolveSessionFile(dir, "0123456789abcdef"); got != session.SessionRef {
+		t.Errorf("ResolveSessionFile() = %q, want %q", got, session.SessionRef)
+	}
+}
+
+func TestSessionEntries_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	g := &GeminiCLIAgent{}
+	entries, err := g.SessionToEntries(&agent.AgentSession{NativeData: []byte(convertTestSession)})
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+	session, err := g.SessionFromEntries("rt", "/repo", t.TempDir(), entries)
+	if err != nil {
+		t.Fatalf("SessionFromEntries() error = %v", err)
+	}
+	again, err := g.SessionToEntries(session)
+	if err != nil {
+		t.Fatalf("SessionToEntries() error = %v", err)
+	}
+
+	if len(again) != len(entries) {
+		t.Fatalf("round trip has %d entries, want %d", len(again), len(entries))
+	}
+	read := again[2]
+	if read.ToolName != agent.ToolRead || read.ToolOutput != "package main" {
+		t.Errorf("read tool after round trip = %+v", read)
+	}
+	if input := agent.ToolInputMap(read.ToolInput); input["file_path"] != "/repo/main.go" {
+		t.Errorf("read input after round trip = %v", input)
+	}
+}
@@ -0,0 +1,23 @@
+package geminicli
+
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+// Ensure GeminiCLIAgent implements FileEditToolParser
+var _ agent.FileEditToolParser = (*GeminiCLIAgent)(nil)
+
+// ParseEditedFile returns the file a write_file or replace tool call wrote.
+func (g *GeminiCLIAgent) ParseEditedFile(toolName string, toolInput []byte) (string, bool) {
+	if !slices.Contains(FileModificationTools, toolName) {
+		return "", false
+	}
+	var input fileToolInput
+	if err := json.Unmarshal(toolInput, &input); err != nil || input.FilePath == "" {
+		return "", false
+	}
+	return input.FilePath, true
+}
@@ -0,0 +1,23 @@
+package geminicli
+
+import "testing"
+
+func TestParseEditedFile(t *testing.T) {
+	t.Parallel()
+
+	g := &GeminiCLIAgent{}
+	file, ok := g.ParseEditedFile(ToolWriteFile, []byte(`{"file_path":"/repo/main.go","content":"package main"}`))
+	if !ok || file != "/repo/main.go" {
+		t.Errorf("ParseEditedFile(write_file) = (%q, %v), want (%q, true)", file, ok, "/repo/main.go")
+	}
+	file, ok = g.ParseEditedFile(ToolReplace, []byte(`{"file_path":"/repo/util.go","old_string":"a","new_string":"b"}`))
+	if !ok || file != "/repo/util.go" {
+		t.Errorf("ParseEditedFile(replace) = (%q, %v), want (%q, true)", file, ok, "/repo/util.go")
+	}
+	if _, ok := g.ParseEditedFile(ToolShell, []byte(`{"command":"touch main.go"}`)); ok {
+		t.Error("ParseEditedFile(run_shell_command) = true, want false")
+	}
+	if _, ok := g.ParseEditedFile(ToolWriteFile, []byte(`{}`)); ok {
+		t.Error("ParseEditedFile() without a file_path = true, want false")
+	}
+}
@@ -99,7 +99,7 @@ func (g *GeminiCLIAgent) ParseHookInput(hookType agent.HookType, reader io.Reade
 
 	// Parse based on hook type
 	switch hookType {
-	case agent.HookSessionStart, agent.HookSessionEnd, agent.HookStop:
+	case agent.HookSessionStart, agent.HookSessionEnd, agent.HookStop, agent.HookPreCompact:
 		var raw sessionInfoRaw
 		if err := json.Unmarshal(data, &raw); err != nil {
 			return nil, fmt.Errorf("failed to parse session info: %w", err)
@@ -115,6 +115,9 @@ func (g *GeminiCLIAgent) ParseHookInput(hookType agent.HookType, reader io.Reade
 		if raw.Reason != "" {
 			input.RawData["reason"] = raw.Reason
 		}
+		if raw.Trigger != "" {
+			input.RawData["trigger"] = raw.Trigger
+		}
 
 	case agent.HookUserPromptSubmit:
 		// BeforeAgent is Gemini's equivalent to Claude's UserPromptSubmit
@@ -463,3 +466,69 @@ func (g *GeminiCLIAgent) ReassembleTranscript(chunks [][]byte) ([]byte, error) {
 	}
 	return result, nil
 }
+
+// TranscriptMerger interface implementation
+
+var _ agent.TranscriptMerger = (*GeminiCLIAgent)(nil)
+
+// MergeTranscripts appends the messages of later that earlier doesn't already
+// contain to earlier's messages. Gemini CLI replaces older messages with a
+// summary when it compresses the chat, so messages are matched by ID (or by
+// content when they have none). Top-level fields are taken from later.
+func (g *GeminiCLIAgent) MergeTranscripts(earlier, later []byte) ([]byte, error) {
+	var earlierTop, laterTop map[string]json.RawMessage
+	if err := json.Unmarshal(earlier, &earlierTop); err != nil {
+		return nil, fmt.Errorf("failed to parse earlier transcript: %w", err)
+	}
+	if err := json.Unmarshal(later, &laterTop); err != nil {
+		return nil, fmt.Errorf("failed to parse later transcript: %w", err)
+	}
+
+	var earlierMessages, laterMessages []json.RawMessage
+	if raw, ok := earlierTop["messages"]; ok {
+		if err := json.Unmarshal(raw, &earlierMessages); err != nil {
+			return nil, fmt.Errorf("failed to parse earlier messages: %w", err)
+		}
+	}
+	if raw, ok := laterTop["messages"]; ok {
+		if err := json.Unmarshal(raw, &laterMessages); err != nil {
+			return nil, fmt.Errorf("failed to parse later messages: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool, len(earlierMessages))
+	for _, msg := range earlierMessages {
+		seen[geminiMessageKey(msg)] = true
+	}
+	merged := earlierMessages
+	for _, msg := range laterMessages {
+		key := geminiMessageKey(msg)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, msg)
+	}
+
+	messagesJSON, err := json.Marshal(merged)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged messages: %w", err)
+	}
+	laterTop["messages"] = messagesJSON
+	result, err := json.Marshal(laterTop)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal merged transcript: %w", err)
+	}
+	return result, nil
+}
+
+// geminiMessageKey identifies a message by its ID, or by its content if it has none.
+func geminiMessageKey(msg json.RawMessage) string {
+	var withID struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(msg, &withID); err == nil && withID.ID != "" {
+		return "id:" + withID.ID
+	}
+	return "raw:" + string(msg)
+}
@@ -470,6 +470,7 @@ func TestGetSupportedHooks(t *testing.T) {
 		agent.HookUserPromptSubmit, // Maps to Gemini's BeforeAgent
 		agent.HookPreToolUse,       // Maps to Gemini's BeforeTool
 		agent.HookPostToolUse,      // Maps to Gemini's AfterTool
+		agent.HookPreCompact,       // Maps to Gemini's PreCompress
 	}
 
 	if len(hooks) != len(expected) {
@@ -726,6 +727,45 @@ func TestReassembleTranscript_EmptyChunks(t *testing.T) {
 	}
 }
 
+func TestMergeTranscripts_AfterCompression(t *testing.T) {
+	ag := &GeminiCLIAgent{}
+
+	earlier := []byte(`{"sessionId":"s1","messages":[{"id":"m1","type":"user","content":"one"},{"id":"m2","type":"gemini","content":"two"}]}`)
+	later := []byte(`{"sessionId":"s1","lastUpdated":"now","messages":[{"id":"sum","type":"user","content":"summary"},{"id":"m2","type":"gemini","content":"two"},{"id":"m3","type":"user","content":"three"}]}`)
+
+	merged, err := ag.MergeTranscripts(earlier, later)
+	if err != nil {
+		t.Fatalf("MergeTranscripts() error = %v", err)
+	}
+
+	var parsed struct {
+		SessionID   string          `json:"sessionId"`
+		LastUpdated string          `json:"lastUpdated"`
+		Messages    []GeminiMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(merged, &parsed); err != nil {
+		t.Fatalf("Failed to unmarshal result: %v", err)
+	}
+	var ids []string
+	for _, msg := range parsed.Messages {
+		ids = append(ids, msg.ID)
+	}
+	if strings.Join(ids, ",") != "m1,m2,sum,m3" {
+		t.Errorf("merged message IDs = %v, want [m1 m2 sum m3]", ids)
+	}
+	if parsed.SessionID != "s1" || parsed.LastUpdated != "now" {
+		t.Errorf("top-level fields = %q, %q; want them taken from the later transcript", parsed.SessionID, parsed.LastUpdated)
+	}
+}
+
+func TestMergeTranscripts_InvalidJSON(t *testing.T) {
+	ag := &GeminiCLIAgent{}
+
+	if _, err := ag.MergeTranscripts([]byte(`not json`), []byte(`{"messages":[]}`)); err == nil {
+		t.Error("MergeTranscripts() should error on invalid JSON")
+	}
+}
+
 func TestChunkTranscript_SingleOversizedMessage(t *testing.T) {
 	ag := &GeminiCLIAgent{}
 
@@ -11,10 +11,11 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 )
 
-// Ensure GeminiCLIAgent implements HookSupport and HookHandler
+// Ensure GeminiCLIAgent implements HookSupport, HookHandler and HookCommandLister
 var (
-	_ agent.HookSupport = (*GeminiCLIAgent)(nil)
-	_ agent.HookHandler = (*GeminiCLIAgent)(nil)
+	_ agent.HookSupport       = (*GeminiCLIAgent)(nil)
+	_ agent.HookHandler       = (*GeminiCLIAgent)(nil)
+	_ agent.HookCommandLister = (*GeminiCLIAgent)(nil)
 )
 
 // Gemini CLI hook names - these become subcommands under `entire hooks gemini`
@@ -399,6 +400,7 @@ func (g *GeminiCLIAgent) GetSupportedHooks() []agent.HookType {
 		agent.HookUserPromptSubmit, // Maps to Gemini's BeforeAgent (user prompt)
 		agent.HookPreToolUse,       // Maps to Gemini's BeforeTool
 		agent.HookPostToolUse,      // Maps to Gemini's AfterTool
+		agent.HookPreCompact,       // Maps to Gemini's PreCompress
 	}
 }
 
@@ -483,3 +485,42 @@ func removeEntireHooks(matchers []GeminiHookMatcher) []GeminiHookMatcher {
 	}
 	return result
 }
+
+// InstalledHookCommands returns the commands of the Entire hooks in
+// .gemini/settings.json.
+func (g *GeminiCLIAgent) InstalledHookCommands() ([]string, error) {
+	repoRoot, err := paths.RepoRoot()
+	if err != nil {
+		repoRoot = "."
+	}
+	data, err := os.ReadFile(filepath.Join(repoRoot, g.GetHookConfigPath())) //nolint:gosec // path is constructed from repo root + fixed path
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read settings.json: %w", err)
+	}
+
+	var settings GeminiSettings
+	if err := json.Unmarshal(data, &settings); err != nil {
+		return nil, fmt.Errorf("failed to parse settings.json: %w", err)
+	}
+
+	var commands []string
+	seen := make(map[string]bool)
+	h := settings.Hooks
+	for _, matchers := range [][]GeminiHookMatcher{
+		h.SessionStart, h.SessionEnd, h.BeforeAgent, h.AfterAgent, h.BeforeModel, h.AfterModel,
+		h.BeforeToolSelection, h.BeforeTool, h.AfterTool, h.PreCompress, h.Notification,
+	} {
+		for _, matcher := range matchers {
+			for _, hook := range matcher.Hooks {
+				if isEntireHook(hook.Command) && !seen[hook.Command] {
+					seen[hook.Command] = true
+					commands = append(commands, hook.Command)
+				}
+			}
+		}
+	}
+	return commands, nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/entireio/cli/cmd/entire/cli/agent/testutil"
@@ -537,3 +538,26 @@ func verifyHookCommand(t *testing.T, matchers []GeminiHookMatcher, expectedMatch
 	}
 	t.Errorf("hook with matcher=%q command=%q not found", expectedMatcher, expectedCommand)
 }
+
+func TestInstalledHookCommands(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Chdir(tempDir)
+
+	agent := &GeminiCLIAgent{}
+	if _, err := agent.InstallHooks(true, false); err != nil {
+		t.Fatalf("InstallHooks() error = %v", err)
+	}
+
+	commands, err := agent.InstalledHookCommands()
+	if err != nil {
+		t.Fatalf("InstalledHookCommands() error = %v", err)
+	}
+	if len(commands) == 0 {
+		t.Fatal("InstalledHookCommands() returned no commands")
+	}
+	for _, command := range commands {
+		if !strings.HasPrefix(command, "go run ${GEMINI_PROJECT_DIR}/cmd/entire/main.go hooks gemini ") {
+			t.Errorf("unexpected command %q", command)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package geminicli
+
+import (
+	"encoding/json"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+)
+
+// Ensure GeminiCLIAgent implements ShellToolParser
+var _ agent.ShellToolParser = (*GeminiCLIAgent)(nil)
+
+// shellOutputPattern matches the command output in run_shell_command's
+// llmContent, which is followed by the "Error:" and "Exit Code:" fields.
+var shellOutputPattern = regexp.MustCompile(`(?s)Output: (.*?)\nError: `)
+
+// shellExitCodePattern matches the exit status in run_shell_command's llmContent
+var shellExitCodePattern = regexp.MustCompile(`(?m)^Exit Code: (-?\d+)\s*$`)
+
+// ParseShellCommand returns the command line of a run_shell_command call.
+func (g *GeminiCLIAgent) ParseShellCommand(toolName string, toolInput []byte) (string, bool) {
+	if toolName != ToolShell {
+		return "", false
+	}
+	var input shellToolInput
+	if err := json.Unmarshal(toolInput, &input); err != nil || input.Command == "" {
+		return "", false
+	}
+	return input.Command, true
+}
+
+// ParseShellResult extracts the output and exit status from a
+// run_shell_command response. Gemini CLI reports "Exit Code: (none)" for
+// commands that succeeded, so a command that reports no exit status and
+// wasn't cancelled exited 0.
+func (g *GeminiCLIAgent) ParseShellResult(toolResponse []byte) agent.ShellResult {
+	var response shellToolResponse
+	if err := json.Unmarshal(toolResponse, &response); err != nil {
+		return agent.ShellResult{}
+	}
+	llmContent := rawText(response.LLMContent)
+
+	var result agent.ShellResult
+	if match := shellOutputPattern.FindStringSubmatch(llmContent); match != nil {
+		result.Output = strings.TrimSpace(match[1])
+		if result.Output == "(empty)" {
+			result.Output = ""
+		}
+	} else {
+		result.Output = strings.TrimSpace(rawText(response.ReturnDisplay))
+	}
+	result.Interrupted = strings.Contains(llmContent, "Command was cancelled")
+	// The last match is the field; earlier ones are in the command's output
+	if matches := shellExitCodePattern.FindAllStringSubmatch(llmContent, -1); len(matches) > 0 {
+		if code, err := strconv.Atoi(matches[len(matches)-1][1]); err == nil {
+			result.ExitCode = &code
+		}
+	}
+	if result.ExitCode == nil && !result.Interrupted && response.Error == nil {
+		zero := 0
+		result.ExitCode = &zero
+	}
+	return result
+}
+
+// rawText returns a JSON string's value. Structured values (Gemini CLI sends
+// parts arrays for some tools) are returned as raw JSON.
+func rawText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	return string(raw)
+}
@@ -0,0 +1,74 @@
+package geminicli
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseShellCommand(t *testing.T) {
+	t.Parallel()
+
+	g := &GeminiCLIAgent{}
+	command, ok := g.ParseShellCommand(ToolShell, []byte(`{"command":"npm test","description":"Run tests"}`))
+	if !ok || command != "npm test" {
+		t.Errorf("ParseShellCommand(run_shell_command) = (%q, %v), want (%q, true)", command, ok, "npm test")
+	}
+	if _, ok := g.ParseShellCommand(ToolWriteFile, []byte(`{"command":"npm test"}`)); ok {
+		t.Error("ParseShellCommand(write_file) = true, want false")
+	}
+}
+
+func TestParseShellResult(t *testing.T) {
+	t.Parallel()
+
+	shellResponse := func(llmContent string) []byte {
+		data, err := json.Marshal(map[string]string{"llmContent": llmContent, "returnDisplay": "display"})
+		if err != nil {
+			t.Fatalf("failed to marshal response: %v", err)
+		}
+		return data
+	}
+
+	g := &GeminiCLIAgent{}
+
+	t.Run("success", func(t *testing.T) {
+		t.Parallel()
+		result := g.ParseShellResult(shellResponse("Command: ls\nDirectory: (root)\nOutput: a.go\nb.go\nError: (none)\nExit Code: (none)\nSignal: (none)"))
+		if result.Output != "a.go\nb.go" {
+			t.Errorf("Output = %q, want %q", result.Output, "a.go\nb.go")
+		}
+		if result.ExitCode == nil || *result.ExitCode != 0 {
+			t.Errorf("ExitCode = %v, want 0", result.ExitCode)
+		}
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		t.Parallel()
+		result := g.ParseShellResult(shellResponse("Command: make\nDirectory: (root)\nOutput: Exit Code: 7 is not real\nError: (none)\nExit Code: 2\nSignal: (none)"))
+		if result.ExitCode == nil || *result.ExitCode != 2 {
+			t.Errorf("ExitCode = %v, want 2", result.ExitCode)
+		}
+	})
+
+	t.Run("empty output", func(t *testing.T) {
+		t.Parallel()
+		result := g.ParseShellResult(shellResponse("Command: true\nDirectory: (root)\nOutput: (empty)\nError: (none)\nExit Code: (none)"))
+		if result.Output != "" {
+			t.Errorf("Output = %q, want empty", result.Output)
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		t.Parallel()
+		result := g.ParseShellResult(shellResponse("Command was cancelled by user before it could complete."))
+		if !result.Interrupted {
+			t.Error("Interrupted = false, want true")
+		}
+		if result.ExitCode != nil {
+			t.Errorf("ExitCode = %d, want nil", *result.ExitCode)
+		}
+		if result.Output != "display" {
+			t.Errorf("Output = %q, want the display text", result.Output)
+		}
+	})
+}
@@ -50,8 +50,9 @@ type sessionInfoRaw struct {
 	Cwd            string `json:"cwd"`
 	HookEventName  string `json:"hook_event_name"`
 	Timestamp      string `json:"timestamp"`
-	Source         string `json:"source,omitempty"` // For SessionStart: startup, resume, clear
-	Reason         string `json:"reason,omitempty"` // For SessionEnd: exit, logout
+	Source         string `json:"source,omitempty"`  // For SessionStart: startup, resume, clear
+	Reason         string `json:"reason,omitempty"`  // For SessionEnd: exit, logout
+	Trigger        string `json:"trigger,omitempty"` // For PreCompress: manual, auto
 }
 
 // agentHookInputRaw is the JSON structure from BeforeAgent/AfterAgent hooks.
@@ -96,6 +97,32 @@ var FileModificationTools = []string{
 	ToolReplace,
 }
 
+// fileToolInput is the tool_input of a file-modifying tool call
+type fileToolInput struct {
+	FilePath string `json:"file_path"`
+}
+
+// ToolShell is Gemini CLI's tool for running shell commands
+const ToolShell = "run_shell_command"
+
+// shellToolInput is the tool_input of a run_shell_command call
+type shellToolInput struct {
+	Command     string `json:"command"`
+	Description string `json:"description,omitempty"`
+	Directory   string `json:"directory,omitempty"`
+}
+
+// shellToolResponse is the tool_response of a run_shell_command call.
+// llmContent is the report sent to the model, with "Command:", "Output:",
+// "Error:" and "Exit Code:" lines; returnDisplay is the output shown to the user.
+type shellToolResponse struct {
+	LLMContent    json.RawMessage `json:"llmContent"`
+	ReturnDisplay json.RawMessage `json:"returnDisplay"`
+	Error         *struct {
+		Message string `json:"message"`
+	} `json:"error,omitempty"`
+}
+
 // geminiMessageTokens represents token usage from a Gemini API response.
 // This is specific to Gemini's API format where each message has a tokens object.
 type geminiMessageTokens struct {
@@ -0,0 +1,82 @@
+package agent
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// MergeTranscripts merges a transcript saved before context compaction with
+// the live transcript using the appropriate agent.
+// If agentType is empty or the agent doesn't implement TranscriptMerger,
+// falls back to JSONL (line-based) merging.
+func MergeTranscripts(earlier, later []byte, agentType AgentType) ([]byte, error) {
+	if len(earlier) == 0 {
+		return later, nil
+	}
+	if len(later) == 0 {
+		return earlier, nil
+	}
+
+	// Try to get the agent by type
+	if agentType != "" {
+		ag, err := GetByAgentType(agentType)
+		if err == nil {
+			if merger, ok := ag.(TranscriptMerger); ok {
+				merged, mergeErr := merger.MergeTranscripts(earlier, later)
+				if mergeErr != nil {
+					return nil, fmt.Errorf("agent merge failed: %w", mergeErr)
+				}
+				return merged, nil
+			}
+		}
+	}
+
+	// Fall back to JSONL merging (default)
+	return MergeJSONL(earlier, later), nil
+}
+
+// MergeJSONL appends the entries of later that aren't already in earlier.
+// Entries are matched by their "uuid" field, so an entry the agent rewrote
+// after compaction isn't saved twice; lines without one are matched by their
+// content. When later simply continued earlier, the result is later.
+func MergeJSONL(earlier, later []byte) []byte {
+	seen := make(map[string]bool)
+	for _, line := range bytes.Split(earlier, []byte("\n")) {
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			seen[jsonlEntryKey(line)] = true
+		}
+	}
+
+	var result bytes.Buffer
+	result.Write(earlier)
+	if !bytes.HasSuffix(earlier, []byte("\n")) {
+		result.WriteByte('\n')
+	}
+	for _, line := range bytes.Split(later, []byte("\n")) {
+		trimmed := bytes.TrimSpace(line)
+		if len(trimmed) == 0 {
+			continue
+		}
+		key := jsonlEntryKey(trimmed)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Write(line)
+		result.WriteByte('\n')
+	}
+	return result.Bytes()
+}
+
+// jsonlEntryKey identifies a transcript line by its entry UUID, falling back
+// to the line itself.
+func jsonlEntryKey(line []byte) string {
+	var entry struct {
+		UUID string `json:"uuid"`
+	}
+	if err := json.Unmarshal(line, &entry); err == nil && entry.UUID != "" {
+		return "uuid:" + entry.UUID
+	}
+	return "line:" + string(line)
+}
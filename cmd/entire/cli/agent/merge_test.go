@@ -0,0 +1,48 @@
+package agent
+
+import "testing"
+
+func TestMergeJSONL_Continuation(t *testing.T) {
+	earlier := []byte("{\"uuid\":\"1\"}\n{\"uuid\":\"2\"}\n")
+	later := []byte("{\"uuid\":\"1\"}\n{\"uuid\":\"2\"}\n{\"uuid\":\"3\"}\n")
+
+	merged := MergeJSONL(earlier, later)
+	if string(merged) != string(later) {
+		t.Errorf("MergeJSONL() = %q, want %q", merged, later)
+	}
+}
+
+func TestMergeJSONL_Rewritten(t *testing.T) {
+	earlier := []byte("{\"uuid\":\"1\"}\n{\"uuid\":\"2\"}")
+	later := []byte("{\"uuid\":\"summary\"}\n{\"uuid\":\"2\"}\n{\"uuid\":\"3\"}\n")
+
+	merged := MergeJSONL(earlier, later)
+	want := "{\"uuid\":\"1\"}\n{\"uuid\":\"2\"}\n{\"uuid\":\"summary\"}\n{\"uuid\":\"3\"}\n"
+	if string(merged) != want {
+		t.Errorf("MergeJSONL() = %q, want %q", merged, want)
+	}
+}
+
+func TestMergeJSONL_DedupesByUUID(t *testing.T) {
+	earlier := []byte("{\"uuid\":\"1\",\"text\":\"hello\"}\n")
+	later := []byte("{\"uuid\":\"1\",\"text\":\"hello\",\"isCompacted\":true}\n{\"uuid\":\"2\"}\n")
+
+	merged := MergeJSONL(earlier, later)
+	want := "{\"uuid\":\"1\",\"text\":\"hello\"}\n{\"uuid\":\"2\"}\n"
+	if string(merged) != want {
+		t.Errorf("MergeJSONL() = %q, want %q", merged, want)
+	}
+}
+
+func TestMergeTranscripts_Empty(t *testing.T) {
+	content := []byte("{\"uuid\":\"1\"}\n")
+
+	merged, err := MergeTranscripts(nil, content, "")
+	if err != nil || string(merged) != string(content) {
+		t.Errorf("MergeTranscripts(nil, content) = %q, %v; want content", merged, err)
+	}
+	merged, err = MergeTranscripts(content, nil, "")
+	if err != nil || string(merged) != string(content) {
+		t.Errorf("MergeTranscripts(content, nil) = %q, %v; want content", merged, err)
+	}
+}
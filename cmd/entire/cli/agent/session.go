@@ -6,9 +6,10 @@ import "time"
 // Each agent stores data in its native format (JSONL, SQLite, Markdown, etc.)
 // and only the originating agent can read/write it.
 //
-// Design: Sessions are NOT interoperable between agents. A session created by
-// Claude Code can only be read/written by Claude Code. This simplifies the
-// implementation as we don't need format conversion.
+// Design: NativeData is never shared between agents. A session created by
+// Claude Code can only be read/written by Claude Code. Agents that implement
+// SessionConverter can translate their sessions to and from normalized
+// Entries, which ConvertSession uses to move a session between agents.
 //
 //nolint:revive // AgentSession is clearer than Session in context of the package
 type AgentSession struct {
@@ -32,7 +33,8 @@ type AgentSession struct {
 	DeletedFiles  []string
 
 	// Optional normalized entries - agents may populate this if needed
-	// for operations that benefit from structured access
+	// for operations that benefit from structured access, such as
+	// cross-agent conversion (see SessionConverter)
 	Entries []SessionEntry
 }
 
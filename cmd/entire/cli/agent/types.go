@@ -12,6 +12,7 @@ const (
 	HookStop             HookType = "stop"
 	HookPreToolUse       HookType = "pre_tool_use"
 	HookPostToolUse      HookType = "post_tool_use"
+	HookPreCompact       HookType = "pre_compact"
 )
 
 // HookInput contains normalized data from hook callbacks
@@ -35,6 +36,16 @@ type HookInput struct {
 	RawData map[string]interface{}
 }
 
+// ShellResult is the outcome of a shell command run by the agent's shell tool
+type ShellResult struct {
+	// Output is the command's combined stdout and stderr
+	Output string
+	// ExitCode is the command's exit status, nil if the agent didn't report one
+	ExitCode *int
+	// Interrupted is true if the command was stopped before it finished
+	Interrupted bool
+}
+
 // SessionChange represents detected session activity (for FileWatcher)
 type SessionChange struct {
 	SessionID  string
@@ -0,0 +1,281 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// browsePointLimit matches the number of points "entire rewind" considers,
+// so every point shown in the browser can be rewound to by ID.
+const browsePointLimit = 20
+
+func newBrowseCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "browse",
+		Short: "Browse sessions and checkpoints in an interactive viewer",
+		Long: `Browse opens a full-screen viewer for the sessions and checkpoints on the
+current branch.
+
+The left pane lists sessions, the middle pane shows the selected session's
+checkpoint timeline, and the right pane shows the selected checkpoint's
+transcript (with collapsible tool calls), its diff, or a rewind preview.
+
+Keys:
+  tab / shift+tab   Move between panes
+  ↑/↓ or k/j        Select, or scroll the right pane
+  enter             Open the selection / expand or collapse a tool call
+  t / d / p         Show transcript, diff or rewind preview
+  e                 Expand or collapse all tool calls
+  r                 Rewind to the selected checkpoint (asks for confirmation)
+  R                 Resume the selected checkpoint's session
+  q                 Quit
+
+With ACCESSIBLE=1 set, browse falls back to the "entire rewind" prompts.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Check if Entire is disabled
+			if checkDisabledGuard(cmd.OutOrStdout()) {
+				return nil
+			}
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+			if IsAccessibleMode() {
+				return runRewindInteractive()
+			}
+			if !term.IsTerminal(int(os.Stdout.Fd())) {
+				return errors.New("entire browse requires a terminal; use 'entire rewind --list' or 'entire explain' instead")
+			}
+			return runBrowse()
+		},
+	}
+
+	return cmd
+}
+
+func runBrowse() error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	start := GetStrategy()
+	points, err := start.GetRewindPoints(browsePointLimit)
+	if err != nil {
+		return fmt.Errorf("failed to find rewind points: %w", err)
+	}
+	if len(points) == 0 {
+		fmt.Println("No checkpoints found.")
+		fmt.Println("Checkpoints are created automatically when agent sessions end.")
+		return nil
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+	store := checkpoint.NewGitStore(repo)
+
+	model := newBrowseModel(groupBrowseSessions(points), browseLoaders{
+		transcript: func(p strategy.RewindPoint) ([]summarize.Entry, error) {
+			return loadBrowseTranscript(store, p)
+		},
+		diff: func(p strategy.RewindPoint) (string, error) {
+			return loadBrowseDiff(repo, p)
+		},
+		preview: start.PreviewRewind,
+	})
+
+	final, err := tea.NewProgram(model, tea.WithAltScreen()).Run()
+	if err != nil {
+		return fmt.Errorf("browser failed: %w", err)
+	}
+
+	m, ok := final.(*browseModel)
+	if !ok || m.action == nil {
+		return nil
+	}
+	return runBrowseAction(*m.action)
+}
+
+// runBrowseAction performs the action chosen in the browser, after the
+// full-screen UI has exited so that output and prompts use the normal terminal.
+func runBrowseAction(action browseAction) error {
+	switch action.kind {
+	case browseActionRewind:
+		return runRewindToInternal(action.point.ID, false, false)
+	case browseActionResume:
+		if !action.point.CheckpointID.IsEmpty() {
+			return resumeSession(action.point.SessionID, action.point.CheckpointID, false)
+		}
+		// Uncommitted checkpoints belong to a session whose transcript is still local
+		ag, err := getAgent(action.point.Agent)
+		if err != nil {
+			return fmt.Errorf("failed to get agent: %w", err)
+		}
+		fmt.Printf("To continue this session, run:\n  %s\n", ag.FormatResumeCommand(action.point.SessionID))
+		return nil
+	default:
+		return nil
+	}
+}
+
+// browseSession is a session and its checkpoints, newest first.
+type browseSession struct {
+	ID     string
+	Prompt string
+	Agent  string
+	Latest time.Time
+	Points []strategy.RewindPoint
+}
+
+// groupBrowseSessions groups rewind points by session, most recently active first.
+// Points without a session ID are grouped together.
+func groupBrowseSessions(points []strategy.RewindPoint) []browseSession {
+	byID := make(map[string]*browseSession)
+	var order []string
+	for _, p := range points {
+		s, ok := byID[p.SessionID]
+		if !ok {
+			s = &browseSession{ID: p.SessionID, Agent: string(p.Agent)}
+			byID[p.SessionID] = s
+			order = append(order, p.SessionID)
+		}
+		if s.Prompt == "" {
+			s.Prompt = p.SessionPrompt
+		}
+		if p.Date.After(s.Latest) {
+			s.Latest = p.Date
+		}
+		s.Points = append(s.Points, p)
+	}
+
+	sessions := make([]browseSession, 0, len(order))
+	for _, sessionID := range order {
+		s := byID[sessionID]
+		sort.SliceStable(s.Points, func(i, j int) bool {
+			return s.Points[i].Date.After(s.Points[j].Date)
+		})
+		sessions = append(sessions, *s)
+	}
+	sort.SliceStable(sessions, func(i, j int) bool {
+		return sessions[i].Latest.After(sessions[j].Latest)
+	})
+	return sessions
+}
+
+// loadBrowseTranscript returns the condensed transcript of a checkpoint. For
+// committed checkpoints only the checkpoint's own portion is returned.
+func loadBrowseTranscript(store *checkpoint.GitStore, point strategy.RewindPoint) ([]summarize.Entry, error) {
+	var transcript []byte
+	agentType := point.Agent
+
+	if !point.CheckpointID.IsEmpty() {
+		ctx := context.Background()
+		content, err := store.ReadSessionContentByID(ctx, point.CheckpointID, point.SessionID)
+		if err != nil {
+			content, err = store.ReadLatestSessionContent(ctx, point.CheckpointID)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checkpoint %s: %w", point.CheckpointID, err)
+		}
+		agentType = content.Metadata.Agent
+		transcript = scopeTranscriptForCheckpoint(content.Transcript, content.Metadata.GetTranscriptStart(), agentType)
+	} else {
+		if point.MetadataDir == "" {
+			return nil, errors.New("checkpoint has no transcript")
+		}
+		var err error
+		transcript, err = store.GetTranscriptFromCommit(plumbing.NewHash(point.ID), point.MetadataDir, agentType)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transcript: %w", err)
+		}
+	}
+
+	if len(transcript) == 0 {
+		return nil, nil
+	}
+	entries, err := summarize.BuildCondensedTranscriptFromBytes(transcript, agentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	return entries, nil
+}
+
+// loadBrowseDiff returns the unified diff of a checkpoint commit against its
+// first parent, leaving out Entire's own metadata files.
+func loadBrowseDiff(repo *git.Repository, point strategy.RewindPoint) (string, error) {
+	commit, err := repo.CommitObject(plumbing.NewHash(point.ID))
+	if err != nil {
+		return "", fmt.Errorf("failed to read commit %s: %w", point.ID, err)
+	}
+
+	toTree, err := commit.Tree()
+	if err != nil {
+		return "", fmt.Errorf("failed to read tree: %w", err)
+	}
+	fromTree := &object.Tree{}
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return "", fmt.Errorf("failed to read parent commit: %w", err)
+		}
+		if fromTree, err = parent.Tree(); err != nil {
+			return "", fmt.Errorf("failed to read parent tree: %w", err)
+		}
+	}
+
+	patch, err := fromTree.Patch(toTree)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute diff: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := diff.NewUnifiedEncoder(&buf, diff.DefaultContextLines).Encode(codeOnlyPatch{patch}); err != nil {
+		return "", fmt.Errorf("failed to format diff: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// codeOnlyPatch filters Entire metadata (.entire/) out of a patch.
+type codeOnlyPatch struct {
+	*object.Patch
+}
+
+func (p codeOnlyPatch) FilePatches() []diff.FilePatch {
+	var patches []diff.FilePatch
+	for _, fp := range p.Patch.FilePatches() {
+		from, to := fp.Files()
+		if isEntireMetadataPath(from) && isEntireMetadataPath(to) {
+			continue
+		}
+		patches = append(patches, fp)
+	}
+	return patches
+}
+
+// isEntireMetadataPath reports whether a diff file is absent or under .entire/.
+func isEntireMetadataPath(f diff.File) bool {
+	return f == nil || paths.IsInfrastructurePath(f.Path())
+}
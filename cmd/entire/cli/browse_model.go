@@ -0,0 +1,639 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// browsePane identifies the pane that has keyboard focus.
+type browsePane int
+
+const (
+	browsePaneSessions browsePane = iota
+	browsePaneTimeline
+	browsePaneDetail
+	browsePaneCount
+)
+
+// browseView is what the detail pane shows for the selected checkpoint.
+type browseView int
+
+const (
+	browseViewTranscript browseView = iota
+	browseViewDiff
+	browseViewPreview
+)
+
+// browseActionKind is an action the browser hands back to run after it exits.
+type browseActionKind int
+
+const (
+	browseActionRewind browseActionKind = iota + 1
+	browseActionResume
+)
+
+type browseAction struct {
+	kind  browseActionKind
+	point strategy.RewindPoint
+}
+
+// browseLoaders fetch detail pane content for a checkpoint. They are called
+// lazily, once per checkpoint, and can be replaced in tests.
+type browseLoaders struct {
+	transcript func(strategy.RewindPoint) ([]summarize.Entry, error)
+	diff       func(strategy.RewindPoint) (string, error)
+	preview    func(strategy.RewindPoint) (*strategy.RewindPreview, error)
+}
+
+// transcriptBlock is a user or assistant message, or a run of consecutive
+// tool calls that can be collapsed into a single line.
+type transcriptBlock struct {
+	entries  []summarize.Entry
+	tools    bool
+	expanded bool
+}
+
+// browseModel is the bubbletea model behind "entire browse".
+type browseModel struct {
+	sessions []browseSession
+	loaders  browseLoaders
+
+	focus      browsePane
+	sessionIdx int
+	pointIdx   int
+	view       browseView
+	blockIdx   int // Selected transcript block
+	scroll     int // First visible line of the detail pane
+
+	// Loaded detail content, keyed by point ID
+	transcripts map[string][]transcriptBlock
+	diffs       map[string]string
+	previews    map[string]*strategy.RewindPreview
+	loadErrs    map[string]error // Keyed by view and point ID
+
+	width  int
+	height int
+
+	confirmingRewind bool
+	status           string
+	action           *browseAction // Set when the user picks an action; runs after exit
+}
+
+func newBrowseModel(sessions []browseSession, loaders browseLoaders) *browseModel {
+	m := &browseModel{
+		sessions:    sessions,
+		loaders:     loaders,
+		transcripts: make(map[string][]transcriptBlock),
+		diffs:       make(map[string]string),
+		previews:    make(map[string]*strategy.RewindPreview),
+		loadErrs:    make(map[string]error),
+		width:       120,
+		height:      30,
+	}
+	m.loadDetail()
+	return m
+}
+
+// Init implements tea.Model.
+func (m *browseModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update implements tea.Model.
+func (m *browseModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+	case tea.KeyMsg:
+		return m.handleKey(msg.String())
+	}
+	return m, nil
+}
+
+func (m *browseModel) handleKey(key string) (tea.Model, tea.Cmd) {
+	if key == "ctrl+c" {
+		return m, tea.Quit
+	}
+
+	if m.confirmingRewind {
+		m.confirmingRewind = false
+		if key == "y" || key == "Y" {
+			if point, ok := m.selectedPoint(); ok {
+				m.action = &browseAction{kind: browseActionRewind, point: point}
+				return m, tea.Quit
+			}
+		}
+		m.status = "Rewind cancelled."
+		return m, nil
+	}
+	m.status = ""
+
+	switch key {
+	case "q", "esc":
+		return m, tea.Quit
+	case "tab", "right", "l":
+		if key == "tab" || m.focus < browsePaneCount-1 {
+			m.focus = (m.focus + 1) % browsePaneCount
+		}
+	case "shift+tab", "left", "h":
+		if key == "shift+tab" || m.focus > 0 {
+			m.focus = (m.focus + browsePaneCount - 1) % browsePaneCount
+		}
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "pgup":
+		m.scrollBy(-m.detailHeight())
+	case "pgdown":
+		m.scrollBy(m.detailHeight())
+	case "enter":
+		switch m.focus {
+		case browsePaneSessions, browsePaneTimeline:
+			m.focus++
+		case browsePaneDetail:
+			m.toggleBlock()
+		}
+	case "t":
+		m.setView(browseViewTranscript)
+	case "d":
+		m.setView(browseViewDiff)
+	case "p":
+		m.setView(browseViewPreview)
+	case "e":
+		m.toggleAllBlocks()
+	case "r":
+		if _, ok := m.selectedPoint(); ok {
+			m.confirmingRewind = true
+		}
+	case "R":
+		if point, ok := m.selectedPoint(); ok {
+			m.action = &browseAction{kind: browseActionResume, point: point}
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// move moves the selection in the focused pane, or scrolls the detail pane.
+func (m *browseModel) move(delta int) {
+	switch m.focus {
+	case browsePaneSessions:
+		if next := m.sessionIdx + delta; next >= 0 && next < len(m.sessions) {
+			m.sessionIdx = next
+			m.pointIdx = 0
+			m.resetDetail()
+		}
+	case browsePaneTimeline:
+		if next := m.pointIdx + delta; next >= 0 && next < len(m.currentPoints()) {
+			m.pointIdx = next
+			m.resetDetail()
+		}
+	case browsePaneDetail:
+		if m.view == browseViewTranscript {
+			blocks := m.currentBlocks()
+			if next := m.blockIdx + delta; next >= 0 && next < len(blocks) {
+				m.blockIdx = next
+				m.ensureBlockVisible()
+			}
+			return
+		}
+		m.scrollBy(delta)
+	}
+}
+
+func (m *browseModel) scrollBy(delta int) {
+	maxScroll := max(0, len(m.detailLines(m.detailWidth()))-m.detailHeight())
+	m.scroll = min(max(0, m.scroll+delta), maxScroll)
+}
+
+func (m *browseModel) setView(view browseView) {
+	m.view = view
+	m.scroll = 0
+	m.loadDetail()
+}
+
+func (m *browseModel) resetDetail() {
+	m.blockIdx = 0
+	m.scroll = 0
+	m.loadDetail()
+}
+
+func (m *browseModel) toggleBlock() {
+	blocks := m.currentBlocks()
+	if m.view != browseViewTranscript || m.blockIdx >= len(blocks) || !blocks[m.blockIdx].tools {
+		return
+	}
+	blocks[m.blockIdx].expanded = !blocks[m.blockIdx].expanded
+}
+
+// toggleAllBlocks expands every tool call block, or collapses them all if
+// they are already expanded.
+func (m *browseModel) toggleAllBlocks() {
+	blocks := m.currentBlocks()
+	expand := false
+	for _, b := range blocks {
+		if b.tools && !b.expanded {
+			expand = true
+			break
+		}
+	}
+	for i := range blocks {
+		if blocks[i].tools {
+			blocks[i].expanded = expand
+		}
+	}
+	m.ensureBlockVisible()
+}
+
+// ensureBlockVisible scrolls the detail pane so the selected block's first line is shown.
+func (m *browseModel) ensureBlockVisible() {
+	line := 0
+	blocks := m.currentBlocks()
+	width := m.detailWidth()
+	for i := 0; i < m.blockIdx && i < len(blocks); i++ {
+		line += len(renderTranscriptBlock(blocks[i], false, width))
+	}
+	height := m.detailHeight()
+	if line < m.scroll {
+		m.scroll = line
+	} else if line >= m.scroll+height {
+		m.scroll = line - height + 1
+	}
+}
+
+func (m *browseModel) currentPoints() []strategy.RewindPoint {
+	if m.sessionIdx >= len(m.sessions) {
+		return nil
+	}
+	return m.sessions[m.sessionIdx].Points
+}
+
+func (m *browseModel) selectedPoint() (strategy.RewindPoint, bool) {
+	points := m.currentPoints()
+	if m.pointIdx >= len(points) {
+		return strategy.RewindPoint{}, false
+	}
+	return points[m.pointIdx], true
+}
+
+func (m *browseModel) currentBlocks() []transcriptBlock {
+	point, ok := m.selectedPoint()
+	if !ok {
+		return nil
+	}
+	return m.transcripts[point.ID]
+}
+
+// loadDetail loads the current view's content for the selected point, once.
+func (m *browseModel) loadDetail() {
+	point, ok := m.selectedPoint()
+	if !ok {
+		return
+	}
+	errKey := fmt.Sprintf("%d:%s", m.view, point.ID)
+	if _, failed := m.loadErrs[errKey]; failed {
+		return
+	}
+
+	var err error
+	switch m.view {
+	case browseViewTranscript:
+		if _, loaded := m.transcripts[point.ID]; loaded || m.loaders.transcript == nil {
+			return
+		}
+		var entries []summarize.Entry
+		if entries, err = m.loaders.transcript(point); err == nil {
+			m.transcripts[point.ID] = buildTranscriptBlocks(entries)
+		}
+	case browseViewDiff:
+		if _, loaded := m.diffs[point.ID]; loaded || m.loaders.diff == nil {
+			return
+		}
+		var d string
+		if d, err = m.loaders.diff(point); err == nil {
+			m.diffs[point.ID] = d
+		}
+	case browseViewPreview:
+		if _, loaded := m.previews[point.ID]; loaded || m.loaders.preview == nil {
+			return
+		}
+		var preview *strategy.RewindPreview
+		if preview, err = m.loaders.preview(point); err == nil {
+			m.previews[point.ID] = preview
+		}
+	}
+	if err != nil {
+		m.loadErrs[errKey] = err
+	}
+}
+
+// buildTranscriptBlocks groups consecutive tool calls into collapsed blocks.
+func buildTranscriptBlocks(entries []summarize.Entry) []transcriptBlock {
+	blocks := make([]transcriptBlock, 0, len(entries))
+	for _, e := range entries {
+		isTool := e.Type == summarize.EntryTypeTool
+		if isTool && len(blocks) > 0 && blocks[len(blocks)-1].tools {
+			blocks[len(blocks)-1].entries = append(blocks[len(blocks)-1].entries, e)
+			continue
+		}
+		blocks = append(blocks, transcriptBlock{entries: []summarize.Entry{e}, tools: isTool})
+	}
+	return blocks
+}
+
+// Styles for the browser.
+var (
+	browseTitleStyle    = lipgloss.NewStyle().Bold(true)
+	browseDimStyle      = lipgloss.NewStyle().Faint(true)
+	browseSelectedStyle = lipgloss.NewStyle().Reverse(true)
+	browseAddStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("2"))
+	browseDelStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("1"))
+	browseHunkStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("6"))
+	browseUserStyle     = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("4"))
+	browseAgentStyle    = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("5"))
+	browseWarnStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("3"))
+	browsePaneStyle     = lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(lipgloss.Color("8"))
+	browseFocusedStyle  = browsePaneStyle.BorderForeground(lipgloss.Color("12"))
+)
+
+// Pane widths include borders. The detail pane takes the remaining width.
+func (m *browseModel) sessionsWidth() int { return max(24, m.width/4) }
+func (m *browseModel) timelineWidth() int { return max(30, m.width/3) }
+func (m *browseModel) detailWidth() int {
+	return max(20, m.width-m.sessionsWidth()-m.timelineWidth()) - 2
+}
+
+// paneHeight is the content height of each pane: the screen minus the title,
+// help line and pane borders.
+func (m *browseModel) paneHeight() int { return max(3, m.height-4) }
+
+// detailHeight leaves room for the detail pane's tab line.
+func (m *browseModel) detailHeight() int { return max(1, m.paneHeight()-1) }
+
+// View implements tea.Model.
+func (m *browseModel) View() string {
+	title := browseTitleStyle.Render("entire browse") + browseDimStyle.Render(fmt.Sprintf("  %d sessions", len(m.sessions)))
+
+	panes := lipgloss.JoinHorizontal(lipgloss.Top,
+		m.renderPane(browsePaneSessions, m.sessionsWidth()-2, m.sessionLines(m.sessionsWidth()-2)),
+		m.renderPane(browsePaneTimeline, m.timelineWidth()-2, m.timelineLines(m.timelineWidth()-2)),
+		m.renderPane(browsePaneDetail, m.detailWidth(), m.visibleDetailLines()),
+	)
+
+	return lipgloss.JoinVertical(lipgloss.Left, title, panes, m.footer())
+}
+
+func (m *browseModel) renderPane(pane browsePane, width int, lines []string) string {
+	height := m.paneHeight()
+	if len(lines) > height {
+		lines = lines[:height]
+	}
+	style := browsePaneStyle
+	if m.focus == pane {
+		style = browseFocusedStyle
+	}
+	return style.Width(width).Height(height).Render(strings.Join(lines, "\n"))
+}
+
+func (m *browseModel) footer() string {
+	if m.confirmingRewind {
+		point, _ := m.selectedPoint() //nolint:errcheck // confirmingRewind is only set with a selection
+		return browseWarnStyle.Render(fmt.Sprintf("Rewind to %s %s? Changes after this point may be lost. [y/N]",
+			shortPointID(point), truncateRunes(sanitizeForTerminal(point.Message), 40)))
+	}
+	if m.status != "" {
+		return m.status
+	}
+	return browseDimStyle.Render("tab: pane  ↑↓: select  enter: open/toggle  t/d/p: transcript/diff/preview  e: expand all  r: rewind  R: resume  q: quit")
+}
+
+func (m *browseModel) sessionLines(width int) []string {
+	lines := make([]string, 0, len(m.sessions)+1)
+	lines = append(lines, browseTitleStyle.Render("Sessions"))
+	for i, s := range m.sessions {
+		label := s.Prompt
+		if label == "" {
+			label = s.ID
+		}
+		if label == "" {
+			label = "(unknown session)"
+		}
+		line := truncateRunes(fmt.Sprintf("%s %s", s.Latest.Format("01-02 15:04"), sanitizeForTerminal(label)), width)
+		if i == m.sessionIdx {
+			line = browseSelectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return scrollToSelection(lines, m.sessionIdx+1, m.paneHeight())
+}
+
+func (m *browseModel) timelineLines(width int) []string {
+	points := m.currentPoints()
+	lines := make([]string, 0, len(points)+1)
+	lines = append(lines, browseTitleStyle.Render("Checkpoints"))
+	for i, p := range points {
+		marker := "○"
+		switch {
+		case p.IsLogsOnly:
+			marker = "●"
+		case p.IsTaskCheckpoint:
+			marker = "◆"
+		}
+		line := truncateRunes(fmt.Sprintf("%s %s %s %s", marker, shortPointID(p), p.Date.Format("01-02 15:04"), sanitizeForTerminal(p.Message)), width)
+		if i == m.pointIdx {
+			line = browseSelectedStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return scrollToSelection(lines, m.pointIdx+1, m.paneHeight())
+}
+
+// shortPointID returns the short commit hash of committed points, and a
+// placeholder for uncommitted ones whose hash is an internal shadow commit.
+func shortPointID(p strategy.RewindPoint) string {
+	if !p.IsLogsOnly {
+		return "(temp) "
+	}
+	if len(p.ID) > 7 {
+		return p.ID[:7]
+	}
+	return p.ID
+}
+
+func (m *browseModel) visibleDetailLines() []string {
+	tabs := []string{"Transcript", "Diff", "Preview"}
+	for i, tab := range tabs {
+		if browseView(i) == m.view {
+			tabs[i] = browseSelectedStyle.Render(" " + tab + " ")
+		} else {
+			tabs[i] = " " + tab + " "
+		}
+	}
+	lines := m.detailLines(m.detailWidth())
+	start := min(m.scroll, len(lines))
+	end := min(start+m.detailHeight(), len(lines))
+	return append([]string{strings.Join(tabs, browseDimStyle.Render("│"))}, lines[start:end]...)
+}
+
+// detailLines renders the full content of the detail pane for the selected point.
+func (m *browseModel) detailLines(width int) []string {
+	point, ok := m.selectedPoint()
+	if !ok {
+		return []string{browseDimStyle.Render("No checkpoint selected")}
+	}
+	if err, failed := m.loadErrs[fmt.Sprintf("%d:%s", m.view, point.ID)]; failed {
+		return wrapLines(browseWarnStyle.Render("Could not load: "+err.Error()), width)
+	}
+
+	switch m.view {
+	case browseViewDiff:
+		return renderDiffLines(m.diffs[point.ID], width)
+	case browseViewPreview:
+		return renderPreviewLines(point, m.previews[point.ID], width)
+	default:
+		blocks := m.transcripts[point.ID]
+		if len(blocks) == 0 {
+			return []string{browseDimStyle.Render("No transcript for this checkpoint")}
+		}
+		var lines []string
+		for i, b := range blocks {
+			lines = append(lines, renderTranscriptBlock(b, m.focus == browsePaneDetail && i == m.blockIdx, width)...)
+		}
+		return lines
+	}
+}
+
+// renderTranscriptBlock renders a block. Selected blocks get a marker so the
+// rendered height is the same whether or not the block is selected.
+func renderTranscriptBlock(b transcriptBlock, selected bool, width int) []string {
+	marker := "  "
+	if selected {
+		marker = "› "
+	}
+	inner := max(1, width-2)
+
+	var lines []string
+	if b.tools {
+		names := make([]string, 0, len(b.entries))
+		for _, e := range b.entries {
+			names = append(names, e.ToolName)
+		}
+		arrow := "▸"
+		if b.expanded {
+			arrow = "▾"
+		}
+		header := fmt.Sprintf("%s %d tool call(s): %s", arrow, len(b.entries), strings.Join(names, ", "))
+		lines = append(lines, marker+browseDimStyle.Render(truncateRunes(header, inner)))
+		if b.expanded {
+			for _, e := range b.entries {
+				detail := e.ToolName
+				if e.ToolDetail != "" {
+					detail += ": " + sanitizeForTerminal(e.ToolDetail)
+				}
+				lines = append(lines, "    "+truncateRunes(detail, max(1, width-4)))
+			}
+		}
+		return lines
+	}
+
+	e := b.entries[0]
+	label := browseAgentStyle.Render("Agent")
+	if e.Type == summarize.EntryTypeUser {
+		label = browseUserStyle.Render("You")
+	}
+	lines = append(lines, marker+label)
+	for _, line := range wrapLines(sanitizeForTerminal(e.Content), inner) {
+		lines = append(lines, "  "+line)
+	}
+	return append(lines, "")
+}
+
+func renderDiffLines(d string, width int) []string {
+	if strings.TrimSpace(d) == "" {
+		return []string{browseDimStyle.Render("No code changes in this checkpoint")}
+	}
+	raw := strings.Split(strings.TrimRight(d, "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		line = truncateRunes(strings.ReplaceAll(sanitizeForTerminal(line), "\t", "    "), width)
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"), strings.HasPrefix(line, "diff "):
+			line = browseTitleStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			line = browseAddStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			line = browseDelStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			line = browseHunkStyle.Render(line)
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func renderPreviewLines(point strategy.RewindPoint, preview *strategy.RewindPreview, width int) []string {
+	var lines []string
+	if point.IsLogsOnly {
+		lines = append(lines, wrapLines("This is a committed checkpoint: rewinding restores the session transcript only and leaves your files unchanged.", width)...)
+		return lines
+	}
+	if preview == nil {
+		return []string{browseDimStyle.Render("No preview available")}
+	}
+
+	section := func(title string, files []string, style lipgloss.Style) {
+		if len(files) == 0 {
+			return
+		}
+		lines = append(lines, style.Render(fmt.Sprintf("%s (%d):", title, len(files))))
+		for _, f := range files {
+			lines = append(lines, "  "+truncateRunes(f, max(1, width-2)))
+		}
+		lines = append(lines, "")
+	}
+	section("Files restored", preview.FilesToRestore, browseTitleStyle)
+	section("Untracked files DELETED", preview.FilesToDelete, browseDelStyle)
+	section("Uncommitted changes reverted", preview.TrackedChanges, browseWarnStyle)
+	if len(lines) == 0 {
+		lines = append(lines, "Rewinding changes no files.")
+	}
+	return lines
+}
+
+// scrollToSelection returns the window of lines that keeps the selected line
+// visible, always keeping the first (title) line.
+func scrollToSelection(lines []string, selected, height int) []string {
+	if len(lines) <= height || selected < height {
+		return lines
+	}
+	start := selected - height + 2
+	return append([]string{lines[0]}, lines[start:selected+1]...)
+}
+
+// wrapLines soft-wraps text to width.
+func wrapLines(s string, width int) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(lipgloss.NewStyle().Width(width).Render(s), "\n")
+}
+
+// truncateRunes shortens s to at most width runes, marking the cut with "…".
+func truncateRunes(s string, width int) string {
+	runes := []rune(s)
+	if len(runes) <= width {
+		return s
+	}
+	if width <= 1 {
+		return string(runes[:width])
+	}
+	return string(runes[:width-1]) + "…"
+}
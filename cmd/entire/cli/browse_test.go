@@ -0,0 +1,233 @@
+package cli
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func sampleBrowsePoints() []strategy.RewindPoint {
+	base := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	return []strategy.RewindPoint{
+		{ID: "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa", Message: "Old session", Date: base, SessionID: "s1", SessionPrompt: "first prompt", IsLogsOnly: true, CheckpointID: id.MustCheckpointID("a1b2c3d4e5f6")},
+		{ID: "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb", Message: "New session step 1", Date: base.Add(time.Hour), SessionID: "s2", SessionPrompt: "second prompt"},
+		{ID: "cccccccccccccccccccccccccccccccccccccccc", Message: "New session step 2", Date: base.Add(2 * time.Hour), SessionID: "s2"},
+	}
+}
+
+func sampleBrowseEntries() []summarize.Entry {
+	return []summarize.Entry{
+		{Type: summarize.EntryTypeUser, Content: "please fix it"},
+		{Type: summarize.EntryTypeTool, ToolName: "Read", ToolDetail: "main.go"},
+		{Type: summarize.EntryTypeTool, ToolName: "Edit", ToolDetail: "main.go"},
+		{Type: summarize.EntryTypeAssistant, Content: "Fixed."},
+	}
+}
+
+func newTestBrowseModel(t *testing.T) *browseModel {
+	t.Helper()
+	return newBrowseModel(groupBrowseSessions(sampleBrowsePoints()), browseLoaders{
+		transcript: func(strategy.RewindPoint) ([]summarize.Entry, error) {
+			return sampleBrowseEntries(), nil
+		},
+		diff: func(p strategy.RewindPoint) (string, error) {
+			return "diff --git a/main.go b/main.go\n@@ -1 +1 @@\n-old\n+new " + p.ID[:3] + "\n", nil
+		},
+		preview: func(strategy.RewindPoint) (*strategy.RewindPreview, error) {
+			return nil, errors.New("preview unavailable")
+		},
+	})
+}
+
+func pressKeys(m *browseModel, keys ...string) tea.Cmd {
+	var cmd tea.Cmd
+	for _, key := range keys {
+		var msg tea.KeyMsg
+		switch key {
+		case "tab":
+			msg = tea.KeyMsg{Type: tea.KeyTab}
+		case "enter":
+			msg = tea.KeyMsg{Type: tea.KeyEnter}
+		default:
+			msg = tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+		}
+		_, cmd = m.Update(msg)
+	}
+	return cmd
+}
+
+func TestGroupBrowseSessions(t *testing.T) {
+	t.Parallel()
+
+	sessions := groupBrowseSessions(sampleBrowsePoints())
+
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	if sessions[0].ID != "s2" || sessions[1].ID != "s1" {
+		t.Errorf("sessions should be most recent first, got %s, %s", sessions[0].ID, sessions[1].ID)
+	}
+	if sessions[0].Prompt != "second prompt" {
+		t.Errorf("session prompt = %q", sessions[0].Prompt)
+	}
+	if len(sessions[0].Points) != 2 || sessions[0].Points[0].Message != "New session step 2" {
+		t.Errorf("points should be newest first, got %+v", sessions[0].Points)
+	}
+}
+
+func TestBuildTranscriptBlocks(t *testing.T) {
+	t.Parallel()
+
+	blocks := buildTranscriptBlocks(sampleBrowseEntries())
+
+	if len(blocks) != 3 {
+		t.Fatalf("expected 3 blocks, got %d", len(blocks))
+	}
+	if !blocks[1].tools || len(blocks[1].entries) != 2 || blocks[1].expanded {
+		t.Errorf("consecutive tool calls should form one collapsed block, got %+v", blocks[1])
+	}
+}
+
+func TestBrowseModel_NavigationLoadsDetail(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t)
+	if !strings.Contains(m.View(), "please fix it") {
+		t.Errorf("expected transcript of the first checkpoint in view:\n%s", m.View())
+	}
+
+	// Select the second checkpoint in the timeline and show its diff
+	pressKeys(m, "tab", "j", "d")
+	point, _ := m.selectedPoint()
+	if point.ID[:3] != "bbb" {
+		t.Fatalf("selected point = %s, want bbb...", point.ID)
+	}
+	if !strings.Contains(m.View(), "+new bbb") {
+		t.Errorf("expected diff of the selected checkpoint in view:\n%s", m.View())
+	}
+
+	// Switch session: the timeline resets to its newest checkpoint
+	pressKeys(m, "tab", "tab", "j")
+	if m.sessionIdx != 1 || m.pointIdx != 0 {
+		t.Errorf("sessionIdx/pointIdx = %d/%d, want 1/0", m.sessionIdx, m.pointIdx)
+	}
+}
+
+func TestBrowseModel_ToggleToolCalls(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t)
+	if strings.Contains(m.View(), "Edit: main.go") {
+		t.Fatal("tool calls should start collapsed")
+	}
+
+	// Focus the detail pane, select the tool block and expand it
+	pressKeys(m, "tab", "tab", "j", "enter")
+	if !strings.Contains(m.View(), "Edit: main.go") {
+		t.Errorf("expected expanded tool calls in view:\n%s", m.View())
+	}
+
+	pressKeys(m, "e")
+	if strings.Contains(m.View(), "Edit: main.go") {
+		t.Error("e should collapse all tool calls when they are all expanded")
+	}
+}
+
+func TestBrowseModel_RewindRequiresConfirmation(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t)
+
+	pressKeys(m, "r")
+	if !strings.Contains(m.View(), "[y/N]") {
+		t.Errorf("expected confirmation prompt in view:\n%s", m.View())
+	}
+	pressKeys(m, "n")
+	if m.action != nil || !strings.Contains(m.View(), "Rewind cancelled.") {
+		t.Errorf("declining should cancel the rewind, action = %+v", m.action)
+	}
+
+	if cmd := pressKeys(m, "r", "y"); cmd == nil {
+		t.Error("confirming should quit the browser")
+	}
+	if m.action == nil || m.action.kind != browseActionRewind || m.action.point.ID[:3] != "ccc" {
+		t.Errorf("unexpected action: %+v", m.action)
+	}
+}
+
+func TestBrowseModel_ResumeAndLoadErrors(t *testing.T) {
+	t.Parallel()
+
+	m := newTestBrowseModel(t)
+
+	pressKeys(m, "p")
+	if !strings.Contains(m.View(), "Could not load: preview unavailable") {
+		t.Errorf("expected load error in view:\n%s", m.View())
+	}
+
+	pressKeys(m, "tab", "tab", "R")
+	if m.action == nil || m.action.kind != browseActionResume {
+		t.Errorf("expected resume action, got %+v", m.action)
+	}
+}
+
+func TestLoadBrowseDiff(t *testing.T) {
+	repo, dir, _ := setupCITestRepo(t)
+	commitFile(t, repo, dir, "main.go", "package main\n", "Add main")
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	d, err := loadBrowseDiff(repo, strategy.RewindPoint{ID: head.Hash().String()})
+	if err != nil {
+		t.Fatalf("loadBrowseDiff() error = %v", err)
+	}
+	if !strings.Contains(d, "+package main") {
+		t.Errorf("expected added line in diff, got:\n%s", d)
+	}
+}
+
+func TestCodeOnlyPatch_FiltersEntireDir(t *testing.T) {
+	repo, dir, _ := setupCITestRepo(t)
+	metadataDir := filepath.Join(dir, ".entire", "metadata", "s1")
+	if err := os.MkdirAll(metadataDir, 0o755); err != nil {
+		t.Fatalf("failed to create metadata dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(metadataDir, "full.jsonl"), []byte("{}\n"), 0o644); err != nil {
+		t.Fatalf("failed to write metadata: %v", err)
+	}
+	commitFile(t, repo, dir, "code.go", "x\n", "Checkpoint")
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if _, err := wt.Add(".entire"); err != nil {
+		t.Fatalf("failed to add metadata: %v", err)
+	}
+	commitFile(t, repo, dir, "code.go", "y\n", "Checkpoint 2")
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	d, err := loadBrowseDiff(repo, strategy.RewindPoint{ID: head.Hash().String()})
+	if err != nil {
+		t.Fatalf("loadBrowseDiff() error = %v", err)
+	}
+	if strings.Contains(d, ".entire") {
+		t.Errorf("diff should not include .entire files, got:\n%s", d)
+	}
+	if !strings.Contains(d, "+y") {
+		t.Errorf("expected code change in diff, got:\n%s", d)
+	}
+}
@@ -0,0 +1,380 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+// Changelog sections, in the order they are rendered.
+const (
+	changelogAdded    = "Added"
+	changelogChanged  = "Changed"
+	changelogFixed    = "Fixed"
+	changelogInternal = "Internal"
+)
+
+var changelogSectionOrder = []string{changelogAdded, changelogChanged, changelogFixed, changelogInternal}
+
+func newChangelogCmd() *cobra.Command {
+	var versionFlag string
+	var noGenerateFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "changelog <from>..<to>",
+		Short: "Generate a changelog from checkpoint summaries",
+		Long: `Changelog writes a Keep a Changelog section for the commits in a range.
+
+Commits that share a checkpoint are grouped into one entry described by the
+checkpoint's summary (its outcome, or its intent). Checkpoints without a
+summary get one generated with the claude CLI; generated summaries are not
+saved. Use --no-generate, or run without the claude CLI installed, to fall
+back to the stored intent and outcome. Commits without a checkpoint are listed
+by their subject line.
+
+Entries are sorted into Added, Changed, Fixed and Internal sections, using the
+commit's conventional commit prefix (feat:, fix:, chore: ...) when present and
+the change type of the checkpoint's generated summary otherwise. Stored
+summaries without a change type get one generated too. Without a generated
+change type, the wording of the summary or subject decides.
+
+Examples:
+  entire changelog v1.2.0..HEAD
+  entire changelog v1.2.0..v1.3.0 --version 1.3.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+			var generator summarize.Generator
+			if !noGenerateFlag {
+				generator = &summarize.ClaudeGenerator{}
+			}
+			return runChangelog(cmd.OutOrStdout(), cmd.ErrOrStderr(), args[0], versionFlag, generator)
+		},
+	}
+
+	cmd.Flags().StringVar(&versionFlag, "version", "", "Version for the section heading (default: the end of the range, or Unreleased for HEAD)")
+	cmd.Flags().BoolVar(&noGenerateFlag, "no-generate", false, "Don't generate missing summaries")
+
+	return cmd
+}
+
+// changelogEntry is a single line in the changelog: one checkpoint (or the
+// checkpoints of one squashed commit), or one commit without a checkpoint.
+type changelogEntry struct {
+	Section       string
+	Text          string
+	CheckpointIDs []id.CheckpointID // Empty for commits without a checkpoint
+	Commits       []plumbing.Hash
+}
+
+// changelogRelease is the rendered changelog section for a range.
+type changelogRelease struct {
+	Version string
+	Date    time.Time
+	Entries []*changelogEntry // In commit order, oldest first
+}
+
+func runChangelog(w, errW io.Writer, revRange, version string, generator summarize.Generator) error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	_, to, ok := strings.Cut(revRange, "..")
+	if !ok || strings.HasPrefix(to, ".") {
+		return fmt.Errorf("invalid range %q (expected <from>..<to>)", revRange)
+	}
+	if to == "" {
+		to = "HEAD"
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+
+	toHash, err := repo.ResolveRevision(plumbing.Revision(to))
+	if err != nil {
+		return fmt.Errorf("revision not found: %s", to)
+	}
+	toCommit, err := repo.CommitObject(*toHash)
+	if err != nil {
+		return fmt.Errorf("failed to read commit %s: %w", to, err)
+	}
+
+	release, err := buildChangelog(context.Background(), errW, repo, revRange, generator)
+	if err != nil {
+		return err
+	}
+
+	release.Version = version
+	if release.Version == "" {
+		release.Version = to
+		if to == "HEAD" {
+			release.Version = "Unreleased"
+		}
+	}
+	release.Date = toCommit.Committer.When
+
+	fmt.Fprint(w, formatChangelog(release))
+	return nil
+}
+
+// buildChangelog collects one entry per checkpoint (or per commit without a
+// checkpoint) in revRange. A nil generator disables summary generation.
+func buildChangelog(ctx context.Context, errW io.Writer, repo *git.Repository, revRange string, generator summarize.Generator) (*changelogRelease, error) {
+	hashes, err := ListCommitsInRange(revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	store := checkpoint.NewGitStore(repo)
+	release := &changelogRelease{}
+	byCheckpoint := make(map[id.CheckpointID]*changelogEntry)
+
+	for _, hash := range hashes {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", hash, err)
+		}
+		if commit.NumParents() > 1 {
+			// Merge commits repeat the changes of the commits they merge
+			continue
+		}
+		subject := strings.SplitN(commit.Message, "\n", 2)[0]
+
+		// Squashed commits carry one trailer per checkpoint
+		cpIDs := trailers.ParseAllCheckpoints(commit.Message)
+		if entry := changelogEntryForCheckpoints(byCheckpoint, cpIDs); entry != nil {
+			entry.Commits = append(entry.Commits, hash)
+			continue
+		}
+
+		var summary *checkpoint.Summary
+		for _, cpID := range cpIDs {
+			summary, generator, err = readChangelogSummary(ctx, errW, store, cpID, generator)
+			if err != nil {
+				return nil, err
+			}
+			if summary != nil {
+				break
+			}
+		}
+
+		entry := &changelogEntry{
+			Section: classifyChangelogEntry(subject, summary),
+			Text:    changelogEntryText(subject, summary),
+			Commits: []plumbing.Hash{hash},
+		}
+		entry.CheckpointIDs = cpIDs
+		for _, cpID := range cpIDs {
+			byCheckpoint[cpID] = entry
+		}
+		release.Entries = append(release.Entries, entry)
+	}
+
+	return release, nil
+}
+
+// changelogEntryForCheckpoints returns the entry an earlier commit created
+// for any of the checkpoints, or nil if there is none.
+func changelogEntryForCheckpoints(byCheckpoint map[id.CheckpointID]*changelogEntry, cpIDs []id.CheckpointID) *changelogEntry {
+	for _, cpID := range cpIDs {
+		if entry, ok := byCheckpoint[cpID]; ok {
+			return entry
+		}
+	}
+	return nil
+}
+
+// readChangelogSummary returns the stored summary of a checkpoint, generating
+// one when it has none. A stored summary without a change type keeps its text
+// and takes the change type of a generated one. Returns a nil summary for
+// checkpoints that don't exist.
+// After the first generation failure the returned generator is nil, so the
+// rest of the range falls back to stored summaries instead of failing repeatedly.
+func readChangelogSummary(ctx context.Context, errW io.Writer, store *checkpoint.GitStore, cpID id.CheckpointID, generator summarize.Generator) (*checkpoint.Summary, summarize.Generator, error) {
+	cpSummary, err := store.ReadCommitted(ctx, cpID)
+	if err != nil {
+		return nil, generator, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+	}
+	if cpSummary == nil || len(cpSummary.Sessions) == 0 {
+		return nil, generator, nil
+	}
+
+	content, err := store.ReadLatestSessionContent(ctx, cpID)
+	if err != nil {
+		return nil, generator, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+	}
+	stored := content.Metadata.Summary
+	if (stored != nil && stored.ChangeType != "") || generator == nil {
+		return stored, generator, nil
+	}
+
+	scoped := scopeTranscriptForCheckpoint(content.Transcript, content.Metadata.GetTranscriptStart(), content.Metadata.Agent)
+	if len(scoped) == 0 {
+		return stored, generator, nil
+	}
+	summary, err := summarize.GenerateFromTranscript(ctx, scoped, cpSummary.FilesTouched, content.Metadata.Agent, generator)
+	if err != nil {
+		fmt.Fprintf(errW, "Warning: could not generate summary for checkpoint %s: %v\n", cpID, err)
+		fmt.Fprintln(errW, "Continuing with stored summaries and commit subjects.")
+		return stored, nil, nil
+	}
+	if stored != nil {
+		classified := *stored
+		classified.ChangeType = summary.ChangeType
+		return &classified, generator, nil
+	}
+	return summary, generator, nil
+}
+
+// conventionalPrefixPattern matches a conventional commit prefix such as
+// "feat:", "fix(cli):" or "refactor!:".
+var conventionalPrefixPattern = regexp.MustCompile(`^([a-zA-Z]+)(\([^)]*\))?!?:\s*`)
+
+// conventionalSections maps conventional commit types to changelog sections.
+var conventionalSections = map[string]string{
+	"feat":     changelogAdded,
+	"feature":  changelogAdded,
+	"fix":      changelogFixed,
+	"bugfix":   changelogFixed,
+	"perf":     changelogChanged,
+	"refactor": changelogInternal,
+	"chore":    changelogInternal,
+	"test":     changelogInternal,
+	"tests":    changelogInternal,
+	"ci":       changelogInternal,
+	"build":    changelogInternal,
+	"docs":     changelogInternal,
+	"style":    changelogInternal,
+}
+
+// changeTypeSections maps the change types of generated summaries to
+// changelog sections.
+var changeTypeSections = map[string]string{
+	checkpoint.ChangeTypeAdded:    changelogAdded,
+	checkpoint.ChangeTypeChanged:  changelogChanged,
+	checkpoint.ChangeTypeFixed:    changelogFixed,
+	checkpoint.ChangeTypeInternal: changelogInternal,
+}
+
+// Leading verbs that decide the section offline, when there is neither a
+// conventional prefix nor a generated change type.
+var (
+	addedVerbs    = []string{"add", "adds", "added", "adding", "introduce", "introduces", "introduced", "implement", "implements", "implemented", "create", "creates", "created", "support", "supports"}
+	fixedVerbs    = []string{"fix", "fixes", "fixed", "fixing", "resolve", "resolves", "resolved", "correct", "corrects", "corrected", "repair", "repairs", "repaired", "prevent", "prevents", "prevented"}
+	internalVerbs = []string{"refactor", "refactors", "refactored", "cleanup", "clean", "cleans", "cleaned", "bump", "bumps", "bumped", "test", "tests", "tested", "lint", "format", "formats", "formatted", "rename", "renames", "renamed"}
+)
+
+// classifyChangelogEntry picks the changelog section for a commit. The
+// conventional commit prefix of the subject wins, then the change type the
+// summary generator picked. Otherwise the leading verb of the summary (intent,
+// then outcome) or subject decides, defaulting to Changed.
+func classifyChangelogEntry(subject string, summary *checkpoint.Summary) string {
+	if m := conventionalPrefixPattern.FindStringSubmatch(subject); m != nil {
+		if section, ok := conventionalSections[strings.ToLower(m[1])]; ok {
+			return section
+		}
+	}
+	if summary != nil {
+		if section, ok := changeTypeSections[strings.ToLower(summary.ChangeType)]; ok {
+			return section
+		}
+	}
+
+	var candidates []string
+	if summary != nil {
+		candidates = append(candidates, summary.Intent, summary.Outcome)
+	}
+	candidates = append(candidates, subject)
+
+	for _, text := range candidates {
+		fields := strings.Fields(strings.ToLower(text))
+		if len(fields) == 0 {
+			continue
+		}
+		verb := strings.Trim(fields[0], ".,:;")
+		switch {
+		case slices.Contains(fixedVerbs, verb):
+			return changelogFixed
+		case slices.Contains(addedVerbs, verb):
+			return changelogAdded
+		case slices.Contains(internalVerbs, verb):
+			return changelogInternal
+		}
+	}
+	return changelogChanged
+}
+
+// changelogEntryText describes an entry by the summary's outcome or intent,
+// falling back to the commit subject without its conventional commit prefix.
+func changelogEntryText(subject string, summary *checkpoint.Summary) string {
+	text := ""
+	if summary != nil {
+		text = summary.Outcome
+		if text == "" {
+			text = summary.Intent
+		}
+	}
+	if text == "" {
+		text = conventionalPrefixPattern.ReplaceAllString(subject, "")
+	}
+	// Keep each entry on one line
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// formatChangelog renders the release as a Keep a Changelog section.
+func formatChangelog(release *changelogRelease) string {
+	var sb strings.Builder
+
+	if release.Version == "Unreleased" {
+		sb.WriteString("## [Unreleased]\n")
+	} else {
+		fmt.Fprintf(&sb, "## [%s] - %s\n", release.Version, release.Date.Format("2006-01-02"))
+	}
+
+	for _, section := range changelogSectionOrder {
+		var lines []string
+		for _, entry := range release.Entries {
+			if entry.Section != section {
+				continue
+			}
+			shortHashes := make([]string, 0, len(entry.Commits))
+			for _, hash := range entry.Commits {
+				shortHashes = append(shortHashes, hash.String()[:7])
+			}
+			lines = append(lines, fmt.Sprintf("- %s (%s)", entry.Text, strings.Join(shortHashes, ", ")))
+		}
+		if len(lines) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n### %s\n\n", section)
+		for _, line := range lines {
+			sb.WriteString(line)
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// fakeChangelogGenerator returns a fixed summary, or an error, and counts calls.
+type fakeChangelogGenerator struct {
+	summary *checkpoint.Summary
+	err     error
+	calls   int
+}
+
+func (g *fakeChangelogGenerator) Generate(_ context.Context, _ summarize.Input) (*checkpoint.Summary, error) {
+	g.calls++
+	return g.summary, g.err
+}
+
+func TestClassifyChangelogEntry(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		subject string
+		summary *checkpoint.Summary
+		want    string
+	}{
+		{"conventional feat", "feat(cli): new command", nil, changelogAdded},
+		{"conventional fix", "fix: crash on empty repo", nil, changelogFixed},
+		{"conventional chore", "chore!: drop old config", nil, changelogInternal},
+		{"conventional prefix wins over summary", "fix: typo", &checkpoint.Summary{Intent: "Add a feature"}, changelogFixed},
+		{"generated change type", "Update login", &checkpoint.Summary{Intent: "Fix the login redirect loop", ChangeType: checkpoint.ChangeTypeInternal}, changelogInternal},
+		{"conventional prefix wins over change type", "feat: login", &checkpoint.Summary{ChangeType: checkpoint.ChangeTypeFixed}, changelogAdded},
+		{"summary intent verb", "Update login", &checkpoint.Summary{Intent: "Fix the login redirect loop"}, changelogFixed},
+		{"summary outcome verb", "WIP", &checkpoint.Summary{Intent: "The user wanted X", Outcome: "Added X"}, changelogAdded},
+		{"subject verb", "Refactor the parser", nil, changelogInternal},
+		{"default", "Update the README wording", nil, changelogChanged},
+		{"unknown conventional type", "wip: stuff", nil, changelogChanged},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := classifyChangelogEntry(tt.subject, tt.summary); got != tt.want {
+				t.Errorf("classifyChangelogEntry(%q) = %q, want %q", tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangelogEntryText(t *testing.T) {
+	t.Parallel()
+
+	if got := changelogEntryText("feat: add x", nil); got != "add x" {
+		t.Errorf("expected subject without prefix, got %q", got)
+	}
+	if got := changelogEntryText("subject", &checkpoint.Summary{Intent: "intent", Outcome: "Did the\nthing"}); got != "Did the thing" {
+		t.Errorf("expected single-line outcome, got %q", got)
+	}
+	if got := changelogEntryText("subject", &checkpoint.Summary{Intent: "intent"}); got != "intent" {
+		t.Errorf("expected intent fallback, got %q", got)
+	}
+}
+
+func TestFormatChangelog(t *testing.T) {
+	t.Parallel()
+
+	release := &changelogRelease{
+		Version: "1.3.0",
+		Date:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Entries: []*changelogEntry{
+			{Section: changelogFixed, Text: "Fixed a crash", Commits: []plumbing.Hash{plumbing.NewHash("1111111111111111111111111111111111111111")}},
+			{Section: changelogAdded, Text: "Added a command", Commits: []plumbing.Hash{
+				plumbing.NewHash("2222222222222222222222222222222222222222"),
+				plumbing.NewHash("3333333333333333333333333333333333333333"),
+			}},
+		},
+	}
+
+	want := "## [1.3.0] - 2026-01-02\n\n### Added\n\n- Added a command (2222222, 3333333)\n\n### Fixed\n\n- Fixed a crash (1111111)\n"
+	if got := formatChangelog(release); got != want {
+		t.Errorf("formatChangelog() =\n%s\nwant:\n%s", got, want)
+	}
+
+	release.Version = "Unreleased"
+	if got := formatChangelog(release); !strings.HasPrefix(got, "## [Unreleased]\n") {
+		t.Errorf("expected Unreleased heading without date, got:\n%s", got)
+	}
+}
+
+func TestRunChangelog(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+
+	// Two commits share a checkpoint with a stored summary
+	withSummary := id.MustCheckpointID("a1b2c3d4e5f6")
+	writeAttributedCheckpoint(t, repo, withSummary, 1, 1)
+	if err := checkpoint.NewGitStore(repo).UpdateSummary(context.Background(), withSummary, &checkpoint.Summary{
+		Intent:     "Add a widget",
+		Outcome:    "Added the widget command",
+		ChangeType: checkpoint.ChangeTypeAdded,
+	}); err != nil {
+		t.Fatalf("UpdateSummary() error = %v", err)
+	}
+	first := commitFile(t, repo, dir, "widget.go", "a\n", trailers.FormatCheckpoint("Widget part 1", withSummary))
+	second := commitFile(t, repo, dir, "widget2.go", "b\n", trailers.FormatCheckpoint("Widget part 2", withSummary))
+
+	// A stored summary without a change type is classified by the generator
+	oldSummary := id.MustCheckpointID("c3d4e5f6a1b2")
+	writeAttributedCheckpoint(t, repo, oldSummary, 1, 1)
+	if err := checkpoint.NewGitStore(repo).UpdateSummary(context.Background(), oldSummary, &checkpoint.Summary{
+		Intent:  "Make the prompt shorter",
+		Outcome: "Shortened the prompt",
+	}); err != nil {
+		t.Fatalf("UpdateSummary() error = %v", err)
+	}
+	commitFile(t, repo, dir, "prompt.go", "e\n", trailers.FormatCheckpoint("Prompt", oldSummary))
+
+	// A checkpoint without a summary gets one from the generator
+	withoutSummary := id.MustCheckpointID("b2c3d4e5f6a1")
+	writeAttributedCheckpoint(t, repo, withoutSummary, 1, 1)
+	commitFile(t, repo, dir, "bug.go", "c\n", trailers.FormatCheckpoint("Handle nil config", withoutSummary))
+
+	commitFile(t, repo, dir, "notes.md", "d\n", "chore: tidy notes")
+
+	generator := &fakeChangelogGenerator{summary: &checkpoint.Summary{
+		Intent:     "Handle a nil config",
+		Outcome:    "The CLI no longer panics when the config is missing",
+		ChangeType: checkpoint.ChangeTypeFixed,
+	}}
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog(&stdout, &stderr, initial.String()+"..HEAD", "", generator); err != nil {
+		t.Fatalf("runChangelog() error = %v", err)
+	}
+	output := stdout.String()
+
+	if generator.calls != 2 {
+		t.Errorf("expected 2 generator calls, got %d", generator.calls)
+	}
+	for _, want := range []string{
+		"## [Unreleased]\n",
+		"### Added\n\n- Added the widget command (" + first.String()[:7] + ", " + second.String()[:7] + ")\n",
+		"### Fixed\n\n- Shortened the prompt (",
+		"- The CLI no longer panics when the config is missing (",
+		"### Internal\n\n- tidy notes (",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunChangelog_GeneratorFailureFallsBack(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+
+	for _, cpID := range []id.CheckpointID{id.MustCheckpointID("a1b2c3d4e5f6"), id.MustCheckpointID("b2c3d4e5f6a1")} {
+		writeAttributedCheckpoint(t, repo, cpID, 1, 1)
+		commitFile(t, repo, dir, cpID.String()+".go", "a\n", trailers.FormatCheckpoint("Fix "+cpID.String(), cpID))
+	}
+
+	generator := &fakeChangelogGenerator{err: errors.New("claude CLI not found")}
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog(&stdout, &stderr, initial.String()+"..HEAD", "2.0.0", generator); err != nil {
+		t.Fatalf("runChangelog() error = %v", err)
+	}
+
+	if generator.calls != 1 {
+		t.Errorf("generation should stop after the first failure, got %d calls", generator.calls)
+	}
+	if !strings.Contains(stderr.String(), "could not generate summary") {
+		t.Errorf("expected a warning on stderr, got: %s", stderr.String())
+	}
+	output := stdout.String()
+	for _, want := range []string{"## [2.0.0] - ", "- Fix a1b2c3d4e5f6 (", "- Fix b2c3d4e5f6a1 ("} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestRunChangelog_InvalidRange(t *testing.T) {
+	setupCITestRepo(t)
+
+	var stdout, stderr bytes.Buffer
+	if err := runChangelog(&stdout, &stderr, "HEAD", "", nil); err == nil {
+		t.Error("runChangelog() should reject a range without ..")
+	}
+}
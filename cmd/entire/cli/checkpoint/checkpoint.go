@@ -152,6 +152,14 @@ type WriteTemporaryOptions struct {
 	// IsFirstCheckpoint indicates if this is the first checkpoint of the session
 	// When true, all working directory files are captured (not just modified)
 	IsFirstCheckpoint bool
+
+	// TurnID identifies the agent turn the checkpoint belongs to
+	// (written as the Entire-Turn trailer, omitted if empty)
+	TurnID string
+
+	// ToolName is set for fine-grained checkpoints taken after a file-editing
+	// tool call (written as the Entire-Tool trailer, omitted if empty)
+	ToolName string
 }
 
 // ReadTemporaryResult contains the result of reading a temporary checkpoint.
@@ -213,6 +221,9 @@ type WriteCommittedOptions struct {
 	// Context is the generated context.md content
 	Context []byte
 
+	// Commands are the shell commands the agent ran during this checkpoint
+	Commands []CommandRecord
+
 	// FilesTouched are files modified during the session
 	FilesTouched []string
 
@@ -329,6 +340,9 @@ type SessionContent struct {
 
 	// Context is the context.md content
 	Context string
+
+	// Commands are the shell commands the agent ran, from commands.jsonl
+	Commands []CommandRecord
 }
 
 // CommittedMetadata contains the metadata stored in metadata.json for each checkpoint.
@@ -424,13 +438,22 @@ type CheckpointSummary struct {
 
 // Summary contains AI-generated summary of a checkpoint.
 type Summary struct {
-	Intent    string           `json:"intent"`     // What user wanted to accomplish
-	Outcome   string           `json:"outcome"`    // What was achieved
-	Learnings LearningsSummary `json:"learnings"`  // Categorized learnings
-	Friction  []string         `json:"friction"`   // Problems/annoyances encountered
-	OpenItems []string         `json:"open_items"` // Tech debt, unfinished work
+	Intent     string           `json:"intent"`                // What user wanted to accomplish
+	Outcome    string           `json:"outcome"`               // What was achieved
+	ChangeType string           `json:"change_type,omitempty"` // Kind of change, one of the ChangeType constants
+	Learnings  LearningsSummary `json:"learnings"`             // Categorized learnings
+	Friction   []string         `json:"friction"`              // Problems/annoyances encountered
+	OpenItems  []string         `json:"open_items"`            // Tech debt, unfinished work
 }
 
+// Change types a summary can classify its checkpoint's work as.
+const (
+	ChangeTypeAdded    = "added"    // New user-facing functionality
+	ChangeTypeChanged  = "changed"  // Changes to existing functionality
+	ChangeTypeFixed    = "fixed"    // Bug fixes
+	ChangeTypeInternal = "internal" // Refactoring, tests, tooling and docs
+)
+
 // LearningsSummary contains learnings grouped by scope.
 type LearningsSummary struct {
 	Repo     []string       `json:"repo"`     // Codebase-specific patterns/conventions
@@ -570,6 +593,13 @@ type TemporaryCheckpointInfo struct {
 	// ToolUseID is the tool use ID for task checkpoints
 	ToolUseID string
 
+	// TurnID is the agent turn from the Entire-Turn trailer
+	TurnID string
+
+	// ToolName is the file-editing tool from the Entire-Tool trailer,
+	// set only for fine-grained checkpoints
+	ToolName string
+
 	// Timestamp is when the checkpoint was created
 	Timestamp time.Time
 }
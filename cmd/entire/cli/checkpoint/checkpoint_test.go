@@ -1169,6 +1169,32 @@ func writeSingleSession(t *testing.T, cpIDStr, sessionID, transcript string) (*G
 	return store, checkpointID
 }
 
+// TestReadSessionMetadata verifies that session metadata can be read without
+// the transcript, and that missing checkpoints and sessions are reported.
+func TestReadSessionMetadata(t *testing.T) {
+	store, checkpointID := writeSingleSession(t, "f1f2f3f4f5f6", "meta-session", `{"single": true}`)
+
+	meta, err := store.ReadSessionMetadata(context.Background(), checkpointID, 0)
+	if err != nil {
+		t.Fatalf("ReadSessionMetadata() error = %v", err)
+	}
+	if meta.SessionID != "meta-session" {
+		t.Errorf("SessionID = %q, want %q", meta.SessionID, "meta-session")
+	}
+	if meta.CheckpointsCount != 1 {
+		t.Errorf("CheckpointsCount = %d, want 1", meta.CheckpointsCount)
+	}
+
+	if _, err := store.ReadSessionMetadata(context.Background(), checkpointID, 1); err == nil {
+		t.Error("ReadSessionMetadata(1) should return error for non-existent session")
+	}
+
+	_, err = store.ReadSessionMetadata(context.Background(), id.MustCheckpointID("000000000000"), 0)
+	if !errors.Is(err, ErrCheckpointNotFound) {
+		t.Errorf("ReadSessionMetadata() error = %v, want ErrCheckpointNotFound", err)
+	}
+}
+
 // TestReadSessionContent_InvalidIndex verifies that ReadSessionContent returns
 // an error when requesting a session index that doesn't exist.
 func TestReadSessionContent_InvalidIndex(t *testing.T) {
@@ -0,0 +1,98 @@
+package checkpoint
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxCommandOutputBytes is how much of a command's output is kept in its
+// record. Longer output keeps its beginning and end, which is where
+// commands usually print what went wrong.
+const MaxCommandOutputBytes = 2048
+
+// commandOutputOmission separates the kept beginning and end of truncated output
+const commandOutputOmission = "\n[... output truncated ...]\n"
+
+// CommandRecord is a shell command run by the agent, stored as one line of
+// commands.jsonl.
+type CommandRecord struct {
+	// ToolUseID is the agent's ID for the tool call, if it has one
+	ToolUseID string `json:"tool_use_id,omitempty"`
+	// Command is the command line as the agent ran it
+	Command string `json:"command"`
+	// ExitCode is the exit status, nil if the agent didn't report one
+	ExitCode *int `json:"exit_code,omitempty"`
+	// Interrupted is true if the command was stopped before it finished
+	Interrupted bool `json:"interrupted,omitempty"`
+	// StartedAt is when the agent started the command
+	StartedAt time.Time `json:"started_at"`
+	// DurationMs is how long the command ran, 0 if the start wasn't seen
+	DurationMs int64 `json:"duration_ms"`
+	// Output is the command's output, truncated to MaxCommandOutputBytes
+	Output string `json:"output,omitempty"`
+	// OutputTruncated is true if Output was cut down
+	OutputTruncated bool `json:"output_truncated,omitempty"`
+}
+
+// Duration returns how long the command ran.
+func (r CommandRecord) Duration() time.Duration {
+	return time.Duration(r.DurationMs) * time.Millisecond
+}
+
+// TruncateCommandOutput cuts output down to MaxCommandOutputBytes, keeping
+// its beginning and end. Returns the output and whether it was cut.
+func TruncateCommandOutput(output string) (string, bool) {
+	if len(output) <= MaxCommandOutputBytes {
+		return output, false
+	}
+	keep := (MaxCommandOutputBytes - len(commandOutputOmission)) / 2
+
+	// Cut at rune boundaries so multi-byte characters aren't split
+	headEnd := keep
+	for headEnd > 0 && !utf8.RuneStart(output[headEnd]) {
+		headEnd--
+	}
+	tailStart := len(output) - keep
+	for tailStart < len(output) && !utf8.RuneStart(output[tailStart]) {
+		tailStart++
+	}
+	return output[:headEnd] + commandOutputOmission + output[tailStart:], true
+}
+
+// MarshalCommands encodes records as JSONL.
+func MarshalCommands(records []CommandRecord) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, record := range records {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal command record: %w", err)
+		}
+		buf.Write(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes(), nil
+}
+
+// ParseCommands decodes commands.jsonl content. Lines that can't be parsed,
+// such as one cut short by an interrupted write, are skipped.
+func ParseCommands(data []byte) []CommandRecord {
+	var records []CommandRecord
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var record CommandRecord
+		if err := json.Unmarshal(line, &record); err != nil || record.Command == "" {
+			continue
+		}
+		records = append(records, record)
+	}
+	return records
+}
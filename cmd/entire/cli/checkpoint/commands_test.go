@@ -0,0 +1,101 @@
+package checkpoint
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+)
+
+func TestTruncateCommandOutput(t *testing.T) {
+	t.Parallel()
+
+	short := "ok"
+	if got, truncated := TruncateCommandOutput(short); got != short || truncated {
+		t.Errorf("TruncateCommandOutput(%q) = (%q, %v), want it unchanged", short, got, truncated)
+	}
+
+	long := "BEGIN" + strings.Repeat("é", MaxCommandOutputBytes) + "END"
+	got, truncated := TruncateCommandOutput(long)
+	if !truncated {
+		t.Fatal("TruncateCommandOutput() truncated = false, want true")
+	}
+	if len(got) > MaxCommandOutputBytes {
+		t.Errorf("len(output) = %d, want at most %d", len(got), MaxCommandOutputBytes)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("truncated output isn't valid UTF-8")
+	}
+	if !strings.HasPrefix(got, "BEGIN") || !strings.HasSuffix(got, "END") {
+		t.Errorf("truncated output should keep its beginning and end, got %q...%q", got[:10], got[len(got)-10:])
+	}
+	if !strings.Contains(got, "output truncated") {
+		t.Error("truncated output should say it was truncated")
+	}
+}
+
+func TestParseCommands_SkipsMalformedLines(t *testing.T) {
+	t.Parallel()
+
+	exitCode := 0
+	data, err := MarshalCommands([]CommandRecord{
+		{Command: "go build ./...", ExitCode: &exitCode, DurationMs: 1200},
+		{Command: "go test ./...", Interrupted: true},
+	})
+	if err != nil {
+		t.Fatalf("MarshalCommands() error = %v", err)
+	}
+	data = append(data, []byte("{\"command\":\"cut sh")...)
+
+	records := ParseCommands(data)
+	if len(records) != 2 {
+		t.Fatalf("ParseCommands() returned %d records, want 2", len(records))
+	}
+	if records[0].ExitCode == nil || *records[0].ExitCode != 0 {
+		t.Errorf("records[0].ExitCode = %v, want 0", records[0].ExitCode)
+	}
+	if records[0].Duration() != 1200*time.Millisecond {
+		t.Errorf("records[0].Duration() = %v, want 1.2s", records[0].Duration())
+	}
+	if !records[1].Interrupted || records[1].ExitCode != nil {
+		t.Errorf("records[1] = %+v, want interrupted with no exit code", records[1])
+	}
+}
+
+func TestWriteCommitted_Commands(t *testing.T) {
+	repo, _ := setupBranchTestRepo(t)
+	store := NewGitStore(repo)
+	checkpointID := id.MustCheckpointID("a1b2c3d4e5f6")
+
+	exitCode := 1
+	err := store.WriteCommitted(context.Background(), WriteCommittedOptions{
+		CheckpointID: checkpointID,
+		SessionID:    "commands-session",
+		Strategy:     "manual-commit",
+		Transcript:   []byte(`{"type":"user"}`),
+		Commands: []CommandRecord{
+			{ToolUseID: "toolu_01", Command: "make test", ExitCode: &exitCode, Output: "FAIL"},
+		},
+		CheckpointsCount: 1,
+		AuthorName:       "Test Author",
+		AuthorEmail:      "test@example.com",
+	})
+	if err != nil {
+		t.Fatalf("WriteCommitted() error = %v", err)
+	}
+
+	content, err := store.ReadSessionContent(context.Background(), checkpointID, 0)
+	if err != nil {
+		t.Fatalf("ReadSessionContent() error = %v", err)
+	}
+	if len(content.Commands) != 1 {
+		t.Fatalf("Commands = %v, want 1 record", content.Commands)
+	}
+	got := content.Commands[0]
+	if got.Command != "make test" || got.Output != "FAIL" || got.ExitCode == nil || *got.ExitCode != 1 {
+		t.Errorf("Commands[0] = %+v, want make test exiting 1 with output FAIL", got)
+	}
+}
@@ -3,7 +3,6 @@ package checkpoint
 import (
 	"bytes"
 	"context"
-	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -21,6 +20,7 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/jsonutil"
 	"github.com/entireio/cli/cmd/entire/cli/logging"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
 	"github.com/entireio/cli/cmd/entire/cli/trailers"
 	"github.com/entireio/cli/cmd/entire/cli/validation"
 	"github.com/entireio/cli/redact"
@@ -30,6 +30,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/utils/binary"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // errStopIteration is used to stop commit iteration early in GetCheckpointAuthor.
@@ -41,8 +42,12 @@ var errStopIteration = errors.New("stop iteration")
 // For task checkpoints (IsTask=true), additional files are written under tasks/<tool-use-id>/:
 //   - For incremental checkpoints: checkpoints/NNN-<tool-use-id>.json
 //   - For final checkpoints: checkpoint.json and agent-<agent-id>.jsonl
-func (s *GitStore) WriteCommitted(ctx context.Context, opts WriteCommittedOptions) error {
-	_ = ctx // Reserved for future use
+func (s *GitStore) WriteCommitted(ctx context.Context, opts WriteCommittedOptions) (err error) {
+	ctx, span := tracing.Start(ctx, "checkpoint.WriteCommitted",
+		attribute.String("checkpoint_id", opts.CheckpointID.String()),
+		attribute.Bool("is_task", opts.IsTask),
+	)
+	defer func() { tracing.End(span, err) }()
 
 	// Validate identifiers to prevent path traversal and malformed data
 	if opts.CheckpointID.IsEmpty() {
@@ -84,7 +89,7 @@ func (s *GitStore) WriteCommitted(ctx context.Context, opts WriteCommittedOption
 	}
 
 	// Write standard checkpoint entries (transcript, prompts, context, metadata)
-	if err := s.writeStandardCheckpointEntries(opts, basePath, entries); err != nil {
+	if err := s.writeStandardCheckpointEntries(ctx, opts, basePath, entries); err != nil {
 		return err
 	}
 
@@ -238,7 +243,7 @@ func (s *GitStore) writeFinalTaskCheckpoint(opts WriteCommittedOptions, taskPath
 //	│   └── content_hash.txt
 //	├── 2/                    # Second session
 //	└── ...
-func (s *GitStore) writeStandardCheckpointEntries(opts WriteCommittedOptions, basePath string, entries map[string]object.TreeEntry) error {
+func (s *GitStore) writeStandardCheckpointEntries(ctx context.Context, opts WriteCommittedOptions, basePath string, entries map[string]object.TreeEntry) error {
 	// Read existing summary to get current session count
 	var existingSummary *CheckpointSummary
 	metadataPath := basePath + paths.MetadataFileName
@@ -254,14 +259,17 @@ func (s *GitStore) writeStandardCheckpointEntries(opts WriteCommittedOptions, ba
 
 	// Write session files to numbered subdirectory
 	sessionPath := fmt.Sprintf("%s%d/", basePath, sessionIndex)
-	sessionFilePaths, err := s.writeSessionToSubdirectory(opts, sessionPath, entries)
+	sessionFilePaths, err := s.writeSessionToSubdirectory(ctx, opts, sessionPath, entries)
 	if err != nil {
 		return err
 	}
 
 	// Copy additional metadata files from directory if specified (to session subdirectory)
 	if opts.MetadataDir != "" {
-		if err := s.copyMetadataDir(opts.MetadataDir, sessionPath, entries); err != nil {
+		_, span := tracing.Start(ctx, "redact.metadata_dir")
+		err := s.copyMetadataDir(opts.MetadataDir, sessionPath, entries)
+		tracing.End(span, err)
+		if err != nil {
 			return fmt.Errorf("failed to copy metadata directory: %w", err)
 		}
 	}
@@ -282,7 +290,7 @@ func (s *GitStore) writeStandardCheckpointEntries(opts WriteCommittedOptions, ba
 
 // writeSessionToSubdirectory writes a single session's files to a numbered subdirectory.
 // Returns the absolute file paths from the git tree root for the sessions map.
-func (s *GitStore) writeSessionToSubdirectory(opts WriteCommittedOptions, sessionPath string, entries map[string]object.TreeEntry) (SessionFilePaths, error) {
+func (s *GitStore) writeSessionToSubdirectory(ctx context.Context, opts WriteCommittedOptions, sessionPath string, entries map[string]object.TreeEntry) (SessionFilePaths, error) {
 	filePaths := SessionFilePaths{}
 
 	// Clear any existing entries at this path so stale files from a previous
@@ -294,7 +302,7 @@ func (s *GitStore) writeSessionToSubdirectory(opts WriteCommittedOptions, sessio
 	}
 
 	// Write transcript
-	if err := s.writeTranscript(opts, sessionPath, entries); err != nil {
+	if err := s.writeTranscript(ctx, opts, sessionPath, entries); err != nil {
 		return filePaths, err
 	}
 	filePaths.Transcript = "/" + sessionPath + paths.TranscriptFileName
@@ -329,6 +337,27 @@ func (s *GitStore) writeSessionToSubdirectory(opts WriteCommittedOptions, sessio
 		filePaths.Context = "/" + sessionPath + paths.ContextFileName
 	}
 
+	// Write commands
+	if len(opts.Commands) > 0 {
+		commandsContent, err := MarshalCommands(opts.Commands)
+		if err != nil {
+			return filePaths, err
+		}
+		commandsContent, err = redact.JSONLBytes(commandsContent)
+		if err != nil {
+			return filePaths, fmt.Errorf("failed to redact commands: %w", err)
+		}
+		blobHash, err := CreateBlobFromContent(s.repo, commandsContent)
+		if err != nil {
+			return filePaths, err
+		}
+		entries[sessionPath+paths.CommandsFileName] = object.TreeEntry{
+			Name: sessionPath + paths.CommandsFileName,
+			Mode: filemode.Regular,
+			Hash: blobHash,
+		}
+	}
+
 	// Write session-level metadata.json (CommittedMetadata with all fields including initial_attribution)
 	sessionMetadata := CommittedMetadata{
 		CheckpointID:                opts.CheckpointID,
@@ -508,7 +537,7 @@ func aggregateTokenUsage(a, b *agent.TokenUsage) *agent.TokenUsage {
 
 // writeTranscript writes the transcript file from in-memory content or file path.
 // If the transcript exceeds MaxChunkSize, it's split into multiple chunk files.
-func (s *GitStore) writeTranscript(opts WriteCommittedOptions, basePath string, entries map[string]object.TreeEntry) error {
+func (s *GitStore) writeTranscript(ctx context.Context, opts WriteCommittedOptions, basePath string, entries map[string]object.TreeEntry) error {
 	transcript := opts.Transcript
 	if len(transcript) == 0 && opts.TranscriptPath != "" {
 		var readErr error
@@ -523,7 +552,9 @@ func (s *GitStore) writeTranscript(opts WriteCommittedOptions, basePath string,
 	}
 
 	// Redact secrets before chunking so content hash reflects redacted content
+	_, span := tracing.Start(ctx, "redact.transcript", attribute.Int("bytes", len(transcript)))
 	transcript, err := redact.JSONLBytes(transcript)
+	tracing.End(span, err)
 	if err != nil {
 		return fmt.Errorf("failed to redact transcript secrets: %w", err)
 	}
@@ -549,8 +580,7 @@ func (s *GitStore) writeTranscript(opts WriteCommittedOptions, basePath string,
 	}
 
 	// Content hash for deduplication (hash of full transcript)
-	contentHash := fmt.Sprintf("sha256:%x", sha256.Sum256(transcript))
-	hashBlob, err := CreateBlobFromContent(s.repo, []byte(contentHash))
+	hashBlob, err := CreateBlobFromContent(s.repo, []byte(contentHash(transcript)))
 	if err != nil {
 		return err
 	}
@@ -738,9 +768,49 @@ func (s *GitStore) ReadSessionContent(ctx context.Context, checkpointID id.Check
 		}
 	}
 
+	// Read commands
+	if file, fileErr := sessionTree.File(paths.CommandsFileName); fileErr == nil {
+		if content, contentErr := file.Contents(); contentErr == nil {
+			result.Commands = ParseCommands([]byte(content))
+		}
+	}
+
 	return result, nil
 }
 
+// ReadSessionMetadata reads only the metadata.json of a session within a checkpoint.
+// Unlike ReadSessionContent it does not load the transcript, which makes it
+// suitable for scanning many checkpoints.
+// Returns ErrCheckpointNotFound if the checkpoint doesn't exist.
+func (s *GitStore) ReadSessionMetadata(ctx context.Context, checkpointID id.CheckpointID, sessionIndex int) (*CommittedMetadata, error) {
+	_ = ctx // Reserved for future use
+
+	tree, err := s.getSessionsBranchTree()
+	if err != nil {
+		return nil, ErrCheckpointNotFound
+	}
+
+	metadataPath := fmt.Sprintf("%s/%d/%s", checkpointID.Path(), sessionIndex, paths.MetadataFileName)
+	file, err := tree.File(metadataPath)
+	if err != nil {
+		if _, treeErr := tree.Tree(checkpointID.Path()); treeErr != nil {
+			return nil, ErrCheckpointNotFound
+		}
+		return nil, fmt.Errorf("session %d metadata not found: %w", sessionIndex, err)
+	}
+
+	content, err := file.Contents()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session metadata: %w", err)
+	}
+
+	var metadata CommittedMetadata
+	if err := json.Unmarshal([]byte(content), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse session metadata: %w", err)
+	}
+	return &metadata, nil
+}
+
 // ReadLatestSessionContent is a convenience method that reads the latest session's content.
 // This is equivalent to ReadSessionContent(ctx, checkpointID, len(summary.Sessions)-1).
 func (s *GitStore) ReadLatestSessionContent(ctx context.Context, checkpointID id.CheckpointID) (*SessionContent, error) {
@@ -927,8 +997,9 @@ func LookupSessionLog(cpID id.CheckpointID) ([]byte, string, error) {
 
 // UpdateSummary updates the summary field in the latest session's metadata.
 // Returns ErrCheckpointNotFound if the checkpoint doesn't exist.
-func (s *GitStore) UpdateSummary(ctx context.Context, checkpointID id.CheckpointID, summary *Summary) error {
-	_ = ctx // Reserved for future use
+func (s *GitStore) UpdateSummary(ctx context.Context, checkpointID id.CheckpointID, summary *Summary) (err error) {
+	_, span := tracing.Start(ctx, "checkpoint.UpdateSummary", attribute.String("checkpoint_id", checkpointID.String()))
+	defer func() { tracing.End(span, err) }()
 
 	// Ensure sessions branch exists
 	if err := s.ensureSessionsBranch(); err != nil {
@@ -19,6 +19,7 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/jsonutil"
 	"github.com/entireio/cli/cmd/entire/cli/logging"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
 	"github.com/entireio/cli/cmd/entire/cli/trailers"
 	"github.com/entireio/cli/cmd/entire/cli/validation"
 	"github.com/entireio/cli/redact"
@@ -27,6 +28,7 @@ import (
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 const (
@@ -39,6 +41,20 @@ const (
 
 	// WorktreeIDHashLength is the number of hex characters used for worktree ID hash.
 	WorktreeIDHashLength = 6
+
+	// UndoBranchPrefix is the prefix for per-worktree rewind undo branches.
+	// Undo branches are named "entire/undo/<hash(worktreeID)[:6]>".
+	UndoBranchPrefix = "entire/undo/"
+
+	// SessionBranchPrefix is the prefix for per-session worktree branches
+	// created by the worktree strategy. Session branches are named
+	// "entire/session/<session-id>" and hold real user commits, not checkpoints.
+	SessionBranchPrefix = "entire/session/"
+
+	// SquashBranchPrefix is the prefix for the hidden per-session branches of
+	// the squash-commit strategy. Squash branches are named
+	// "entire/squash/<session-id>" and hold one commit per agent turn.
+	SquashBranchPrefix = "entire/squash/"
 )
 
 // HashWorktreeID returns a short hash of the worktree identifier.
@@ -53,7 +69,10 @@ func HashWorktreeID(worktreeID string) string {
 // Returns the result containing commit hash and whether it was skipped.
 // If the new tree hash matches the last checkpoint's tree hash, the checkpoint
 // is skipped to avoid duplicate commits (deduplication).
-func (s *GitStore) WriteTemporary(ctx context.Context, opts WriteTemporaryOptions) (WriteTemporaryResult, error) {
+func (s *GitStore) WriteTemporary(ctx context.Context, opts WriteTemporaryOptions) (_ WriteTemporaryResult, err error) {
+	ctx, span := tracing.Start(ctx, "checkpoint.WriteTemporary", attribute.Bool("first_checkpoint", opts.IsFirstCheckpoint))
+	defer func() { tracing.End(span, err) }()
+
 	// Validate base commit - required for shadow branch naming
 	if opts.BaseCommit == "" {
 		return WriteTemporaryResult{}, errors.New("BaseCommit is required for temporary checkpoint")
@@ -113,6 +132,7 @@ func (s *GitStore) WriteTemporary(ctx context.Context, opts WriteTemporaryOption
 
 	// Deduplication: skip if tree hash matches the last checkpoint
 	if lastTreeHash != plumbing.ZeroHash && treeHash == lastTreeHash {
+		span.SetAttributes(attribute.Bool("skipped", true))
 		return WriteTemporaryResult{
 			CommitHash: parentHash,
 			Skipped:    true,
@@ -121,6 +141,7 @@ func (s *GitStore) WriteTemporary(ctx context.Context, opts WriteTemporaryOption
 
 	// Create checkpoint commit with trailers
 	commitMsg := trailers.FormatShadowCommit(opts.CommitMessage, opts.MetadataDir, opts.SessionID)
+	commitMsg = trailers.AppendShadowTurn(commitMsg, opts.TurnID, opts.ToolName)
 
 	commitHash, err := s.createCommit(treeHash, parentHash, commitMsg, opts.AuthorName, opts.AuthorEmail)
 	if err != nil {
@@ -188,8 +209,11 @@ func (s *GitStore) ListTemporary(ctx context.Context) ([]TemporaryInfo, error) {
 			return nil
 		}
 
-		// Skip the sessions branch
-		if branchName == paths.MetadataBranchName {
+		// Skip the sessions branch, rewind undo branches and per-session branches
+		if branchName == paths.MetadataBranchName ||
+			strings.HasPrefix(branchName, UndoBranchPrefix) ||
+			strings.HasPrefix(branchName, SessionBranchPrefix) ||
+			strings.HasPrefix(branchName, SquashBranchPrefix) {
 			return nil
 		}
 
@@ -225,8 +249,9 @@ func (s *GitStore) ListTemporary(ctx context.Context) ([]TemporaryInfo, error) {
 // WriteTemporaryTask writes a task checkpoint to a shadow branch.
 // Task checkpoints include both code changes and task-specific metadata.
 // Returns the commit hash of the created checkpoint.
-func (s *GitStore) WriteTemporaryTask(ctx context.Context, opts WriteTemporaryTaskOptions) (plumbing.Hash, error) {
-	_ = ctx // Reserved for future use
+func (s *GitStore) WriteTemporaryTask(ctx context.Context, opts WriteTemporaryTaskOptions) (_ plumbing.Hash, err error) {
+	_, span := tracing.Start(ctx, "checkpoint.WriteTemporaryTask", attribute.Bool("incremental", opts.IsIncremental))
+	defer func() { tracing.End(span, err) }()
 
 	// Validate base commit - required for shadow branch naming
 	if opts.BaseCommit == "" {
@@ -493,6 +518,8 @@ func (s *GitStore) listCheckpointsForBranch(ctx context.Context, shadowBranchNam
 				info.MetadataDir = metadataDir
 			}
 		}
+		info.TurnID, _ = trailers.ParseTurn(c.Message)
+		info.ToolName, _ = trailers.ParseTool(c.Message)
 
 		results = append(results, info)
 
@@ -0,0 +1,363 @@
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// VerifyProblem describes a single integrity problem in a committed checkpoint.
+type VerifyProblem struct {
+	CheckpointID id.CheckpointID
+	// SessionIndex is the 0-based session subdirectory, or -1 for problems
+	// with the checkpoint as a whole.
+	SessionIndex int
+	Message      string
+	// Warning is set for findings that could not be checked rather than
+	// data that is known to be wrong.
+	Warning bool
+}
+
+func (p VerifyProblem) String() string {
+	if p.SessionIndex < 0 {
+		return fmt.Sprintf("%s: %s", p.CheckpointID, p.Message)
+	}
+	return fmt.Sprintf("%s/%d: %s", p.CheckpointID, p.SessionIndex, p.Message)
+}
+
+// VerifyResult summarizes a verification pass over committed checkpoints.
+type VerifyResult struct {
+	CheckpointsChecked int
+	SessionsChecked    int
+	// HashesVerified counts transcripts whose content_hash.txt matched.
+	HashesVerified int
+	Problems       []VerifyProblem
+}
+
+// HasErrors reports whether any problem is not a warning.
+func (r *VerifyResult) HasErrors() bool {
+	for _, p := range r.Problems {
+		if !p.Warning {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyCommitted checks the integrity of committed checkpoints on the
+// entire/checkpoints/v1 branch. If checkpointIDs is empty, every checkpoint is checked.
+//
+// For each checkpoint it confirms that:
+//   - the root metadata.json parses and lists one subdirectory per session
+//   - transcript chunks are contiguous, reassemble, and match content_hash.txt
+//   - the root aggregates (checkpoints_count, files_touched, token_usage)
+//     match the session metadata, as recomputed when the checkpoint was written
+//
+// Checkpoints whose transcripts were removed by 'entire prune' have neither a
+// transcript nor a content hash and are reported as valid.
+func (s *GitStore) VerifyCommitted(ctx context.Context, checkpointIDs []id.CheckpointID) (*VerifyResult, error) {
+	_ = ctx // Reserved for future use
+
+	result := &VerifyResult{}
+
+	tree, err := s.getSessionsBranchTree()
+	if err != nil {
+		if len(checkpointIDs) == 0 {
+			return result, nil // No sessions branch means nothing to verify
+		}
+		return nil, err
+	}
+
+	if len(checkpointIDs) == 0 {
+		checkpointIDs = listCheckpointIDs(s, tree)
+	}
+
+	for _, cpID := range checkpointIDs {
+		cpTree, treeErr := tree.Tree(cpID.Path())
+		if treeErr != nil {
+			result.CheckpointsChecked++
+			result.addProblem(cpID, -1, "checkpoint not found on "+paths.MetadataBranchName)
+			continue
+		}
+		s.verifyCheckpointTree(cpID, cpTree, result)
+	}
+
+	return result, nil
+}
+
+// listCheckpointIDs returns the IDs of all checkpoint directories in the sharded layout.
+func listCheckpointIDs(s *GitStore, tree *object.Tree) []id.CheckpointID {
+	var ids []id.CheckpointID
+	for _, bucketEntry := range tree.Entries {
+		if bucketEntry.Mode != filemode.Dir || len(bucketEntry.Name) != 2 {
+			continue
+		}
+		bucketTree, err := s.repo.TreeObject(bucketEntry.Hash)
+		if err != nil {
+			continue
+		}
+		for _, checkpointEntry := range bucketTree.Entries {
+			if checkpointEntry.Mode != filemode.Dir {
+				continue
+			}
+			cpID, err := id.NewCheckpointID(bucketEntry.Name + checkpointEntry.Name)
+			if err != nil {
+				continue
+			}
+			ids = append(ids, cpID)
+		}
+	}
+	return ids
+}
+
+func (r *VerifyResult) addProblem(cpID id.CheckpointID, sessionIndex int, msg string) {
+	r.Problems = append(r.Problems, VerifyProblem{CheckpointID: cpID, SessionIndex: sessionIndex, Message: msg})
+}
+
+func (r *VerifyResult) addWarning(cpID id.CheckpointID, sessionIndex int, msg string) {
+	r.Problems = append(r.Problems, VerifyProblem{CheckpointID: cpID, SessionIndex: sessionIndex, Message: msg, Warning: true})
+}
+
+// verifyCheckpointTree verifies a single checkpoint directory, appending problems to result.
+func (s *GitStore) verifyCheckpointTree(cpID id.CheckpointID, cpTree *object.Tree, result *VerifyResult) {
+	result.CheckpointsChecked++
+
+	metadataFile, err := cpTree.File(paths.MetadataFileName)
+	if err != nil {
+		result.addProblem(cpID, -1, "missing "+paths.MetadataFileName)
+		return
+	}
+	content, err := metadataFile.Contents()
+	if err != nil {
+		result.addProblem(cpID, -1, fmt.Sprintf("failed to read %s: %v", paths.MetadataFileName, err))
+		return
+	}
+	var summary CheckpointSummary
+	if err := json.Unmarshal([]byte(content), &summary); err != nil {
+		result.addProblem(cpID, -1, fmt.Sprintf("invalid %s: %v", paths.MetadataFileName, err))
+		return
+	}
+
+	if summary.CheckpointID != cpID {
+		result.addProblem(cpID, -1, fmt.Sprintf("metadata.json records checkpoint_id %q", summary.CheckpointID))
+	}
+	if len(summary.Sessions) == 0 {
+		result.addProblem(cpID, -1, "metadata.json lists no sessions")
+		return
+	}
+
+	sessionsOK := true
+	for i := range summary.Sessions {
+		sessionTree, treeErr := cpTree.Tree(strconv.Itoa(i))
+		if treeErr != nil {
+			result.addProblem(cpID, i, "session directory missing")
+			sessionsOK = false
+			continue
+		}
+		result.SessionsChecked++
+		if !s.verifySessionTree(cpID, i, sessionTree, result) {
+			sessionsOK = false
+		}
+	}
+
+	// Session directories beyond the sessions array are never aggregated
+	for _, entry := range cpTree.Entries {
+		if entry.Mode != filemode.Dir {
+			continue
+		}
+		if idx, convErr := strconv.Atoi(entry.Name); convErr == nil && idx >= len(summary.Sessions) {
+			result.addProblem(cpID, idx, "session directory not listed in metadata.json")
+		}
+	}
+
+	// Aggregates can only be recomputed when every session metadata.json is readable
+	if sessionsOK {
+		s.verifyAggregates(cpID, cpTree, &summary, result)
+	}
+}
+
+// verifySessionTree verifies one session subdirectory.
+// Returns false if the session metadata.json is missing or unreadable.
+func (s *GitStore) verifySessionTree(cpID id.CheckpointID, index int, sessionTree *object.Tree, result *VerifyResult) bool {
+	metadataOK := true
+	var meta CommittedMetadata
+	if file, err := sessionTree.File(paths.MetadataFileName); err != nil {
+		result.addProblem(cpID, index, "missing "+paths.MetadataFileName)
+		metadataOK = false
+	} else if content, err := file.Contents(); err != nil {
+		result.addProblem(cpID, index, fmt.Sprintf("failed to read %s: %v", paths.MetadataFileName, err))
+		metadataOK = false
+	} else if err := json.Unmarshal([]byte(content), &meta); err != nil {
+		result.addProblem(cpID, index, fmt.Sprintf("invalid %s: %v", paths.MetadataFileName, err))
+		metadataOK = false
+	}
+
+	var storedHash string
+	if file, err := sessionTree.File(paths.ContentHashFileName); err == nil {
+		content, contentErr := file.Contents()
+		if contentErr != nil {
+			result.addProblem(cpID, index, fmt.Sprintf("failed to read %s: %v", paths.ContentHashFileName, contentErr))
+			return metadataOK
+		}
+		storedHash = strings.TrimSpace(content)
+	}
+
+	chunks, err := readTranscriptChunks(sessionTree)
+	if err != nil {
+		result.addProblem(cpID, index, err.Error())
+		return metadataOK
+	}
+
+	switch {
+	case len(chunks) == 0 && storedHash == "":
+		// No transcript captured, or removed by prune
+		return metadataOK
+	case len(chunks) == 0:
+		result.addProblem(cpID, index, paths.ContentHashFileName+" present but transcript is missing")
+		return metadataOK
+	case storedHash == "":
+		result.addProblem(cpID, index, "transcript present but "+paths.ContentHashFileName+" is missing")
+		return metadataOK
+	}
+
+	transcript, err := agent.ReassembleTranscript(chunks, meta.Agent)
+	if err != nil {
+		result.addProblem(cpID, index, fmt.Sprintf("transcript chunks failed to reassemble: %v", err))
+		return metadataOK
+	}
+
+	if contentHash(transcript) == storedHash {
+		result.HashesVerified++
+		return metadataOK
+	}
+
+	// Agents with structured transcripts (e.g. Gemini JSON) re-serialize on
+	// reassembly, so the bytes can legitimately differ from what was hashed.
+	// Only a plain concatenation is byte-for-byte comparable.
+	if len(chunks) > 1 {
+		joined := agent.ReassembleJSONL(chunks)
+		if contentHash(joined) == storedHash {
+			result.HashesVerified++
+			return metadataOK
+		}
+		if !bytes.Equal(joined, transcript) {
+			result.addWarning(cpID, index, fmt.Sprintf("content hash not checked: %s transcript is re-serialized when reassembled", meta.Agent))
+			return metadataOK
+		}
+	}
+
+	result.addProblem(cpID, index, fmt.Sprintf("content hash mismatch: stored %s, transcript hashes to %s", storedHash, contentHash(transcript)))
+	return metadataOK
+}
+
+// readTranscriptChunks returns the transcript chunks of a session directory in order.
+// Unlike readTranscriptFromTree, a gap in the chunk sequence is an error rather
+// than being skipped.
+func readTranscriptChunks(sessionTree *object.Tree) ([][]byte, error) {
+	var chunkFiles []string
+	hasBaseFile := false
+	for _, entry := range sessionTree.Entries {
+		switch {
+		case entry.Name == paths.TranscriptFileName:
+			hasBaseFile = true
+		case strings.HasPrefix(entry.Name, paths.TranscriptFileName+"."):
+			if agent.ParseChunkIndex(entry.Name, paths.TranscriptFileName) > 0 {
+				chunkFiles = append(chunkFiles, entry.Name)
+			}
+		}
+	}
+
+	if !hasBaseFile && len(chunkFiles) == 0 {
+		// Older checkpoints may use the legacy transcript name
+		if file, err := sessionTree.File(paths.TranscriptFileNameLegacy); err == nil {
+			content, err := file.Contents()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", paths.TranscriptFileNameLegacy, err)
+			}
+			return [][]byte{[]byte(content)}, nil
+		}
+		return nil, nil
+	}
+
+	// The unsuffixed file is chunk 0; numbered files must follow without gaps
+	if !hasBaseFile {
+		return nil, fmt.Errorf("transcript chunk %s missing", paths.TranscriptFileName)
+	}
+	chunkFiles = agent.SortChunkFiles(chunkFiles, paths.TranscriptFileName)
+	for i, name := range chunkFiles {
+		if agent.ParseChunkIndex(name, paths.TranscriptFileName) != i+1 {
+			return nil, fmt.Errorf("transcript chunk %s missing", agent.ChunkFileName(paths.TranscriptFileName, i+1))
+		}
+	}
+	chunkFiles = append([]string{paths.TranscriptFileName}, chunkFiles...)
+
+	chunks := make([][]byte, 0, len(chunkFiles))
+	for _, name := range chunkFiles {
+		file, err := sessionTree.File(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		content, err := file.Contents()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		chunks = append(chunks, []byte(content))
+	}
+	return chunks, nil
+}
+
+// verifyAggregates recomputes the root aggregates from session metadata the
+// same way writeCheckpointSummary does and compares them with the stored summary.
+func (s *GitStore) verifyAggregates(cpID id.CheckpointID, cpTree *object.Tree, summary *CheckpointSummary, result *VerifyResult) {
+	basePath := cpID.Path()
+	entries := make(map[string]object.TreeEntry)
+	if err := FlattenTree(s.repo, cpTree, basePath, entries); err != nil {
+		result.addProblem(cpID, -1, fmt.Sprintf("failed to read checkpoint tree: %v", err))
+		return
+	}
+
+	count, files, tokens, err := s.reaggregateFromEntries(basePath+"/", len(summary.Sessions), entries)
+	if err != nil {
+		result.addProblem(cpID, -1, fmt.Sprintf("failed to aggregate sessions: %v", err))
+		return
+	}
+
+	if count != summary.CheckpointsCount {
+		result.addProblem(cpID, -1, fmt.Sprintf("checkpoints_count is %d, sessions sum to %d", summary.CheckpointsCount, count))
+	}
+	if !slices.Equal(mergeFilesTouched(nil, summary.FilesTouched), files) {
+		result.addProblem(cpID, -1, fmt.Sprintf("files_touched lists %d files, sessions touch %d", len(summary.FilesTouched), len(files)))
+	}
+	if !tokenUsageEqual(summary.TokenUsage, tokens) {
+		result.addProblem(cpID, -1, "token_usage does not match the sum of session token usage")
+	}
+}
+
+// tokenUsageEqual compares the fields summed by aggregateTokenUsage.
+func tokenUsageEqual(a, b *agent.TokenUsage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return a.InputTokens == b.InputTokens &&
+		a.CacheCreationTokens == b.CacheCreationTokens &&
+		a.CacheReadTokens == b.CacheReadTokens &&
+		a.OutputTokens == b.OutputTokens &&
+		a.APICallCount == b.APICallCount
+}
+
+// contentHash formats a transcript hash the way writeTranscript stores it.
+func contentHash(transcript []byte) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256(transcript))
+}
@@ -0,0 +1,186 @@
+package checkpoint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// tamperCommitted rewrites the tip of the metadata branch by applying modify
+// to its flattened tree entries.
+func tamperCommitted(t *testing.T, store *GitStore, modify func(entries map[string]object.TreeEntry)) {
+	t.Helper()
+
+	ref, entries, err := store.getSessionsBranchEntries()
+	if err != nil {
+		t.Fatalf("getSessionsBranchEntries() error = %v", err)
+	}
+	modify(entries)
+
+	treeHash, err := BuildTreeFromEntries(store.repo, entries)
+	if err != nil {
+		t.Fatalf("BuildTreeFromEntries() error = %v", err)
+	}
+	commitHash, err := store.createCommit(treeHash, ref.Hash(), "tamper", "Test", "test@test.com")
+	if err != nil {
+		t.Fatalf("createCommit() error = %v", err)
+	}
+	newRef := plumbing.NewHashReference(plumbing.NewBranchReferenceName(paths.MetadataBranchName), commitHash)
+	if err := store.repo.Storer.SetReference(newRef); err != nil {
+		t.Fatalf("SetReference() error = %v", err)
+	}
+}
+
+// putBlob stores content at path in a flattened entries map.
+func putBlob(t *testing.T, repo *git.Repository, entries map[string]object.TreeEntry, path, content string) {
+	t.Helper()
+	hash, err := CreateBlobFromContent(repo, []byte(content))
+	if err != nil {
+		t.Fatalf("CreateBlobFromContent() error = %v", err)
+	}
+	entries[path] = object.TreeEntry{Name: path, Mode: filemode.Regular, Hash: hash}
+}
+
+func verifyAll(t *testing.T, store *GitStore) *VerifyResult {
+	t.Helper()
+	result, err := store.VerifyCommitted(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("VerifyCommitted() error = %v", err)
+	}
+	return result
+}
+
+func requireProblem(t *testing.T, result *VerifyResult, substr string) {
+	t.Helper()
+	for _, p := range result.Problems {
+		if strings.Contains(p.Message, substr) {
+			if p.Warning {
+				t.Errorf("problem %q reported as warning", p)
+			}
+			return
+		}
+	}
+	t.Errorf("expected problem containing %q, got %v", substr, result.Problems)
+}
+
+func TestVerifyCommitted_NoSessionsBranch(t *testing.T) {
+	repo, _ := setupBranchTestRepo(t)
+	store := NewGitStore(repo)
+
+	result := verifyAll(t, store)
+	if result.CheckpointsChecked != 0 || len(result.Problems) != 0 {
+		t.Errorf("expected empty result, got %+v", result)
+	}
+}
+
+func TestVerifyCommitted_ValidMultiSession(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	err := store.WriteCommitted(context.Background(), WriteCommittedOptions{
+		CheckpointID:     cpID,
+		SessionID:        "session-two",
+		Strategy:         "manual-commit",
+		Transcript:       []byte(`{"line": 2}` + "\n"),
+		CheckpointsCount: 2,
+		FilesTouched:     []string{"b.go", "a.go"},
+		TokenUsage:       &agent.TokenUsage{InputTokens: 10, OutputTokens: 5},
+		AuthorName:       "Test Author",
+		AuthorEmail:      "test@example.com",
+	})
+	if err != nil {
+		t.Fatalf("WriteCommitted() error = %v", err)
+	}
+
+	result := verifyAll(t, store)
+	if len(result.Problems) != 0 {
+		t.Fatalf("expected no problems, got %v", result.Problems)
+	}
+	if result.CheckpointsChecked != 1 || result.SessionsChecked != 2 || result.HashesVerified != 2 {
+		t.Errorf("unexpected counts: %+v", result)
+	}
+}
+
+func TestVerifyCommitted_ContentHashMismatch(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		putBlob(t, store.repo, entries, cpID.Path()+"/0/"+paths.TranscriptFileName, `{"line": "edited"}`+"\n")
+	})
+
+	result := verifyAll(t, store)
+	requireProblem(t, result, "content hash mismatch")
+	if !result.HasErrors() {
+		t.Error("HasErrors() = false, want true")
+	}
+}
+
+func TestVerifyCommitted_MissingChunk(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		// Chunk .002 without .001
+		putBlob(t, store.repo, entries, cpID.Path()+"/0/"+agent.ChunkFileName(paths.TranscriptFileName, 2), `{"line": 3}`)
+	})
+
+	requireProblem(t, verifyAll(t, store), "full.jsonl.001 missing")
+}
+
+func TestVerifyCommitted_AggregateMismatch(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		summary := `{"checkpoint_id": "` + cpID.String() + `", "strategy": "manual-commit", "checkpoints_count": 7,` +
+			` "files_touched": ["ghost.go"], "sessions": [{"metadata": "/` + cpID.Path() + `/0/metadata.json"}]}`
+		putBlob(t, store.repo, entries, cpID.Path()+"/"+paths.MetadataFileName, summary)
+	})
+
+	result := verifyAll(t, store)
+	requireProblem(t, result, "checkpoints_count is 7, sessions sum to 1")
+	requireProblem(t, result, "files_touched lists 1 files, sessions touch 0")
+}
+
+func TestVerifyCommitted_UnlistedSessionDirectory(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		putBlob(t, store.repo, entries, cpID.Path()+"/1/"+paths.MetadataFileName, `{}`)
+	})
+
+	requireProblem(t, verifyAll(t, store), "session directory not listed")
+}
+
+func TestVerifyCommitted_PrunedTranscriptIsValid(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		delete(entries, cpID.Path()+"/0/"+paths.TranscriptFileName)
+		delete(entries, cpID.Path()+"/0/"+paths.ContentHashFileName)
+	})
+
+	result := verifyAll(t, store)
+	if len(result.Problems) != 0 {
+		t.Errorf("expected no problems for pruned checkpoint, got %v", result.Problems)
+	}
+}
+
+func TestVerifyCommitted_TranscriptWithoutHash(t *testing.T) {
+	store, cpID := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+	tamperCommitted(t, store, func(entries map[string]object.TreeEntry) {
+		delete(entries, cpID.Path()+"/0/"+paths.ContentHashFileName)
+	})
+
+	requireProblem(t, verifyAll(t, store), "content_hash.txt is missing")
+}
+
+func TestVerifyCommitted_UnknownCheckpoint(t *testing.T) {
+	store, _ := writeSingleSession(t, "a1b2c3d4e5f6", "session-one", `{"line": 1}`+"\n")
+
+	result, err := store.VerifyCommitted(context.Background(), []id.CheckpointID{id.MustCheckpointID("ffffffffffff")})
+	if err != nil {
+		t.Fatalf("VerifyCommitted() error = %v", err)
+	}
+	requireProblem(t, result, "checkpoint not found")
+}
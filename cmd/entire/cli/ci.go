@@ -0,0 +1,387 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/spf13/cobra"
+)
+
+// Output formats for "entire ci check".
+const (
+	ciFormatText  = "text"
+	ciFormatJUnit = "junit"
+	ciFormatSARIF = "sarif"
+)
+
+// CI check rules. Used as JUnit failure types and SARIF rule IDs.
+const (
+	ciRuleMissingCheckpoint = "missing-checkpoint"
+	ciRuleMissingTrailer    = "missing-trailer"
+	ciRuleAgentShare        = "agent-share"
+)
+
+// errCICheckFailed is returned (silently) when the check finds policy violations,
+// so the process exits non-zero.
+var errCICheckFailed = errors.New("ci check failed")
+
+func newCICmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "ci",
+		Short: "Commands for CI pipelines",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newCICheckCmd())
+
+	return cmd
+}
+
+func newCICheckCmd() *cobra.Command {
+	var formatFlag string
+	var outputFlag string
+	var fetchFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "check <base>..<head>",
+		Short: "Check agent commit provenance for a commit range",
+		Long: `Check verifies the Entire provenance of every commit in a range, typically
+the commits of a pull request:
+
+  - each Entire-Checkpoint trailer must resolve to a checkpoint on the
+    entire/checkpoints/v1 branch (or origin/entire/checkpoints/v1)
+  - the agent share of added lines is aggregated from the checkpoints'
+    initial attribution; commits without a checkpoint count as human-written
+
+The policy comes from the "ci" section of .entire/settings.json:
+
+  {
+    "ci": {
+      "require_trailers": true,        // every non-merge commit needs a checkpoint
+      "max_agent_percentage": 80,      // fail if the agent share is higher
+      "min_agent_percentage": 0        // fail if the agent share is lower
+    }
+  }
+
+Use --fetch to fetch the metadata branch from origin first. Most CI checkouts
+do not include it.
+
+Use --format junit or --format sarif to produce a report for CI systems.
+The report goes to stdout, or to the file given with --output, in which case
+a text summary is still printed.
+
+Exits with a non-zero status if any check fails.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+			return runCICheck(cmd.OutOrStdout(), args[0], formatFlag, outputFlag, fetchFlag)
+		},
+	}
+
+	cmd.Flags().StringVar(&formatFlag, "format", ciFormatText, "Output format: text, junit or sarif")
+	cmd.Flags().StringVarP(&outputFlag, "output", "o", "", "Write the report to a file instead of stdout")
+	cmd.Flags().BoolVar(&fetchFlag, "fetch", false, "Fetch the metadata branch from origin before checking")
+
+	return cmd
+}
+
+// ciCommit is the provenance of a single commit in the checked range.
+type ciCommit struct {
+	Hash    plumbing.Hash
+	Subject string
+	// CheckpointIDs has one entry per Entire-Checkpoint trailer; squashed
+	// commits carry several. Empty if the commit has none.
+	CheckpointIDs []id.CheckpointID
+	Resolved      bool // All referenced checkpoints exist
+	Merge         bool
+	AgentLines    int
+	TotalLines    int
+}
+
+// ciFinding is a single policy violation.
+type ciFinding struct {
+	Rule    string
+	Commit  plumbing.Hash // ZeroHash for findings about the whole range
+	Message string
+}
+
+// ciCheckReport is the result of checking a commit range.
+type ciCheckReport struct {
+	Range      string
+	Commits    []ciCommit
+	AgentLines int
+	TotalLines int
+	Findings   []ciFinding
+}
+
+// AgentPercentage returns the agent share of added lines across the range.
+func (r *ciCheckReport) AgentPercentage() float64 {
+	if r.TotalLines == 0 {
+		return 0
+	}
+	return float64(r.AgentLines) / float64(r.TotalLines) * 100
+}
+
+// TrailerCount returns the number of commits that carry a checkpoint trailer.
+func (r *ciCheckReport) TrailerCount() int {
+	count := 0
+	for _, c := range r.Commits {
+		if len(c.CheckpointIDs) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func runCICheck(w io.Writer, revRange, format, output string, fetch bool) error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	switch format {
+	case ciFormatText, ciFormatJUnit, ciFormatSARIF:
+	default:
+		return fmt.Errorf("unknown format %q (expected text, junit or sarif)", format)
+	}
+
+	s, err := LoadEntireSettings()
+	if err != nil {
+		return err
+	}
+	policy := s.CI
+	if policy == nil {
+		policy = &settings.CISettings{}
+	}
+	if err := policy.Validate(); err != nil {
+		return fmt.Errorf("invalid ci settings: %w", err)
+	}
+
+	if fetch {
+		if err := FetchMetadataBranch(); err != nil {
+			return err
+		}
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+
+	report, err := buildCICheckReport(context.Background(), repo, revRange, policy)
+	if err != nil {
+		return err
+	}
+
+	if format == ciFormatText {
+		writeCICheckText(w, report)
+	} else {
+		dest := w
+		if output != "" {
+			//nolint:gosec // G304: output path comes from the user's CLI flag
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("failed to create report file: %w", err)
+			}
+			defer f.Close()
+			dest = f
+			writeCICheckText(w, report)
+		}
+		if format == ciFormatJUnit {
+			err = writeCICheckJUnit(dest, report)
+		} else {
+			err = writeCICheckSARIF(dest, report)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(report.Findings) > 0 {
+		return NewSilentError(errCICheckFailed)
+	}
+	return nil
+}
+
+// buildCICheckReport resolves the checkpoint trailers of every commit in
+// revRange, aggregates attribution and evaluates the policy.
+func buildCICheckReport(ctx context.Context, repo *git.Repository, revRange string, policy *settings.CISettings) (*ciCheckReport, error) {
+	hashes, err := ListCommitsInRange(revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	store := checkpoint.NewGitStore(repo)
+	report := &ciCheckReport{Range: revRange}
+
+	for _, hash := range hashes {
+		commit, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read commit %s: %w", hash, err)
+		}
+
+		c := ciCommit{
+			Hash:    hash,
+			Subject: strings.SplitN(commit.Message, "\n", 2)[0],
+			Merge:   commit.NumParents() > 1,
+		}
+		if c.Merge {
+			// Merge commits add no lines of their own and never carry trailers
+			report.Commits = append(report.Commits, c)
+			continue
+		}
+
+		var attribution *checkpoint.InitialAttribution
+		var checkpointStrategy string
+		c.CheckpointIDs = trailers.ParseAllCheckpoints(commit.Message)
+		c.Resolved = len(c.CheckpointIDs) > 0
+		for _, cpID := range c.CheckpointIDs {
+			cpAttribution, cpStrategy, found, err := readCheckpointAttribution(ctx, store, cpID)
+			if err != nil {
+				return nil, err
+			}
+			if !found {
+				c.Resolved = false
+				report.Findings = append(report.Findings, ciFinding{
+					Rule:    ciRuleMissingCheckpoint,
+					Commit:  hash,
+					Message: fmt.Sprintf("%s %s not found on %s", trailers.CheckpointTrailerKey, cpID, paths.MetadataBranchName),
+				})
+				continue
+			}
+			checkpointStrategy = cpStrategy
+			if cpAttribution == nil {
+				continue
+			}
+			// Squashed turns each attribute their own lines, like sessions
+			// within a checkpoint
+			if attribution == nil {
+				attribution = &checkpoint.InitialAttribution{}
+			}
+			attribution.AgentLines += cpAttribution.AgentLines
+			attribution.TotalCommitted = max(attribution.TotalCommitted, cpAttribution.TotalCommitted)
+		}
+		if attribution != nil {
+			attribution.AgentLines = min(attribution.AgentLines, attribution.TotalCommitted)
+		}
+		if len(c.CheckpointIDs) == 0 && policy.RequireTrailers {
+			report.Findings = append(report.Findings, ciFinding{
+				Rule:    ciRuleMissingTrailer,
+				Commit:  hash,
+				Message: "commit has no " + trailers.CheckpointTrailerKey + " trailer",
+			})
+		}
+
+		if attribution != nil {
+			c.AgentLines = attribution.AgentLines
+			c.TotalLines = attribution.TotalCommitted
+		} else {
+			added, err := countAddedLines(commit)
+			if err != nil {
+				return nil, err
+			}
+			c.TotalLines = added
+			// Auto-commit checkpoints are created from agent changes only
+			if checkpointStrategy == strategy.StrategyNameAutoCommit {
+				c.AgentLines = added
+			}
+		}
+
+		report.AgentLines += c.AgentLines
+		report.TotalLines += c.TotalLines
+		report.Commits = append(report.Commits, c)
+	}
+
+	// Percentage rules only apply when the range adds lines
+	if report.TotalLines > 0 {
+		pct := report.AgentPercentage()
+		if policy.MaxAgentPercentage != nil && pct > *policy.MaxAgentPercentage {
+			report.Findings = append(report.Findings, ciFinding{
+				Rule:    ciRuleAgentShare,
+				Message: fmt.Sprintf("agent share %.1f%% exceeds ci.max_agent_percentage %g%%", pct, *policy.MaxAgentPercentage),
+			})
+		}
+		if policy.MinAgentPercentage != nil && pct < *policy.MinAgentPercentage {
+			report.Findings = append(report.Findings, ciFinding{
+				Rule:    ciRuleAgentShare,
+				Message: fmt.Sprintf("agent share %.1f%% is below ci.min_agent_percentage %g%%", pct, *policy.MinAgentPercentage),
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// readCheckpointAttribution combines the initial attribution of all sessions
+// in a checkpoint. Each session attributes only its own lines to the agent and
+// measures the same commit, so agent lines are summed while the committed
+// total is shared. Returns found=false if the checkpoint does not exist.
+func readCheckpointAttribution(ctx context.Context, store *checkpoint.GitStore, cpID id.CheckpointID) (attribution *checkpoint.InitialAttribution, strategyName string, found bool, err error) {
+	summary, err := store.ReadCommitted(ctx, cpID)
+	if err != nil {
+		return nil, "", false, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+	}
+	if summary == nil {
+		return nil, "", false, nil
+	}
+
+	for i := range summary.Sessions {
+		meta, err := store.ReadSessionMetadata(ctx, cpID, i)
+		if err != nil {
+			logging.Warn(ctx, "skipping unreadable session metadata",
+				slog.String("checkpoint_id", cpID.String()),
+				slog.Int("session_index", i),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if meta.InitialAttribution == nil {
+			continue
+		}
+		if attribution == nil {
+			attribution = &checkpoint.InitialAttribution{}
+		}
+		attribution.AgentLines += meta.InitialAttribution.AgentLines
+		attribution.TotalCommitted = max(attribution.TotalCommitted, meta.InitialAttribution.TotalCommitted)
+	}
+	if attribution != nil {
+		attribution.AgentLines = min(attribution.AgentLines, attribution.TotalCommitted)
+	}
+
+	return attribution, summary.Strategy, true, nil
+}
+
+// countAddedLines returns the number of lines a commit adds relative to its first parent.
+func countAddedLines(commit *object.Commit) (int, error) {
+	stats, err := commit.Stats()
+	if err != nil {
+		return 0, fmt.Errorf("failed to compute commit stats: %w", err)
+	}
+	added := 0
+	for _, stat := range stats {
+		added += stat.Addition
+	}
+	return added, nil
+}
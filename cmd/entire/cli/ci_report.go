@@ -0,0 +1,229 @@
+package cli
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/entireio/cli/cmd/entire/cli/buildinfo"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ciRuleDescriptions documents each rule for report consumers.
+var ciRuleDescriptions = map[string]string{
+	ciRuleMissingCheckpoint: "Entire-Checkpoint trailer references a checkpoint that does not exist",
+	ciRuleMissingTrailer:    "Commit has no Entire-Checkpoint trailer",
+	ciRuleAgentShare:        "Agent share of added lines is outside the configured range",
+}
+
+// ciRuleOrder fixes the order rules appear in SARIF output.
+var ciRuleOrder = []string{ciRuleMissingCheckpoint, ciRuleMissingTrailer, ciRuleAgentShare}
+
+// writeCICheckText prints a human-readable summary of the report.
+func writeCICheckText(w io.Writer, report *ciCheckReport) {
+	fmt.Fprintf(w, "Checked %d commits in %s\n", len(report.Commits), report.Range)
+	fmt.Fprintf(w, "  %d with %s trailers\n", report.TrailerCount(), trailers.CheckpointTrailerKey)
+	fmt.Fprintf(w, "  Agent share: %.1f%% of %d added lines\n", report.AgentPercentage(), report.TotalLines)
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(w, "\n✓ All provenance checks passed")
+		return
+	}
+
+	fmt.Fprintln(w, "\nFailures:")
+	for _, f := range report.Findings {
+		if f.Commit.IsZero() {
+			fmt.Fprintf(w, "  ✗ %s\n", f.Message)
+			continue
+		}
+		fmt.Fprintf(w, "  ✗ %s %s: %s\n", f.Commit.String()[:7], report.subject(f.Commit), f.Message)
+	}
+}
+
+// subject returns the subject line of a commit in the report.
+func (r *ciCheckReport) subject(hash plumbing.Hash) string {
+	for _, c := range r.Commits {
+		if c.Hash == hash {
+			return c.Subject
+		}
+	}
+	return ""
+}
+
+// JUnit XML structures. Each non-merge commit is a test case, plus one case
+// for the agent share policy.
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Properties []junitProperty `xml:"properties>property"`
+	Cases      []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string         `xml:"name,attr"`
+	ClassName string         `xml:"classname,attr"`
+	Failures  []junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeCICheckJUnit writes the report as JUnit XML.
+func writeCICheckJUnit(w io.Writer, report *ciCheckReport) error {
+	suite := junitTestSuite{
+		Name: "entire ci check",
+		Properties: []junitProperty{
+			{Name: "range", Value: report.Range},
+			{Name: "agent_lines", Value: strconv.Itoa(report.AgentLines)},
+			{Name: "total_lines", Value: strconv.Itoa(report.TotalLines)},
+			{Name: "agent_percentage", Value: fmt.Sprintf("%.1f", report.AgentPercentage())},
+		},
+	}
+
+	for _, c := range report.Commits {
+		if c.Merge {
+			continue
+		}
+		tc := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", c.Hash.String()[:7], c.Subject),
+			ClassName: "entire.provenance",
+		}
+		for _, f := range report.Findings {
+			if f.Commit == c.Hash {
+				tc.Failures = append(tc.Failures, junitFailure{Message: f.Message, Type: f.Rule, Text: f.Message})
+			}
+		}
+		suite.Cases = append(suite.Cases, tc)
+	}
+
+	policyCase := junitTestCase{Name: ciRuleAgentShare, ClassName: "entire.policy"}
+	for _, f := range report.Findings {
+		if f.Commit.IsZero() {
+			policyCase.Failures = append(policyCase.Failures, junitFailure{Message: f.Message, Type: f.Rule, Text: f.Message})
+		}
+	}
+	suite.Cases = append(suite.Cases, policyCase)
+
+	suite.Tests = len(suite.Cases)
+	for _, tc := range suite.Cases {
+		if len(tc.Failures) > 0 {
+			suite.Failures++
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(junitTestSuites{Suites: []junitTestSuite{suite}}); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	if _, err := io.WriteString(w, "\n"); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// SARIF 2.1.0 structures (subset used by the report).
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool      `json:"tool"`
+	Results    []sarifResult  `json:"results"`
+	Properties map[string]any `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Version        string      `json:"version"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// writeCICheckSARIF writes the report as a SARIF 2.1.0 log.
+func writeCICheckSARIF(w io.Writer, report *ciCheckReport) error {
+	rules := make([]sarifRule, 0, len(ciRuleOrder))
+	for _, ruleID := range ciRuleOrder {
+		rules = append(rules, sarifRule{ID: ruleID, ShortDescription: sarifMessage{Text: ciRuleDescriptions[ruleID]}})
+	}
+
+	results := make([]sarifResult, 0, len(report.Findings))
+	for _, f := range report.Findings {
+		result := sarifResult{RuleID: f.Rule, Level: "error", Message: sarifMessage{Text: f.Message}}
+		if !f.Commit.IsZero() {
+			result.Message.Text = fmt.Sprintf("%s (%s %s)", f.Message, f.Commit.String()[:7], report.subject(f.Commit))
+			result.PartialFingerprints = map[string]string{"commitSha": f.Commit.String()}
+		}
+		results = append(results, result)
+	}
+
+	log := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "entire",
+				InformationURI: "https://github.com/entireio/cli",
+				Version:        buildinfo.Version,
+				Rules:          rules,
+			}},
+			Results: results,
+			Properties: map[string]any{
+				"range":           report.Range,
+				"agentLines":      report.AgentLines,
+				"totalLines":      report.TotalLines,
+				"agentPercentage": report.AgentPercentage(),
+			},
+		}},
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(log); err != nil {
+		return fmt.Errorf("failed to write SARIF report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func sampleCICheckReport() *ciCheckReport {
+	agentCommit := plumbing.NewHash("1111111111111111111111111111111111111111")
+	humanCommit := plumbing.NewHash("2222222222222222222222222222222222222222")
+	return &ciCheckReport{
+		Range: "main..HEAD",
+		Commits: []ciCommit{
+			{Hash: agentCommit, Subject: "Agent change", CheckpointIDs: []id.CheckpointID{id.MustCheckpointID("a1b2c3d4e5f6")}, AgentLines: 9, TotalLines: 10},
+			{Hash: humanCommit, Subject: "Human change", TotalLines: 10},
+		},
+		AgentLines: 9,
+		TotalLines: 20,
+		Findings: []ciFinding{
+			{Rule: ciRuleMissingTrailer, Commit: humanCommit, Message: "commit has no Entire-Checkpoint trailer"},
+			{Rule: ciRuleAgentShare, Message: "agent share 45.0% is below ci.min_agent_percentage 50%"},
+		},
+	}
+}
+
+func TestWriteCICheckText(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	writeCICheckText(&buf, sampleCICheckReport())
+	output := buf.String()
+
+	for _, want := range []string{
+		"Checked 2 commits in main..HEAD",
+		"1 with Entire-Checkpoint trailers",
+		"Agent share: 45.0% of 20 added lines",
+		"✗ 2222222 Human change: commit has no Entire-Checkpoint trailer",
+		"✗ agent share 45.0% is below",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestWriteCICheckJUnit(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := writeCICheckJUnit(&buf, sampleCICheckReport()); err != nil {
+		t.Fatalf("writeCICheckJUnit() error = %v", err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid JUnit XML: %v\n%s", err, buf.String())
+	}
+	if len(parsed.Suites) != 1 {
+		t.Fatalf("expected 1 suite, got %d", len(parsed.Suites))
+	}
+	suite := parsed.Suites[0]
+	// Two commits plus the agent share policy case
+	if suite.Tests != 3 || suite.Failures != 2 {
+		t.Errorf("tests/failures = %d/%d, want 3/2", suite.Tests, suite.Failures)
+	}
+	if len(suite.Cases[0].Failures) != 0 {
+		t.Errorf("agent commit should pass, got %+v", suite.Cases[0].Failures)
+	}
+	if len(suite.Cases[1].Failures) != 1 || suite.Cases[1].Failures[0].Type != ciRuleMissingTrailer {
+		t.Errorf("human commit should fail with %s, got %+v", ciRuleMissingTrailer, suite.Cases[1].Failures)
+	}
+}
+
+func TestWriteCICheckSARIF(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := writeCICheckSARIF(&buf, sampleCICheckReport()); err != nil {
+		t.Fatalf("writeCICheckSARIF() error = %v", err)
+	}
+
+	var parsed sarifLog
+	if err := json.Unmarshal(buf.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid SARIF JSON: %v", err)
+	}
+	if parsed.Version != "2.1.0" || len(parsed.Runs) != 1 {
+		t.Fatalf("unexpected SARIF log: %+v", parsed)
+	}
+	run := parsed.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(ciRuleOrder) {
+		t.Errorf("expected %d rules, got %d", len(ciRuleOrder), len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(run.Results))
+	}
+	if got := run.Results[0].PartialFingerprints["commitSha"]; got != "2222222222222222222222222222222222222222" {
+		t.Errorf("commitSha fingerprint = %q", got)
+	}
+	if run.Results[1].PartialFingerprints != nil {
+		t.Errorf("range-wide finding should have no commit fingerprint, got %v", run.Results[1].PartialFingerprints)
+	}
+}
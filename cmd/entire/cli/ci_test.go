@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// setupCITestRepo creates a repository with an initial commit and returns it
+// with its directory and the initial commit hash.
+func setupCITestRepo(t *testing.T) (*git.Repository, string, plumbing.Hash) {
+	t.Helper()
+
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	if err != nil {
+		t.Fatalf("failed to init git repo: %v", err)
+	}
+	t.Chdir(dir)
+	paths.ClearRepoRootCache()
+
+	initial := commitFile(t, repo, dir, "README.md", "# Test\n", "Initial commit")
+	return repo, dir, initial
+}
+
+// commitFile writes a file and commits it, returning the new commit hash.
+func commitFile(t *testing.T, repo *git.Repository, dir, name, content, message string) plumbing.Hash {
+	t.Helper()
+
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if _, err := wt.Add(name); err != nil {
+		t.Fatalf("failed to add %s: %v", name, err)
+	}
+	hash, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@test.com", When: time.Now()},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	return hash
+}
+
+// writeAttributedCheckpoint writes a committed checkpoint with initial attribution.
+func writeAttributedCheckpoint(t *testing.T, repo *git.Repository, cpID id.CheckpointID, agentLines, total int) {
+	t.Helper()
+	err := checkpoint.NewGitStore(repo).WriteCommitted(context.Background(), checkpoint.WriteCommittedOptions{
+		CheckpointID: cpID,
+		SessionID:    "session-" + cpID.String(),
+		Strategy:     strategy.StrategyNameManualCommit,
+		Transcript:   []byte(`{"type":"user","message":{"content":"hello"}}` + "\n"),
+		AuthorName:   "Test",
+		AuthorEmail:  "test@test.com",
+		InitialAttribution: &checkpoint.InitialAttribution{
+			AgentLines:     agentLines,
+			TotalCommitted: total,
+		},
+	})
+	if err != nil {
+		t.Fatalf("WriteCommitted() error = %v", err)
+	}
+}
+
+func TestBuildCICheckReport_AggregatesAttribution(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+
+	cpID := id.MustCheckpointID("a1b2c3d4e5f6")
+	writeAttributedCheckpoint(t, repo, cpID, 3, 4)
+	commitFile(t, repo, dir, "agent.go", "a\nb\nc\nd\n", trailers.FormatCheckpoint("Agent change", cpID))
+	// Human commit without a trailer: 4 added lines, all human
+	commitFile(t, repo, dir, "human.go", "1\n2\n3\n4\n", "Human change")
+
+	report, err := buildCICheckReport(context.Background(), repo, initial.String()+"..HEAD", &settings.CISettings{})
+	if err != nil {
+		t.Fatalf("buildCICheckReport() error = %v", err)
+	}
+
+	if len(report.Commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d", len(report.Commits))
+	}
+	if !report.Commits[0].Resolved || report.Commits[0].AgentLines != 3 {
+		t.Errorf("unexpected agent commit: %+v", report.Commits[0])
+	}
+	if report.AgentLines != 3 || report.TotalLines != 8 {
+		t.Errorf("AgentLines/TotalLines = %d/%d, want 3/8", report.AgentLines, report.TotalLines)
+	}
+	if got := report.AgentPercentage(); got != 37.5 {
+		t.Errorf("AgentPercentage() = %v, want 37.5", got)
+	}
+	if len(report.Findings) != 0 {
+		t.Errorf("expected no findings, got %+v", report.Findings)
+	}
+}
+
+func TestBuildCICheckReport_PolicyFindings(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+
+	writeAttributedCheckpoint(t, repo, id.MustCheckpointID("a1b2c3d4e5f6"), 4, 4)
+	commitFile(t, repo, dir, "agent.go", "a\nb\nc\nd\n", trailers.FormatCheckpoint("Agent change", id.MustCheckpointID("a1b2c3d4e5f6")))
+	commitFile(t, repo, dir, "missing.go", "x\n", trailers.FormatCheckpoint("Dangling trailer", id.MustCheckpointID("ffffffffffff")))
+	commitFile(t, repo, dir, "human.go", "1\n", "No trailer")
+
+	maxPct := 50.0
+	policy := &settings.CISettings{RequireTrailers: true, MaxAgentPercentage: &maxPct}
+	report, err := buildCICheckReport(context.Background(), repo, initial.String()+"..HEAD", policy)
+	if err != nil {
+		t.Fatalf("buildCICheckReport() error = %v", err)
+	}
+
+	rules := make(map[string]int)
+	for _, f := range report.Findings {
+		rules[f.Rule]++
+	}
+	if rules[ciRuleMissingCheckpoint] != 1 {
+		t.Errorf("expected 1 %s finding, got %+v", ciRuleMissingCheckpoint, report.Findings)
+	}
+	if rules[ciRuleMissingTrailer] != 1 {
+		t.Errorf("expected 1 %s finding, got %+v", ciRuleMissingTrailer, report.Findings)
+	}
+	// 4 agent lines of 6 added is 66.7%, above the 50% maximum
+	if rules[ciRuleAgentShare] != 1 {
+		t.Errorf("expected 1 %s finding, got %+v", ciRuleAgentShare, report.Findings)
+	}
+}
+
+func TestBuildCICheckReport_SquashedTrailers(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+
+	first, second := id.MustCheckpointID("a1b2c3d4e5f6"), id.MustCheckpointID("b2c3d4e5f6a1")
+	writeAttributedCheckpoint(t, repo, first, 2, 4)
+	writeAttributedCheckpoint(t, repo, second, 1, 4)
+	message := "Squashed change\n\n" +
+		trailers.CheckpointTrailerKey + ": " + first.String() + "\n" +
+		trailers.CheckpointTrailerKey + ": " + second.String() + "\n" +
+		trailers.CheckpointTrailerKey + ": ffffffffffff\n"
+	commitFile(t, repo, dir, "agent.go", "a\nb\nc\nd\n", message)
+
+	report, err := buildCICheckReport(context.Background(), repo, initial.String()+"..HEAD", &settings.CISettings{})
+	if err != nil {
+		t.Fatalf("buildCICheckReport() error = %v", err)
+	}
+	if len(report.Commits) != 1 || len(report.Commits[0].CheckpointIDs) != 3 {
+		t.Fatalf("expected 1 commit with 3 checkpoints, got %+v", report.Commits)
+	}
+	if c := report.Commits[0]; c.Resolved || c.AgentLines != 3 || c.TotalLines != 4 {
+		t.Errorf("unexpected squashed commit: %+v", c)
+	}
+	if len(report.Findings) != 1 || report.Findings[0].Rule != ciRuleMissingCheckpoint {
+		t.Errorf("expected 1 %s finding, got %+v", ciRuleMissingCheckpoint, report.Findings)
+	}
+}
+
+func TestRunCICheck_FailsWithSilentError(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+	commitFile(t, repo, dir, "human.go", "1\n", "No trailer")
+
+	if err := os.MkdirAll(filepath.Join(dir, ".entire"), 0o755); err != nil {
+		t.Fatalf("failed to create .entire: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".entire", "settings.json"), []byte(`{"ci": {"require_trailers": true}}`), 0o644); err != nil {
+		t.Fatalf("failed to write settings: %v", err)
+	}
+
+	var stdout bytes.Buffer
+	err := runCICheck(&stdout, initial.String()+"..HEAD", ciFormatText, "", false)
+
+	var silentErr *SilentError
+	if !errors.As(err, &silentErr) {
+		t.Fatalf("runCICheck() error = %v, want SilentError", err)
+	}
+	if !strings.Contains(stdout.String(), "commit has no Entire-Checkpoint trailer") {
+		t.Errorf("expected missing trailer failure, got: %s", stdout.String())
+	}
+}
+
+func TestRunCICheck_WritesReportFile(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+	commitFile(t, repo, dir, "human.go", "1\n", "No trailer")
+
+	reportPath := filepath.Join(t.TempDir(), "report.xml")
+	var stdout bytes.Buffer
+	if err := runCICheck(&stdout, initial.String()+"..HEAD", ciFormatJUnit, reportPath, false); err != nil {
+		t.Fatalf("runCICheck() error = %v", err)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	if !strings.Contains(string(data), "<testsuites>") {
+		t.Errorf("expected JUnit report, got: %s", data)
+	}
+	if !strings.Contains(stdout.String(), "All provenance checks passed") {
+		t.Errorf("expected text summary on stdout, got: %s", stdout.String())
+	}
+}
+
+func TestRunCICheck_UnknownFormat(t *testing.T) {
+	_, _, initial := setupCITestRepo(t)
+
+	var stdout bytes.Buffer
+	if err := runCICheck(&stdout, initial.String()+"..HEAD", "xml", "", false); err == nil {
+		t.Error("runCICheck() should reject unknown formats")
+	}
+}
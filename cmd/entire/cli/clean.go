@@ -33,6 +33,15 @@ This command finds and removes orphaned data from any strategy:
     Manual-commit checkpoints are permanent (condensed history) and are
     never considered orphaned.
 
+  Session worktrees (entire/session/<session-id>)
+    Created by worktree strategy. Orphaned when the session has ended, its
+    branch is already in HEAD and its worktree has no changes, for example
+    when a session never used the worktree prepared for it.
+
+  Squash branches (entire/squash/<session-id>)
+    Created by squash-commit strategy. Orphaned when the session is no
+    longer active and has no turns above HEAD, or was landed with --keep.
+
 Default: shows a preview of items that would be deleted.
 With --force, actually deletes the orphaned items.
 
@@ -74,7 +83,7 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 	}
 
 	// Group items by type for display
-	var branches, states, checkpoints []strategy.CleanupItem
+	var branches, states, checkpoints, worktrees, squashBranches []strategy.CleanupItem
 	for _, item := range items {
 		switch item.Type {
 		case strategy.CleanupTypeShadowBranch:
@@ -83,6 +92,10 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 			states = append(states, item)
 		case strategy.CleanupTypeCheckpoint:
 			checkpoints = append(checkpoints, item)
+		case strategy.CleanupTypeSessionWorktree:
+			worktrees = append(worktrees, item)
+		case strategy.CleanupTypeSquashBranch:
+			squashBranches = append(squashBranches, item)
 		}
 	}
 
@@ -114,6 +127,22 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 			fmt.Fprintln(w)
 		}
 
+		if len(worktrees) > 0 {
+			fmt.Fprintf(w, "Session worktrees (%d):\n", len(worktrees))
+			for _, item := range worktrees {
+				fmt.Fprintf(w, "  %s\n", item.ID)
+			}
+			fmt.Fprintln(w)
+		}
+
+		if len(squashBranches) > 0 {
+			fmt.Fprintf(w, "Squash branches (%d):\n", len(squashBranches))
+			for _, item := range squashBranches {
+				fmt.Fprintf(w, "  %s\n", item.ID)
+			}
+			fmt.Fprintln(w)
+		}
+
 		fmt.Fprintln(w, "Run with --force to delete these items.")
 		return nil
 	}
@@ -125,8 +154,8 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 	}
 
 	// Report results
-	totalDeleted := len(result.ShadowBranches) + len(result.SessionStates) + len(result.Checkpoints)
-	totalFailed := len(result.FailedBranches) + len(result.FailedStates) + len(result.FailedCheckpoints)
+	totalDeleted := len(result.ShadowBranches) + len(result.SessionStates) + len(result.Checkpoints) + len(result.SessionWorktrees) + len(result.SquashBranches)
+	totalFailed := len(result.FailedBranches) + len(result.FailedStates) + len(result.FailedCheckpoints) + len(result.FailedWorktrees) + len(result.FailedSquashBranches)
 
 	if totalDeleted > 0 {
 		fmt.Fprintf(w, "Deleted %d items:\n", totalDeleted)
@@ -151,6 +180,20 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 				fmt.Fprintf(w, "    %s\n", cp)
 			}
 		}
+
+		if len(result.SessionWorktrees) > 0 {
+			fmt.Fprintf(w, "\n  Session worktrees (%d):\n", len(result.SessionWorktrees))
+			for _, branch := range result.SessionWorktrees {
+				fmt.Fprintf(w, "    %s\n", branch)
+			}
+		}
+
+		if len(result.SquashBranches) > 0 {
+			fmt.Fprintf(w, "\n  Squash branches (%d):\n", len(result.SquashBranches))
+			for _, branch := range result.SquashBranches {
+				fmt.Fprintf(w, "    %s\n", branch)
+			}
+		}
 	}
 
 	if totalFailed > 0 {
@@ -177,6 +220,20 @@ func runCleanWithItems(w io.Writer, force bool, items []strategy.CleanupItem) er
 			}
 		}
 
+		if len(result.FailedWorktrees) > 0 {
+			fmt.Fprintf(w, "\n  Session worktrees:\n")
+			for _, branch := range result.FailedWorktrees {
+				fmt.Fprintf(w, "    %s\n", branch)
+			}
+		}
+
+		if len(result.FailedSquashBranches) > 0 {
+			fmt.Fprintf(w, "\n  Squash branches:\n")
+			for _, branch := range result.FailedSquashBranches {
+				fmt.Fprintf(w, "    %s\n", branch)
+			}
+		}
+
 		return fmt.Errorf("failed to delete %d items", totalFailed)
 	}
 
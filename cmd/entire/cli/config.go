@@ -24,9 +24,10 @@ const (
 // EntireSettings is an alias for settings.EntireSettings.
 type EntireSettings = settings.EntireSettings
 
-// LoadEntireSettings loads the Entire settings from .entire/settings.json,
-// then applies any overrides from .entire/settings.local.json if it exists.
-// Returns default settings if neither file exists.
+// LoadEntireSettings loads the effective Entire settings: user settings,
+// then .entire/settings.json, then .entire/settings.local.json, then
+// environment overrides.
+// Returns default settings if none of the files exist.
 // Works correctly from any subdirectory within the repository.
 func LoadEntireSettings() (*settings.EntireSettings, error) {
 	s, err := settings.Load()
@@ -36,6 +37,17 @@ func LoadEntireSettings() (*settings.EntireSettings, error) {
 	return s, nil
 }
 
+// LoadEntireRepoSettings loads only .entire/settings.json and
+// .entire/settings.local.json. Use it for settings that are saved back to the
+// repository, so user-level defaults and environment overrides stay out of it.
+func LoadEntireRepoSettings() (*settings.EntireSettings, error) {
+	s, err := settings.LoadRepo()
+	if err != nil {
+		return nil, fmt.Errorf("loading settings: %w", err)
+	}
+	return s, nil
+}
+
 // SaveEntireSettings saves the Entire settings to .entire/settings.json.
 func SaveEntireSettings(s *settings.EntireSettings) error {
 	if err := settings.Save(s); err != nil {
@@ -98,6 +110,23 @@ func GetLogLevel() string {
 	return s.LogLevel
 }
 
+// GetTracingEndpoint returns the configured OTLP/HTTP collector endpoint,
+// including the ENTIRE_TRACING_ENDPOINT override.
+// Returns empty string if tracing is not configured.
+func GetTracingEndpoint() string {
+	s, err := settings.Load()
+	if err != nil || s.Tracing == nil {
+		return ""
+	}
+	return s.Tracing.Endpoint
+}
+
+// telemetryOptOutEnv returns the environment variable that turns telemetry
+// off (ENTIRE_TELEMETRY_OPTOUT, ENTIRE_TELEMETRY=0 or DO_NOT_TRACK), or "".
+func telemetryOptOutEnv() string {
+	return settings.TelemetryOptOutEnv()
+}
+
 // GetAgentsWithHooksInstalled returns names of agents that have hooks installed.
 func GetAgentsWithHooksInstalled() []agent.AgentName {
 	var installed []agent.AgentName
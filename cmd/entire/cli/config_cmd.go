@@ -0,0 +1,511 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/jsonutil"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+
+	"github.com/spf13/cobra"
+)
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Get and set Entire settings",
+		Long: `Read and edit Entire settings without editing JSON by hand.
+
+Settings come from three files, each overriding the one before:
+  --global   $XDG_CONFIG_HOME/entire/settings.json (personal defaults)
+  --project  .entire/settings.json (shared with the team)
+  --local    .entire/settings.local.json (personal, not committed)
+
+Environment variables (ENTIRE_LOG_LEVEL, ENTIRE_TELEMETRY_OPTOUT,
+ENTIRE_TELEMETRY, DO_NOT_TRACK, ENTIRE_TRACING_ENDPOINT) override all of them.
+A telemetry opt-out in the user settings file also overrides the repository
+files. set and unset write to the local file unless another one is chosen; get
+and list show effective values unless a file is chosen.
+
+Keys use dots for nested settings. Values are checked against the settings
+schema (see 'entire config schema'), which editors can use for completion by
+adding "$schema": "` + settings.SchemaURL + `" to a settings file.
+
+  entire config list --all
+  entire config get strategy
+  entire config set log_level debug --global
+  entire config set retention.max_age 90d --project
+  entire config unset strategy_options.summarize.enabled`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newConfigGetCmd())
+	cmd.AddCommand(newConfigSetCmd())
+	cmd.AddCommand(newConfigUnsetCmd())
+	cmd.AddCommand(newConfigListCmd())
+	cmd.AddCommand(newConfigSchemaCmd())
+
+	return cmd
+}
+
+// configScope selects the settings file a config subcommand works on.
+type configScope struct {
+	local   bool
+	project bool
+	global  bool
+}
+
+func (s *configScope) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&s.local, "local", false, "Use .entire/settings.local.json")
+	cmd.Flags().BoolVar(&s.project, "project", false, "Use .entire/settings.json")
+	cmd.Flags().BoolVar(&s.global, "global", false, "Use the user settings file ($XDG_CONFIG_HOME/entire/settings.json)")
+	cmd.MarkFlagsMutuallyExclusive("local", "project", "global")
+}
+
+func (s configScope) isSet() bool {
+	return s.local || s.project || s.global
+}
+
+// file returns the selected settings file and its display name. With no
+// flag set, the local file is used.
+func (s configScope) file() (path, display string, err error) {
+	if s.global {
+		path, err := settings.UserSettingsPath()
+		if err != nil {
+			return "", "", fmt.Errorf("failed to locate user settings: %w", err)
+		}
+		return path, path, nil
+	}
+	if _, err := paths.RepoRoot(); err != nil {
+		return "", "", errors.New("not a git repository (use --global for user settings)")
+	}
+	rel := EntireSettingsLocalFile
+	if s.project {
+		rel = EntireSettingsFile
+	}
+	path, err = paths.AbsPath(rel)
+	if err != nil {
+		path = rel
+	}
+	return path, rel, nil
+}
+
+func newConfigGetCmd() *cobra.Command {
+	var scope configScope
+
+	cmd := &cobra.Command{
+		Use:   "get <key>",
+		Short: "Print the value of a setting",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigGet(cmd.OutOrStdout(), args[0], scope)
+		},
+	}
+	scope.addFlags(cmd)
+	return cmd
+}
+
+func newConfigSetCmd() *cobra.Command {
+	var scope configScope
+
+	cmd := &cobra.Command{
+		Use:   "set <key> <value>",
+		Short: "Set a setting",
+		Args:  cobra.ExactArgs(2),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			return completeConfigArgs(args)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigSet(cmd.OutOrStdout(), args[0], args[1], scope)
+		},
+	}
+	scope.addFlags(cmd)
+	return cmd
+}
+
+func newConfigUnsetCmd() *cobra.Command {
+	var scope configScope
+
+	cmd := &cobra.Command{
+		Use:   "unset <key>",
+		Short: "Remove a setting from a settings file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConfigUnset(cmd.OutOrStdout(), args[0], scope)
+		},
+	}
+	scope.addFlags(cmd)
+	return cmd
+}
+
+func newConfigListCmd() *cobra.Command {
+	var scope configScope
+	var allFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List settings and where they come from",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if allFlag {
+				return runConfigListAll(cmd.OutOrStdout())
+			}
+			return runConfigList(cmd.OutOrStdout(), scope)
+		},
+	}
+	scope.addFlags(cmd)
+	cmd.Flags().BoolVar(&allFlag, "all", false, "List every known setting with its description")
+	cmd.MarkFlagsMutuallyExclusive("all", "local", "project", "global")
+	return cmd
+}
+
+func newConfigSchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON Schema for settings files",
+		Long: `Print the JSON Schema for settings files. The same schema is published at
+` + settings.SchemaURL,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			data, err := settings.SchemaJSON()
+			if err != nil {
+				return err //nolint:wrapcheck // already wrapped by settings
+			}
+			_, err = cmd.OutOrStdout().Write(data)
+			return err //nolint:wrapcheck // writing to stdout
+		},
+	}
+}
+
+// completeConfigArgs completes known keys, then enum or boolean values.
+func completeConfigArgs(args []string) ([]string, cobra.ShellCompDirective) {
+	if len(args) == 0 {
+		var keys []string
+		for _, s := range settings.KnownSettings() {
+			keys = append(keys, s.Key)
+		}
+		return keys, cobra.ShellCompDirectiveNoFileComp
+	}
+	if len(args) == 1 {
+		if s, ok := settings.LookupSetting(args[0]); ok {
+			if s.Type == "boolean" {
+				return []string{"true", "false"}, cobra.ShellCompDirectiveNoFileComp
+			}
+			return s.Enum, cobra.ShellCompDirectiveNoFileComp
+		}
+	}
+	return nil, cobra.ShellCompDirectiveNoFileComp
+}
+
+// checkConfigKey rejects keys that are neither known settings, objects of
+// known settings, nor strategy options of other tools.
+func checkConfigKey(key string) error {
+	if _, ok := settings.LookupSetting(key); ok {
+		return nil
+	}
+	for _, s := range settings.KnownSettings() {
+		if strings.HasPrefix(s.Key, key+".") {
+			return nil
+		}
+	}
+	if strings.HasPrefix(key, "strategy_options.") {
+		return nil
+	}
+	return &settings.UnknownSettingError{Key: key}
+}
+
+func runConfigGet(w io.Writer, key string, scope configScope) error {
+	if err := checkConfigKey(key); err != nil {
+		return err
+	}
+
+	var raw map[string]any
+	where := "effective settings"
+	if scope.isSet() {
+		path, display, err := scope.file()
+		if err != nil {
+			return err
+		}
+		if raw, err = readSettingsFileRaw(path); err != nil {
+			return err
+		}
+		where = display
+	} else {
+		if _, err := paths.RepoRoot(); err != nil {
+			return errors.New("not a git repository (use --global for user settings)")
+		}
+		effective, _, err := settings.LoadWithSources()
+		if err != nil {
+			return fmt.Errorf("failed to load settings: %w", err)
+		}
+		if raw, err = settingsToRaw(effective); err != nil {
+			return err
+		}
+	}
+
+	value, ok := settings.GetPath(raw, key)
+	if !ok {
+		return fmt.Errorf("%s is not set in %s", key, where)
+	}
+	fmt.Fprintln(w, formatConfigValue(value, false))
+	return nil
+}
+
+func runConfigSet(w io.Writer, key, rawValue string, scope configScope) error {
+	setting, ok := settings.LookupSetting(key)
+	if !ok {
+		if err := checkConfigKey(key); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s is a group of settings; set one of its keys instead (see 'entire config list --all')", key)
+	}
+	value, err := setting.Parse(rawValue)
+	if err != nil {
+		return err //nolint:wrapcheck // validation errors are user-facing as is
+	}
+
+	path, display, err := scope.file()
+	if err != nil {
+		return err
+	}
+	raw, err := readSettingsFileRaw(path)
+	if err != nil {
+		return err
+	}
+	if err := settings.SetPath(raw, key, value); err != nil {
+		return fmt.Errorf("cannot set %s in %s: %w", key, display, err)
+	}
+	if err := validateSettingsRaw(raw, display); err != nil {
+		return err
+	}
+	if err := writeSettingsFileRaw(path, raw); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Set %s to %s in %s\n", key, formatConfigValue(value, true), display)
+	return nil
+}
+
+func runConfigUnset(w io.Writer, key string, scope configScope) error {
+	path, display, err := scope.file()
+	if err != nil {
+		return err
+	}
+	raw, err := readSettingsFileRaw(path)
+	if err != nil {
+		return err
+	}
+	// Unknown keys can be unset, so invalid files can be repaired
+	if !settings.DeletePath(raw, key) {
+		return fmt.Errorf("%s is not set in %s", key, display)
+	}
+	if err := writeSettingsFileRaw(path, raw); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "Removed %s from %s\n", key, display)
+	return nil
+}
+
+func runConfigList(w io.Writer, scope configScope) error {
+	if scope.isSet() {
+		path, display, err := scope.file()
+		if err != nil {
+			return err
+		}
+		raw, err := readSettingsFileRaw(path)
+		if err != nil {
+			return err
+		}
+		rows := flattenSettings("", raw, nil)
+		if len(rows) == 0 {
+			fmt.Fprintf(w, "No settings in %s\n", display)
+			return nil
+		}
+		writeSettingRows(w, "", rows)
+		return nil
+	}
+
+	if _, err := paths.RepoRoot(); err != nil {
+		return errors.New("not a git repository (use --global for user settings)")
+	}
+	effective, sources, err := settings.LoadWithSources()
+	if err != nil {
+		return fmt.Errorf("failed to load settings: %w", err)
+	}
+	raw, err := settingsToRaw(effective)
+	if err != nil {
+		return err
+	}
+	writeSettingRows(w, "", flattenSettings("", raw, sources))
+	return nil
+}
+
+func runConfigListAll(w io.Writer) error {
+	var effective map[string]any
+	var sources settings.Sources
+	if _, err := paths.RepoRoot(); err == nil {
+		s, src, err := settings.LoadWithSources()
+		if err != nil {
+			return fmt.Errorf("failed to load settings: %w", err)
+		}
+		if effective, err = settingsToRaw(s); err != nil {
+			return err
+		}
+		sources = src
+	}
+
+	for _, s := range settings.KnownSettings() {
+		value := "(unset)"
+		if v, ok := settings.GetPath(effective, s.Key); ok {
+			value = formatConfigValue(v, true) + " (" + sources.Source(s.Key) + ")"
+		}
+		kind := s.Type
+		if len(s.Enum) > 0 {
+			kind = strings.Join(s.Enum, "|")
+		}
+		fmt.Fprintf(w, "%s = %s\n  %s [%s]\n", s.Key, value, s.Description, kind)
+	}
+	return nil
+}
+
+// settingRow is one flattened setting for display.
+type settingRow struct {
+	key    string
+	value  string
+	source string
+}
+
+// flattenSettings turns nested settings into dotted keys, sorted within
+// each object. Sources are attached when given.
+func flattenSettings(prefix string, raw map[string]any, sources settings.Sources) []settingRow {
+	keys := make([]string, 0, len(raw))
+	for k := range raw {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var rows []settingRow
+	for _, k := range keys {
+		key := prefix + k
+		if child, ok := raw[k].(map[string]any); ok && len(child) > 0 {
+			rows = append(rows, flattenSettings(key+".", child, sources)...)
+			continue
+		}
+		row := settingRow{key: key, value: formatConfigValue(raw[k], true)}
+		if sources != nil {
+			row.source = sources.Source(key)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// writeSettingRows writes aligned "key  value  (source)" lines.
+func writeSettingRows(w io.Writer, indent string, rows []settingRow) {
+	keyWidth, valueWidth := 0, 0
+	for _, r := range rows {
+		keyWidth = max(keyWidth, len(r.key))
+		valueWidth = max(valueWidth, len(r.value))
+	}
+	for _, r := range rows {
+		if r.source == "" {
+			fmt.Fprintf(w, "%s%-*s  %s\n", indent, keyWidth, r.key, r.value)
+			continue
+		}
+		fmt.Fprintf(w, "%s%-*s  %-*s  (%s)\n", indent, keyWidth, r.key, valueWidth, r.value, r.source)
+	}
+}
+
+// formatConfigValue formats a value as JSON. Strings are quoted only when
+// quote is set, so `config get` output can be used in scripts.
+func formatConfigValue(value any, quote bool) string {
+	if s, ok := value.(string); ok && !quote {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// settingsToRaw converts settings to the decoded JSON form used by the
+// dotted-key helpers.
+func settingsToRaw(s *EntireSettings) (map[string]any, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode settings: %w", err)
+	}
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to decode settings: %w", err)
+	}
+	delete(raw, "$schema")
+	return raw, nil
+}
+
+// readSettingsFileRaw reads a settings file as decoded JSON. A missing file
+// reads as empty.
+func readSettingsFileRaw(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is a settings file location
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]any{}, nil
+		}
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	raw := map[string]any{}
+	if len(strings.TrimSpace(string(data))) == 0 {
+		return raw, nil
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s is not valid JSON: %w", path, err)
+	}
+	return raw, nil
+}
+
+// validateSettingsRaw reports every problem in decoded settings file content.
+func validateSettingsRaw(raw map[string]any, display string) error {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	errs := settings.ValidateJSON(data)
+	if len(errs) == 0 {
+		return nil
+	}
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s would be invalid:", display)
+	for _, e := range errs {
+		sb.WriteString("\n  " + e.Error())
+	}
+	sb.WriteString("\nUse 'entire config unset <key>' to remove invalid settings.")
+	return errors.New(sb.String())
+}
+
+// writeSettingsFileRaw writes decoded settings file content, creating the
+// directory if needed.
+func writeSettingsFileRaw(path string, raw map[string]any) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create settings directory: %w", err)
+	}
+	data, err := jsonutil.MarshalIndentWithNewline(raw, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode settings: %w", err)
+	}
+	//nolint:gosec // G306: settings file is config, not secrets; 0o644 is appropriate
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
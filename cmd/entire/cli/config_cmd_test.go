@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+)
+
+// setupConfigTestRepo creates a repo with an isolated user config directory.
+func setupConfigTestRepo(t *testing.T) string {
+	t.Helper()
+	setupTestRepo(t)
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv(settings.LogLevelEnvVar, "")
+	t.Setenv(settings.TelemetryOptOutEnvVar, "")
+	t.Setenv(settings.TelemetryEnvVar, "")
+	t.Setenv(settings.DoNotTrackEnvVar, "")
+	return filepath.Join(configHome, "entire", "settings.json")
+}
+
+func TestStrategyNamesMatchRegistry(t *testing.T) {
+	t.Parallel()
+
+	got := slices.Sorted(slices.Values(settings.StrategyNames))
+	if want := strategy.List(); !slices.Equal(got, want) {
+		t.Errorf("settings.StrategyNames = %v, registered strategies = %v", got, want)
+	}
+}
+
+func TestRunConfigSetGetUnset(t *testing.T) {
+	userPath := setupConfigTestRepo(t)
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true}`)
+
+	var out bytes.Buffer
+	if err := runConfigSet(&out, "log_level", "debug", configScope{}); err != nil {
+		t.Fatalf("runConfigSet() error = %v", err)
+	}
+	if !strings.Contains(out.String(), `Set log_level to "debug" in .entire/settings.local.json`) {
+		t.Errorf("runConfigSet() output = %q", out.String())
+	}
+	data, err := os.ReadFile(EntireSettingsLocalFile)
+	if err != nil || strings.TrimSpace(string(data)) != "{\n  \"log_level\": \"debug\"\n}" {
+		t.Errorf("local settings = %q, %v", data, err)
+	}
+
+	if err := runConfigSet(&out, "retention.keep_last_per_branch", "5", configScope{global: true}); err != nil {
+		t.Fatalf("runConfigSet(--global) error = %v", err)
+	}
+	if _, err := os.Stat(userPath); err != nil {
+		t.Errorf("user settings not written: %v", err)
+	}
+
+	out.Reset()
+	if err := runConfigGet(&out, "log_level", configScope{}); err != nil || out.String() != "debug\n" {
+		t.Errorf("runConfigGet(log_level) = %q, %v", out.String(), err)
+	}
+	out.Reset()
+	if err := runConfigGet(&out, "retention.keep_last_per_branch", configScope{}); err != nil || out.String() != "5\n" {
+		t.Errorf("runConfigGet(retention.keep_last_per_branch) = %q, %v", out.String(), err)
+	}
+	if err := runConfigGet(&out, "log_level", configScope{project: true}); err == nil || !strings.Contains(err.Error(), "not set in .entire/settings.json") {
+		t.Errorf("runConfigGet(--project) error = %v", err)
+	}
+
+	out.Reset()
+	if err := runConfigList(&out, configScope{}); err != nil {
+		t.Fatalf("runConfigList() error = %v", err)
+	}
+	for _, want := range []string{"(local)", "(user)", "(project)"} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("runConfigList() missing %s:\n%s", want, out.String())
+		}
+	}
+
+	out.Reset()
+	if err := runConfigUnset(&out, "log_level", configScope{}); err != nil {
+		t.Fatalf("runConfigUnset() error = %v", err)
+	}
+	if err := runConfigUnset(&out, "log_level", configScope{}); err == nil {
+		t.Error("runConfigUnset() of unset key succeeded")
+	}
+}
+
+func TestRunConfigSet_Validation(t *testing.T) {
+	setupConfigTestRepo(t)
+
+	var out bytes.Buffer
+	for _, tt := range []struct{ key, value, wantErr string }{
+		{"strategy", "yolo", "strategy must be one of"},
+		{"colour", "blue", `unknown setting "colour"`},
+		{"retention", "90d", "group of settings"},
+		{"ci.max_agent_percentage", "101", "between 0 and 100"},
+	} {
+		if err := runConfigSet(&out, tt.key, tt.value, configScope{}); err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("runConfigSet(%s, %s) error = %v, want %q", tt.key, tt.value, err, tt.wantErr)
+		}
+	}
+
+	// Cross-field rules are checked against the whole file
+	if err := runConfigSet(&out, "ci.max_agent_percentage", "40", configScope{}); err != nil {
+		t.Fatalf("runConfigSet() error = %v", err)
+	}
+	if err := runConfigSet(&out, "ci.min_agent_percentage", "60", configScope{}); err == nil || !strings.Contains(err.Error(), "would be invalid") {
+		t.Errorf("runConfigSet(min > max) error = %v", err)
+	}
+}
+
+func TestRunConfigUnset_RepairsUnknownKey(t *testing.T) {
+	setupConfigTestRepo(t)
+	writeLocalSettings(t, `{"log_level": "info", "typo": true}`)
+
+	var out bytes.Buffer
+	if err := runConfigSet(&out, "enabled", "false", configScope{}); err == nil || !strings.Contains(err.Error(), `unknown setting "typo"`) {
+		t.Errorf("runConfigSet() on invalid file error = %v", err)
+	}
+	if err := runConfigUnset(&out, "typo", configScope{}); err != nil {
+		t.Fatalf("runConfigUnset() error = %v", err)
+	}
+	if err := runConfigSet(&out, "enabled", "false", configScope{}); err != nil {
+		t.Errorf("runConfigSet() after repair error = %v", err)
+	}
+}
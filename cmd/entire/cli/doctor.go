@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -8,6 +9,7 @@ import (
 
 	"github.com/charmbracelet/huh"
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/session"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
 
@@ -20,34 +22,55 @@ import (
 const stalenessThreshold = 1 * time.Hour
 
 func newDoctorCmd() *cobra.Command {
-	var forceFlag bool
+	var forceFlag, jsonFlag bool
 
 	cmd := &cobra.Command{
 		Use:   "doctor",
-		Short: "Fix stuck sessions",
-		Long: `Scan for stuck or problematic sessions and offer to fix them.
+		Short: "Diagnose and fix problems with Entire in this repository",
+		Long: `Check the health of Entire in this repository and offer to fix what's wrong.
+
+Checks:
+  - Settings files are valid JSON and match the settings schema
+  - Git hooks are installed where git runs them (core.hooksPath), and hooks
+    Entire replaced still run (.pre-entire backups)
+  - Hook managers (Husky, lefthook, pre-commit) run Entire's git hooks
+  - Agent hooks (.claude/settings.json, .gemini/settings.json) run a binary that exists
+  - The entire/checkpoints/v1 branch hasn't diverged from a remote's copy
+  - Loose objects are below gc.auto
+  - Every worktree's index can be read
+  - No sessions are stuck
 
 A session is considered stuck if:
   - It is in ACTIVE or ACTIVE_COMMITTED phase with no interaction for over 1 hour
   - It is in ENDED phase with uncondensed checkpoint data on a shadow branch
 
-For each stuck session, you can choose to:
+Each problem comes with a fix. Fixes doctor can apply itself are offered one by
+one. For each stuck session, you can choose to:
   - Condense: Save session data to permanent storage (entire/checkpoints/v1 branch)
   - Discard: Remove the session state and shadow branch data
   - Skip: Leave the session as-is
 
-Use --force to condense all fixable sessions without prompting.  Sessions that can't
-be condensed will be discarded.`,
+Use --force to apply every fix without prompting. Stuck sessions that can't
+be condensed will be discarded. Use --json for a report without fixing anything.`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runSessionsFix(cmd, forceFlag)
+			return runDoctor(cmd, forceFlag, jsonFlag)
 		},
 	}
 
-	cmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Fix all stuck sessions without prompting (condense if possible, otherwise discard)")
+	cmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Apply all fixes without prompting (condense stuck sessions if possible, otherwise discard)")
+	cmd.Flags().BoolVar(&jsonFlag, "json", false, "Print the checks and problems as JSON without fixing anything")
+	cmd.MarkFlagsMutuallyExclusive("force", "json")
 
 	return cmd
 }
 
+// doctorReport is the JSON form of "entire doctor".
+type doctorReport struct {
+	Healthy bool          `json:"healthy"`
+	Checks  []doctorCheck `json:"checks"`
+}
+
 // stuckSession holds a session state along with diagnostic info.
 type stuckSession struct {
 	State             *strategy.SessionState
@@ -58,40 +81,181 @@ type stuckSession struct {
 	FilesTouchedCount int
 }
 
-func runSessionsFix(cmd *cobra.Command, force bool) error {
+func runDoctor(cmd *cobra.Command, force, jsonOutput bool) error {
+	if _, err := paths.RepoRoot(); err != nil {
+		return errors.New("not a git repository")
+	}
+
+	checks := runDoctorChecks()
+	stuck, err := findStuckSessions()
+	if err != nil {
+		return err
+	}
+	checks = append(checks, newDoctorCheck(doctorCheckSessions, stuckSessionProblems(stuck)))
+
+	if jsonOutput {
+		report := doctorReport{Healthy: true, Checks: checks}
+		for _, c := range checks {
+			report.Healthy = report.Healthy && c.OK
+		}
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		enc.SetEscapeHTML(false)
+		if err := enc.Encode(report); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+		return nil
+	}
+
+	writeDoctorChecks(cmd.OutOrStdout(), checks)
+	if aborted, err := applyDoctorFixes(cmd, checks, force); err != nil || aborted {
+		return err
+	}
+	if len(stuck) == 0 {
+		return nil
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	return fixStuckSessions(cmd, stuck, force)
+}
+
+// writeDoctorChecks prints each check with its problems and their fixes.
+func writeDoctorChecks(w io.Writer, checks []doctorCheck) {
+	problemCount := 0
+	for _, c := range checks {
+		if c.OK {
+			fmt.Fprintf(w, "✓ %s\n", doctorCheckTitles[c.Name])
+			continue
+		}
+		fmt.Fprintf(w, "✗ %s\n", doctorCheckTitles[c.Name])
+		for _, p := range c.Problems {
+			problemCount++
+			fmt.Fprintf(w, "    %s: %s\n", p.Severity, p.Message)
+			fmt.Fprintf(w, "      Fix: %s\n", p.Fix)
+		}
+	}
+	if problemCount == 0 {
+		fmt.Fprintln(w, "\nNo problems found.")
+		return
+	}
+	fmt.Fprintf(w, "\nFound %d problem(s).\n", problemCount)
+}
+
+// applyDoctorFixes applies the fixes doctor can make itself, asking first
+// unless force is set. Stuck sessions are handled by fixStuckSessions.
+// Returns true if the user aborted.
+func applyDoctorFixes(cmd *cobra.Command, checks []doctorCheck, force bool) (bool, error) {
+	for _, c := range checks {
+		if c.Name == doctorCheckSessions {
+			continue
+		}
+		for _, p := range c.Problems {
+			if p.apply == nil {
+				continue
+			}
+			if !force {
+				apply, err := confirmDoctorFix(p)
+				if err != nil {
+					if errors.Is(err, huh.ErrUserAborted) {
+						return true, nil
+					}
+					return false, err
+				}
+				if !apply {
+					continue
+				}
+			}
+			if err := p.apply(); err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to fix %q: %v\n", p.Message, err)
+				continue
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "  -> Fixed: %s\n", p.Message)
+		}
+	}
+	return false, nil
+}
+
+// confirmDoctorFix asks whether to apply a problem's fix.
+func confirmDoctorFix(p doctorProblem) (bool, error) {
+	var confirmed bool
+	form := NewAccessibleForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(p.Message).
+				Description("Fix: " + p.Fix).
+				Value(&confirmed),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return false, fmt.Errorf("fix prompt failed: %w", err)
+	}
+	return confirmed, nil
+}
+
+// findStuckSessions classifies every session and returns the stuck ones.
+func findStuckSessions() ([]stuckSession, error) {
 	// Load all session states
 	states, err := strategy.ListSessionStates()
 	if err != nil {
-		return fmt.Errorf("failed to list session states: %w", err)
+		return nil, fmt.Errorf("failed to list session states: %w", err)
 	}
-
 	if len(states) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No stuck sessions found.")
-		return nil
+		return nil, nil
 	}
 
 	// Open repository to check shadow branches (uses worktree-aware helper)
 	repo, err := openRepository()
 	if err != nil {
-		return fmt.Errorf("failed to open repository: %w", err)
+		return nil, fmt.Errorf("failed to open repository: %w", err)
 	}
 
 	// Identify stuck sessions
 	now := time.Now()
 	var stuck []stuckSession
-
 	for _, state := range states {
 		ss := classifySession(state, repo, now)
 		if ss != nil {
 			stuck = append(stuck, *ss)
 		}
 	}
+	return stuck, nil
+}
 
-	if len(stuck) == 0 {
-		fmt.Fprintln(cmd.OutOrStdout(), "No stuck sessions found.")
-		return nil
+// stuckSessionProblems reports each stuck session as a problem. The fix
+// condenses the session if possible and discards it otherwise.
+func stuckSessionProblems(stuck []stuckSession) []doctorProblem {
+	problems := make([]doctorProblem, 0, len(stuck))
+	for _, ss := range stuck {
+		problems = append(problems, doctorProblem{
+			Severity: doctorSeverityWarning,
+			Message:  fmt.Sprintf("Session %s is stuck (%s)", ss.State.SessionID, ss.Reason),
+			Fix:      "Condense or discard the session with 'entire doctor'",
+			apply: func() error {
+				_, err := forceFixStuckSession(ss, io.Discard)
+				return err
+			},
+		})
 	}
+	return problems
+}
 
+// forceFixStuckSession condenses a stuck session if the strategy and its
+// data allow it, and discards it otherwise. Returns the action taken.
+func forceFixStuckSession(ss stuckSession, errW io.Writer) (string, error) {
+	condenser, canCondense := GetStrategy().(strategy.SessionCondenser)
+	if canCondense && ss.HasShadowBranch && ss.CheckpointCount > 0 {
+		if err := condenser.CondenseSessionByID(ss.State.SessionID); err != nil {
+			return "", fmt.Errorf("failed to condense session %s: %w", ss.State.SessionID, err)
+		}
+		return "Condensed", nil
+	}
+	// Discard if we can't condense
+	if err := discardSession(ss, nil, errW); err != nil {
+		return "", fmt.Errorf("failed to discard session %s: %w", ss.State.SessionID, err)
+	}
+	return "Discarded", nil
+}
+
+func fixStuckSessions(cmd *cobra.Command, stuck []stuckSession, force bool) error {
 	// Get the current strategy for condense operations
 	strat := GetStrategy()
 	condenser, canCondense := strat.(strategy.SessionCondenser)
@@ -102,19 +266,11 @@ func runSessionsFix(cmd *cobra.Command, force bool) error {
 		displayStuckSession(cmd, ss)
 
 		if force {
-			if canCondense && ss.HasShadowBranch && ss.CheckpointCount > 0 {
-				if err := condenser.CondenseSessionByID(ss.State.SessionID); err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to condense session %s: %v\n", ss.State.SessionID, err)
-				} else {
-					fmt.Fprintf(cmd.OutOrStdout(), "  -> Condensed session %s\n\n", ss.State.SessionID)
-				}
+			action, err := forceFixStuckSession(ss, cmd.ErrOrStderr())
+			if err != nil {
+				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: %v\n", err)
 			} else {
-				// Discard if we can't condense
-				if err := discardSession(ss, repo, cmd.ErrOrStderr()); err != nil {
-					fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to discard session %s: %v\n", ss.State.SessionID, err)
-				} else {
-					fmt.Fprintf(cmd.OutOrStdout(), "  -> Discarded session %s\n\n", ss.State.SessionID)
-				}
+				fmt.Fprintf(cmd.OutOrStdout(), "  -> %s session %s\n\n", action, ss.State.SessionID)
 			}
 			continue
 		}
@@ -140,7 +296,7 @@ func runSessionsFix(cmd *cobra.Command, force bool) error {
 				fmt.Fprintf(cmd.OutOrStdout(), "  -> Condensed session %s\n\n", ss.State.SessionID)
 			}
 		case "discard":
-			if err := discardSession(ss, repo, cmd.ErrOrStderr()); err != nil {
+			if err := discardSession(ss, nil, cmd.ErrOrStderr()); err != nil {
 				fmt.Fprintf(cmd.ErrOrStderr(), "Warning: failed to discard session %s: %v\n", ss.State.SessionID, err)
 			} else {
 				fmt.Fprintf(cmd.OutOrStdout(), "  -> Discarded session %s\n\n", ss.State.SessionID)
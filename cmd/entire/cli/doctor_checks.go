@@ -0,0 +1,514 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// Names of the checks run by `entire doctor`, in the order they run.
+const (
+	doctorCheckSettings       = "settings"
+	doctorCheckGitHooks       = "git_hooks"
+	doctorCheckHookManager    = "hook_manager"
+	doctorCheckAgentHooks     = "agent_hooks"
+	doctorCheckMetadataBranch = "metadata_branch"
+	doctorCheckLooseObjects   = "loose_objects"
+	doctorCheckWorktreeIndex  = "worktree_index"
+	doctorCheckSessions       = "sessions"
+)
+
+// doctorCheckTitles describes each check in text output.
+var doctorCheckTitles = map[string]string{
+	doctorCheckSettings:       "Settings files are valid",
+	doctorCheckGitHooks:       "Git hooks are installed and chained",
+	doctorCheckHookManager:    "Hook managers run Entire's git hooks",
+	doctorCheckAgentHooks:     "Agent hooks run a binary that exists",
+	doctorCheckMetadataBranch: "Metadata branch hasn't diverged from its remote",
+	doctorCheckLooseObjects:   "Loose objects are below gc.auto",
+	doctorCheckWorktreeIndex:  "Worktree indexes are readable",
+	doctorCheckSessions:       "No stuck sessions",
+}
+
+// defaultGCAuto is git's default gc.auto threshold.
+const defaultGCAuto = 6700
+
+// Problem severities.
+const (
+	doctorSeverityError   = "error"
+	doctorSeverityWarning = "warning"
+)
+
+// doctorProblem is something a check found wrong, with how to fix it.
+// Problems with an apply function can be fixed by doctor itself.
+type doctorProblem struct {
+	Check    string `json:"check"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	Fix      string `json:"fix"`
+	AutoFix  bool   `json:"auto_fix"`
+
+	apply func() error
+}
+
+// doctorCheck is the result of one check.
+type doctorCheck struct {
+	Name     string          `json:"name"`
+	OK       bool            `json:"ok"`
+	Problems []doctorProblem `json:"problems,omitempty"`
+}
+
+// doctorCheckFunc returns the problems a check finds. An error means the
+// check couldn't run.
+type doctorCheckFunc func() ([]doctorProblem, error)
+
+// runDoctorChecks runs every repository check except stuck sessions, which
+// need the session list and are classified by runDoctor.
+func runDoctorChecks() []doctorCheck {
+	checks := []struct {
+		name string
+		run  doctorCheckFunc
+	}{
+		{doctorCheckSettings, checkSettingsFiles},
+		{doctorCheckGitHooks, checkGitHooks},
+		{doctorCheckHookManager, checkHookManager},
+		{doctorCheckAgentHooks, checkAgentHooks},
+		{doctorCheckMetadataBranch, checkMetadataBranch},
+		{doctorCheckLooseObjects, checkLooseObjects},
+		{doctorCheckWorktreeIndex, checkWorktreeIndexes},
+	}
+
+	results := make([]doctorCheck, 0, len(checks))
+	for _, c := range checks {
+		problems, err := c.run()
+		if err != nil {
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityWarning,
+				Message:  fmt.Sprintf("Check could not run: %v", err),
+				Fix:      "Run 'entire doctor' again from the repository root",
+			})
+		}
+		results = append(results, newDoctorCheck(c.name, problems))
+	}
+	return results
+}
+
+// newDoctorCheck builds a check result, labelling each problem with the check.
+func newDoctorCheck(name string, problems []doctorProblem) doctorCheck {
+	for i := range problems {
+		problems[i].Check = name
+		problems[i].AutoFix = problems[i].apply != nil
+	}
+	return doctorCheck{Name: name, OK: len(problems) == 0, Problems: problems}
+}
+
+// checkSettingsFiles validates the user, project and local settings files
+// against the settings schema.
+func checkSettingsFiles() ([]doctorProblem, error) {
+	type settingsFile struct {
+		path, display, flag string
+	}
+	var files []settingsFile
+	if userPath, err := settings.UserSettingsPath(); err == nil {
+		files = append(files, settingsFile{userPath, userPath, "--global"})
+	}
+	for _, f := range []struct{ rel, flag string }{
+		{EntireSettingsFile, "--project"},
+		{EntireSettingsLocalFile, "--local"},
+	} {
+		path, err := paths.AbsPath(f.rel)
+		if err != nil {
+			path = f.rel
+		}
+		files = append(files, settingsFile{path, f.rel, f.flag})
+	}
+
+	var problems []doctorProblem
+	for _, f := range files {
+		raw, err := readSettingsFileRaw(f.path)
+		if err != nil {
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  err.Error(),
+				Fix:      "Fix the JSON syntax in " + f.display,
+			})
+			continue
+		}
+		if len(raw) == 0 {
+			continue
+		}
+		data, err := json.Marshal(raw)
+		if err != nil {
+			return problems, fmt.Errorf("failed to encode %s: %w", f.display, err)
+		}
+		for _, e := range settings.ValidateJSON(data) {
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("%s: %v", f.display, e),
+				Fix:      fmt.Sprintf("Edit %s, or remove the setting with 'entire config unset <key> %s'", f.display, f.flag),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// checkGitHooks checks that every managed git hook is Entire's and that
+// hooks Entire replaced are still run through their .pre-entire backups,
+// in the directory git runs hooks from (core.hooksPath may move it).
+// Repositories where Entire isn't set up are skipped.
+func checkGitHooks() ([]doctorProblem, error) {
+	if setUp, err := entireSetUp(); err != nil || !setUp {
+		return nil, err
+	}
+	setup, err := strategy.DetectHookSetup()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // already a user-facing message
+	}
+	// DiagnoseGitHooks leaves out hooks a manager owns; checkHookManager
+	// covers those
+	diagnoses, err := strategy.DiagnoseGitHooks()
+	if err != nil {
+		return nil, fmt.Errorf("failed to inspect git hooks: %w", err)
+	}
+
+	reinstall := func() error {
+		_, err := strategy.InstallGitHook(true)
+		return err //nolint:wrapcheck // already wrapped by strategy
+	}
+	var problems []doctorProblem
+	for _, d := range diagnoses {
+		switch {
+		case d.Foreign && d.HasBackup:
+			// Reinstalling would overwrite this hook, since the backup is taken
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("Git hook %s was replaced by another tool's hook", d.Name),
+				Fix:      fmt.Sprintf("Merge %s into %s, then reinstall Entire's hooks with 'entire enable --force'", d.Path, d.BackupPath),
+			})
+		case d.Foreign:
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("Git hook %s was replaced by another tool's hook", d.Name),
+				Fix:      fmt.Sprintf("Reinstall Entire's hooks; the current %s is kept as %s and still runs", d.Name, filepath.Base(d.BackupPath)),
+				apply:    reinstall,
+			})
+		case !d.Installed:
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("Git hook %s is not installed in %s", d.Name, setup.HooksDir),
+				Fix:      "Reinstall Entire's git hooks",
+				apply:    reinstall,
+			})
+		case d.HasBackup && !d.Chained:
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("Git hook %s doesn't run the hook it replaced (%s)", d.Name, filepath.Base(d.BackupPath)),
+				Fix:      "Reinstall Entire's git hooks to chain the backup",
+				apply:    reinstall,
+			})
+		case d.HasBackup && !isExecutable(d.BackupPath):
+			backupPath := d.BackupPath
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityWarning,
+				Message:  fmt.Sprintf("%s is not executable, so git hook %s skips it", filepath.Base(d.BackupPath), d.Name),
+				Fix:      "chmod +x " + d.BackupPath,
+				apply: func() error {
+					info, err := os.Stat(backupPath)
+					if err != nil {
+						return fmt.Errorf("failed to stat %s: %w", backupPath, err)
+					}
+					//nolint:gosec // Git hooks require executable permissions
+					if err := os.Chmod(backupPath, info.Mode().Perm()|0o111); err != nil {
+						return fmt.Errorf("failed to make %s executable: %w", backupPath, err)
+					}
+					return nil
+				},
+			})
+		}
+	}
+	return problems, nil
+}
+
+// entireSetUp reports whether the repository has Entire settings.
+func entireSetUp() (bool, error) {
+	files, err := statSettingsFiles()
+	if err != nil {
+		return false, err
+	}
+	return files.projectExists || files.localExists, nil
+}
+
+// isExecutable reports whether the file at path has any execute bit set.
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.Mode().Perm()&0o111 != 0
+}
+
+// checkHookManager flags a hook manager (Husky, lefthook, pre-commit) that
+// owns some of Entire's git hooks but doesn't run Entire's handlers for them. Repositories where
+// Entire isn't set up are skipped.
+func checkHookManager() ([]doctorProblem, error) {
+	if setUp, err := entireSetUp(); err != nil || !setUp {
+		return nil, err
+	}
+	setup, err := strategy.DetectHookSetup()
+	if err != nil {
+		return nil, err //nolint:wrapcheck // already a user-facing message
+	}
+	if setup.Manager == "" || setup.ManagerRunsEntireHooks() {
+		return nil, nil
+	}
+	return []doctorProblem{{
+		Severity: doctorSeverityError,
+		Message:  fmt.Sprintf("%s manages this repository's %s hooks but doesn't run Entire's", setup.Manager, strings.Join(setup.ManagedHooks, ", ")),
+		Fix:      fmt.Sprintf("Add Entire's hooks to %s (run 'entire enable' to print them)", setup.ManagerConfig),
+	}}, nil
+}
+
+// checkAgentHooks checks that the Entire hooks installed in each agent's
+// settings run a binary that exists.
+func checkAgentHooks() ([]doctorProblem, error) {
+	var problems []doctorProblem
+	for _, name := range agent.List() {
+		ag, err := agent.Get(name)
+		if err != nil {
+			continue
+		}
+		lister, ok := ag.(agent.HookCommandLister)
+		if !ok || !lister.AreHooksInstalled() {
+			continue
+		}
+		commands, err := lister.InstalledHookCommands()
+		if err != nil {
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("%s hooks can't be read: %v", ag.Type(), err),
+				Fix:      "Fix the JSON syntax in " + ag.GetHookConfigPath(),
+			})
+			continue
+		}
+
+		missing := make(map[string]bool)
+		for _, command := range commands {
+			binary := hookCommandBinary(command)
+			if binary == "" || missing[binary] || hookBinaryExists(binary) {
+				continue
+			}
+			missing[binary] = true
+			problems = append(problems, doctorProblem{
+				Severity: doctorSeverityError,
+				Message:  fmt.Sprintf("%s hooks in %s run %s, which was not found", ag.Type(), ag.GetHookConfigPath(), binary),
+				Fix:      fmt.Sprintf("Install the Entire CLI on your PATH, or reinstall the hooks with 'entire enable --agent %s --force'", name),
+			})
+		}
+	}
+	return problems, nil
+}
+
+// hookCommandBinary returns the program an agent hook command runs.
+func hookCommandBinary(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// hookBinaryExists reports whether a hook's program can be run, either as a
+// path or by looking it up on PATH.
+func hookBinaryExists(binary string) bool {
+	if strings.ContainsRune(binary, filepath.Separator) || strings.Contains(binary, "/") {
+		return isExecutable(os.ExpandEnv(binary))
+	}
+	_, err := exec.LookPath(binary)
+	return err == nil
+}
+
+// checkMetadataBranch flags a local metadata branch that has diverged from a
+// remote's copy. The pre-push hook merges them, but only when pushing to
+// that remote.
+func checkMetadataBranch() ([]doctorProblem, error) {
+	repo, err := openRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	if _, err := repo.Reference(plumbing.NewBranchReferenceName(paths.MetadataBranchName), true); err != nil {
+		return nil, nil //nolint:nilerr // No metadata branch yet
+	}
+	remotes, err := repo.Remotes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	var problems []doctorProblem
+	for _, remote := range remotes {
+		remoteName := remote.Config().Name
+		remoteRef := plumbing.NewRemoteReferenceName(remoteName, paths.MetadataBranchName)
+		if _, err := repo.Reference(remoteRef, true); err != nil {
+			continue
+		}
+		local := "refs/heads/" + paths.MetadataBranchName
+		if isAncestorRef(local, remoteRef.String()) || isAncestorRef(remoteRef.String(), local) {
+			continue
+		}
+		problems = append(problems, doctorProblem{
+			Severity: doctorSeverityWarning,
+			Message:  fmt.Sprintf("%s has diverged from %s/%s", paths.MetadataBranchName, remoteName, paths.MetadataBranchName),
+			Fix:      fmt.Sprintf("Merge %s/%s into the local branch, then 'git push %s %s'", remoteName, paths.MetadataBranchName, remoteName, paths.MetadataBranchName),
+			apply: func() error {
+				return strategy.MergeRemoteSessionsBranch(remoteName) //nolint:wrapcheck // already wrapped by strategy
+			},
+		})
+	}
+	return problems, nil
+}
+
+// isAncestorRef reports whether ancestor is reachable from ref.
+func isAncestorRef(ancestor, ref string) bool {
+	return exec.CommandContext(context.Background(), "git", "merge-base", "--is-ancestor", ancestor, ref).Run() == nil
+}
+
+// checkLooseObjects flags more loose objects than gc.auto allows, which
+// slows down git and the checkpoint writes Entire makes on every turn.
+func checkLooseObjects() ([]doctorProblem, error) {
+	threshold := defaultGCAuto
+	if value := getGitConfigValue("gc.auto"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gc.auto %q: %w", value, err)
+		}
+		threshold = n
+	}
+	if threshold <= 0 {
+		// Automatic gc is disabled on purpose
+		return nil, nil
+	}
+
+	count, err := countLooseObjects()
+	if err != nil {
+		return nil, err
+	}
+	if count <= threshold {
+		return nil, nil
+	}
+	return []doctorProblem{{
+		Severity: doctorSeverityWarning,
+		Message:  fmt.Sprintf("%d loose objects, more than gc.auto (%d)", count, threshold),
+		Fix:      "Run 'git gc'",
+		apply: func() error {
+			if output, err := exec.CommandContext(context.Background(), "git", "gc", "--quiet").CombinedOutput(); err != nil {
+				return fmt.Errorf("git gc failed: %s", bytes.TrimSpace(output))
+			}
+			return nil
+		},
+	}}, nil
+}
+
+// countLooseObjects returns the loose object count from git count-objects.
+func countLooseObjects() (int, error) {
+	output, err := exec.CommandContext(context.Background(), "git", "count-objects", "-v").Output()
+	if err != nil {
+		return 0, fmt.Errorf("git count-objects failed: %w", err)
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "count: "); ok {
+			n, err := strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return 0, fmt.Errorf("unexpected git count-objects output %q: %w", scanner.Text(), err)
+			}
+			return n, nil
+		}
+	}
+	return 0, errors.New("git count-objects did not report a count")
+}
+
+// checkWorktreeIndexes checks that git can read the index of every worktree.
+func checkWorktreeIndexes() ([]doctorProblem, error) {
+	worktrees, err := listWorktreePaths()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []doctorProblem
+	for _, wt := range worktrees {
+		if _, err := os.Stat(wt); err != nil {
+			// Missing worktrees are for 'git worktree prune', not index repair
+			continue
+		}
+		cmd := exec.CommandContext(context.Background(), "git", "ls-files", "--stage")
+		cmd.Dir = wt
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if cmd.Run() == nil {
+			continue
+		}
+
+		worktree := wt
+		problems = append(problems, doctorProblem{
+			Severity: doctorSeverityError,
+			Message:  fmt.Sprintf("Index of worktree %s can't be read: %s", wt, firstLine(strings.TrimSpace(stderr.String()))),
+			Fix:      fmt.Sprintf("Rebuild the index from HEAD: remove it and run 'git reset' in %s (working tree files are kept)", wt),
+			apply: func() error {
+				return rebuildWorktreeIndex(worktree)
+			},
+		})
+	}
+	return problems, nil
+}
+
+// listWorktreePaths returns the paths of the main worktree and every linked
+// worktree.
+func listWorktreePaths() ([]string, error) {
+	output, err := exec.CommandContext(context.Background(), "git", "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git worktree list failed: %w", err)
+	}
+	var worktrees []string
+	for _, line := range strings.Split(string(output), "\n") {
+		if path, ok := strings.CutPrefix(line, "worktree "); ok {
+			worktrees = append(worktrees, path)
+		}
+	}
+	return worktrees, nil
+}
+
+// rebuildWorktreeIndex removes a worktree's index and recreates it from
+// HEAD, leaving the working tree alone.
+func rebuildWorktreeIndex(worktree string) error {
+	cmd := exec.CommandContext(context.Background(), "git", "rev-parse", "--git-path", "index")
+	cmd.Dir = worktree
+	output, err := cmd.Output()
+	if err != nil {
+		return fmt.Errorf("failed to locate index of %s: %w", worktree, err)
+	}
+	indexPath := strings.TrimSpace(string(output))
+	if !filepath.IsAbs(indexPath) {
+		indexPath = filepath.Join(worktree, indexPath)
+	}
+	if err := os.Remove(indexPath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove %s: %w", indexPath, err)
+	}
+
+	reset := exec.CommandContext(context.Background(), "git", "reset", "--quiet")
+	reset.Dir = worktree
+	if output, err := reset.CombinedOutput(); err != nil {
+		return fmt.Errorf("git reset failed in %s: %s", worktree, bytes.TrimSpace(output))
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runDoctorGit runs a git command in the current directory.
+func runDoctorGit(t *testing.T, args ...string) {
+	t.Helper()
+	output, err := exec.CommandContext(context.Background(), "git", args...).CombinedOutput()
+	require.NoError(t, err, "git %v: %s", args, output)
+}
+
+func TestCheckGitHooks_MissingAndUnchained(t *testing.T) {
+	setupTestRepo(t)
+	writeSettings(t, testSettingsEnabled)
+
+	// An existing hook is backed up and chained on install
+	hooksDir := filepath.Join(".git", "hooks")
+	require.NoError(t, os.MkdirAll(hooksDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(hooksDir, "pre-push"), []byte("#!/bin/sh\necho mine\n"), 0o755))
+
+	problems, err := checkGitHooks()
+	require.NoError(t, err)
+	require.Len(t, problems, len(strategy.ManagedGitHookNames()))
+	for _, p := range problems {
+		assert.NotNil(t, p.apply, "git hook problems should be fixable: %s", p.Message)
+	}
+	require.NoError(t, problems[0].apply())
+
+	problems, err = checkGitHooks()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// Dropping the chain call from the installed hook is reported
+	hookPath := filepath.Join(hooksDir, "pre-push")
+	data, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	unchained := strings.Split(string(data), "# Chain:")[0]
+	require.NoError(t, os.WriteFile(hookPath, []byte(unchained), 0o755))
+
+	problems, err = checkGitHooks()
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Message, "pre-push.pre-entire")
+}
+
+func TestCheckGitHooks_SkipsWhenNotSetUp(t *testing.T) {
+	setupTestRepo(t)
+
+	problems, err := checkGitHooks()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCheckGitHooks_HooksPath(t *testing.T) {
+	setupTestRepo(t)
+	writeSettings(t, testSettingsEnabled)
+	_, err := strategy.InstallGitHook(true)
+	require.NoError(t, err)
+
+	// Hooks installed before core.hooksPath moved are no longer run
+	runDoctorGit(t, "config", "core.hooksPath", ".githooks")
+	problems, err := checkGitHooks()
+	require.NoError(t, err)
+	require.Len(t, problems, len(strategy.ManagedGitHookNames()))
+	assert.Contains(t, problems[0].Message, ".githooks")
+	require.NoError(t, problems[0].apply())
+
+	problems, err = checkGitHooks()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	_, err = os.Stat(filepath.Join(".githooks", "post-commit"))
+	assert.NoError(t, err)
+}
+
+func TestCheckHookManager(t *testing.T) {
+	setupTestRepo(t)
+	writeSettings(t, testSettingsEnabled)
+
+	problems, err := checkHookManager()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, os.WriteFile("lefthook.yml", []byte("pre-commit:\n  commands:\n    lint:\n      run: make lint\n"), 0o644))
+	require.NoError(t, os.MkdirAll(filepath.Join(".git", "hooks"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(".git", "hooks", "post-commit"), []byte("#!/bin/sh\ncall_lefthook run \"post-commit\" \"$@\"\n"), 0o755))
+	problems, err = checkHookManager()
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	assert.Contains(t, problems[0].Message, "lefthook")
+
+	// git_hooks checks only the hooks lefthook doesn't own
+	_, err = strategy.InstallGitHook(true)
+	require.NoError(t, err)
+	problems, err = checkGitHooks()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	setup, err := strategy.DetectHookSetup()
+	require.NoError(t, err)
+	f, err := os.OpenFile("lefthook.yml", os.O_APPEND|os.O_WRONLY, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(setup.ManagerSnippet())
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	problems, err = checkHookManager()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+}
+
+func TestCheckSettingsFiles(t *testing.T) {
+	setupConfigTestRepo(t)
+	writeSettings(t, `{"strategy": "manual-commit", "log_level": "loud"}`)
+	require.NoError(t, os.WriteFile(EntireSettingsLocalFile, []byte(`{not json`), 0o644))
+
+	problems, err := checkSettingsFiles()
+	require.NoError(t, err)
+	require.Len(t, problems, 2)
+	assert.Contains(t, problems[0].Message, "log_level")
+	assert.Contains(t, problems[0].Fix, "--project")
+	assert.Contains(t, problems[1].Message, "not valid JSON")
+}
+
+func TestHookBinaryExists(t *testing.T) {
+	dir := t.TempDir()
+	binary := filepath.Join(dir, "entire")
+	require.NoError(t, os.WriteFile(binary, []byte("#!/bin/sh\n"), 0o755))
+	t.Setenv("PATH", dir)
+
+	assert.True(t, hookBinaryExists("entire"))
+	assert.True(t, hookBinaryExists(binary))
+	assert.False(t, hookBinaryExists("entire-missing"))
+	assert.False(t, hookBinaryExists(filepath.Join(dir, "missing")))
+	assert.Equal(t, "go", hookCommandBinary("go run ${CLAUDE_PROJECT_DIR}/cmd/entire/main.go hooks claude-code stop"))
+}
+
+func TestCheckLooseObjects(t *testing.T) {
+	setupTestRepo(t)
+	require.NoError(t, os.WriteFile("file.txt", []byte("content"), 0o644))
+	runDoctorGit(t, "add", "file.txt")
+
+	runDoctorGit(t, "config", "gc.auto", "1000")
+	problems, err := checkLooseObjects()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	// Writing one blob is over a threshold that low
+	require.NoError(t, os.WriteFile("other.txt", []byte("other"), 0o644))
+	runDoctorGit(t, "add", "other.txt")
+	runDoctorGit(t, "config", "gc.auto", "1")
+	problems, err = checkLooseObjects()
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.NoError(t, problems[0].apply())
+
+	problems, err = checkLooseObjects()
+	require.NoError(t, err)
+	assert.Empty(t, problems, "git gc should pack the loose objects")
+}
+
+func TestCheckWorktreeIndexes_Corrupt(t *testing.T) {
+	setupTestRepo(t)
+	require.NoError(t, os.WriteFile("file.txt", []byte("content"), 0o644))
+	runDoctorGit(t, "add", "file.txt")
+
+	problems, err := checkWorktreeIndexes()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+
+	require.NoError(t, os.WriteFile(filepath.Join(".git", "index"), []byte("garbage"), 0o644))
+	problems, err = checkWorktreeIndexes()
+	require.NoError(t, err)
+	require.Len(t, problems, 1)
+	require.NoError(t, problems[0].apply())
+
+	problems, err = checkWorktreeIndexes()
+	require.NoError(t, err)
+	assert.Empty(t, problems)
+	_, err = os.Stat("file.txt")
+	assert.NoError(t, err, "rebuilding the index must keep working tree files")
+}
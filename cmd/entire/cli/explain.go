@@ -107,6 +107,7 @@ Checkpoint detail view shows:
   - Author of the checkpoint
   - Associated git commits that reference the checkpoint
   - Prompts and responses from the session
+  - Shell commands the agent ran, with exit status and duration (--verbose)
 
 Note: --session filters the list view; --commit and --checkpoint are mutually exclusive.`,
 		Args: func(_ *cobra.Command, args []string) error {
@@ -449,6 +450,22 @@ func explainTemporaryCheckpoint(w io.Writer, repo *git.Repository, store *checkp
 			}
 		}
 	}
+	// Commands section: each shadow commit has every command run in the
+	// session so far, so the parent's are skipped
+	if full || verbose {
+		commands := strategy.ReadCommandsFromTree(shadowTree, tc.MetadataDir)
+		if shadowCommit.NumParents() > 0 {
+			if parent, parentErr := shadowCommit.Parent(0); parentErr == nil {
+				if parentTree, parentTreeErr := parent.Tree(); parentTreeErr == nil {
+					parentCount := len(strategy.ReadCommandsFromTree(parentTree, tc.MetadataDir))
+					commands = commands[min(parentCount, len(commands)):]
+				}
+			}
+		}
+		sb.WriteString("\n")
+		appendCommandsSection(&sb, full, commands)
+	}
+
 	appendTranscriptSection(&sb, verbose, full, fullTranscript, scopedTranscript, sessionPrompt, agentType)
 
 	return sb.String(), true
@@ -495,8 +512,7 @@ func getAssociatedCommits(repo *git.Repository, checkpointID id.CheckpointID, se
 		defer iter.Close()
 
 		err = iter.ForEach(func(c *object.Commit) error {
-			cpID, found := trailers.ParseCheckpoint(c.Message)
-			if found && cpID.String() == targetID {
+			if commitHasCheckpoint(c.Message, targetID) {
 				collectCommit(c)
 			}
 			return nil
@@ -513,8 +529,7 @@ func getAssociatedCommits(repo *git.Repository, checkpointID id.CheckpointID, se
 				return errStopIteration
 			}
 
-			cpID, found := trailers.ParseCheckpoint(c.Message)
-			if found && cpID.String() == targetID {
+			if commitHasCheckpoint(c.Message, targetID) {
 				collectCommit(c)
 			}
 			return nil
@@ -657,6 +672,8 @@ func formatCheckpointOutput(summary *checkpoint.CheckpointSummary, content *chec
 		} else {
 			sb.WriteString("Files: (none)\n")
 		}
+
+		appendCommandsSection(&sb, full, content.Commands)
 	}
 
 	// Transcript section: full shows entire session, verbose shows checkpoint scope
@@ -665,6 +682,49 @@ func formatCheckpointOutput(summary *checkpoint.CheckpointSummary, content *chec
 	return sb.String()
 }
 
+// appendCommandsSection lists the shell commands the agent ran with their
+// exit status and duration. Full mode also shows each command's output.
+func appendCommandsSection(sb *strings.Builder, full bool, commands []checkpoint.CommandRecord) {
+	if len(commands) == 0 {
+		sb.WriteString("Commands: (none)\n")
+		return
+	}
+	fmt.Fprintf(sb, "Commands: (%d)\n", len(commands))
+	for _, c := range commands {
+		command, _, multiline := strings.Cut(c.Command, "\n")
+		if multiline {
+			command += " ..."
+		}
+		fmt.Fprintf(sb, "  $ %s (%s)\n", command, formatCommandStatus(c))
+		if full && c.Output != "" {
+			for _, line := range strings.Split(c.Output, "\n") {
+				fmt.Fprintf(sb, "      %s\n", line)
+			}
+		}
+	}
+}
+
+// formatCommandStatus describes how a command finished, e.g. "exit 0, 1.2s".
+func formatCommandStatus(c checkpoint.CommandRecord) string {
+	var status string
+	switch {
+	case c.Interrupted:
+		status = "interrupted"
+	case c.ExitCode != nil:
+		status = fmt.Sprintf("exit %d", *c.ExitCode)
+	default:
+		status = "exit unknown"
+	}
+	if c.DurationMs > 0 {
+		d := c.Duration()
+		if d >= time.Second {
+			d = d.Round(100 * time.Millisecond)
+		}
+		status += ", " + d.String()
+	}
+	return status
+}
+
 // appendTranscriptSection appends the appropriate transcript section to the builder
 // based on verbosity level. Full mode shows the entire session, verbose shows checkpoint scope.
 // fullTranscript is the entire session transcript, scopedContent is either scoped transcript bytes
@@ -804,31 +864,40 @@ func computeReachableFromMain(repo *git.Repository) map[plumbing.Hash]bool {
 		return reachableFromMain // No filtering needed on default branch
 	}
 
-	// Resolve main branch hash
-	var mainBranchHash plumbing.Hash
+	mainBranchHash := resolveDefaultBranchHash(repo)
+	if mainBranchHash == plumbing.ZeroHash {
+		return reachableFromMain
+	}
+
+	return computeReachableFrom(repo, mainBranchHash)
+}
+
+// resolveDefaultBranchHash returns the tip of the default branch, preferring the
+// local branch over origin's. Returns ZeroHash if no default branch can be found.
+func resolveDefaultBranchHash(repo *git.Repository) plumbing.Hash {
 	if defaultBranchName := strategy.GetDefaultBranchName(repo); defaultBranchName != "" {
 		ref, refErr := repo.Reference(plumbing.ReferenceName("refs/heads/"+defaultBranchName), true)
 		if refErr != nil {
 			ref, refErr = repo.Reference(plumbing.ReferenceName("refs/remotes/origin/"+defaultBranchName), true)
 		}
 		if refErr == nil {
-			mainBranchHash = ref.Hash()
+			return ref.Hash()
 		}
 	}
-	if mainBranchHash == plumbing.ZeroHash {
-		mainBranchHash = strategy.GetMainBranchHash(repo)
-	}
-	if mainBranchHash == plumbing.ZeroHash {
-		return reachableFromMain
-	}
+	return strategy.GetMainBranchHash(repo)
+}
+
+// computeReachableFrom returns the set of commit hashes on base's first-parent chain.
+func computeReachableFrom(repo *git.Repository, base plumbing.Hash) map[plumbing.Hash]bool {
+	reachable := make(map[plumbing.Hash]bool)
 
-	// Walk main's first-parent chain to build the set
-	_ = walkFirstParentCommits(repo, mainBranchHash, 1000, func(c *object.Commit) error { //nolint:errcheck // Best-effort
-		reachableFromMain[c.Hash] = true
+	// Walk base's first-parent chain to build the set
+	_ = walkFirstParentCommits(repo, base, 1000, func(c *object.Commit) error { //nolint:errcheck // Best-effort
+		reachable[c.Hash] = true
 		return nil
 	})
 
-	return reachableFromMain
+	return reachable
 }
 
 // walkFirstParentCommits walks the first-parent chain starting from `from`,
@@ -873,6 +942,14 @@ func walkFirstParentCommits(repo *git.Repository, from plumbing.Hash, limit int,
 //   - On default branch (main/master): show all checkpoints in history (up to limit)
 //   - Includes both committed checkpoints (entire/checkpoints/v1) and temporary checkpoints (shadow branches)
 func getBranchCheckpoints(repo *git.Repository, limit int) ([]strategy.RewindPoint, error) {
+	return getBranchCheckpointsSince(repo, limit, nil)
+}
+
+// getBranchCheckpointsSince is getBranchCheckpoints with an explicit base.
+// When reachableFromBase is non-nil it replaces the default-branch detection:
+// only checkpoints on HEAD's first-parent chain up to the first commit in
+// reachableFromBase are returned, even when HEAD is on the default branch.
+func getBranchCheckpointsSince(repo *git.Repository, limit int, reachableFromBase map[plumbing.Hash]bool) ([]strategy.RewindPoint, error) {
 	store := checkpoint.NewGitStore(repo)
 
 	// Get all committed checkpoints for lookup
@@ -900,42 +977,44 @@ func getBranchCheckpoints(repo *git.Repository, limit int) ([]strategy.RewindPoi
 
 	// Check if we're on the default branch (needed for getReachableTemporaryCheckpoints)
 	isOnDefault, _ := strategy.IsOnDefaultBranch(repo)
+	if reachableFromBase != nil {
+		isOnDefault = false
+	}
 
 	var points []strategy.RewindPoint
 
 	collectCheckpoint := func(c *object.Commit) {
-		cpID, found := trailers.ParseCheckpoint(c.Message)
-		if !found {
-			return
-		}
-		cpInfo, found := committedByID[cpID]
-		if !found {
-			return
-		}
+		// Squashed commits carry one trailer per checkpoint; each is listed
+		for _, cpID := range trailers.ParseAllCheckpoints(c.Message) {
+			cpInfo, found := committedByID[cpID]
+			if !found {
+				continue
+			}
 
-		message := strings.Split(c.Message, "\n")[0]
-		point := strategy.RewindPoint{
-			ID:               c.Hash.String(),
-			Message:          message,
-			Date:             c.Committer.When,
-			IsLogsOnly:       true, // Committed checkpoints are logs-only
-			CheckpointID:     cpID,
-			SessionID:        cpInfo.SessionID,
-			IsTaskCheckpoint: cpInfo.IsTask,
-			ToolUseID:        cpInfo.ToolUseID,
-			Agent:            cpInfo.Agent,
-		}
-		// Read session prompt from metadata branch (best-effort)
-		content, _ := store.ReadLatestSessionContent(context.Background(), cpID) //nolint:errcheck  // Best-effort
-		if content != nil {
-			scopedTranscript := scopeTranscriptForCheckpoint(content.Transcript, content.Metadata.GetTranscriptStart(), content.Metadata.Agent)
-			scopedPrompts := extractPromptsFromTranscript(scopedTranscript, content.Metadata.Agent)
-			if len(scopedPrompts) > 0 && scopedPrompts[0] != "" {
-				point.SessionPrompt = scopedPrompts[0]
+			message := strings.Split(c.Message, "\n")[0]
+			point := strategy.RewindPoint{
+				ID:               c.Hash.String(),
+				Message:          message,
+				Date:             c.Committer.When,
+				IsLogsOnly:       true, // Committed checkpoints are logs-only
+				CheckpointID:     cpID,
+				SessionID:        cpInfo.SessionID,
+				IsTaskCheckpoint: cpInfo.IsTask,
+				ToolUseID:        cpInfo.ToolUseID,
+				Agent:            cpInfo.Agent,
+			}
+			// Read session prompt from metadata branch (best-effort)
+			content, _ := store.ReadLatestSessionContent(context.Background(), cpID) //nolint:errcheck  // Best-effort
+			if content != nil {
+				scopedTranscript := scopeTranscriptForCheckpoint(content.Transcript, content.Metadata.GetTranscriptStart(), content.Metadata.Agent)
+				scopedPrompts := extractPromptsFromTranscript(scopedTranscript, content.Metadata.Agent)
+				if len(scopedPrompts) > 0 && scopedPrompts[0] != "" {
+					point.SessionPrompt = scopedPrompts[0]
+				}
 			}
-		}
 
-		points = append(points, point)
+			points = append(points, point)
+		}
 	}
 
 	if isOnDefault {
@@ -962,7 +1041,10 @@ func getBranchCheckpoints(repo *git.Repository, limit int) ([]strategy.RewindPoi
 	} else {
 		// On feature branches, use first-parent walk with branch filtering.
 		// This avoids walking into main's full history through merge commit parents.
-		reachableFromMain := computeReachableFromMain(repo)
+		reachableFromMain := reachableFromBase
+		if reachableFromMain == nil {
+			reachableFromMain = computeReachableFromMain(repo)
+		}
 
 		err = walkFirstParentCommits(repo, head.Hash(), commitScanLimit, func(c *object.Commit) error {
 			// Once we hit a commit reachable from main on the first-parent chain,
@@ -1146,8 +1228,19 @@ func outputExplainContent(w io.Writer, content string, noPager bool) {
 	}
 }
 
-// runExplainCommit looks up the checkpoint associated with a commit.
-// Extracts the Entire-Checkpoint trailer and delegates to checkpoint detail view.
+// commitHasCheckpoint reports whether any of a commit message's
+// Entire-Checkpoint trailers is the given checkpoint ID.
+func commitHasCheckpoint(commitMessage, checkpointID string) bool {
+	for _, cpID := range trailers.ParseAllCheckpoints(commitMessage) {
+		if cpID.String() == checkpointID {
+			return true
+		}
+	}
+	return false
+}
+
+// runExplainCommit looks up the checkpoints associated with a commit.
+// Extracts the Entire-Checkpoint trailers and delegates to checkpoint detail view.
 // If no trailer found, shows a message indicating no associated checkpoint.
 func runExplainCommit(w io.Writer, commitRef string, noPager, verbose, full, searchAll bool) error {
 	repo, err := openRepository()
@@ -1166,9 +1259,9 @@ func runExplainCommit(w io.Writer, commitRef string, noPager, verbose, full, sea
 		return fmt.Errorf("failed to get commit: %w", err)
 	}
 
-	// Extract Entire-Checkpoint trailer
-	checkpointID, hasCheckpoint := trailers.ParseCheckpoint(commit.Message)
-	if !hasCheckpoint {
+	// Extract Entire-Checkpoint trailers (squashed commits carry several)
+	checkpointIDs := trailers.ParseAllCheckpoints(commit.Message)
+	if len(checkpointIDs) == 0 {
 		fmt.Fprintln(w, "No associated Entire checkpoint")
 		fmt.Fprintf(w, "\nCommit %s does not have an Entire-Checkpoint trailer.\n", hash.String()[:7])
 		fmt.Fprintln(w, "This commit was not created during an Entire session, or the trailer was removed.")
@@ -1177,7 +1270,17 @@ func runExplainCommit(w io.Writer, commitRef string, noPager, verbose, full, sea
 
 	// Delegate to checkpoint detail view
 	// Note: errW is only used for generate mode, but we pass w for safety
-	return runExplainCheckpoint(w, w, checkpointID.String(), noPager, verbose, full, false, false, false, searchAll)
+	if len(checkpointIDs) == 1 {
+		return runExplainCheckpoint(w, w, checkpointIDs[0].String(), noPager, verbose, full, false, false, false, searchAll)
+	}
+	fmt.Fprintf(w, "Commit %s squashes %d checkpoints\n", hash.String()[:7], len(checkpointIDs))
+	for _, checkpointID := range checkpointIDs {
+		fmt.Fprintf(w, "\n=== Checkpoint %s ===\n", checkpointID)
+		if err := runExplainCheckpoint(w, w, checkpointID.String(), true, verbose, full, false, false, false, searchAll); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // formatSessionInfo formats session information for display.
@@ -1014,6 +1014,47 @@ func TestFormatCheckpointOutput_Verbose_NoCommitMessage(t *testing.T) {
 	}
 }
 
+func TestFormatCheckpointOutput_Commands(t *testing.T) {
+	exitOK, exitFail := 0, 2
+	content := &checkpoint.SessionContent{
+		Metadata: checkpoint.CommittedMetadata{
+			CheckpointID: "abc123def456",
+			SessionID:    "2026-01-21-test-session",
+			CreatedAt:    time.Date(2026, 1, 21, 10, 30, 0, 0, time.UTC),
+		},
+		Commands: []checkpoint.CommandRecord{
+			{Command: "go build ./...", ExitCode: &exitOK, DurationMs: 1234},
+			{Command: "make lint\nmake test", ExitCode: &exitFail, DurationMs: 250, Output: "lint failed"},
+			{Command: "sleep 100", Interrupted: true},
+		},
+	}
+
+	short := formatCheckpointOutput(nil, content, id.MustCheckpointID("abc123def456"), nil, checkpoint.Author{}, false, false)
+	if strings.Contains(short, "Commands:") {
+		t.Error("default output should not list commands")
+	}
+
+	verbose := formatCheckpointOutput(nil, content, id.MustCheckpointID("abc123def456"), nil, checkpoint.Author{}, true, false)
+	for _, want := range []string{
+		"Commands: (3)",
+		"$ go build ./... (exit 0, 1.2s)",
+		"$ make lint ... (exit 2, 250ms)",
+		"$ sleep 100 (interrupted)",
+	} {
+		if !strings.Contains(verbose, want) {
+			t.Errorf("verbose output missing %q:\n%s", want, verbose)
+		}
+	}
+	if strings.Contains(verbose, "lint failed") {
+		t.Error("verbose output should not show command output")
+	}
+
+	full := formatCheckpointOutput(nil, content, id.MustCheckpointID("abc123def456"), nil, checkpoint.Author{}, false, true)
+	if !strings.Contains(full, "      lint failed") {
+		t.Errorf("full output should show command output:\n%s", full)
+	}
+}
+
 func TestFormatCheckpointOutput_Full(t *testing.T) {
 	// Use proper transcript format that matches actual Claude transcripts
 	transcriptData := `{"type":"user","message":{"content":"Add a new feature"}}
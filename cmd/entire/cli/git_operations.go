@@ -299,6 +299,47 @@ func CheckoutBranch(ref string) error {
 	return nil
 }
 
+// CreateBranchAt creates a new branch at commit and switches to it.
+// Uses git CLI instead of go-git for the same reasons as CheckoutBranch.
+func CreateBranchAt(branchName, commit string) error {
+	if err := ValidateBranchName(branchName); err != nil {
+		return err
+	}
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", "checkout", "-b", branchName, commit)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("checkout failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// ListCommitsInRange returns the commits selected by a git revision range
+// (e.g. "main..HEAD" or "v1.0..v1.1"), oldest first.
+// Uses git rev-list so any range syntax git understands is accepted.
+func ListCommitsInRange(revRange string) ([]plumbing.Hash, error) {
+	// Reject option-like input since revRange is passed straight to git
+	if revRange == "" || strings.HasPrefix(revRange, "-") {
+		return nil, fmt.Errorf("invalid revision range %q", revRange)
+	}
+
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", "rev-list", "--reverse", revRange)
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("invalid revision range %q: %s: %w", revRange, strings.TrimSpace(stderr.String()), err)
+	}
+
+	var hashes []plumbing.Hash
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			hashes = append(hashes, plumbing.NewHash(line))
+		}
+	}
+	return hashes, nil
+}
+
 // ValidateBranchName checks if a branch name is valid using git check-ref-format.
 // Returns an error if the name is invalid or contains unsafe characters.
 func ValidateBranchName(branchName string) error {
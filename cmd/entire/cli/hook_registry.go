@@ -12,8 +12,10 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/agent/geminicli"
 	"github.com/entireio/cli/cmd/entire/cli/logging"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // HookHandlerFunc is a function that handles a specific hook event.
@@ -102,6 +104,38 @@ func init() {
 		return handleClaudeCodePostTodo()
 	})
 
+	RegisterHookHandler(agent.AgentNameClaudeCode, claudecode.HookNamePreCompact, func() error {
+		enabled, err := IsEnabled()
+		if err == nil && !enabled {
+			return nil
+		}
+		return handleClaudeCodePreCompact()
+	})
+
+	RegisterHookHandler(agent.AgentNameClaudeCode, claudecode.HookNamePreBash, func() error {
+		enabled, err := IsEnabled()
+		if err == nil && !enabled {
+			return nil
+		}
+		return handleClaudeCodePreBash()
+	})
+
+	RegisterHookHandler(agent.AgentNameClaudeCode, claudecode.HookNamePostBash, func() error {
+		enabled, err := IsEnabled()
+		if err == nil && !enabled {
+			return nil
+		}
+		return handleClaudeCodePostBash()
+	})
+
+	RegisterHookHandler(agent.AgentNameClaudeCode, claudecode.HookNamePostEdit, func() error {
+		enabled, err := IsEnabled()
+		if err == nil && !enabled {
+			return nil
+		}
+		return handleClaudeCodePostEdit()
+	})
+
 	// Register Gemini CLI handlers
 	RegisterHookHandler(agent.AgentNameGemini, geminicli.HookNameSessionStart, func() error {
 		enabled, err := IsEnabled()
@@ -247,13 +281,13 @@ func newAgentHooksCmd(agentName agent.AgentName, handler agent.HookHandler) *cob
 
 // getHookType returns the hook type based on the hook name.
 // Returns "subagent" for task-related hooks (pre-task, post-task, post-todo),
-// "tool" for tool-related hooks (before-tool, after-tool),
+// "tool" for tool-related hooks (before-tool, after-tool, pre-bash, post-bash, post-edit),
 // "agent" for all other agent hooks.
 func getHookType(hookName string) string {
 	switch hookName {
 	case claudecode.HookNamePreTask, claudecode.HookNamePostTask, claudecode.HookNamePostTodo:
 		return "subagent"
-	case geminicli.HookNameBeforeTool, geminicli.HookNameAfterTool:
+	case geminicli.HookNameBeforeTool, geminicli.HookNameAfterTool, claudecode.HookNamePreBash, claudecode.HookNamePostBash, claudecode.HookNamePostEdit:
 		return "tool"
 	default:
 		return "agent"
@@ -275,8 +309,11 @@ func newAgentHookVerbCmdWithLogging(agentName agent.AgentName, hookName string)
 
 			start := time.Now()
 
-			// Initialize logging context with agent name
+			// Initialize logging context with agent and hook name; every line
+			// logged while the hook runs carries the hook name
 			ctx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), agentName)
+			ctx, endHook := logging.WithHook(ctx, hookName)
+			defer endHook()
 
 			// Get strategy name for logging
 			strategyName := unknownStrategyName //nolint:ineffassign,wastedassign // already present in codebase
@@ -285,18 +322,23 @@ func newAgentHookVerbCmdWithLogging(agentName agent.AgentName, hookName string)
 			hookType := getHookType(hookName)
 
 			logging.Debug(ctx, "hook invoked",
-				slog.String("hook", hookName),
 				slog.String("hook_type", hookType),
 				slog.String("strategy", strategyName),
 			)
 
+			ctx, span := tracing.StartHook(ctx, hookName,
+				attribute.String("hook_type", hookType),
+				attribute.String("strategy", strategyName),
+			)
+
 			handler := GetHookHandler(agentName, hookName)
 			if handler == nil {
 				logging.Error(ctx, "no handler registered",
-					slog.String("hook", hookName),
 					slog.String("hook_type", hookType),
 				)
-				return fmt.Errorf("no handler registered for %s/%s", agentName, hookName)
+				err := fmt.Errorf("no handler registered for %s/%s", agentName, hookName)
+				tracing.End(span, err)
+				return err
 			}
 
 			// Set the current hook agent so handlers can retrieve it
@@ -305,9 +347,9 @@ func newAgentHookVerbCmdWithLogging(agentName agent.AgentName, hookName string)
 			defer func() { currentHookAgentName = "" }()
 
 			hookErr := handler()
+			tracing.End(span, hookErr)
 
 			logging.LogDuration(ctx, slog.LevelDebug, "hook completed", start,
-				slog.String("hook", hookName),
 				slog.String("hook_type", hookType),
 				slog.String("strategy", strategyName),
 				slog.Bool("success", hookErr == nil),
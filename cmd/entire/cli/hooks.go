@@ -2,6 +2,8 @@ package cli
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,11 +12,30 @@ import (
 	"os"
 
 	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
 	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/session"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
+	"github.com/entireio/cli/cmd/entire/cli/validation"
+	"github.com/entireio/cli/redact"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// traceStrategyCall runs a strategy method in a "strategy.<method>" span,
+// parented to the current hook span. Spans the strategy starts from its own
+// contexts nest under the method's span.
+func traceStrategyCall(strat strategy.Strategy, method string, call func() error) error {
+	ctx := logging.WithComponent(context.Background(), "strategy")
+	ctx, span := tracing.Start(ctx, "strategy."+method, attribute.String("strategy", strat.Name()))
+	err := tracing.WithParent(ctx, call)
+	tracing.End(span, err)
+	return err
+}
+
 // TaskHookInput represents the JSON input from PreToolUse[Task] hook
 type TaskHookInput struct {
 	SessionID      string          `json:"session_id"`
@@ -273,6 +294,11 @@ func handleSessionStartCommon() error {
 		}
 	}
 
+	if strat.Name() == strategy.StrategyNameSquashCommit {
+		message += "\n  Each turn is recorded on " + strategy.SquashBranchName(input.SessionID) +
+			".\n  Run 'entire land " + input.SessionID + "' to squash them into one commit on your branch."
+	}
+
 	// Output informational message using agent-specific format
 	if err := outputHookResponse(message); err != nil {
 		return err
@@ -293,12 +319,252 @@ func handleSessionStartCommon() error {
 	return nil
 }
 
+// handlePreCompactCommon snapshots the transcript before the agent compacts
+// its context (Claude Code's PreCompact, Gemini CLI's PreCompress). The
+// snapshot is kept in the session metadata directory, which goes into the
+// next shadow checkpoint, and the transcript is read merged with it from then
+// on so offsets and explain/rewind still cover the earlier conversation.
+func handlePreCompactCommon(hookName string) error {
+	ag, err := GetCurrentHookAgent()
+	if err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	input, err := ag.ParseHookInput(agent.HookPreCompact, os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to parse hook input: %w", err)
+	}
+
+	trigger, _ := input.RawData["trigger"].(string) //nolint:errcheck // missing trigger is logged as empty
+	logCtx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), ag.Name())
+	logging.Info(logCtx, hookName,
+		slog.String("hook", hookName),
+		slog.String("hook_type", "agent"),
+		slog.String("model_session_id", input.SessionID),
+		slog.String("transcript_path", input.SessionRef),
+		slog.String("trigger", trigger),
+	)
+
+	sessionID := input.SessionID
+	if sessionID == "" {
+		sessionID = unknownSessionID
+	}
+	if input.SessionRef == "" || !fileExists(input.SessionRef) {
+		fmt.Fprintf(os.Stderr, "Warning: transcript not found, not saved before compaction: %s\n", input.SessionRef)
+		return nil
+	}
+
+	snapshotPath, err := strategy.SnapshotTranscriptBeforeCompaction(sessionID, input.SessionRef, ag.Type())
+	if err != nil {
+		return fmt.Errorf("failed to save transcript before compaction: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Saved transcript before compaction to: %s\n", snapshotPath)
+	return nil
+}
+
+// shellCommandKey identifies a shell tool call across its pre- and
+// post-tool hooks. Gemini CLI doesn't send a tool call ID, so its calls are
+// keyed by session and command line.
+func shellCommandKey(sessionID, toolUseID, command string) string {
+	if toolUseID != "" && validation.ValidateToolUseID(toolUseID) == nil {
+		return toolUseID
+	}
+	sum := sha256.Sum256([]byte(sessionID + "\n" + command))
+	return hex.EncodeToString(sum[:8])
+}
+
+// handlePreShellCommandCommon notes when the agent starts a shell command
+// (Claude Code's PreToolUse[Bash], Gemini CLI's BeforeTool), so the
+// post-tool hook can record how long it ran. Other tools are ignored.
+func handlePreShellCommandCommon(ag agent.Agent, input *agent.HookInput) error {
+	parser, ok := ag.(agent.ShellToolParser)
+	if !ok {
+		return nil
+	}
+	command, ok := parser.ParseShellCommand(input.ToolName, input.ToolInput)
+	if !ok {
+		return nil
+	}
+	if err := CapturePreCommandState(shellCommandKey(input.SessionID, input.ToolUseID, command), command); err != nil {
+		return fmt.Errorf("failed to capture pre-command state: %w", err)
+	}
+	return nil
+}
+
+// handlePostShellCommandCommon records a shell command the agent ran, with
+// its exit status, duration and truncated output, in the session's
+// commands.jsonl (Claude Code's PostToolUse[Bash], Gemini CLI's AfterTool).
+// Other tools are ignored.
+func handlePostShellCommandCommon(ag agent.Agent, input *agent.HookInput) error {
+	parser, ok := ag.(agent.ShellToolParser)
+	if !ok {
+		return nil
+	}
+	command, ok := parser.ParseShellCommand(input.ToolName, input.ToolInput)
+	if !ok {
+		return nil
+	}
+	result := parser.ParseShellResult(input.ToolResponse)
+
+	sessionID := input.SessionID
+	if sessionID == "" {
+		sessionID = unknownSessionID
+	}
+
+	record := checkpoint.CommandRecord{
+		ToolUseID:   input.ToolUseID,
+		Command:     redact.String(command),
+		ExitCode:    result.ExitCode,
+		Interrupted: result.Interrupted,
+		StartedAt:   input.Timestamp.UTC(),
+	}
+	preState, err := LoadPreCommandState(shellCommandKey(input.SessionID, input.ToolUseID, command))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load pre-command state: %v\n", err)
+	}
+	if preState != nil {
+		record.StartedAt = preState.StartedAt
+		record.DurationMs = max(input.Timestamp.Sub(preState.StartedAt).Milliseconds(), 0)
+	}
+	record.Output, record.OutputTruncated = checkpoint.TruncateCommandOutput(redact.String(result.Output))
+
+	if err := strategy.AppendSessionCommand(sessionID, record); err != nil {
+		return fmt.Errorf("failed to record command: %w", err)
+	}
+	return nil
+}
+
+// handlePostFileEditCommon takes a fine-grained checkpoint after the agent
+// wrote a file (Claude Code's PostToolUse[Write|Edit|MultiEdit], Gemini CLI's
+// AfterTool for its write tools), so a long turn can be rewound to its middle.
+// Does nothing unless strategy_options.fine_grained_checkpoints is enabled and
+// the strategy supports it. Other tools are ignored.
+func handlePostFileEditCommon(ag agent.Agent, input *agent.HookInput) error {
+	if !settings.IsFineGrainedCheckpointsEnabled() {
+		return nil
+	}
+	parser, ok := ag.(agent.FileEditToolParser)
+	if !ok {
+		return nil
+	}
+	file, ok := parser.ParseEditedFile(input.ToolName, input.ToolInput)
+	if !ok {
+		return nil
+	}
+	strat := GetStrategy()
+	saver, ok := strat.(strategy.ToolCheckpointSaver)
+	if !ok {
+		return nil
+	}
+	if repo, err := strategy.OpenRepository(); err != nil || strategy.IsEmptyRepository(repo) {
+		return nil //nolint:nilerr // No checkpoints outside a repo or before its first commit
+	}
+
+	sessionID := input.SessionID
+	if sessionID == "" {
+		sessionID = unknownSessionID
+	}
+
+	repoRoot, err := paths.RepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repo root: %w", err)
+	}
+	modifiedFiles := FilterAndNormalizePaths([]string{file}, repoRoot)
+	if len(modifiedFiles) == 0 {
+		return nil // File is outside the repo or in .entire/
+	}
+
+	// New and deleted files since the turn started, as at turn end
+	preState, err := LoadPrePromptState(sessionID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to load pre-prompt state: %v\n", err)
+	}
+	var newFiles, deletedFiles []string
+	changes, err := DetectFileChanges(preState.PreUntrackedFiles())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to compute file changes: %v\n", err)
+	} else {
+		newFiles = FilterAndNormalizePaths(changes.New, repoRoot)
+		deletedFiles = FilterAndNormalizePaths(changes.Deleted, repoRoot)
+	}
+
+	author, err := GetGitAuthor()
+	if err != nil {
+		return fmt.Errorf("failed to get git author: %w", err)
+	}
+
+	ctx := strategy.ToolCheckpointContext{
+		SessionID:     sessionID,
+		ToolName:      input.ToolName,
+		EditedFile:    modifiedFiles[0],
+		ModifiedFiles: modifiedFiles,
+		NewFiles:      newFiles,
+		DeletedFiles:  deletedFiles,
+		AuthorName:    author.Name,
+		AuthorEmail:   author.Email,
+		AgentType:     ag.Type(),
+	}
+	if err := traceStrategyCall(strat, "SaveToolCheckpoint", func() error { return saver.SaveToolCheckpoint(ctx) }); err != nil {
+		return fmt.Errorf("failed to save tool checkpoint: %w", err)
+	}
+	return nil
+}
+
 // hookResponse represents a JSON response.
 // Used to control whether Agent continues processing the prompt.
 type hookResponse struct {
 	SystemMessage string `json:"systemMessage,omitempty"`
 }
 
+// blockingHookResponse stops the agent before it handles the prompt.
+type blockingHookResponse struct {
+	Continue   bool   `json:"continue"`
+	StopReason string `json:"stopReason"`
+}
+
+// outputBlockingHookResponse outputs a JSON response to stdout that refuses
+// the prompt and shows reason to the user instead.
+func outputBlockingHookResponse(reason string) error {
+	resp := blockingHookResponse{
+		Continue:   false,
+		StopReason: reason,
+	}
+	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
+		return fmt.Errorf("failed to encode hook response: %w", err)
+	}
+	return nil
+}
+
+// blockOutsideSessionWorktree refuses the prompt when the strategy runs each
+// session in its own worktree and the agent was started outside one. The
+// session's worktree is created on the first refused prompt and its path is
+// shown, so the user can restart the agent there. Returns true if the prompt
+// was refused, in which case nothing is captured for it.
+func blockOutsideSessionWorktree(strat strategy.Strategy, sessionID string) (bool, error) {
+	provider, ok := strat.(strategy.SessionWorktreeProvider)
+	if !ok {
+		return false, nil
+	}
+	worktreePath, _, err := provider.EnsureSessionWorktree(sessionID)
+	if err != nil {
+		return false, fmt.Errorf("failed to create session worktree: %w", err)
+	}
+	if worktreePath == "" {
+		// Already inside a session worktree
+		return false, nil
+	}
+	reason := fmt.Sprintf("Entire: the %s strategy runs each session in its own worktree, not in the main checkout.\n"+
+		"A worktree on branch %s is ready for this session. Restart the agent there:\n"+
+		"  cd %s\n"+
+		"Run 'entire land %s' from here to bring its commits back when you're done,\n"+
+		"or switch strategies with 'entire enable --strategy manual-commit' to work here.",
+		strat.Name(), strategy.SessionBranchName(sessionID), worktreePath, sessionID)
+	if err := outputBlockingHookResponse(reason); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // outputHookResponse outputs a JSON response to stdout
 func outputHookResponse(reason string) error {
 	resp := hookResponse{
@@ -18,6 +18,9 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/session"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // hookInputData contains parsed hook input and session identifiers.
@@ -70,23 +73,30 @@ func captureInitialState() error {
 		return err
 	}
 
+	// Strategies that run sessions in their own worktree refuse to work here
+	strat := GetStrategy()
+	if blocked, err := blockOutsideSessionWorktree(strat, hookData.sessionID); err != nil || blocked {
+		return err
+	}
+
 	// CLI captures state directly (including transcript position)
 	if err := CapturePrePromptState(hookData.sessionID, hookData.input.SessionRef); err != nil {
 		return err
 	}
 
 	// If strategy implements SessionInitializer, call it to initialize session state
-	strat := GetStrategy()
 
 	// Ensure strategy setup is in place (git hooks, gitignore, metadata branch).
 	// Done here at turn start so hooks are installed before any mid-turn commits.
-	if err := strat.EnsureSetup(); err != nil {
+	if err := traceStrategyCall(strat, "EnsureSetup", strat.EnsureSetup); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to ensure strategy setup: %v\n", err)
 	}
 
 	if initializer, ok := strat.(strategy.SessionInitializer); ok {
 		agentType := hookData.agent.Type()
-		if err := initializer.InitializeSession(hookData.sessionID, agentType, hookData.input.SessionRef, hookData.input.UserPrompt); err != nil {
+		if err := traceStrategyCall(strat, "InitializeSession", func() error {
+			return initializer.InitializeSession(hookData.sessionID, agentType, hookData.input.SessionRef, hookData.input.UserPrompt)
+		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to initialize session state: %v\n", err)
 		}
 	}
@@ -151,9 +161,14 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 	// which guarantees all prior entries have been flushed.
 	waitForTranscriptFlush(transcriptPath, time.Now())
 
-	// Copy transcript
+	// Copy transcript. If the context was compacted, it's merged with the
+	// pre-compaction snapshot, and offsets below are positions in the merged copy.
 	logFile := filepath.Join(sessionDirAbs, paths.TranscriptFileName)
-	if err := copyFile(transcriptPath, logFile); err != nil {
+	fullTranscript, err := strategy.ReadFullTranscript(sessionID, transcriptPath, ag.Type())
+	if err != nil {
+		return fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if err := os.WriteFile(logFile, fullTranscript, 0o600); err != nil {
 		return fmt.Errorf("failed to copy transcript: %w", err)
 	}
 	fmt.Fprintf(os.Stderr, "Copied transcript to: %s\n", sessionDir+"/"+paths.TranscriptFileName)
@@ -190,7 +205,7 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 	var totalLines int
 	if transcriptOffset > 0 {
 		// Parse only NEW lines since last checkpoint
-		transcript, totalLines, err = parseTranscriptFromLine(transcriptPath, transcriptOffset)
+		transcript, totalLines, err = parseTranscriptFromLine(logFile, transcriptOffset)
 		if err != nil {
 			return fmt.Errorf("failed to parse transcript from line %d: %w", transcriptOffset, err)
 		}
@@ -198,7 +213,7 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 	} else {
 		// First prompt or no session state - parse entire transcript
 		// Use parseTranscriptFromLine with offset 0 to also get totalLines
-		transcript, totalLines, err = parseTranscriptFromLine(transcriptPath, 0)
+		transcript, totalLines, err = parseTranscriptFromLine(logFile, 0)
 		if err != nil {
 			return fmt.Errorf("failed to parse transcript: %w", err)
 		}
@@ -323,7 +338,7 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 	if transcriptPath != "" {
 		// Subagents are stored in a subagents/ directory next to the main transcript
 		subagentsDir := filepath.Join(filepath.Dir(transcriptPath), sessionID, "subagents")
-		usage, err := claudecode.CalculateTotalTokenUsage(transcriptPath, transcriptLinesAtStart, subagentsDir)
+		usage, err := claudecode.CalculateTotalTokenUsage(logFile, transcriptLinesAtStart, subagentsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to calculate token usage: %v\n", err)
 		} else {
@@ -349,17 +364,17 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 		TokenUsage:               tokenUsage,
 	}
 
-	if err := strat.SaveChanges(ctx); err != nil {
+	if err := traceStrategyCall(strat, "SaveChanges", func() error { return strat.SaveChanges(ctx) }); err != nil {
 		return fmt.Errorf("failed to save changes: %w", err)
 	}
 
 	// Update session state with new transcript position for strategies that create
-	// commits on the active branch (auto-commit strategy). This prevents parsing old transcript
+	// a commit per turn (auto-commit and squash-commit strategies). This prevents parsing old transcript
 	// lines on subsequent checkpoints.
 	// Note: Shadow strategy tracks transcript position per-step via StepTranscriptStart in
 	// pre-prompt state, but doesn't advance CheckpointTranscriptStart in session state because
 	// its checkpoints accumulate all files touched across the entire session.
-	if strat.Name() == strategy.StrategyNameAutoCommit {
+	if committer, ok := strat.(strategy.TurnCommitter); ok && committer.CommitsEachTurn() {
 		// Load session state for updating transcript position
 		sessionState, loadErr := strategy.LoadSessionState(sessionID)
 		if loadErr != nil {
@@ -380,6 +395,10 @@ func commitWithMetadata() error { //nolint:maintidx // already present in codeba
 			fmt.Fprintf(os.Stderr, "Updated session state: transcript position=%d, checkpoint=%d\n",
 				totalLines, sessionState.StepCount)
 		}
+		// The commands were copied into this checkpoint with the metadata directory
+		if clearErr := strategy.ClearSessionCommands(sessionID); clearErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clear recorded commands: %v\n", clearErr)
+		}
 	}
 
 	// Fire EventTurnEnd to transition session phase (all strategies).
@@ -505,7 +524,7 @@ func handleClaudeCodePostTodo() error {
 	}
 
 	// Save incremental checkpoint
-	if err := strat.SaveTaskCheckpoint(ctx); err != nil {
+	if err := traceStrategyCall(strat, "SaveTaskCheckpoint", func() error { return strat.SaveTaskCheckpoint(ctx) }); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to save incremental checkpoint: %v\n", err)
 		return nil
 	}
@@ -684,8 +703,13 @@ func handleClaudeCodePostTask() error {
 		AgentType:              agentType,
 	}
 
-	// Call strategy to save task checkpoint - strategy handles all metadata creation
-	if err := strat.SaveTaskCheckpoint(ctx); err != nil {
+	// Call strategy to save task checkpoint - strategy handles all metadata creation.
+	// The conversation from before any context compaction is saved with the task.
+	err = strategy.WithFullTranscript(input.SessionID, input.TranscriptPath, ag.Type(), func(fullTranscriptPath string) error {
+		ctx.TranscriptPath = fullTranscriptPath
+		return traceStrategyCall(strat, "SaveTaskCheckpoint", func() error { return strat.SaveTaskCheckpoint(ctx) })
+	})
+	if err != nil {
 		return fmt.Errorf("failed to save task checkpoint: %w", err)
 	}
 
@@ -695,6 +719,82 @@ func handleClaudeCodePostTask() error {
 	return nil
 }
 
+// handleClaudeCodePreCompact handles the PreCompact hook for Claude Code.
+// This fires before the context is compacted, so the full transcript is snapshotted.
+func handleClaudeCodePreCompact() error {
+	return handlePreCompactCommon("pre-compact")
+}
+
+// handleClaudeCodePreBash handles the PreToolUse[Bash] hook
+func handleClaudeCodePreBash() error {
+	ag, err := GetCurrentHookAgent()
+	if err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	input, err := ag.ParseHookInput(agent.HookPreToolUse, os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to parse PreToolUse[Bash] input: %w", err)
+	}
+
+	logCtx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), ag.Name())
+	logging.Debug(logCtx, "pre-bash",
+		slog.String("hook", "pre-bash"),
+		slog.String("hook_type", "tool"),
+		slog.String("model_session_id", input.SessionID),
+		slog.String("tool_use_id", input.ToolUseID),
+	)
+
+	return handlePreShellCommandCommon(ag, input)
+}
+
+// handleClaudeCodePostBash handles the PostToolUse[Bash] hook
+func handleClaudeCodePostBash() error {
+	ag, err := GetCurrentHookAgent()
+	if err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	input, err := ag.ParseHookInput(agent.HookPostToolUse, os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to parse PostToolUse[Bash] input: %w", err)
+	}
+
+	logCtx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), ag.Name())
+	logging.Debug(logCtx, "post-bash",
+		slog.String("hook", "post-bash"),
+		slog.String("hook_type", "tool"),
+		slog.String("model_session_id", input.SessionID),
+		slog.String("tool_use_id", input.ToolUseID),
+	)
+
+	return handlePostShellCommandCommon(ag, input)
+}
+
+// handleClaudeCodePostEdit handles the PostToolUse[Write|Edit|MultiEdit] hook
+func handleClaudeCodePostEdit() error {
+	ag, err := GetCurrentHookAgent()
+	if err != nil {
+		return fmt.Errorf("failed to get agent: %w", err)
+	}
+
+	input, err := ag.ParseHookInput(agent.HookPostToolUse, os.Stdin)
+	if err != nil {
+		return fmt.Errorf("failed to parse PostToolUse[%s] input: %w", claudecode.FileEditToolMatcher, err)
+	}
+
+	logCtx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), ag.Name())
+	logging.Debug(logCtx, "post-edit",
+		slog.String("hook", "post-edit"),
+		slog.String("hook_type", "tool"),
+		slog.String("model_session_id", input.SessionID),
+		slog.String("tool_name", input.ToolName),
+		slog.String("tool_use_id", input.ToolUseID),
+	)
+
+	return handlePostFileEditCommon(ag, input)
+}
+
 // handleClaudeCodeSessionStart handles the SessionStart hook for Claude Code.
 func handleClaudeCodeSessionStart() error {
 	return handleSessionStartCommon()
@@ -750,7 +850,9 @@ func transitionSessionTurnEnd(sessionID string) {
 	if len(remaining) > 0 {
 		strat := GetStrategy()
 		if handler, ok := strat.(strategy.TurnEndHandler); ok {
-			if err := handler.HandleTurnEnd(turnState, remaining); err != nil {
+			if err := traceStrategyCall(strat, "HandleTurnEnd", func() error {
+				return handler.HandleTurnEnd(turnState, remaining)
+			}); err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: turn-end action dispatch failed: %v\n", err)
 			}
 		}
@@ -806,12 +908,16 @@ func waitForTranscriptFlush(transcriptPath string, hookStartTime time.Time) {
 	)
 
 	logCtx := logging.WithComponent(context.Background(), "hooks")
+	_, span := tracing.Start(logCtx, "transcript.wait_for_flush")
+	defer tracing.End(span, nil)
+
 	deadline := time.Now().Add(maxWait)
 	for time.Now().Before(deadline) {
 		if checkStopSentinel(transcriptPath, tailBytes, hookStartTime, maxSkew) {
 			logging.Debug(logCtx, "transcript flush sentinel found",
 				slog.Duration("wait", time.Since(hookStartTime)),
 			)
+			span.SetAttributes(attribute.Bool("sentinel_found", true))
 			return
 		}
 		time.Sleep(pollInterval)
@@ -820,6 +926,7 @@ func waitForTranscriptFlush(transcriptPath string, hookStartTime time.Time) {
 	logging.Warn(logCtx, "transcript flush sentinel not found within timeout, proceeding",
 		slog.Duration("timeout", maxWait),
 	)
+	span.SetAttributes(attribute.Bool("sentinel_found", false))
 }
 
 // checkStopSentinel reads the tail of the transcript file and looks for a
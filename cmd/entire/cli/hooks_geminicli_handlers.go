@@ -109,16 +109,17 @@ func setupGeminiSessionDir(ctx *geminiSessionContext) error {
 		return fmt.Errorf("failed to create session directory: %w", err)
 	}
 
+	// If the chat was compressed, the transcript is merged with the
+	// pre-compression snapshot so message indices stay valid
 	logFile := filepath.Join(sessionDirAbs, paths.TranscriptFileName)
-	if err := copyFile(ctx.transcriptPath, logFile); err != nil {
-		return fmt.Errorf("failed to copy transcript: %w", err)
-	}
-	fmt.Fprintf(os.Stderr, "Copied transcript to: %s\n", ctx.sessionDir+"/"+paths.TranscriptFileName)
-
-	transcriptData, err := os.ReadFile(ctx.transcriptPath)
+	transcriptData, err := strategy.ReadFullTranscript(ctx.sessionID, ctx.transcriptPath, agent.AgentTypeGemini)
 	if err != nil {
 		return fmt.Errorf("failed to read transcript: %w", err)
 	}
+	if err := os.WriteFile(logFile, transcriptData, 0o600); err != nil {
+		return fmt.Errorf("failed to copy transcript: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "Copied transcript to: %s\n", ctx.sessionDir+"/"+paths.TranscriptFileName)
 	ctx.transcriptData = transcriptData
 
 	return nil
@@ -190,11 +191,8 @@ func commitGeminiSession(ctx *geminiSessionContext) error {
 
 	// Calculate token usage for this prompt/response cycle (Gemini-specific)
 	var tokenUsage *agent.TokenUsage
-	if ctx.transcriptPath != "" {
-		usage, tokenErr := geminicli.CalculateTokenUsageFromFile(ctx.transcriptPath, startMessageIndex)
-		if tokenErr != nil {
-			fmt.Fprintf(os.Stderr, "Warning: failed to calculate token usage: %v\n", tokenErr)
-		} else if usage != nil && usage.APICallCount > 0 {
+	if len(ctx.transcriptData) > 0 {
+		if usage := geminicli.CalculateTokenUsage(ctx.transcriptData, startMessageIndex); usage != nil && usage.APICallCount > 0 {
 			tokenUsage = usage
 			fmt.Fprintf(os.Stderr, "Token usage for this checkpoint: input=%d, output=%d, cache_read=%d, api_calls=%d\n",
 				tokenUsage.InputTokens, tokenUsage.OutputTokens, tokenUsage.CacheReadTokens, tokenUsage.APICallCount)
@@ -270,10 +268,18 @@ func commitGeminiSession(ctx *geminiSessionContext) error {
 		TokenUsage:               tokenUsage,
 	}
 
-	if err := strat.SaveChanges(saveCtx); err != nil {
+	if err := traceStrategyCall(strat, "SaveChanges", func() error { return strat.SaveChanges(saveCtx) }); err != nil {
 		return fmt.Errorf("failed to save session: %w", err)
 	}
 
+	// Strategies that commit per turn copied the commands into this checkpoint
+	// with the metadata directory, so the next one starts afresh
+	if committer, ok := strat.(strategy.TurnCommitter); ok && committer.CommitsEachTurn() {
+		if clearErr := strategy.ClearSessionCommands(ctx.sessionID); clearErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to clear recorded commands: %v\n", clearErr)
+		}
+	}
+
 	if cleanupErr := CleanupPrePromptState(ctx.sessionID); cleanupErr != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to cleanup pre-prompt state: %v\n", cleanupErr)
 	}
@@ -352,9 +358,8 @@ func handleGeminiBeforeTool() error {
 		slog.String("tool_name", input.ToolName),
 	)
 
-	// For now, BeforeTool is mainly for logging and potential future use
-	// We don't need to do anything special before tool execution
-	return nil
+	// Note when shell commands start so AfterTool can record their duration
+	return handlePreShellCommandCommon(ag, input)
 }
 
 // handleGeminiAfterTool handles the AfterTool hook for Gemini CLI.
@@ -380,9 +385,12 @@ func handleGeminiAfterTool() error {
 		slog.String("tool_name", input.ToolName),
 	)
 
-	// For now, AfterTool is mainly for logging
-	// Future: Could be used for incremental checkpoints similar to Claude's PostTodo
-	return nil
+	// Record shell commands and checkpoint file writes (if fine-grained
+	// checkpoints are enabled); other tools are only logged
+	if err := handlePostShellCommandCommon(ag, input); err != nil {
+		return err
+	}
+	return handlePostFileEditCommon(ag, input)
 }
 
 // handleGeminiBeforeAgent handles the BeforeAgent hook for Gemini CLI.
@@ -426,6 +434,12 @@ func handleGeminiBeforeAgent() error {
 		return errors.New("no session_id in input")
 	}
 
+	// Strategies that run sessions in their own worktree refuse to work here
+	strat := GetStrategy()
+	if blocked, err := blockOutsideSessionWorktree(strat, input.SessionID); err != nil || blocked {
+		return err
+	}
+
 	// Capture pre-prompt state with transcript position (Gemini-specific)
 	// This captures both untracked files and the current transcript message count
 	// so we can calculate token usage for just this prompt/response cycle
@@ -434,17 +448,18 @@ func handleGeminiBeforeAgent() error {
 	}
 
 	// If strategy implements SessionInitializer, call it to initialize session state
-	strat := GetStrategy()
 
 	// Ensure strategy setup is in place (git hooks, gitignore, metadata branch).
 	// Done here at turn start so hooks are installed before any mid-turn commits.
-	if err := strat.EnsureSetup(); err != nil {
+	if err := traceStrategyCall(strat, "EnsureSetup", strat.EnsureSetup); err != nil {
 		fmt.Fprintf(os.Stderr, "Warning: failed to ensure strategy setup: %v\n", err)
 	}
 
 	if initializer, ok := strat.(strategy.SessionInitializer); ok {
 		agentType := ag.Type()
-		if err := initializer.InitializeSession(input.SessionID, agentType, input.SessionRef, input.UserPrompt); err != nil {
+		if err := traceStrategyCall(strat, "InitializeSession", func() error {
+			return initializer.InitializeSession(input.SessionID, agentType, input.SessionRef, input.UserPrompt)
+		}); err != nil {
 			fmt.Fprintf(os.Stderr, "Warning: failed to initialize session state: %v\n", err)
 		}
 	}
@@ -603,32 +618,9 @@ func handleGeminiBeforeToolSelection() error {
 }
 
 // handleGeminiPreCompress handles the PreCompress hook for Gemini CLI.
-// This fires before chat history compression - useful for backing up transcript.
+// This fires before chat history compression, so the full transcript is snapshotted.
 func handleGeminiPreCompress() error {
-	// Get the agent for hook input parsing
-	ag, err := GetCurrentHookAgent()
-	if err != nil {
-		return fmt.Errorf("failed to get agent: %w", err)
-	}
-
-	// Parse hook input
-	input, err := ag.ParseHookInput(agent.HookSessionStart, os.Stdin)
-	if err != nil {
-		return fmt.Errorf("failed to parse hook input: %w", err)
-	}
-
-	logCtx := logging.WithAgent(logging.WithComponent(context.Background(), "hooks"), ag.Name())
-	logging.Info(logCtx, "gemini-pre-compress",
-		slog.String("hook", "pre-compress"),
-		slog.String("hook_type", "session"),
-		slog.String("model_session_id", input.SessionID),
-		slog.String("transcript_path", input.SessionRef),
-	)
-
-	// PreCompress is important for ensuring we capture the transcript before compression
-	// The transcript_path gives us access to the full conversation before it's compressed
-	// Future: Could automatically backup/checkpoint the transcript here
-	return nil
+	return handlePreCompactCommon("pre-compress")
 }
 
 // handleGeminiNotification handles the Notification hook for Gemini CLI.
@@ -7,16 +7,21 @@ import (
 
 	"github.com/entireio/cli/cmd/entire/cli/logging"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const unknownStrategyName = "unknown"
 
-// gitHookContext holds common state for git hook logging.
+// gitHookContext holds common state for git hook logging and tracing.
 type gitHookContext struct {
 	hookName     string
 	ctx          context.Context
+	endHook      func()
+	span         trace.Span
 	start        time.Time
 	strategy     strategy.Strategy
 	strategyName string
@@ -30,34 +35,42 @@ func newGitHookContext(hookName string) *gitHookContext {
 		ctx:          logging.WithComponent(context.Background(), "hooks"),
 		strategyName: unknownStrategyName,
 	}
+	g.ctx, g.endHook = logging.WithHook(g.ctx, hookName)
 	g.strategy = GetStrategy()
 	g.strategyName = g.strategy.Name()
 	return g
 }
 
-// logInvoked logs that the hook was invoked.
+// logInvoked logs that the hook was invoked and starts the hook span.
 func (g *gitHookContext) logInvoked(extraAttrs ...any) {
 	attrs := []any{
-		slog.String("hook", g.hookName),
 		slog.String("hook_type", "git"),
 		slog.String("strategy", g.strategyName),
 	}
 	logging.Debug(g.ctx, g.hookName+" hook invoked", append(attrs, extraAttrs...)...)
+	g.ctx, g.span = tracing.StartHook(g.ctx, g.hookName,
+		attribute.String("hook_type", "git"),
+		attribute.String("strategy", g.strategyName),
+	)
 }
 
-// logCompleted logs hook completion with duration at DEBUG level.
+// logCompleted logs hook completion with duration at DEBUG level, ends the hook span
+// and stops tagging log lines with the hook.
 // The actual work logging (checkpoint operations) happens at INFO level in the handlers.
 func (g *gitHookContext) logCompleted(err error, extraAttrs ...any) {
+	if g.span != nil {
+		tracing.End(g.span, err)
+	}
 	attrs := []any{
-		slog.String("hook", g.hookName),
 		slog.String("hook_type", "git"),
 		slog.String("strategy", g.strategyName),
 		slog.Bool("success", err == nil),
 	}
 	logging.LogDuration(g.ctx, slog.LevelDebug, g.hookName+" hook completed", g.start, append(attrs, extraAttrs...)...)
+	g.endHook()
 }
 
-// initHookLogging initializes logging for hooks by finding the most recent session.
+// initHookLogging initializes logging and tracing for hooks by finding the most recent session.
 // Returns a cleanup function that should be deferred.
 func initHookLogging() func() {
 	// Set up log level getter so logging can read from settings
@@ -65,11 +78,20 @@ func initHookLogging() func() {
 
 	// Read session ID for the slog attribute (empty string is fine - log file is fixed)
 	sessionID := strategy.FindMostRecentSession()
+	closeLog := logging.Close
 	if err := logging.Init(sessionID); err != nil {
 		// Init failed - logging will use stderr fallback
-		return func() {}
+		closeLog = func() {}
+	}
+
+	shutdownTracing, err := tracing.Init(GetTracingEndpoint())
+	if err != nil {
+		logging.Warn(context.Background(), "tracing disabled", slog.String("error", err.Error()))
+	}
+	return func() {
+		shutdownTracing()
+		closeLog()
 	}
-	return logging.Close
 }
 
 // hookLogCleanup stores the cleanup function for hook logging.
@@ -118,7 +140,9 @@ func newHooksGitPrepareCommitMsgCmd() *cobra.Command {
 			g.logInvoked(slog.String("source", source))
 
 			if handler, ok := g.strategy.(strategy.PrepareCommitMsgHandler); ok {
-				hookErr := handler.PrepareCommitMsg(commitMsgFile, source)
+				hookErr := traceStrategyCall(g.strategy, "PrepareCommitMsg", func() error {
+					return handler.PrepareCommitMsg(commitMsgFile, source)
+				})
 				g.logCompleted(hookErr, slog.String("source", source))
 			}
 
@@ -139,7 +163,9 @@ func newHooksGitCommitMsgCmd() *cobra.Command {
 			g.logInvoked()
 
 			if handler, ok := g.strategy.(strategy.CommitMsgHandler); ok {
-				hookErr := handler.CommitMsg(commitMsgFile)
+				hookErr := traceStrategyCall(g.strategy, "CommitMsg", func() error {
+					return handler.CommitMsg(commitMsgFile)
+				})
 				g.logCompleted(hookErr)
 				return hookErr //nolint:wrapcheck // Thin delegation layer - wrapping adds no value
 			}
@@ -159,7 +185,7 @@ func newHooksGitPostCommitCmd() *cobra.Command {
 			g.logInvoked()
 
 			if handler, ok := g.strategy.(strategy.PostCommitHandler); ok {
-				hookErr := handler.PostCommit()
+				hookErr := traceStrategyCall(g.strategy, "PostCommit", handler.PostCommit)
 				g.logCompleted(hookErr)
 			}
 
@@ -180,7 +206,9 @@ func newHooksGitPrePushCmd() *cobra.Command {
 			g.logInvoked(slog.String("remote", remote))
 
 			if handler, ok := g.strategy.(strategy.PrePushHandler); ok {
-				hookErr := handler.PrePush(remote)
+				hookErr := traceStrategyCall(g.strategy, "PrePush", func() error {
+					return handler.PrePush(remote)
+				})
 				g.logCompleted(hookErr, slog.String("remote", remote))
 			}
 
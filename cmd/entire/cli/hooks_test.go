@@ -2,8 +2,21 @@ package cli
 
 import (
 	"bytes"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/agent/claudecode"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
 )
 
 func TestParsePreTaskHookInput(t *testing.T) {
@@ -539,3 +552,128 @@ func TestLogPostTaskHookContext(t *testing.T) {
 		})
 	}
 }
+
+func TestShellCommandHooks_RecordCommand(t *testing.T) {
+	setupTestRepo(t)
+	paths.ClearRepoRootCache()
+
+	ag := &claudecode.ClaudeCodeAgent{}
+	started := time.Now()
+	pre := &agent.HookInput{
+		SessionID: "sess-shell",
+		ToolName:  claudecode.ToolBash,
+		ToolUseID: "toolu_01",
+		ToolInput: []byte(`{"command":"go test ./..."}`),
+		Timestamp: started,
+	}
+	if err := handlePreShellCommandCommon(ag, pre); err != nil {
+		t.Fatalf("handlePreShellCommandCommon() error = %v", err)
+	}
+
+	post := *pre
+	post.Timestamp = started.Add(1500 * time.Millisecond)
+	post.ToolResponse = []byte(`{"stdout":"ok\n","stderr":"","interrupted":false}`)
+	if err := handlePostShellCommandCommon(ag, &post); err != nil {
+		t.Fatalf("handlePostShellCommandCommon() error = %v", err)
+	}
+
+	// Tools other than the shell tool are ignored
+	other := *pre
+	other.ToolName = "Task"
+	if err := handlePostShellCommandCommon(ag, &other); err != nil {
+		t.Fatalf("handlePostShellCommandCommon(Task) error = %v", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(paths.SessionMetadataDirFromSessionID("sess-shell"), paths.CommandsFileName))
+	if err != nil {
+		t.Fatalf("failed to read commands file: %v", err)
+	}
+	records := checkpoint.ParseCommands(data)
+	if len(records) != 1 {
+		t.Fatalf("recorded %d commands, want 1", len(records))
+	}
+	got := records[0]
+	if got.Command != "go test ./..." || got.ToolUseID != "toolu_01" || got.Output != "ok" {
+		t.Errorf("record = %+v, want go test ./... with output ok", got)
+	}
+	if got.ExitCode == nil || *got.ExitCode != 0 {
+		t.Errorf("ExitCode = %v, want 0", got.ExitCode)
+	}
+	if got.DurationMs < 1000 {
+		t.Errorf("DurationMs = %d, want the time since the pre-tool hook", got.DurationMs)
+	}
+	if fileExists(preCommandStateFile("toolu_01")) {
+		t.Error("pre-command state should be removed once the command is recorded")
+	}
+}
+
+func TestPostFileEditHook_FineGrainedCheckpoint(t *testing.T) {
+	tmpDir := setupTestDir(t)
+	repo, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatalf("failed to init repo: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("failed to write main.go: %v", err)
+	}
+	worktree, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	if _, err := worktree.Add("main.go"); err != nil {
+		t.Fatalf("failed to add main.go: %v", err)
+	}
+	head, err := worktree.Commit("Initial commit", &git.CommitOptions{
+		Author: &object.Signature{Name: "Test User", Email: "test@example.com"},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+
+	ag := &claudecode.ClaudeCodeAgent{}
+	input := &agent.HookInput{
+		SessionID: "sess-edit",
+		ToolName:  claudecode.ToolEdit,
+		ToolUseID: "toolu_02",
+		ToolInput: []byte(`{"file_path":"` + filepath.ToSlash(filepath.Join(tmpDir, "main.go")) + `","old_string":"main","new_string":"app"}`),
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "main.go"), []byte("package app\n"), 0o644); err != nil {
+		t.Fatalf("failed to edit main.go: %v", err)
+	}
+	shadowBranch := plumbing.NewBranchReferenceName(checkpoint.ShadowBranchNameForCommit(head.String(), ""))
+
+	// Off by default
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true}`)
+	if err := handlePostFileEditCommon(ag, input); err != nil {
+		t.Fatalf("handlePostFileEditCommon() error = %v", err)
+	}
+	if _, err := repo.Reference(shadowBranch, true); err == nil {
+		t.Fatal("checkpoint created with fine-grained checkpoints disabled")
+	}
+
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true, "strategy_options": {"fine_grained_checkpoints": true}}`)
+	if err := handlePostFileEditCommon(ag, input); err != nil {
+		t.Fatalf("handlePostFileEditCommon() error = %v", err)
+	}
+	ref, err := repo.Reference(shadowBranch, true)
+	if err != nil {
+		t.Fatalf("expected shadow branch %s: %v", shadowBranch, err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("failed to read checkpoint commit: %v", err)
+	}
+	if !strings.HasPrefix(commit.Message, "Edit: main.go\n") {
+		t.Errorf("checkpoint message = %q, want it to start with %q", commit.Message, "Edit: main.go")
+	}
+	if tool, found := trailers.ParseTool(commit.Message); !found || tool != claudecode.ToolEdit {
+		t.Errorf("Entire-Tool trailer = %q, %v, want %q", tool, found, claudecode.ToolEdit)
+	}
+	file, err := commit.File("main.go")
+	if err != nil {
+		t.Fatalf("checkpoint is missing main.go: %v", err)
+	}
+	if content, _ := file.Contents(); content != "package app\n" {
+		t.Errorf("checkpoint main.go = %q, want the edited content", content)
+	}
+}
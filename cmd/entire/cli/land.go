@@ -0,0 +1,239 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/spf13/cobra"
+)
+
+func newLandCmd() *cobra.Command {
+	var listFlag bool
+	var cherryPickFlag bool
+	var keepFlag bool
+	var noSummaryFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "land [session]",
+		Short: "Bring a session's commits back into the current branch",
+		Long: `Land merges the entire/session/<session-id> branch of a session worktree
+into the current branch, then removes the worktree and its branch. For the
+squash-commit strategy it squashes the session's entire/squash/<session-id>
+branch into a single commit instead.
+
+Session worktrees are created by the worktree strategy: every new agent
+session gets its own git worktree under .entire/worktrees/, so concurrent
+sessions never touch each other's files. Commit in the session worktree as
+usual (checkpoints are linked exactly like the manual-commit strategy), then
+run land from the worktree you want the changes in.
+
+The session can be given as a session ID, a unique prefix of one, or the
+branch name. If omitted and there is only one session worktree, that one is
+landed.
+
+By default the session branch is merged with a merge commit. Use --cherry-pick
+to replay its commits on top of the current branch instead. Either way the
+commits keep their Entire-Checkpoint trailers. If there are conflicts, land
+stops and leaves the merge or cherry-pick in progress for you to resolve;
+the worktree is kept until you land again.
+
+Squash-commit sessions edit the working tree directly and record each turn
+on a hidden branch. Landing commits the files the session changed, as they
+are now, in one commit on the current branch. The message is generated from
+the session summary (or built from the turns' prompts with --no-summary) and
+has an Entire-Checkpoint trailer for every turn, so each turn's checkpoint
+stays linked. Other staged or unstaged changes are left alone.
+
+  entire land --list
+  entire land 0f7a2b3c
+  entire land 0f7a2b3c --cherry-pick --keep
+  entire land 0f7a2b3c --no-summary`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if checkDisabledGuard(cmd.OutOrStdout()) {
+				return nil
+			}
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+
+			if listFlag {
+				return runLandList(cmd.OutOrStdout())
+			}
+			query := ""
+			if len(args) > 0 {
+				query = args[0]
+			}
+			return runLand(cmd.OutOrStdout(), cmd.ErrOrStderr(), query, strategy.LandOptions{
+				CherryPick: cherryPickFlag,
+				Keep:       keepFlag,
+				NoSummary:  noSummaryFlag,
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&listFlag, "list", false, "List sessions waiting to land")
+	cmd.Flags().BoolVar(&cherryPickFlag, "cherry-pick", false, "Cherry-pick the session's commits instead of merging (worktree sessions)")
+	cmd.Flags().BoolVar(&keepFlag, "keep", false, "Keep the worktree and branch after landing")
+	cmd.Flags().BoolVar(&noSummaryFlag, "no-summary", false, "Build the squashed commit message from the turns instead of a summary (squash-commit sessions)")
+
+	return cmd
+}
+
+// landTarget is a session waiting to land: either a session worktree or a
+// squash-commit session.
+type landTarget struct {
+	SessionID string
+	Worktree  *strategy.SessionWorktree
+	Squash    *strategy.SquashSession
+}
+
+// listLandTargets returns session worktrees followed by squash sessions.
+func listLandTargets() ([]landTarget, error) {
+	worktrees, err := strategy.ListSessionWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	squashes, err := strategy.ListSquashSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]landTarget, 0, len(worktrees)+len(squashes))
+	for i := range worktrees {
+		targets = append(targets, landTarget{SessionID: worktrees[i].SessionID, Worktree: &worktrees[i]})
+	}
+	for i := range squashes {
+		targets = append(targets, landTarget{SessionID: squashes[i].SessionID, Squash: &squashes[i]})
+	}
+	return targets, nil
+}
+
+func runLandList(w io.Writer) error {
+	targets, err := listLandTargets()
+	if err != nil {
+		return err
+	}
+	if len(targets) == 0 {
+		fmt.Fprintln(w, "No sessions to land.")
+		return nil
+	}
+	for _, t := range targets {
+		var detail string
+		switch {
+		case t.Squash != nil:
+			detail = fmt.Sprintf("%d turn(s) on %s", len(t.Squash.Checkpoints), t.Squash.Branch)
+		case t.Worktree.Path == "":
+			detail = "(worktree removed)"
+		default:
+			detail = t.Worktree.Path
+		}
+		fmt.Fprintf(w, "%s  %s\n", t.SessionID, detail)
+	}
+	return nil
+}
+
+func runLand(w, errW io.Writer, query string, opts strategy.LandOptions) error {
+	target, err := resolveLandSession(query)
+	if err != nil {
+		return err
+	}
+	if target.Squash != nil {
+		return runLandSquash(w, target.Squash, opts)
+	}
+	sw := target.Worktree
+
+	applied, err := strategy.LandSessionWorktree(sw, opts)
+	if errors.Is(err, strategy.ErrLandConflict) {
+		fmt.Fprintf(errW, "Landing session %s stopped: %v\n", sw.SessionID, err)
+		if sw.Path != "" {
+			fmt.Fprintf(errW, "The session worktree is still at %s\n", sw.Path)
+		}
+		return NewSilentError(err)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to land session %s: %w", sw.SessionID, err)
+	}
+
+	switch {
+	case !applied:
+		fmt.Fprintf(w, "Session %s is already in the current branch.\n", sw.SessionID)
+	case opts.CherryPick:
+		fmt.Fprintf(w, "Cherry-picked %s onto the current branch.\n", sw.Branch)
+	default:
+		fmt.Fprintf(w, "Merged %s into the current branch.\n", sw.Branch)
+	}
+	if opts.Keep {
+		fmt.Fprintf(w, "Kept %s.\n", sw.Branch)
+	} else {
+		fmt.Fprintf(w, "Removed the session worktree and %s.\n", sw.Branch)
+	}
+	return nil
+}
+
+func runLandSquash(w io.Writer, sess *strategy.SquashSession, opts strategy.LandOptions) error {
+	if !opts.NoSummary {
+		fmt.Fprintln(w, "Summarizing session...")
+	}
+	result, err := strategy.LandSquashSession(sess, opts)
+	if err != nil {
+		return fmt.Errorf("failed to land session %s: %w", sess.SessionID, err)
+	}
+
+	if result.Commit == "" {
+		fmt.Fprintf(w, "Session %s has no changes left to commit.\n", sess.SessionID)
+	} else {
+		subject, _, _ := strings.Cut(result.Message, "\n")
+		fmt.Fprintf(w, "Squashed %d turn(s) into %s: %s\n", len(sess.Checkpoints), result.Commit[:min(7, len(result.Commit))], subject)
+	}
+	if opts.Keep {
+		fmt.Fprintf(w, "Kept %s.\n", sess.Branch)
+	} else {
+		fmt.Fprintf(w, "Removed %s.\n", sess.Branch)
+	}
+	return nil
+}
+
+// resolveLandSession finds the session to land. An empty query picks the
+// only session waiting to land, if there is exactly one.
+func resolveLandSession(query string) (*landTarget, error) {
+	if query != "" {
+		return findLandTarget(query)
+	}
+
+	targets, err := listLandTargets()
+	if err != nil {
+		return nil, err
+	}
+	switch len(targets) {
+	case 0:
+		return nil, errors.New("no session worktrees or squash sessions to land")
+	case 1:
+		return &targets[0], nil
+	default:
+		return nil, fmt.Errorf("%d session worktrees or squash sessions exist; specify one (see 'entire land --list')", len(targets))
+	}
+}
+
+// findLandTarget resolves a query against session worktrees first, then
+// squash sessions.
+func findLandTarget(query string) (*landTarget, error) {
+	sw, err := strategy.FindSessionWorktree(query)
+	if err == nil {
+		return &landTarget{SessionID: sw.SessionID, Worktree: sw}, nil
+	}
+	sess, squashErr := strategy.FindSquashSession(query)
+	if squashErr == nil {
+		return &landTarget{SessionID: sess.SessionID, Squash: sess}, nil
+	}
+	for _, e := range []error{err, squashErr} {
+		if strings.Contains(e.Error(), "ambiguous") {
+			return nil, fmt.Errorf("%w (see 'entire land --list')", e)
+		}
+	}
+	return nil, fmt.Errorf("no session worktree or squash session matches %q (see 'entire land --list')", query)
+}
@@ -0,0 +1,204 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+)
+
+func setupLandTestRepo(t *testing.T) string {
+	t.Helper()
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+	paths.ClearRepoRootCache()
+	t.Setenv("GIT_AUTHOR_NAME", "Test User")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test User")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	setupResumeTestRepo(t, tmpDir, false)
+	return tmpDir
+}
+
+func createLandTestSession(t *testing.T, sessionID string) string {
+	t.Helper()
+	s, err := strategy.Get(strategy.StrategyNameWorktree)
+	if err != nil {
+		t.Fatalf("failed to get worktree strategy: %v", err)
+	}
+	provider, ok := s.(strategy.SessionWorktreeProvider)
+	if !ok {
+		t.Fatal("worktree strategy does not implement SessionWorktreeProvider")
+	}
+	path, _, err := provider.EnsureSessionWorktree(sessionID)
+	if err != nil {
+		t.Fatalf("EnsureSessionWorktree() error = %v", err)
+	}
+	return path
+}
+
+func TestRunLand(t *testing.T) {
+	tmpDir := setupLandTestRepo(t)
+	worktreePath := createLandTestSession(t, "sess-1")
+
+	if err := os.WriteFile(filepath.Join(worktreePath, "new.txt"), []byte("from session"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	for _, args := range [][]string{{"add", "new.txt"}, {"commit", "-m", "Session work"}} {
+		cmd := exec.CommandContext(context.Background(), "git", args...)
+		cmd.Dir = worktreePath
+		if output, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, output)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runLandList(&out); err != nil {
+		t.Fatalf("runLandList() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "sess-1  "+worktreePath) {
+		t.Errorf("runLandList() output = %q", out.String())
+	}
+
+	// No argument lands the only session worktree
+	out.Reset()
+	var errOut bytes.Buffer
+	if err := runLand(&out, &errOut, "", strategy.LandOptions{}); err != nil {
+		t.Fatalf("runLand() error = %v\nstderr: %s", err, errOut.String())
+	}
+	if !strings.Contains(out.String(), "Merged entire/session/sess-1") {
+		t.Errorf("runLand() output = %q", out.String())
+	}
+	if data, err := os.ReadFile(filepath.Join(tmpDir, "new.txt")); err != nil || string(data) != "from session" {
+		t.Errorf("new.txt = %q, %v", data, err)
+	}
+
+	if err := runLand(&out, &errOut, "", strategy.LandOptions{}); err == nil || !strings.Contains(err.Error(), "no session worktrees") {
+		t.Errorf("runLand() with nothing left error = %v", err)
+	}
+}
+
+func TestRunLand_SquashSession(t *testing.T) {
+	tmpDir := setupLandTestRepo(t)
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte("changed by agent"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	metadataDir := filepath.Join(paths.EntireMetadataDir, "sess-9")
+	if err := os.MkdirAll(filepath.Join(tmpDir, metadataDir), 0o750); err != nil {
+		t.Fatalf("failed to create metadata dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, metadataDir, paths.TranscriptFileName), []byte(`{"type":"user"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+	s, err := strategy.Get(strategy.StrategyNameSquashCommit)
+	if err != nil {
+		t.Fatalf("failed to get squash-commit strategy: %v", err)
+	}
+	if err := s.SaveChanges(strategy.SaveContext{
+		SessionID:      "sess-9",
+		CommitMessage:  "Update test file",
+		MetadataDir:    metadataDir,
+		MetadataDirAbs: filepath.Join(tmpDir, metadataDir),
+		ModifiedFiles:  []string{"test.txt"},
+		AuthorName:     "Test User",
+		AuthorEmail:    "test@example.com",
+	}); err != nil {
+		t.Fatalf("SaveChanges() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := runLandList(&out); err != nil {
+		t.Fatalf("runLandList() error = %v", err)
+	}
+	if !strings.Contains(out.String(), "sess-9  1 turn(s) on entire/squash/sess-9") {
+		t.Errorf("runLandList() output = %q", out.String())
+	}
+
+	out.Reset()
+	var errOut bytes.Buffer
+	if err := runLand(&out, &errOut, "sess-9", strategy.LandOptions{NoSummary: true}); err != nil {
+		t.Fatalf("runLand() error = %v\nstderr: %s", err, errOut.String())
+	}
+	if !strings.Contains(out.String(), "Squashed 1 turn(s) into") || !strings.Contains(out.String(), "Update test file") {
+		t.Errorf("runLand() output = %q", out.String())
+	}
+	msg, err := exec.CommandContext(context.Background(), "git", "log", "-1", "--format=%B").Output()
+	if err != nil {
+		t.Fatalf("git log failed: %v", err)
+	}
+	if !strings.Contains(string(msg), "Entire-Checkpoint: ") {
+		t.Errorf("landed commit message = %q, want checkpoint trailer", msg)
+	}
+}
+
+func TestResolveLandSession_RequiresChoice(t *testing.T) {
+	setupLandTestRepo(t)
+	createLandTestSession(t, "sess-1")
+	createLandTestSession(t, "sess-2")
+
+	if _, err := resolveLandSession(""); err == nil || !strings.Contains(err.Error(), "2 session worktrees") {
+		t.Errorf("resolveLandSession(\"\") error = %v, want choice required", err)
+	}
+	sw, err := resolveLandSession("sess-2")
+	if err != nil || sw.SessionID != "sess-2" {
+		t.Errorf("resolveLandSession(sess-2) = %+v, %v", sw, err)
+	}
+	if _, err := resolveLandSession("nope"); err == nil || !strings.Contains(err.Error(), "entire land --list") {
+		t.Errorf("resolveLandSession(nope) error = %v", err)
+	}
+}
+
+func TestBlockOutsideSessionWorktree(t *testing.T) {
+	tmpDir := setupLandTestRepo(t)
+	strat, err := strategy.Get(strategy.StrategyNameWorktree)
+	if err != nil {
+		t.Fatalf("failed to get worktree strategy: %v", err)
+	}
+
+	// In the main checkout the prompt is refused and the worktree created
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	blocked, err := blockOutsideSessionWorktree(strat, "sess-1")
+	os.Stdout = stdout
+	w.Close()
+	if err != nil {
+		t.Fatalf("blockOutsideSessionWorktree() error = %v", err)
+	}
+	if !blocked {
+		t.Fatal("blockOutsideSessionWorktree() = false, want prompt refused in the main checkout")
+	}
+	var resp blockingHookResponse
+	if err := json.NewDecoder(r).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode hook response: %v", err)
+	}
+	worktreePath := filepath.Join(tmpDir, ".entire", "worktrees", "sess-1")
+	if resp.Continue || !strings.Contains(resp.StopReason, "cd "+worktreePath) {
+		t.Errorf("hook response = %+v, want continue false with the worktree path", resp)
+	}
+
+	// Inside the session worktree the agent works as usual
+	t.Chdir(worktreePath)
+	paths.ClearRepoRootCache()
+	blocked, err = blockOutsideSessionWorktree(strat, "sess-2")
+	if err != nil {
+		t.Fatalf("blockOutsideSessionWorktree() in worktree error = %v", err)
+	}
+	if blocked {
+		t.Error("blockOutsideSessionWorktree() = true inside the session worktree")
+	}
+	if worktrees, err := strategy.ListSessionWorktrees(); err != nil || len(worktrees) != 1 {
+		t.Errorf("ListSessionWorktrees() = %+v, %v; want only the first session's worktree", worktrees, err)
+	}
+}
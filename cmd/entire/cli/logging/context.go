@@ -16,6 +16,7 @@ const (
 	toolCallIDKey
 	componentKey
 	agentKey
+	hookKey
 )
 
 // WithSession adds a session ID to the context.
@@ -53,6 +54,15 @@ func WithAgent(ctx context.Context, agentName agent.AgentName) context.Context {
 	return context.WithValue(ctx, agentKey, string(agentName))
 }
 
+// WithHook adds the name of the hook being handled to the context.
+// Hook handlers mostly log from contexts of their own, so until the returned
+// function is called, lines logged from contexts without a hook carry this
+// one too. Each hook runs in its own process, so there is only ever one.
+func WithHook(ctx context.Context, hookName string) (context.Context, func()) {
+	setCurrentHook(hookName)
+	return context.WithValue(ctx, hookKey, hookName), func() { setCurrentHook("") }
+}
+
 // SessionIDFromContext extracts the session ID from the context.
 // Returns empty string if not set.
 func SessionIDFromContext(ctx context.Context) string {
@@ -97,6 +107,17 @@ func ComponentFromContext(ctx context.Context) string {
 	return ""
 }
 
+// HookFromContext extracts the hook name from the context.
+// Returns empty string if not set.
+func HookFromContext(ctx context.Context) string {
+	if v := ctx.Value(hookKey); v != nil {
+		if s, ok := v.(string); ok {
+			return s
+		}
+	}
+	return ""
+}
+
 // AgentFromContext extracts the agent name from the context.
 // Returns empty string if not set.
 func AgentFromContext(ctx context.Context) string {
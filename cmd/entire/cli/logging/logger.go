@@ -54,12 +54,19 @@ var (
 	// currentSessionID stores the session ID from Init() to include in all logs
 	currentSessionID string
 
-	// mu protects logger, logFile, logBufWriter, and currentSessionID
+	// currentHook stores the hook from WithHook, for contexts without one
+	currentHook string
+
+	// mu protects logger, logFile, logBufWriter, currentSessionID and currentHook
 	mu sync.RWMutex
 
 	// logLevelGetter is an optional callback to get log level from settings.
 	// Set by SetLogLevelGetter before Init is called.
 	logLevelGetter func() string
+
+	// afterInit holds the callbacks AfterInit queued before the logger was
+	// initialized. Init runs them once the logger is set.
+	afterInit []func()
 )
 
 // SetLogLevelGetter sets a callback function to get the log level from settings.
@@ -71,8 +78,24 @@ func SetLogLevelGetter(getter func() string) {
 	logLevelGetter = getter
 }
 
+// AfterInit runs fn once the logger is initialized: right away if Init has
+// already run, otherwise at the end of the next Init. This lets packages that
+// log while Init is still reading settings, such as the settings package,
+// write to the log file instead of to stderr.
+func AfterInit(fn func()) {
+	mu.Lock()
+	if logger == nil {
+		afterInit = append(afterInit, fn)
+		mu.Unlock()
+		return
+	}
+	mu.Unlock()
+	fn()
+}
+
 // Init initializes the logger for a session, writing JSON logs to
-// .entire/logs/entire.log.
+// .entire/logs/entire.log. The log file is rotated and compressed once it
+// grows too large or too old (see rotate).
 //
 // If sessionID is non-empty, it is stored as an slog attribute on every log line for filtering.
 // If the log file cannot be created, falls back to stderr.
@@ -85,8 +108,25 @@ func Init(sessionID string) error {
 		}
 	}
 
+	// Get log level from environment first, then settings. The getter is
+	// called before taking the lock, since loading settings may log.
+	levelStr := os.Getenv(LogLevelEnvVar)
+	if levelStr == "" && logLevelGetter != nil {
+		levelStr = logLevelGetter()
+	}
+	level := parseLogLevel(levelStr)
+
+	// Queued callbacks run after the lock is released, since they log
+	var ready []func()
+	defer func() {
+		for _, fn := range ready {
+			fn()
+		}
+	}()
+
 	mu.Lock()
 	defer mu.Unlock()
+	ready, afterInit = afterInit, nil
 
 	// Close any existing log file (flush buffer first)
 	if logBufWriter != nil {
@@ -98,13 +138,6 @@ func Init(sessionID string) error {
 		logFile = nil
 	}
 
-	// Get log level from environment first, then settings
-	levelStr := os.Getenv(LogLevelEnvVar)
-	if levelStr == "" && logLevelGetter != nil {
-		levelStr = logLevelGetter()
-	}
-	level := parseLogLevel(levelStr)
-
 	// Warn if invalid level was provided
 	if levelStr != "" && !isValidLogLevel(levelStr) {
 		fmt.Fprintf(os.Stderr, "[entire] Warning: invalid log level %q, defaulting to INFO\n", levelStr)
@@ -124,7 +157,9 @@ func Init(sessionID string) error {
 		return nil
 	}
 
-	logFilePath := filepath.Join(logsPath, "entire.log")
+	rotate(logsPath, time.Now())
+
+	logFilePath := filepath.Join(logsPath, LogFileName)
 	f, err := os.OpenFile(logFilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // fixed filename, not user-controlled
 	if err != nil {
 		// Fall back to stderr
@@ -164,6 +199,8 @@ func resetLogger() {
 	defer mu.Unlock()
 	logger = nil
 	currentSessionID = ""
+	currentHook = ""
+	afterInit = nil
 	if logBufWriter != nil {
 		_ = logBufWriter.Flush()
 		logBufWriter = nil
@@ -193,6 +230,20 @@ func getSessionID() string {
 	return currentSessionID
 }
 
+// getCurrentHook returns the hook being handled (thread-safe).
+func getCurrentHook() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return currentHook
+}
+
+// setCurrentHook sets the hook being handled (thread-safe).
+func setCurrentHook(hookName string) {
+	mu.Lock()
+	defer mu.Unlock()
+	currentHook = hookName
+}
+
 // createLogger creates a JSON logger writing to the given writer at the specified level.
 func createLogger(w io.Writer, level slog.Level) *slog.Logger {
 	opts := &slog.HandlerOptions{
@@ -276,18 +327,14 @@ func LogDuration(ctx context.Context, level slog.Level, msg string, start time.T
 func log(ctx context.Context, level slog.Level, msg string, attrs ...any) {
 	l := getLogger()
 
-	// Build attributes slice with session ID first (if set)
+	// Session ID and context values first, then caller-provided attributes.
+	// Callers that name the hook themselves take precedence over the context.
 	var allAttrs []any
-
-	// Add session ID from Init() if set (always first for consistency)
-	globalSessionID := getSessionID()
-	if globalSessionID != "" {
-		allAttrs = append(allAttrs, slog.String("session_id", globalSessionID))
-	}
-
-	// Extract context values, skipping session_id if already added from Init()
-	contextAttrs := attrsFromContext(ctx, globalSessionID)
-	for _, a := range contextAttrs {
+	callerHook := hasAttr(attrs, "hook")
+	for _, a := range Attrs(ctx) {
+		if callerHook && a.Key == "hook" {
+			continue
+		}
 		allAttrs = append(allAttrs, a)
 	}
 
@@ -299,6 +346,38 @@ func log(ctx context.Context, level slog.Level, msg string, attrs ...any) {
 	l.Log(nil, level, msg, allAttrs...) //nolint:staticcheck // nil context is intentional - we extract values as attributes
 }
 
+// hasAttr reports whether slog-style attrs include the given key.
+func hasAttr(attrs []any, key string) bool {
+	for i := 0; i < len(attrs); i++ {
+		switch a := attrs[i].(type) {
+		case slog.Attr:
+			if a.Key == key {
+				return true
+			}
+		case string:
+			// Key-value pair
+			if a == key {
+				return true
+			}
+			i++
+		}
+	}
+	return false
+}
+
+// Attrs returns the attributes every log line for ctx carries: the session ID
+// from Init (always first), then the session, tool call, component, agent and
+// hook values of ctx. The hook falls back to the one from WithHook.
+func Attrs(ctx context.Context) []slog.Attr {
+	var attrs []slog.Attr
+	globalSessionID := getSessionID()
+	if globalSessionID != "" {
+		attrs = append(attrs, slog.String("session_id", globalSessionID))
+	}
+	// Skip session_id from ctx if already added from Init()
+	return append(attrs, attrsFromContext(ctx, globalSessionID)...)
+}
+
 // attrsFromContext extracts logging attributes from a context.
 // If globalSessionID is non-empty, skips adding session_id from context to avoid duplicates.
 func attrsFromContext(ctx context.Context, globalSessionID string) []slog.Attr {
@@ -336,6 +415,13 @@ func attrsFromContext(ctx context.Context, globalSessionID string) []slog.Attr {
 			attrs = append(attrs, slog.String("agent", s))
 		}
 	}
+	hook := HookFromContext(ctx)
+	if hook == "" {
+		hook = getCurrentHook()
+	}
+	if hook != "" {
+		attrs = append(attrs, slog.String("hook", hook))
+	}
 
 	return attrs
 }
@@ -277,6 +277,47 @@ func TestLogging_BeforeInit(_ *testing.T) {
 	Error(ctx, "error before init")
 }
 
+func TestAfterInit_WaitsForInit(t *testing.T) {
+	resetLogger()
+	t.Cleanup(resetLogger)
+	tmpDir := t.TempDir()
+	initGitRepo(t, tmpDir)
+
+	// The level getter runs inside Init, before the logger is set, like
+	// settings warnings logged while loading the log level
+	ran := 0
+	SetLogLevelGetter(func() string {
+		AfterInit(func() {
+			ran++
+			Warn(context.Background(), "queued warning")
+		})
+		return ""
+	})
+	t.Cleanup(func() { SetLogLevelGetter(nil) })
+
+	if err := Init(testSessionID); err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+	if ran != 1 {
+		t.Fatalf("queued callback ran %d times during Init, want 1", ran)
+	}
+
+	// Once initialized, callbacks run right away
+	AfterInit(func() { ran++ })
+	if ran != 2 {
+		t.Errorf("callback after Init ran %d times in total, want 2", ran)
+	}
+
+	Close()
+	data, err := os.ReadFile(testLogFilePath(tmpDir))
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if !strings.Contains(string(data), "queued warning") {
+		t.Errorf("log file missing queued warning:\n%s", data)
+	}
+}
+
 // Helper to initialize a git repo for tests
 func initGitRepo(t *testing.T, dir string) {
 	t.Helper()
@@ -536,6 +577,42 @@ func TestLogging_ContextSessionID_WhenNoGlobalSet(t *testing.T) {
 	resetLogger()
 }
 
+func TestLogging_WithHook(t *testing.T) {
+	resetLogger()
+	var buf bytes.Buffer
+	mu.Lock()
+	logger = createLogger(&buf, slog.LevelInfo)
+	mu.Unlock()
+
+	hookCtx, endHook := WithHook(WithComponent(context.Background(), testComponent), "stop")
+	Info(hookCtx, "from hook context")
+	// Handlers that build their own contexts still log the hook
+	Info(context.Background(), "from own context")
+	// A hook named by the caller isn't duplicated
+	Info(hookCtx, "explicit hook", slog.String("hook", "stop"))
+	endHook()
+	Info(context.Background(), "after hook")
+
+	lines := bytes.Split(bytes.TrimSpace(buf.Bytes()), []byte("\n"))
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 log lines, got %d:\n%s", len(lines), buf.String())
+	}
+	for i, want := range []string{"stop", "stop", "stop", ""} {
+		var entry map[string]interface{}
+		if err := json.Unmarshal(lines[i], &entry); err != nil {
+			t.Fatalf("Log output is not valid JSON: %v\nContent: %s", err, lines[i])
+		}
+		if got, _ := entry["hook"].(string); got != want {
+			t.Errorf("line %d hook = %q, want %q", i, got, want)
+		}
+	}
+	if n := bytes.Count(lines[2], []byte(`"hook"`)); n != 1 {
+		t.Errorf("explicit hook logged %d times: %s", n, lines[2])
+	}
+
+	resetLogger()
+}
+
 func TestInit_RejectsInvalidSessionIDs(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -0,0 +1,224 @@
+package logging
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// LogFileName is the name of the active log file in LogsDir.
+const LogFileName = "entire.log"
+
+// rotatedPrefix and rotatedTimeFormat name rotated log files, e.g.
+// entire-20260115T093000.000.log(.gz). Names sort chronologically.
+const (
+	rotatedPrefix     = "entire-"
+	rotatedTimeFormat = "20060102T150405.000"
+	gzipSuffix        = ".gz"
+)
+
+// Rotation limits. These are variables so tests can lower them.
+var (
+	// rotateSize is the size at which the active log file is rotated.
+	rotateSize int64 = 10 << 20
+
+	// rotateAge is how long the active log file is written before it is rotated.
+	rotateAge = 24 * time.Hour
+
+	// rotatedRetention is how long rotated log files are kept.
+	rotatedRetention = 7 * 24 * time.Hour
+
+	// maxRotatedFiles is how many rotated log files are kept.
+	maxRotatedFiles = 10
+
+	// compressAfter is how long a rotated file stays uncompressed. Other hook
+	// processes may still hold it open and append to it for a moment.
+	compressAfter = time.Minute
+)
+
+// rotate rotates the active log file in logsPath if it is too large or too
+// old, compresses previously rotated files and removes expired ones. Failures
+// are ignored: logging must never break the command that is running.
+func rotate(logsPath string, now time.Time) {
+	active := filepath.Join(logsPath, LogFileName)
+	if info, err := os.Stat(active); err == nil && needsRotation(active, info, now) {
+		rotated := filepath.Join(logsPath, rotatedPrefix+now.UTC().Format(rotatedTimeFormat)+".log")
+		// Another process may rotate at the same time; whichever rename loses is a no-op
+		_ = os.Rename(active, rotated) //nolint:errcheck // best effort
+	}
+
+	rotatedFiles, err := listRotated(logsPath)
+	if err != nil {
+		return
+	}
+	for _, path := range rotatedFiles {
+		info, err := os.Stat(path)
+		if err != nil {
+			continue
+		}
+		if now.Sub(info.ModTime()) > rotatedRetention {
+			_ = os.Remove(path) //nolint:errcheck // best effort
+			continue
+		}
+		if !strings.HasSuffix(path, gzipSuffix) && now.Sub(info.ModTime()) > compressAfter {
+			_ = compressFile(path) //nolint:errcheck // best effort, retried on the next rotation check
+		}
+	}
+
+	// Keep only the newest rotated files
+	rotatedFiles, err = listRotated(logsPath)
+	if err != nil || len(rotatedFiles) <= maxRotatedFiles {
+		return
+	}
+	for _, path := range rotatedFiles[:len(rotatedFiles)-maxRotatedFiles] {
+		_ = os.Remove(path) //nolint:errcheck // best effort
+	}
+}
+
+// needsRotation reports whether the active log file has reached rotateSize,
+// or its first entry is older than rotateAge.
+func needsRotation(path string, info os.FileInfo, now time.Time) bool {
+	if info.Size() == 0 {
+		return false
+	}
+	if info.Size() >= rotateSize {
+		return true
+	}
+	started, ok := firstEntryTime(path)
+	return ok && now.Sub(started) >= rotateAge
+}
+
+// firstEntryTime returns the time of the first entry in a log file.
+func firstEntryTime(path string) (time.Time, bool) {
+	f, err := os.Open(path) //nolint:gosec // path is in the logs directory
+	if err != nil {
+		return time.Time{}, false
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil && len(line) == 0 {
+		return time.Time{}, false
+	}
+	var entry struct {
+		Time time.Time `json:"time"`
+	}
+	if err := json.Unmarshal(line, &entry); err != nil || entry.Time.IsZero() {
+		return time.Time{}, false
+	}
+	return entry.Time, true
+}
+
+// compressFile gzips path to path.gz and removes the original.
+func compressFile(path string) error {
+	src, err := os.Open(path) //nolint:gosec // path is in the logs directory
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer src.Close()
+
+	tmp := path + gzipSuffix + ".tmp"
+	dst, err := os.OpenFile(tmp, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600) //nolint:gosec // path is in the logs directory
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", tmp, err)
+	}
+	zw := gzip.NewWriter(dst)
+	_, copyErr := io.Copy(zw, src)
+	closeErr := zw.Close()
+	if err := dst.Close(); err != nil && closeErr == nil {
+		closeErr = err
+	}
+	if copyErr != nil || closeErr != nil {
+		_ = os.Remove(tmp) //nolint:errcheck // cleanup
+		return fmt.Errorf("failed to compress %s: %w", path, errors.Join(copyErr, closeErr))
+	}
+
+	if err := os.Rename(tmp, path+gzipSuffix); err != nil {
+		_ = os.Remove(tmp) //nolint:errcheck // cleanup
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", path, err)
+	}
+	return nil
+}
+
+// listRotated returns rotated log files in logsPath, oldest first.
+func listRotated(logsPath string) ([]string, error) {
+	entries, err := os.ReadDir(logsPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read logs directory: %w", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, rotatedPrefix) {
+			continue
+		}
+		if strings.HasSuffix(name, ".log") || strings.HasSuffix(name, ".log"+gzipSuffix) {
+			files = append(files, filepath.Join(logsPath, name))
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// LogFiles returns all log files in logsPath in chronological order: rotated
+// files oldest first, then the active log file if it exists.
+func LogFiles(logsPath string) ([]string, error) {
+	files, err := listRotated(logsPath)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	active := filepath.Join(logsPath, LogFileName)
+	if _, err := os.Stat(active); err == nil {
+		files = append(files, active)
+	}
+	return files, nil
+}
+
+// OpenLogFile opens a log file for reading, decompressing rotated .gz files.
+func OpenLogFile(path string) (io.ReadCloser, error) {
+	f, err := os.Open(path) //nolint:gosec // path comes from LogFiles
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	if !strings.HasSuffix(path, gzipSuffix) {
+		return f, nil
+	}
+	zr, err := gzip.NewReader(f)
+	if err != nil {
+		_ = f.Close()
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return &gzipFile{Reader: zr, file: f}, nil
+}
+
+// gzipFile closes both the gzip reader and the underlying file.
+type gzipFile struct {
+	*gzip.Reader
+	file *os.File
+}
+
+func (g *gzipFile) Close() error {
+	zerr := g.Reader.Close()
+	if err := g.file.Close(); err != nil {
+		return fmt.Errorf("failed to close log file: %w", err)
+	}
+	if zerr != nil {
+		return fmt.Errorf("failed to close gzip reader: %w", zerr)
+	}
+	return nil
+}
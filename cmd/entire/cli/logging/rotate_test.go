@@ -0,0 +1,148 @@
+package logging
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeLog writes an active log file whose first entry is at started.
+func writeLog(t *testing.T, logsPath string, started time.Time, size int) {
+	t.Helper()
+	line := `{"time":"` + started.Format(time.RFC3339Nano) + `","level":"INFO","msg":"hello"}` + "\n"
+	content := line + strings.Repeat("x", max(0, size-len(line)))
+	if err := os.WriteFile(filepath.Join(logsPath, LogFileName), []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+}
+
+func TestRotate_BySizeAndAge(t *testing.T) {
+	now := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		started     time.Time
+		size        int
+		wantRotated bool
+	}{
+		{name: "small and recent", started: now.Add(-time.Hour), size: 100},
+		{name: "too large", started: now.Add(-time.Hour), size: int(rotateSize), wantRotated: true},
+		{name: "too old", started: now.Add(-rotateAge), size: 100, wantRotated: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logsPath := t.TempDir()
+			writeLog(t, logsPath, tt.started, tt.size)
+
+			rotate(logsPath, now)
+
+			_, activeErr := os.Stat(filepath.Join(logsPath, LogFileName))
+			rotated, err := listRotated(logsPath)
+			if err != nil {
+				t.Fatalf("listRotated() error = %v", err)
+			}
+			if tt.wantRotated {
+				if !os.IsNotExist(activeErr) || len(rotated) != 1 || filepath.Base(rotated[0]) != "entire-20260115T120000.000.log" {
+					t.Errorf("active err = %v, rotated = %v; want the active log rotated", activeErr, rotated)
+				}
+				return
+			}
+			if activeErr != nil || len(rotated) != 0 {
+				t.Errorf("active err = %v, rotated = %v; want no rotation", activeErr, rotated)
+			}
+		})
+	}
+}
+
+func TestRotate_CompressesAndExpiresRotatedFiles(t *testing.T) {
+	logsPath := t.TempDir()
+	now := time.Now()
+
+	write := func(name, content string, modTime time.Time) string {
+		path := filepath.Join(logsPath, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to set mtime: %v", err)
+		}
+		return path
+	}
+	expired := write("entire-20250101T000000.000.log.gz", "old", now.Add(-rotatedRetention-time.Hour))
+	settled := write("entire-20260101T000000.000.log", "settled\n", now.Add(-time.Hour))
+	fresh := write("entire-20260102T000000.000.log", "fresh\n", now)
+
+	rotate(logsPath, now)
+
+	if _, err := os.Stat(expired); !os.IsNotExist(err) {
+		t.Errorf("expired file still exists: %v", err)
+	}
+	if _, err := os.Stat(settled); !os.IsNotExist(err) {
+		t.Errorf("settled file not compressed: %v", err)
+	}
+	if _, err := os.Stat(fresh); err != nil {
+		t.Errorf("fresh rotated file should stay uncompressed: %v", err)
+	}
+
+	r, err := OpenLogFile(settled + gzipSuffix)
+	if err != nil {
+		t.Fatalf("OpenLogFile() error = %v", err)
+	}
+	defer r.Close()
+	data, err := io.ReadAll(r)
+	if err != nil || string(data) != "settled\n" {
+		t.Errorf("decompressed = %q, %v", data, err)
+	}
+}
+
+func TestRotate_KeepsNewestRotatedFiles(t *testing.T) {
+	logsPath := t.TempDir()
+	now := time.Now()
+	for i := range maxRotatedFiles + 2 {
+		name := rotatedPrefix + now.Add(time.Duration(i)*time.Second).UTC().Format(rotatedTimeFormat) + ".log"
+		if err := os.WriteFile(filepath.Join(logsPath, name), []byte("x\n"), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	rotate(logsPath, now)
+
+	files, err := LogFiles(logsPath)
+	if err != nil {
+		t.Fatalf("LogFiles() error = %v", err)
+	}
+	if len(files) != maxRotatedFiles {
+		t.Fatalf("LogFiles() = %d files, want %d", len(files), maxRotatedFiles)
+	}
+	if want := rotatedPrefix + now.Add(2*time.Second).UTC().Format(rotatedTimeFormat) + ".log"; filepath.Base(files[0]) != want {
+		t.Errorf("oldest kept file = %s, want %s", filepath.Base(files[0]), want)
+	}
+}
+
+func TestLogFiles_ActiveLast(t *testing.T) {
+	logsPath := t.TempDir()
+	for _, name := range []string{LogFileName, "entire-20260102T000000.000.log", "entire-20260101T000000.000.log.gz"} {
+		if err := os.WriteFile(filepath.Join(logsPath, name), nil, 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	files, err := LogFiles(logsPath)
+	if err != nil {
+		t.Fatalf("LogFiles() error = %v", err)
+	}
+	var names []string
+	for _, f := range files {
+		names = append(names, filepath.Base(f))
+	}
+	if got := strings.Join(names, ","); got != "entire-20260101T000000.000.log.gz,entire-20260102T000000.000.log,entire.log" {
+		t.Errorf("LogFiles() = %s", got)
+	}
+
+	if files, err := LogFiles(filepath.Join(logsPath, "missing")); err != nil || files != nil {
+		t.Errorf("LogFiles(missing) = %v, %v", files, err)
+	}
+}
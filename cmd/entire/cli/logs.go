@@ -0,0 +1,369 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+
+	"github.com/spf13/cobra"
+)
+
+// logsFollowInterval is how often --follow checks the log file for new entries.
+const logsFollowInterval = 250 * time.Millisecond
+
+// logAttrOrder lists the attributes shown first, in this order. The rest
+// follow alphabetically.
+var logAttrOrder = []string{"session_id", "parent_session_id", "tool_call_id", "agent", "component", "hook", "hook_type"}
+
+func newLogsCmd() *cobra.Command {
+	var sessionFlag, levelFlag, hookFlag, sinceFlag string
+	var followFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "logs",
+		Short: "Show Entire's logs",
+		Long: `Show Entire's logs from .entire/logs, oldest first, including rotated files.
+
+Each entry is printed on one line with its time, level, message and attributes.
+Filters can be combined:
+
+  entire logs --session 2026-01-15-abc123   # one session (a prefix is enough)
+  entire logs --level warn                  # warnings and errors
+  entire logs --hook stop --since 1h        # stop hook entries from the last hour
+  entire logs --follow                      # keep printing new entries
+
+--since accepts a duration (30m, 2h, 7d) or a timestamp (2026-01-15, RFC 3339).`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			repoRoot, err := paths.RepoRoot()
+			if err != nil {
+				return errors.New("not a git repository")
+			}
+			filter, err := newLogFilter(sessionFlag, levelFlag, hookFlag, sinceFlag, time.Now())
+			if err != nil {
+				return err
+			}
+			return runLogs(cmd.Context(), cmd.OutOrStdout(), filepath.Join(repoRoot, logging.LogsDir), filter, followFlag)
+		},
+	}
+
+	cmd.Flags().StringVar(&sessionFlag, "session", "", "Only show entries for this session ID or prefix")
+	cmd.Flags().StringVar(&levelFlag, "level", "", "Only show entries at or above this level (debug, info, warn, error)")
+	cmd.Flags().StringVar(&hookFlag, "hook", "", "Only show entries for this hook (e.g. stop, post-commit)")
+	cmd.Flags().StringVar(&sinceFlag, "since", "", "Only show entries since a duration ago or a timestamp")
+	cmd.Flags().BoolVarP(&followFlag, "follow", "f", false, "Keep printing new entries as they are logged")
+
+	return cmd
+}
+
+// logFilter selects log entries. Zero values match everything.
+type logFilter struct {
+	session  string
+	minLevel slog.Level
+	hook     string
+	since    time.Time
+}
+
+// newLogFilter parses the logs command flags.
+func newLogFilter(session, level, hook, since string, now time.Time) (logFilter, error) {
+	filter := logFilter{session: session, minLevel: slog.LevelDebug, hook: hook}
+	if level != "" {
+		if err := filter.minLevel.UnmarshalText([]byte(level)); err != nil {
+			return logFilter{}, fmt.Errorf("invalid level %q (expected debug, info, warn or error)", level)
+		}
+	}
+	if since != "" {
+		t, err := parseSince(since, now)
+		if err != nil {
+			return logFilter{}, err
+		}
+		filter.since = t
+	}
+	return filter, nil
+}
+
+// parseSince parses a duration ago ("1h", "7d") or a timestamp.
+func parseSince(s string, now time.Time) (time.Time, error) {
+	if d, err := settings.ParseAge(s); err == nil {
+		return now.Add(-d), nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	if t, err := time.ParseInLocation(time.DateOnly, s, time.Local); err == nil {
+		return t, nil
+	}
+	return time.Time{}, fmt.Errorf("invalid --since %q (expected a duration like 1h or 7d, or a timestamp)", s)
+}
+
+// active reports whether any filter is set.
+func (f logFilter) active() bool {
+	return f.session != "" || f.minLevel > slog.LevelDebug || f.hook != "" || !f.since.IsZero()
+}
+
+// matches reports whether an entry passes the filter.
+func (f logFilter) matches(e *logEntry) bool {
+	if e.level < f.minLevel {
+		return false
+	}
+	if !f.since.IsZero() && e.time.Before(f.since) {
+		return false
+	}
+	if f.hook != "" && e.attrString("hook") != f.hook {
+		return false
+	}
+	if f.session != "" {
+		sessionID, parentID := e.attrString("session_id"), e.attrString("parent_session_id")
+		if !strings.HasPrefix(sessionID, f.session) && !strings.HasPrefix(parentID, f.session) {
+			return false
+		}
+	}
+	return true
+}
+
+// logEntry is one decoded slog JSON line.
+type logEntry struct {
+	time  time.Time
+	level slog.Level
+	msg   string
+	attrs map[string]any
+}
+
+// parseLogEntry decodes a slog JSON line. Returns false for lines that are
+// not log entries.
+func parseLogEntry(line []byte) (*logEntry, bool) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	dec.UseNumber()
+	var attrs map[string]any
+	if err := dec.Decode(&attrs); err != nil {
+		return nil, false
+	}
+
+	entry := &logEntry{attrs: attrs}
+	timeStr, _ := attrs[slog.TimeKey].(string)     //nolint:errcheck // checked below
+	levelStr, _ := attrs[slog.LevelKey].(string)   //nolint:errcheck // checked below
+	entry.msg, _ = attrs[slog.MessageKey].(string) //nolint:errcheck // a missing message reads as empty
+	t, err := time.Parse(time.RFC3339Nano, timeStr)
+	if err != nil || entry.level.UnmarshalText([]byte(levelStr)) != nil {
+		return nil, false
+	}
+	entry.time = t
+	delete(attrs, slog.TimeKey)
+	delete(attrs, slog.LevelKey)
+	delete(attrs, slog.MessageKey)
+	return entry, true
+}
+
+// attrString returns a string attribute, or "" if it is missing.
+func (e *logEntry) attrString(key string) string {
+	s, _ := e.attrs[key].(string) //nolint:errcheck // missing or non-string attributes read as empty
+	return s
+}
+
+// format renders the entry as "time LEVEL message key=value ...".
+func (e *logEntry) format() string {
+	var sb strings.Builder
+	sb.WriteString(e.time.Local().Format("2006-01-02 15:04:05.000"))
+	fmt.Fprintf(&sb, " %-5s %s", e.level.String(), e.msg)
+
+	keys := make([]string, 0, len(e.attrs))
+	for k := range e.attrs {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ri, rj := slices.Index(logAttrOrder, keys[i]), slices.Index(logAttrOrder, keys[j])
+		switch {
+		case ri >= 0 && rj >= 0:
+			return ri < rj
+		case ri >= 0 || rj >= 0:
+			return ri >= 0
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+	for _, k := range keys {
+		sb.WriteString(" " + k + "=" + formatLogValue(e.attrs[k]))
+	}
+	return sb.String()
+}
+
+// formatLogValue formats an attribute value. Strings are quoted only when
+// they contain spaces; groups and lists are shown as JSON.
+func formatLogValue(v any) string {
+	switch val := v.(type) {
+	case string:
+		if val == "" || strings.ContainsAny(val, " \t\n\"=") {
+			data, err := json.Marshal(val)
+			if err == nil {
+				return string(data)
+			}
+		}
+		return val
+	case json.Number:
+		return val.String()
+	default:
+		data, err := json.Marshal(val)
+		if err != nil {
+			return fmt.Sprint(val)
+		}
+		return string(data)
+	}
+}
+
+// runLogs prints matching entries from every log file, then keeps following
+// the active log file if follow is set.
+func runLogs(ctx context.Context, w io.Writer, logsPath string, filter logFilter, follow bool) error {
+	files, err := logging.LogFiles(logsPath)
+	if err != nil {
+		return fmt.Errorf("failed to list log files: %w", err)
+	}
+	if len(files) == 0 && !follow {
+		fmt.Fprintln(w, "No logs yet.")
+		return nil
+	}
+
+	activePath := filepath.Join(logsPath, logging.LogFileName)
+	var offset int64
+	for _, path := range files {
+		// Rotated files last written before --since hold only older entries
+		if info, err := os.Stat(path); err == nil && info.ModTime().Before(filter.since) {
+			continue
+		}
+		n, err := printLogFile(w, path, filter)
+		if err != nil {
+			return err
+		}
+		if path == activePath {
+			offset = n
+		}
+	}
+
+	if !follow {
+		return nil
+	}
+	return followLogs(ctx, w, activePath, filter, offset)
+}
+
+// printLogFile prints the matching entries of one log file. Returns the
+// number of bytes read up to the last complete line.
+func printLogFile(w io.Writer, path string, filter logFilter) (int64, error) {
+	f, err := logging.OpenLogFile(path)
+	if err != nil {
+		return 0, err //nolint:wrapcheck // already wrapped by the logging package
+	}
+	defer f.Close()
+
+	var offset int64
+	r := bufio.NewReader(f)
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > 0 && line[len(line)-1] == '\n' {
+			offset += int64(len(line))
+			printLogLine(w, line, filter)
+		}
+		if errors.Is(err, io.EOF) {
+			return offset, nil
+		}
+		if err != nil {
+			return offset, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+}
+
+// printLogLine prints one log line if it matches. Lines that aren't log
+// entries are printed as-is when no filter is set.
+func printLogLine(w io.Writer, line []byte, filter logFilter) {
+	line = bytes.TrimSpace(line)
+	if len(line) == 0 {
+		return
+	}
+	entry, ok := parseLogEntry(line)
+	if !ok {
+		if !filter.active() {
+			fmt.Fprintln(w, string(line))
+		}
+		return
+	}
+	if filter.matches(entry) {
+		fmt.Fprintln(w, entry.format())
+	}
+}
+
+// followLogs prints entries appended to the active log file after offset,
+// until ctx is cancelled. When the file is rotated, the new file is read
+// from the start.
+func followLogs(ctx context.Context, w io.Writer, path string, filter logFilter, offset int64) error {
+	current, _ := os.Stat(path) //nolint:errcheck // a missing file is picked up once it is created
+	var partial []byte
+
+	ticker := time.NewTicker(logsFollowInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		info, err := os.Stat(path)
+		if err != nil {
+			continue // Rotated away and not yet recreated
+		}
+		if current == nil || !os.SameFile(current, info) || info.Size() < offset {
+			offset, partial = 0, nil
+		}
+		current = info
+		if info.Size() == offset {
+			continue
+		}
+
+		data, err := readLogFrom(path, offset)
+		if err != nil {
+			return err
+		}
+		offset += int64(len(data))
+		data = append(partial, data...)
+
+		end := bytes.LastIndexByte(data, '\n')
+		if end < 0 {
+			partial = data
+			continue
+		}
+		for _, line := range bytes.Split(data[:end], []byte("\n")) {
+			printLogLine(w, line, filter)
+		}
+		partial = append([]byte(nil), data[end+1:]...)
+	}
+}
+
+// readLogFrom reads a log file from offset to its current end.
+func readLogFrom(path string, offset int64) ([]byte, error) {
+	f, err := os.Open(path) //nolint:gosec // path is the active log file
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+	if _, err := f.Seek(offset, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("failed to seek %s: %w", path, err)
+	}
+	data, err := io.ReadAll(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,170 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+)
+
+const testLogLines = `{"time":"2026-01-15T10:00:00Z","level":"INFO","msg":"hook invoked","session_id":"2026-01-15-aaa","hook":"stop","hook_type":"agent"}
+{"time":"2026-01-15T10:05:00Z","level":"WARN","msg":"transcript missing","session_id":"2026-01-15-bbb","tool_call_id":"toolu_1","path":"/tmp/a b"}
+not json
+{"time":"2026-01-15T11:00:00Z","level":"ERROR","msg":"condense failed","session_id":"2026-01-15-aaa","hook":"post-commit","attempt":2}
+`
+
+func writeTestLogs(t *testing.T) string {
+	t.Helper()
+	logsPath := filepath.Join(t.TempDir(), "logs")
+	if err := os.MkdirAll(logsPath, 0o750); err != nil {
+		t.Fatalf("failed to create logs dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(logsPath, logging.LogFileName), []byte(testLogLines), 0o600); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	return logsPath
+}
+
+func TestRunLogs_Filters(t *testing.T) {
+	t.Parallel()
+	logsPath := writeTestLogs(t)
+	now := time.Date(2026, 1, 15, 11, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                        string
+		session, level, hook, since string
+		wantMessages                []string
+		wantRaw                     bool
+	}{
+		{name: "no filter", wantMessages: []string{"hook invoked", "transcript missing", "condense failed"}, wantRaw: true},
+		{name: "session prefix", session: "2026-01-15-a", wantMessages: []string{"hook invoked", "condense failed"}},
+		{name: "level", level: "warn", wantMessages: []string{"transcript missing", "condense failed"}},
+		{name: "hook", hook: "stop", wantMessages: []string{"hook invoked"}},
+		{name: "since", since: "1h", wantMessages: []string{"condense failed"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			filter, err := newLogFilter(tt.session, tt.level, tt.hook, tt.since, now)
+			if err != nil {
+				t.Fatalf("newLogFilter() error = %v", err)
+			}
+			var out bytes.Buffer
+			if err := runLogs(context.Background(), &out, logsPath, filter, false); err != nil {
+				t.Fatalf("runLogs() error = %v", err)
+			}
+			lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+			wantLines := len(tt.wantMessages)
+			if tt.wantRaw {
+				wantLines++
+			}
+			if len(lines) != wantLines {
+				t.Fatalf("runLogs() printed %d lines, want %d:\n%s", len(lines), wantLines, out.String())
+			}
+			for _, msg := range tt.wantMessages {
+				if !strings.Contains(out.String(), msg) {
+					t.Errorf("runLogs() missing %q:\n%s", msg, out.String())
+				}
+			}
+			if strings.Contains(out.String(), "not json") != tt.wantRaw {
+				t.Errorf("raw line shown = %v, want %v", !tt.wantRaw, tt.wantRaw)
+			}
+		})
+	}
+}
+
+func TestNewLogFilter_InvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	if _, err := newLogFilter("", "loud", "", "", time.Now()); err == nil || !strings.Contains(err.Error(), "invalid level") {
+		t.Errorf("newLogFilter(level=loud) error = %v", err)
+	}
+	if _, err := newLogFilter("", "", "", "yesterday", time.Now()); err == nil || !strings.Contains(err.Error(), "invalid --since") {
+		t.Errorf("newLogFilter(since=yesterday) error = %v", err)
+	}
+	filter, err := newLogFilter("", "", "", "2026-01-15T10:30:00Z", time.Now())
+	if err != nil || !filter.since.Equal(time.Date(2026, 1, 15, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("newLogFilter(since=RFC 3339) = %v, %v", filter.since, err)
+	}
+}
+
+func TestLogEntry_Format(t *testing.T) {
+	t.Parallel()
+
+	entry, ok := parseLogEntry([]byte(`{"time":"2026-01-15T10:05:00Z","level":"WARN","msg":"transcript missing","path":"/tmp/a b","tool_call_id":"toolu_1","session_id":"s1","count":3}`))
+	if !ok {
+		t.Fatal("parseLogEntry() failed")
+	}
+	got := entry.format()
+	// Time is shown in local time; check everything after it
+	if _, rest, _ := strings.Cut(got, " WARN "); strings.TrimSpace(rest) != `transcript missing session_id=s1 tool_call_id=toolu_1 count=3 path="/tmp/a b"` {
+		t.Errorf("format() = %q", got)
+	}
+
+	if _, ok := parseLogEntry([]byte(`{"msg":"no time"}`)); ok {
+		t.Error("parseLogEntry() accepted an entry without time and level")
+	}
+}
+
+func TestRunLogs_Follow(t *testing.T) {
+	t.Parallel()
+	logsPath := writeTestLogs(t)
+	activePath := filepath.Join(logsPath, logging.LogFileName)
+
+	var out syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runLogs(ctx, &out, logsPath, logFilter{}, true)
+	}()
+
+	waitForOutput := func(want string) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for !strings.Contains(out.String(), want) {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %q, got:\n%s", want, out.String())
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	waitForOutput("condense failed")
+
+	f, err := os.OpenFile(activePath, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	// Write a line in two parts; it is printed only once complete
+	if _, err := f.WriteString(`{"time":"2026-01-15T12:00:00Z","level":"INFO",`); err != nil {
+		t.Fatalf("write error = %v", err)
+	}
+	time.Sleep(2 * logsFollowInterval)
+	if _, err := f.WriteString(`"msg":"appended"}` + "\n"); err != nil {
+		t.Fatalf("write error = %v", err)
+	}
+	f.Close()
+	waitForOutput("appended")
+
+	// After rotation, the new file is read from the start
+	if err := os.Rename(activePath, filepath.Join(logsPath, "entire-20260115T120000.000.log")); err != nil {
+		t.Fatalf("failed to rotate: %v", err)
+	}
+	if err := os.WriteFile(activePath, []byte(`{"time":"2026-01-15T12:01:00Z","level":"INFO","msg":"after rotation"}`+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write new log: %v", err)
+	}
+	waitForOutput("after rotation")
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runLogs() error = %v", err)
+	}
+	if n := strings.Count(out.String(), "appended"); n != 1 {
+		t.Errorf("appended entry printed %d times, want 1", n)
+	}
+}
@@ -27,6 +27,8 @@ const (
 	SummaryFileName          = "summary.txt"
 	TranscriptFileName       = "full.jsonl"
 	TranscriptFileNameLegacy = "full.log"
+	PreCompactionFileName    = "full.pre-compaction.jsonl"
+	CommandsFileName         = "commands.jsonl"
 	MetadataFileName         = "metadata.json"
 	CheckpointFileName       = "checkpoint.json"
 	ContentHashFileName      = "content_hash.txt"
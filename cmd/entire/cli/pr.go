@@ -0,0 +1,363 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+func newPRCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Commands for pull requests",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newPRDescribeCmd())
+
+	return cmd
+}
+
+func newPRDescribeCmd() *cobra.Command {
+	var noGenerateFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "describe [base]",
+		Short: "Generate a pull request description from the branch's checkpoints",
+		Long: `Describe writes a Markdown pull request description built from the committed
+checkpoints on HEAD that are not on the base branch (the default branch
+when no base is given).
+
+The description includes:
+  - the intent of each checkpoint
+  - the changes made, with each commit's outcome
+  - the agent/human attribution of the lines added since the base
+  - token usage
+  - the open items reported by the AI summaries
+
+Checkpoints without an AI summary get one generated first (requires the
+claude CLI). Use --no-generate to skip generation and fall back to the
+session prompts.
+
+The description is written to stdout, so it can be piped, for example:
+  entire pr describe | gh pr create --body-file -`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+			var base string
+			if len(args) > 0 {
+				base = args[0]
+			}
+			return runPRDescribe(cmd.OutOrStdout(), cmd.ErrOrStderr(), base, !noGenerateFlag)
+		},
+	}
+
+	cmd.Flags().BoolVar(&noGenerateFlag, "no-generate", false, "Don't generate missing AI summaries")
+
+	return cmd
+}
+
+// prCheckpoint is a committed checkpoint included in a PR description.
+type prCheckpoint struct {
+	CheckpointID id.CheckpointID
+	CommitHash   string
+	Subject      string
+	Summary      *checkpoint.Summary // nil if no summary exists
+	Prompt       string              // Fallback intent when there is no summary
+	FilesTouched []string
+	TokenUsage   *agent.TokenUsage
+}
+
+// prDescription is everything rendered by formatPRDescription.
+type prDescription struct {
+	Base        string
+	Checkpoints []prCheckpoint // Oldest first
+	AgentLines  int
+	TotalLines  int
+	TokenUsage  *agent.TokenUsage
+}
+
+func runPRDescribe(w, errW io.Writer, base string, generate bool) error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+
+	baseHash, baseName, err := resolvePRBase(repo, base)
+	if err != nil {
+		return err
+	}
+
+	desc, err := buildPRDescription(context.Background(), errW, repo, baseHash, generate)
+	if err != nil {
+		return err
+	}
+	if len(desc.Checkpoints) == 0 {
+		return fmt.Errorf("no committed checkpoints on HEAD since %s", baseName)
+	}
+	desc.Base = baseName
+
+	fmt.Fprint(w, formatPRDescription(desc))
+	return nil
+}
+
+// resolvePRBase resolves the base revision, defaulting to the default branch.
+// Returns the base commit and a display name for it.
+func resolvePRBase(repo *git.Repository, base string) (plumbing.Hash, string, error) {
+	if base != "" {
+		hash, err := repo.ResolveRevision(plumbing.Revision(base))
+		if err != nil {
+			return plumbing.ZeroHash, "", fmt.Errorf("base not found: %s", base)
+		}
+		return *hash, base, nil
+	}
+
+	hash := resolveDefaultBranchHash(repo)
+	if hash == plumbing.ZeroHash {
+		return plumbing.ZeroHash, "", errors.New("could not determine the default branch; pass the base branch explicitly")
+	}
+	name := strategy.GetDefaultBranchName(repo)
+	if name == "" {
+		name = hash.String()[:7]
+	}
+	return hash, name, nil
+}
+
+// buildPRDescription collects the committed checkpoints on HEAD since base,
+// generating missing summaries when requested, and aggregates attribution and tokens.
+func buildPRDescription(ctx context.Context, errW io.Writer, repo *git.Repository, base plumbing.Hash, generate bool) (*prDescription, error) {
+	points, err := getBranchCheckpointsSince(repo, commitScanLimit, computeReachableFrom(repo, base))
+	if err != nil {
+		return nil, err
+	}
+
+	store := checkpoint.NewGitStore(repo)
+	desc := &prDescription{}
+	seen := make(map[id.CheckpointID]bool)
+
+	// Points are newest first; the description reads oldest first
+	for _, point := range slices.Backward(points) {
+		// Temporary checkpoints are uncommitted work and not part of the PR
+		if !point.IsLogsOnly || point.CheckpointID.IsEmpty() || seen[point.CheckpointID] {
+			continue
+		}
+		seen[point.CheckpointID] = true
+
+		cp, err := readPRCheckpoint(ctx, errW, store, point, generate)
+		if err != nil {
+			return nil, err
+		}
+		desc.Checkpoints = append(desc.Checkpoints, *cp)
+		desc.TokenUsage = addTokenUsage(desc.TokenUsage, cp.TokenUsage)
+	}
+
+	if len(desc.Checkpoints) > 0 {
+		report, err := buildCICheckReport(ctx, repo, base.String()+"..HEAD", &settings.CISettings{})
+		if err != nil {
+			return nil, err
+		}
+		desc.AgentLines = report.AgentLines
+		desc.TotalLines = report.TotalLines
+	}
+
+	return desc, nil
+}
+
+// readPRCheckpoint loads the summary data for a checkpoint, generating an AI
+// summary first if it has none and generate is set. Generation failures are
+// reported on errW and the checkpoint falls back to its session prompt.
+func readPRCheckpoint(ctx context.Context, errW io.Writer, store *checkpoint.GitStore, point strategy.RewindPoint, generate bool) (*prCheckpoint, error) {
+	cpID := point.CheckpointID
+	cpSummary, err := store.ReadCommitted(ctx, cpID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+	}
+	if cpSummary == nil || len(cpSummary.Sessions) == 0 {
+		return nil, fmt.Errorf("checkpoint not found: %s", cpID)
+	}
+
+	cp := &prCheckpoint{
+		CheckpointID: cpID,
+		CommitHash:   point.ID,
+		Subject:      point.Message,
+		Prompt:       point.SessionPrompt,
+		FilesTouched: cpSummary.FilesTouched,
+		TokenUsage:   cpSummary.TokenUsage,
+	}
+
+	latest := len(cpSummary.Sessions) - 1
+	meta, err := store.ReadSessionMetadata(ctx, cpID, latest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+	}
+	cp.Summary = meta.Summary
+
+	if cp.Summary == nil && generate {
+		content, err := store.ReadLatestSessionContent(ctx, cpID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read checkpoint content: %w", err)
+		}
+		fmt.Fprintf(errW, "Generating summary for checkpoint %s...\n", cpID)
+		if err := generateCheckpointSummary(errW, errW, store, cpID, cpSummary, content, false); err != nil {
+			fmt.Fprintf(errW, "Warning: %v\n", err)
+			return cp, nil
+		}
+		meta, err = store.ReadSessionMetadata(ctx, cpID, latest)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reload checkpoint %s: %w", cpID, err)
+		}
+		cp.Summary = meta.Summary
+	}
+
+	return cp, nil
+}
+
+// addTokenUsage sums two token usages. Returns nil if both are nil.
+func addTokenUsage(a, b *agent.TokenUsage) *agent.TokenUsage {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &agent.TokenUsage{}
+	}
+	return &agent.TokenUsage{
+		InputTokens:         a.InputTokens + b.InputTokens,
+		CacheCreationTokens: a.CacheCreationTokens + b.CacheCreationTokens,
+		CacheReadTokens:     a.CacheReadTokens + b.CacheReadTokens,
+		OutputTokens:        a.OutputTokens + b.OutputTokens,
+		APICallCount:        a.APICallCount + b.APICallCount,
+	}
+}
+
+// intent returns the checkpoint's intent, falling back to its session prompt.
+func (cp *prCheckpoint) intent() string {
+	if cp.Summary != nil && cp.Summary.Intent != "" {
+		return cp.Summary.Intent
+	}
+	if cp.Prompt != "" {
+		return strategy.TruncateDescription(cp.Prompt, maxIntentDisplayLength)
+	}
+	return cp.Subject
+}
+
+// formatPRDescription renders the description as Markdown.
+func formatPRDescription(desc *prDescription) string {
+	var sb strings.Builder
+
+	sb.WriteString("## Summary\n\n")
+	var intents []string
+	for i := range desc.Checkpoints {
+		if intent := desc.Checkpoints[i].intent(); !slices.Contains(intents, intent) {
+			intents = append(intents, intent)
+		}
+	}
+	if len(intents) == 1 {
+		fmt.Fprintf(&sb, "%s\n", intents[0])
+	} else {
+		for _, intent := range intents {
+			fmt.Fprintf(&sb, "- %s\n", intent)
+		}
+	}
+
+	sb.WriteString("\n## Changes\n\n")
+	for i := range desc.Checkpoints {
+		cp := &desc.Checkpoints[i]
+		shortSHA := cp.CommitHash
+		if len(shortSHA) > 7 {
+			shortSHA = shortSHA[:7]
+		}
+		fmt.Fprintf(&sb, "- **%s** (`%s`, checkpoint `%s`)", cp.Subject, shortSHA, cp.CheckpointID)
+		if cp.Summary != nil && cp.Summary.Outcome != "" {
+			fmt.Fprintf(&sb, ": %s", cp.Summary.Outcome)
+		}
+		sb.WriteString("\n")
+		if n := len(cp.FilesTouched); n > 0 {
+			fmt.Fprintf(&sb, "  - Files: %s\n", formatFileList(cp.FilesTouched))
+		}
+	}
+
+	if desc.TotalLines > 0 {
+		agentPct := float64(desc.AgentLines) / float64(desc.TotalLines) * 100
+		humanLines := desc.TotalLines - desc.AgentLines
+		sb.WriteString("\n## Attribution\n\n")
+		sb.WriteString("| Author | Lines added | Share |\n")
+		sb.WriteString("| --- | ---: | ---: |\n")
+		fmt.Fprintf(&sb, "| Agent | %d | %.1f%% |\n", desc.AgentLines, agentPct)
+		fmt.Fprintf(&sb, "| Human | %d | %.1f%% |\n", humanLines, 100-agentPct)
+	}
+
+	if t := desc.TokenUsage; t != nil {
+		total := t.InputTokens + t.CacheCreationTokens + t.CacheReadTokens + t.OutputTokens
+		sb.WriteString("\n## Tokens\n\n")
+		fmt.Fprintf(&sb, "%d tokens over %d API calls (input %d, cache write %d, cache read %d, output %d)\n",
+			total, t.APICallCount, t.InputTokens, t.CacheCreationTokens, t.CacheReadTokens, t.OutputTokens)
+	}
+
+	var openItems []string
+	for i := range desc.Checkpoints {
+		if s := desc.Checkpoints[i].Summary; s != nil {
+			for _, item := range s.OpenItems {
+				if !slices.Contains(openItems, item) {
+					openItems = append(openItems, item)
+				}
+			}
+		}
+	}
+	if len(openItems) > 0 {
+		sb.WriteString("\n## Open Items\n\n")
+		for _, item := range openItems {
+			fmt.Fprintf(&sb, "- [ ] %s\n", item)
+		}
+	}
+
+	fmt.Fprintf(&sb, "\n---\n<sub>Generated by `entire pr describe` from %d checkpoint(s) since `%s`.</sub>\n",
+		len(desc.Checkpoints), desc.Base)
+
+	return sb.String()
+}
+
+// maxPRFilesListed caps the per-checkpoint file list in the description.
+const maxPRFilesListed = 5
+
+// formatFileList renders files as inline code, eliding long lists.
+func formatFileList(files []string) string {
+	shown := files
+	if len(shown) > maxPRFilesListed {
+		shown = shown[:maxPRFilesListed]
+	}
+	quoted := make([]string, 0, len(shown))
+	for _, f := range shown {
+		quoted = append(quoted, "`"+f+"`")
+	}
+	result := strings.Join(quoted, ", ")
+	if extra := len(files) - len(shown); extra > 0 {
+		result += fmt.Sprintf(" and %d more", extra)
+	}
+	return result
+}
@@ -0,0 +1,140 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+)
+
+func TestFormatPRDescription(t *testing.T) {
+	t.Parallel()
+
+	desc := &prDescription{
+		Base: "main",
+		Checkpoints: []prCheckpoint{
+			{
+				CheckpointID: id.MustCheckpointID("a1b2c3d4e5f6"),
+				CommitHash:   "1111111111111111111111111111111111111111",
+				Subject:      "Add login form",
+				Summary: &checkpoint.Summary{
+					Intent:    "Add a login page",
+					Outcome:   "Login form with validation",
+					OpenItems: []string{"Add rate limiting"},
+				},
+				FilesTouched: []string{"a.go", "b.go", "c.go", "d.go", "e.go", "f.go"},
+			},
+			{
+				CheckpointID: id.MustCheckpointID("b2c3d4e5f6a1"),
+				CommitHash:   "2222222222222222222222222222222222222222",
+				Subject:      "Fix tests",
+				Prompt:       "fix the failing login tests",
+				Summary:      &checkpoint.Summary{OpenItems: []string{"Add rate limiting", "Document the API"}},
+			},
+		},
+		AgentLines: 30,
+		TotalLines: 40,
+		TokenUsage: &agent.TokenUsage{InputTokens: 100, OutputTokens: 50, APICallCount: 3},
+	}
+
+	output := formatPRDescription(desc)
+
+	for _, want := range []string{
+		"## Summary\n\n- Add a login page\n- fix the failing login tests\n",
+		"- **Add login form** (`1111111`, checkpoint `a1b2c3d4e5f6`): Login form with validation\n",
+		"  - Files: `a.go`, `b.go`, `c.go`, `d.go`, `e.go` and 1 more\n",
+		"- **Fix tests** (`2222222`, checkpoint `b2c3d4e5f6a1`)\n",
+		"| Agent | 30 | 75.0% |",
+		"| Human | 10 | 25.0% |",
+		"150 tokens over 3 API calls",
+		"## Open Items\n\n- [ ] Add rate limiting\n- [ ] Document the API\n",
+		"from 2 checkpoint(s) since `main`",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+}
+
+func TestFormatPRDescription_SingleIntentNoAttribution(t *testing.T) {
+	t.Parallel()
+
+	desc := &prDescription{
+		Base: "main",
+		Checkpoints: []prCheckpoint{
+			{CheckpointID: id.MustCheckpointID("a1b2c3d4e5f6"), CommitHash: "1111111", Subject: "Only change"},
+		},
+	}
+
+	output := formatPRDescription(desc)
+
+	if !strings.Contains(output, "## Summary\n\nOnly change\n") {
+		t.Errorf("expected single intent paragraph, got:\n%s", output)
+	}
+	for _, unwanted := range []string{"## Attribution", "## Tokens", "## Open Items"} {
+		if strings.Contains(output, unwanted) {
+			t.Errorf("unexpected %q in output:\n%s", unwanted, output)
+		}
+	}
+}
+
+func TestRunPRDescribe(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+	if _, err := repo.CreateTag("base", initial, nil); err != nil {
+		t.Fatalf("failed to tag base: %v", err)
+	}
+
+	cpID := id.MustCheckpointID("a1b2c3d4e5f6")
+	writeAttributedCheckpoint(t, repo, cpID, 3, 4)
+	err := checkpoint.NewGitStore(repo).UpdateSummary(context.Background(), cpID, &checkpoint.Summary{
+		Intent:    "Add the widget",
+		Outcome:   "Widget added",
+		OpenItems: []string{"Write docs"},
+	})
+	if err != nil {
+		t.Fatalf("UpdateSummary() error = %v", err)
+	}
+	commitFile(t, repo, dir, "widget.go", "a\nb\nc\nd\n", trailers.FormatCheckpoint("Add widget", cpID))
+	commitFile(t, repo, dir, "human.go", "1\n2\n3\n4\n", "Human change")
+
+	var stdout, stderr bytes.Buffer
+	if err := runPRDescribe(&stdout, &stderr, "base", false); err != nil {
+		t.Fatalf("runPRDescribe() error = %v\nstderr: %s", err, stderr.String())
+	}
+	output := stdout.String()
+
+	for _, want := range []string{
+		"## Summary\n\nAdd the widget\n",
+		"- **Add widget**",
+		": Widget added",
+		"| Agent | 3 | 37.5% |",
+		"- [ ] Write docs",
+		"from 1 checkpoint(s) since `base`",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "Human change") {
+		t.Errorf("commits without checkpoints should not be listed, got:\n%s", output)
+	}
+}
+
+func TestRunPRDescribe_NoCheckpoints(t *testing.T) {
+	repo, dir, initial := setupCITestRepo(t)
+	if _, err := repo.CreateTag("base", initial, nil); err != nil {
+		t.Fatalf("failed to tag base: %v", err)
+	}
+	commitFile(t, repo, dir, "human.go", "1\n", "Human change")
+
+	var stdout, stderr bytes.Buffer
+	err := runPRDescribe(&stdout, &stderr, "base", false)
+	if err == nil || !strings.Contains(err.Error(), "no committed checkpoints") {
+		t.Errorf("runPRDescribe() error = %v, want no committed checkpoints", err)
+	}
+}
@@ -0,0 +1,191 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/charmbracelet/huh"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+func newPruneCmd() *cobra.Command {
+	var dryRunFlag bool
+	var forceFlag bool
+	var maxAgeFlag string
+	var maxSizeFlag string
+	var keepLastFlag int
+	var keepSummariesFlag bool
+
+	cmd := &cobra.Command{
+		Use:   "prune",
+		Short: "Enforce retention rules on committed checkpoints",
+		Long: `Prune removes old checkpoint data from the entire/checkpoints/v1 branch
+according to the retention rules in .entire/settings.json:
+
+  {
+    "retention": {
+      "max_age": "90d",              // prune checkpoints older than this
+      "max_total_size": "500MB",     // prune oldest checkpoints beyond this size
+      "keep_last_per_branch": 50,    // keep only the N newest per branch
+      "keep_summaries": true         // drop transcripts but keep metadata/summaries
+    }
+  }
+
+Each rule can be overridden for a single run with the matching flag.
+
+Checkpoints that are still referenced by an Entire-Checkpoint commit trailer,
+on any branch, remote-tracking branch, tag or unlanded squash branch, always
+keep their metadata.json records, so 'entire explain --commit' keeps
+resolving them. Only their transcripts, prompts and context are removed.
+
+Prune rewrites the history of the local entire/checkpoints/v1 branch so that
+the removed data is no longer reachable. Run 'git gc' afterwards to reclaim
+disk space. If the branch has been pushed, force-push it to update the remote
+copy; otherwise the next sync merges the pruned data back in.
+
+Use --dry-run to preview what would be pruned.
+Without --force, prompts for confirmation before rewriting the branch.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+
+			s, err := LoadEntireSettings()
+			if err != nil {
+				return err
+			}
+			retention := settings.RetentionSettings{}
+			if s.Retention != nil {
+				retention = *s.Retention
+			}
+			if cmd.Flags().Changed("max-age") {
+				retention.MaxAge = maxAgeFlag
+			}
+			if cmd.Flags().Changed("max-size") {
+				retention.MaxTotalSize = maxSizeFlag
+			}
+			if cmd.Flags().Changed("keep-last") {
+				retention.KeepLastPerBranch = keepLastFlag
+			}
+			if cmd.Flags().Changed("keep-summaries") {
+				retention.KeepSummaries = keepSummariesFlag
+			}
+
+			policy, err := strategy.RetentionPolicyFromSettings(&retention)
+			if err != nil {
+				return fmt.Errorf("invalid retention settings: %w", err)
+			}
+
+			return runPrune(cmd.OutOrStdout(), policy, dryRunFlag, forceFlag)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRunFlag, "dry-run", false, "Show what would be pruned without changing anything")
+	cmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Skip confirmation prompt")
+	cmd.Flags().StringVar(&maxAgeFlag, "max-age", "", "Override retention.max_age (e.g. 90d, 2w, 720h)")
+	cmd.Flags().StringVar(&maxSizeFlag, "max-size", "", "Override retention.max_total_size (e.g. 500MB)")
+	cmd.Flags().IntVar(&keepLastFlag, "keep-last", 0, "Override retention.keep_last_per_branch")
+	cmd.Flags().BoolVar(&keepSummariesFlag, "keep-summaries", false, "Override retention.keep_summaries")
+
+	return cmd
+}
+
+func runPrune(w io.Writer, policy strategy.RetentionPolicy, dryRun, force bool) error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	if policy.IsEmpty() {
+		fmt.Fprintln(w, "No retention rules configured.")
+		fmt.Fprintln(w, "Add a \"retention\" section to .entire/settings.json or pass --max-age, --max-size or --keep-last.")
+		return NewSilentError(strategy.ErrNoRetentionPolicy)
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+
+	plan, err := strategy.PlanPrune(repo, policy, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to plan prune: %w", err)
+	}
+
+	if len(plan.Items) == 0 {
+		fmt.Fprintf(w, "Nothing to prune (%s of checkpoint data).\n", strategy.FormatByteSize(plan.TotalBytes))
+		return nil
+	}
+
+	writePrunePlan(w, plan)
+
+	if dryRun {
+		fmt.Fprintln(w, "\nDry run: no changes made.")
+		return nil
+	}
+
+	if !force {
+		var confirmed bool
+		form := NewAccessibleForm(
+			huh.NewGroup(
+				huh.NewConfirm().
+					Title(fmt.Sprintf("Prune %d checkpoints and rewrite %s?", len(plan.Items), paths.MetadataBranchName)).
+					Value(&confirmed),
+			),
+		)
+		if err := form.Run(); err != nil {
+			if errors.Is(err, huh.ErrUserAborted) {
+				return nil
+			}
+			return fmt.Errorf("failed to get confirmation: %w", err)
+		}
+		if !confirmed {
+			return nil
+		}
+	}
+
+	result, err := strategy.ApplyPrunePlan(repo, plan)
+	if err != nil {
+		return fmt.Errorf("failed to prune checkpoints: %w", err)
+	}
+
+	fmt.Fprintf(w, "\n✓ Pruned %d checkpoints (%d metadata commits rewritten)\n", len(plan.Items), result.RewrittenCommits)
+	fmt.Fprintln(w, "  Run 'git gc --prune=now' to reclaim disk space.")
+
+	remoteRef := plumbing.NewRemoteReferenceName("origin", paths.MetadataBranchName)
+	if _, err := repo.Reference(remoteRef, true); err == nil {
+		fmt.Fprintf(w, "  The remote still has the pruned data. Update it with:\n")
+		fmt.Fprintf(w, "    git push --force-with-lease origin %s\n", paths.MetadataBranchName)
+	}
+
+	return nil
+}
+
+// writePrunePlan prints the checkpoints selected for pruning.
+func writePrunePlan(w io.Writer, plan *strategy.PrunePlan) {
+	fmt.Fprintf(w, "Checkpoints to prune (%d):\n\n", len(plan.Items))
+	for _, item := range plan.Items {
+		action := "delete"
+		if item.Action == strategy.PruneActionStrip {
+			action = "drop transcript"
+		}
+		created := "unknown date"
+		if !item.CreatedAt.IsZero() {
+			created = item.CreatedAt.Local().Format("2006-01-02")
+		}
+		fmt.Fprintf(w, "  %s  %s  %-15s  %9s  %s\n",
+			item.CheckpointID, created, action, strategy.FormatByteSize(item.FreedBytes), item.Reason)
+	}
+	fmt.Fprintf(w, "\nWould free %s of %s.\n",
+		strategy.FormatByteSize(plan.FreedBytes), strategy.FormatByteSize(plan.TotalBytes))
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+)
+
+func TestRunPrune_NoPolicy(t *testing.T) {
+	setupCleanTestRepo(t)
+
+	var stdout bytes.Buffer
+	err := runPrune(&stdout, strategy.RetentionPolicy{}, false, true)
+
+	var silentErr *SilentError
+	if !errors.As(err, &silentErr) {
+		t.Fatalf("runPrune() error = %v, want SilentError", err)
+	}
+	if !errors.Is(err, strategy.ErrNoRetentionPolicy) {
+		t.Errorf("runPrune() error = %v, want ErrNoRetentionPolicy", err)
+	}
+	if !strings.Contains(stdout.String(), "No retention rules configured") {
+		t.Errorf("Expected 'No retention rules configured' message, got: %s", stdout.String())
+	}
+}
+
+func TestRunPrune_NothingToPrune(t *testing.T) {
+	setupCleanTestRepo(t)
+
+	var stdout bytes.Buffer
+	if err := runPrune(&stdout, strategy.RetentionPolicy{MaxAge: time.Hour}, false, true); err != nil {
+		t.Fatalf("runPrune() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "Nothing to prune") {
+		t.Errorf("Expected 'Nothing to prune' message, got: %s", stdout.String())
+	}
+}
+
+func TestRunPrune_DryRunKeepsData(t *testing.T) {
+	repo, _ := setupCleanTestRepo(t)
+
+	cpID := id.MustCheckpointID("a1b2c3d4e5f6")
+	store := checkpoint.NewGitStore(repo)
+	err := store.WriteCommitted(context.Background(), checkpoint.WriteCommittedOptions{
+		CheckpointID: cpID,
+		SessionID:    "session-1",
+		Strategy:     strategy.StrategyNameManualCommit,
+		Branch:       "master",
+		Transcript:   []byte(`{"type":"user","message":{"content":"hello"}}` + "\n"),
+		AuthorName:   "Test",
+		AuthorEmail:  "test@test.com",
+	})
+	if err != nil {
+		t.Fatalf("WriteCommitted() error = %v", err)
+	}
+
+	var stdout bytes.Buffer
+	if err := runPrune(&stdout, strategy.RetentionPolicy{MaxTotalSize: 1}, true, false); err != nil {
+		t.Fatalf("runPrune() error = %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, cpID.String()) {
+		t.Errorf("Expected checkpoint %s in plan, got: %s", cpID, output)
+	}
+	if !strings.Contains(output, "Dry run") {
+		t.Errorf("Expected 'Dry run' message, got: %s", output)
+	}
+
+	content, err := store.ReadSessionContent(context.Background(), cpID, 0)
+	if err != nil {
+		t.Fatalf("ReadSessionContent() error = %v", err)
+	}
+	if len(content.Transcript) == 0 {
+		t.Error("dry run should not remove the transcript")
+	}
+}
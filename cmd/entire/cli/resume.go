@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/entireio/cli/cmd/entire/cli/agent"
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
@@ -20,14 +23,17 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
 func newResumeCmd() *cobra.Command {
 	var force bool
+	var agentName string
+	var at resumeAtOptions
 
 	cmd := &cobra.Command{
-		Use:   "resume <branch>",
+		Use:   "resume [branch]",
 		Short: "Switch to a branch and resume its session",
 		Long: `Switch to a local branch and resume the agent session from its last commit.
 
@@ -42,27 +48,65 @@ to fetch it.
 
 If newer commits without checkpoints exist on the branch (e.g., after merging main
 or cherry-picking from elsewhere), this operation will reset your Git status to the
-most recent commit with a checkpoint.  You'll be prompted to confirm resuming in this case.`,
-		Args: cobra.ExactArgs(1),
+most recent commit with a checkpoint.  You'll be prompted to confirm resuming in this case.
+
+To resume an older session without switching branches, pass --checkpoint
+(a checkpoint ID or unique prefix) or --commit (any commit with an
+Entire-Checkpoint trailer) instead of a branch. The transcripts of all
+sessions in that checkpoint are restored. Add --new-branch to also check out
+the linked commit on a new branch, or --worktree to check it out in a new
+worktree (on --new-branch if given, otherwise detached):
+
+  entire resume --checkpoint a3b2c4d5e6f7
+  entire resume --commit 1f2e3d4 --new-branch retry-login
+  entire resume --checkpoint a3b2c4 --worktree ../retry --new-branch retry
+
+Use --agent to continue the session in a different agent than the one that
+created it. The latest session in the checkpoint is converted to that agent's
+format and written as a new session, leaving the original untouched. Messages
+and common tool calls (file reads, writes, edits, shell commands, searches)
+carry over; tools the other agent doesn't have are kept as text notes.
+
+  entire resume --agent gemini feature/login`,
+		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := at.validate(args); err != nil {
+				return err
+			}
 			if checkDisabledGuard(cmd.OutOrStdout()) {
 				return nil
 			}
-			return runResume(args[0], force)
+			if agentName != "" {
+				if _, err := agent.Get(agent.AgentName(agentName)); err != nil {
+					return fmt.Errorf("invalid --agent: %w", err)
+				}
+			}
+			if at.checkpoint != "" || at.commit != "" {
+				return runResumeAt(at, force, agent.AgentName(agentName))
+			}
+			return runResume(args[0], force, agent.AgentName(agentName))
 		},
 	}
 
 	cmd.Flags().BoolVarP(&force, "force", "f", false, "Resume from older checkpoint without confirmation")
+	cmd.Flags().StringVar(&agentName, "agent", "", "Convert the session to this agent and resume it there (e.g. gemini)")
+	cmd.Flags().StringVar(&at.checkpoint, "checkpoint", "", "Resume the sessions of this checkpoint ID (or unique prefix)")
+	cmd.Flags().StringVar(&at.commit, "commit", "", "Resume the sessions of the checkpoint linked to this commit")
+	cmd.Flags().StringVar(&at.newBranch, "new-branch", "", "With --checkpoint/--commit, check out the linked commit on this new branch")
+	cmd.Flags().StringVar(&at.worktree, "worktree", "", "With --checkpoint/--commit, check out the linked commit in a new worktree at this path")
+	cmd.MarkFlagsMutuallyExclusive("checkpoint", "commit")
 
 	return cmd
 }
 
-func runResume(branchName string, force bool) error {
+// runResume switches to branchName and resumes its session. If targetAgent is
+// set, the session is converted to that agent instead of restored as-is.
+func runResume(branchName string, force bool, targetAgent agent.AgentName) error {
 	// Check if we're already on this branch
 	currentBranch, err := GetCurrentBranch()
 	if err == nil && currentBranch == branchName {
 		// Already on the branch, skip checkout
-		return resumeFromCurrentBranch(branchName, force)
+		return resumeFromCurrentBranch(branchName, force, targetAgent)
 	}
 
 	// Check if branch exists locally
@@ -116,10 +160,10 @@ func runResume(branchName string, force bool) error {
 		fmt.Fprintf(os.Stderr, "Switched to branch '%s'\n", branchName)
 	}
 
-	return resumeFromCurrentBranch(branchName, force)
+	return resumeFromCurrentBranch(branchName, force, targetAgent)
 }
 
-func resumeFromCurrentBranch(branchName string, force bool) error {
+func resumeFromCurrentBranch(branchName string, force bool, targetAgent agent.AgentName) error {
 	repo, err := openRepository()
 	if err != nil {
 		return fmt.Errorf("not a git repository: %w", err)
@@ -152,23 +196,237 @@ func resumeFromCurrentBranch(branchName string, force bool) error {
 		}
 	}
 
-	checkpointID := result.checkpointID
+	return resumeFromCheckpointID(repo, result.checkpointID, force, targetAgent)
+}
 
+// resumeFromCheckpointID resumes the sessions of a committed checkpoint,
+// fetching its metadata from origin if it isn't available locally.
+func resumeFromCheckpointID(repo *git.Repository, checkpointID id.CheckpointID, force bool, targetAgent agent.AgentName) error {
 	// Get metadata branch tree for lookups
 	metadataTree, err := strategy.GetMetadataBranchTree(repo)
 	if err != nil {
 		// No local metadata branch, check if remote has it
-		return checkRemoteMetadata(repo, checkpointID)
+		return checkRemoteMetadata(repo, checkpointID, targetAgent)
 	}
 
 	// Look up metadata from sharded path
 	metadata, err := strategy.ReadCheckpointMetadata(metadataTree, checkpointID.Path())
 	if err != nil {
 		// Checkpoint exists in commit but no local metadata - check remote
-		return checkRemoteMetadata(repo, checkpointID)
+		return checkRemoteMetadata(repo, checkpointID, targetAgent)
+	}
+
+	return resumeCheckpoint(metadata.SessionID, checkpointID, force, targetAgent)
+}
+
+// resumeAtOptions selects a checkpoint to resume directly, instead of the
+// latest checkpoint on a branch.
+type resumeAtOptions struct {
+	checkpoint string // checkpoint ID or unique prefix
+	commit     string // commit with an Entire-Checkpoint trailer
+	newBranch  string // optional branch to create at the linked commit
+	worktree   string // optional path for a new worktree at the linked commit
+}
+
+// validate checks that exactly one of a branch argument, --checkpoint or
+// --commit was given, and that checkout flags are only used with the latter.
+func (o resumeAtOptions) validate(args []string) error {
+	direct := o.checkpoint != "" || o.commit != ""
+	switch {
+	case len(args) > 0 && direct:
+		return errors.New("pass either a branch or --checkpoint/--commit, not both")
+	case len(args) == 0 && !direct:
+		return errors.New("specify a branch, --checkpoint, or --commit")
+	case !direct && (o.newBranch != "" || o.worktree != ""):
+		return errors.New("--new-branch and --worktree can only be used with --checkpoint or --commit")
+	}
+	return nil
+}
+
+// runResumeAt restores the sessions of the checkpoint selected by opts,
+// optionally checking out its linked commit on a new branch or worktree first.
+func runResumeAt(opts resumeAtOptions, force bool, targetAgent agent.AgentName) error {
+	repo, err := openRepository()
+	if err != nil {
+		return errors.New("not a git repository")
+	}
+
+	var checkpointID id.CheckpointID
+	var commitHash string
+	if opts.commit != "" {
+		checkpointID, commitHash, err = resolveResumeCommit(repo, opts.commit)
+	} else {
+		checkpointID, err = resolveResumeCheckpoint(repo, opts.checkpoint)
+	}
+	if err != nil {
+		return err
+	}
+
+	if opts.newBranch == "" && opts.worktree == "" {
+		return resumeFromCheckpointID(repo, checkpointID, force, targetAgent)
+	}
+
+	if commitHash == "" {
+		commitHash, err = findCheckpointCommit(checkpointID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.worktree != "" {
+		worktreePath, err := addResumeWorktree(commitHash, opts.worktree, opts.newBranch)
+		if err != nil {
+			return err
+		}
+		// Restore from inside the worktree so agents file the sessions under its path
+		if err := os.Chdir(worktreePath); err != nil {
+			return fmt.Errorf("failed to enter worktree: %w", err)
+		}
+		repo, err = openRepository()
+		if err != nil {
+			return err
+		}
+		if err := resumeFromCheckpointID(repo, checkpointID, force, targetAgent); err != nil {
+			return err
+		}
+		fmt.Fprintf(os.Stderr, "\nRun the command from the new worktree:\n  cd %s\n", worktreePath)
+		return nil
+	}
+
+	hasChanges, err := HasUncommittedChanges()
+	if err != nil {
+		return fmt.Errorf("failed to check for uncommitted changes: %w", err)
+	}
+	if hasChanges {
+		return errors.New("you have uncommitted changes. Please commit or stash them first")
+	}
+	if err := CreateBranchAt(opts.newBranch, commitHash); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create branch: %v\n", err)
+		return NewSilentError(errors.New("failed to create branch"))
+	}
+	fmt.Fprintf(os.Stderr, "Switched to a new branch '%s' at %s\n", opts.newBranch, commitHash[:7])
+
+	return resumeFromCheckpointID(repo, checkpointID, force, targetAgent)
+}
+
+// resolveResumeCommit returns the checkpoint linked to a commit by its
+// Entire-Checkpoint trailer, and the commit's full hash.
+func resolveResumeCommit(repo *git.Repository, ref string) (id.CheckpointID, string, error) {
+	hash, err := repo.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return "", "", fmt.Errorf("commit not found: %s", ref)
+	}
+	commit, err := repo.CommitObject(*hash)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to read commit %s: %w", ref, err)
 	}
+	checkpointID, found := latestCheckpoint(commit.Message)
+	if !found {
+		return "", "", fmt.Errorf("commit %s has no %s trailer", hash.String()[:7], trailers.CheckpointTrailerKey)
+	}
+	return checkpointID, hash.String(), nil
+}
 
-	return resumeSession(metadata.SessionID, checkpointID, force)
+// latestCheckpoint returns the last checkpoint a commit message links to.
+// Squashed commits carry one Entire-Checkpoint trailer per turn, oldest
+// first, and the last one has the session's full transcript.
+func latestCheckpoint(commitMessage string) (id.CheckpointID, bool) {
+	cpIDs := trailers.ParseAllCheckpoints(commitMessage)
+	if len(cpIDs) == 0 {
+		return id.EmptyCheckpointID, false
+	}
+	return cpIDs[len(cpIDs)-1], true
+}
+
+// resolveResumeCheckpoint expands a checkpoint ID prefix using the local
+// metadata branch. A full ID that isn't known locally is returned as-is so
+// its metadata can be fetched from origin.
+func resolveResumeCheckpoint(repo *git.Repository, prefix string) (id.CheckpointID, error) {
+	committed, err := checkpoint.NewGitStore(repo).ListCommitted(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("failed to list checkpoints: %w", err)
+	}
+
+	var matches []id.CheckpointID
+	for _, info := range committed {
+		if strings.HasPrefix(info.CheckpointID.String(), prefix) {
+			matches = append(matches, info.CheckpointID)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		if cpID, err := id.NewCheckpointID(prefix); err == nil {
+			return cpID, nil
+		}
+		return "", fmt.Errorf("checkpoint not found: %s", prefix)
+	case 1:
+		return matches[0], nil
+	default:
+		examples := make([]string, 0, 5)
+		for i := 0; i < len(matches) && i < 5; i++ {
+			examples = append(examples, matches[i].String())
+		}
+		return "", fmt.Errorf("ambiguous checkpoint prefix %q matches %d checkpoints: %s", prefix, len(matches), strings.Join(examples, ", "))
+	}
+}
+
+// findCheckpointCommit returns the most recent commit on any local branch
+// whose Entire-Checkpoint trailer matches checkpointID. Entire's own
+// branches are skipped.
+func findCheckpointCommit(checkpointID id.CheckpointID) (string, error) {
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", "log", "--exclude=entire/*", "--branches", "-F",
+		"--grep="+trailers.CheckpointTrailerKey+": "+checkpointID.String(), "--format=%H%x00%B%x00")
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to search commits: %w", err)
+	}
+
+	fields := strings.Split(string(output), "\x00")
+	for i := 0; i+1 < len(fields); i += 2 {
+		hash := strings.TrimSpace(fields[i])
+		// --grep matches anywhere in the message, so confirm it's the trailer
+		if slices.Contains(trailers.ParseAllCheckpoints(fields[i+1]), checkpointID) {
+			return hash, nil
+		}
+	}
+	return "", fmt.Errorf("no commit found for checkpoint %s; use --commit to pick one", checkpointID)
+}
+
+// addResumeWorktree creates a worktree at path checked out at commitHash,
+// on a new branch if one is given and detached otherwise. Returns the
+// worktree's absolute path.
+func addResumeWorktree(commitHash, path, branch string) (string, error) {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return "", fmt.Errorf("invalid worktree path: %w", err)
+	}
+
+	args := []string{"worktree", "add"}
+	if branch != "" {
+		if err := ValidateBranchName(branch); err != nil {
+			return "", err
+		}
+		args = append(args, "-b", branch)
+	} else {
+		args = append(args, "--detach")
+	}
+	args = append(args, "--", absPath, commitHash)
+
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create worktree: %s\n", strings.TrimSpace(string(output)))
+		return "", NewSilentError(errors.New("failed to create worktree"))
+	}
+
+	if branch != "" {
+		fmt.Fprintf(os.Stderr, "Created worktree at %s on new branch '%s'\n", absPath, branch)
+	} else {
+		fmt.Fprintf(os.Stderr, "Created worktree at %s (detached at %s)\n", absPath, commitHash[:7])
+	}
+	return absPath, nil
 }
 
 // branchCheckpointResult contains the result of searching for a checkpoint on a branch.
@@ -198,7 +456,7 @@ func findBranchCheckpoint(repo *git.Repository, branchName string) (*branchCheck
 	}
 
 	// First, check if HEAD itself has a checkpoint (most common case)
-	if cpID, found := trailers.ParseCheckpoint(headCommit.Message); found {
+	if cpID, found := latestCheckpoint(headCommit.Message); found {
 		result.checkpointID = cpID
 		result.commitHash = head.Hash().String()
 		result.commitMessage = headCommit.Message
@@ -267,7 +525,7 @@ func findCheckpointInHistory(start *object.Commit, stopAt *plumbing.Hash) *branc
 		}
 
 		// Check for checkpoint trailer
-		if cpID, found := trailers.ParseCheckpoint(current.Message); found {
+		if cpID, found := latestCheckpoint(current.Message); found {
 			result.checkpointID = cpID
 			result.commitHash = current.Hash.String()
 			result.commitMessage = current.Message
@@ -324,7 +582,7 @@ func promptResumeFromOlderCheckpoint() (bool, error) {
 
 // checkRemoteMetadata checks if checkpoint metadata exists on origin/entire/checkpoints/v1
 // and automatically fetches it if available.
-func checkRemoteMetadata(repo *git.Repository, checkpointID id.CheckpointID) error {
+func checkRemoteMetadata(repo *git.Repository, checkpointID id.CheckpointID, targetAgent agent.AgentName) error {
 	// Try to get remote metadata branch tree
 	remoteTree, err := strategy.GetRemoteMetadataBranchTree(repo)
 	if err != nil {
@@ -349,7 +607,115 @@ func checkRemoteMetadata(repo *git.Repository, checkpointID id.CheckpointID) err
 	}
 
 	// Now resume the session with the fetched metadata
-	return resumeSession(metadata.SessionID, checkpointID, false)
+	return resumeCheckpoint(metadata.SessionID, checkpointID, false, targetAgent)
+}
+
+// resumeCheckpoint resumes the checkpoint's session, converting it to
+// targetAgent first when one is given.
+func resumeCheckpoint(sessionID string, checkpointID id.CheckpointID, force bool, targetAgent agent.AgentName) error {
+	if targetAgent == "" {
+		return resumeSession(sessionID, checkpointID, force)
+	}
+	return resumeSessionAs(sessionID, checkpointID, force, targetAgent)
+}
+
+// resumeSessionAs converts the checkpoint's latest session to targetAgent's
+// format and writes it as a new session in that agent's storage. If the
+// checkpoint was already made by targetAgent, this is a normal resume.
+func resumeSessionAs(sessionID string, checkpointID id.CheckpointID, force bool, targetAgent agent.AgentName) error {
+	repo, err := openRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open repository: %w", err)
+	}
+
+	metadataTree, err := strategy.GetMetadataBranchTree(repo)
+	if err != nil {
+		return fmt.Errorf("failed to get metadata branch: %w", err)
+	}
+
+	metadata, err := strategy.ReadCheckpointMetadata(metadataTree, checkpointID.Path())
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint metadata: %w", err)
+	}
+
+	target, err := agent.Get(targetAgent)
+	if err != nil {
+		return fmt.Errorf("failed to resolve agent: %w", err)
+	}
+
+	source, err := strategy.ResolveAgentForRewind(metadata.Agent)
+	if err != nil {
+		return fmt.Errorf("failed to resolve agent: %w", err)
+	}
+	if source.Name() == target.Name() {
+		return resumeSession(sessionID, checkpointID, force)
+	}
+
+	ctx := logging.WithAgent(logging.WithComponent(context.Background(), "resume"), target.Name())
+
+	// Multi-session checkpoints are converted from their latest session only
+	content, err := checkpoint.NewGitStore(repo).ReadLatestSessionContent(ctx, checkpointID)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint session: %w", err)
+	}
+	if len(content.Transcript) == 0 {
+		return fmt.Errorf("checkpoint %s has no session transcript to convert", checkpointID)
+	}
+	if content.Metadata.Agent != "" {
+		if sessionAgent, agentErr := strategy.ResolveAgentForRewind(content.Metadata.Agent); agentErr == nil {
+			source = sessionAgent
+		}
+	}
+	if content.Metadata.SessionID != "" {
+		sessionID = content.Metadata.SessionID
+	}
+
+	repoRoot, err := paths.RepoRoot()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	sessionDir, err := target.GetSessionDir(repoRoot)
+	if err != nil {
+		return fmt.Errorf("failed to determine session directory: %w", err)
+	}
+	if err := os.MkdirAll(sessionDir, 0o700); err != nil {
+		return fmt.Errorf("failed to create session directory: %w", err)
+	}
+
+	converted, err := agent.ConvertSession(&agent.AgentSession{
+		SessionID:  sessionID,
+		AgentName:  source.Name(),
+		RepoPath:   repoRoot,
+		NativeData: content.Transcript,
+	}, target, uuid.NewString(), sessionDir)
+	if err != nil {
+		return fmt.Errorf("failed to convert session: %w", err)
+	}
+
+	if err := target.WriteSession(converted); err != nil {
+		logging.Error(ctx, "resume session conversion failed during write",
+			slog.String("checkpoint_id", checkpointID.String()),
+			slog.String("session_id", sessionID),
+			slog.String("error", err.Error()),
+		)
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	logging.Debug(ctx, "resume session converted",
+		slog.String("checkpoint_id", checkpointID.String()),
+		slog.String("source_session_id", sessionID),
+		slog.String("session_id", converted.SessionID),
+		slog.String("source_agent", string(source.Name())),
+		slog.Int("entries", len(converted.Entries)),
+	)
+
+	fmt.Fprintf(os.Stderr, "Session converted from %s to %s: %s\n", source.Type(), target.Type(), converted.SessionRef)
+	fmt.Fprintf(os.Stderr, "Session: %s (from %s)\n", converted.SessionID, sessionID)
+	fmt.Fprintf(os.Stderr, "\nTo continue this session, run:\n")
+	fmt.Fprintf(os.Stderr, "  %s\n", target.FormatResumeCommand(converted.SessionID))
+
+	return nil
 }
 
 // resumeSession restores and displays the resume command for a specific session.
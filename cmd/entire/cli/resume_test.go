@@ -3,13 +3,18 @@ package cli
 import (
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/agent/geminicli"
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -173,7 +178,7 @@ func TestResumeFromCurrentBranch_NoCheckpoint(t *testing.T) {
 	setupResumeTestRepo(t, tmpDir, false)
 
 	// Run resumeFromCurrentBranch - should not error, just report no checkpoint found
-	err := resumeFromCurrentBranch("master", false)
+	err := resumeFromCurrentBranch("master", false, "")
 	if err != nil {
 		t.Errorf("resumeFromCurrentBranch() returned error for commit without checkpoint: %v", err)
 	}
@@ -229,7 +234,7 @@ func TestResumeFromCurrentBranch_WithEntireCheckpointTrailer(t *testing.T) {
 	}
 
 	// Run resumeFromCurrentBranch
-	err := resumeFromCurrentBranch("master", false)
+	err := resumeFromCurrentBranch("master", false, "")
 	if err != nil {
 		t.Errorf("resumeFromCurrentBranch() returned error: %v", err)
 	}
@@ -247,6 +252,235 @@ func TestResumeFromCurrentBranch_WithEntireCheckpointTrailer(t *testing.T) {
 	}
 }
 
+func TestResumeFromCurrentBranch_ConvertsToOtherAgent(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	claudeDir := filepath.Join(tmpDir, "claude-projects")
+	geminiDir := filepath.Join(tmpDir, "gemini-chats")
+	t.Setenv("ENTIRE_TEST_CLAUDE_PROJECT_DIR", claudeDir)
+	t.Setenv("ENTIRE_TEST_GEMINI_PROJECT_DIR", geminiDir)
+
+	_, _, _ = setupResumeTestRepo(t, tmpDir, false)
+
+	sessionID := "4f8c1176-7025-4530-a860-c6fc4c63a150"
+	sessionLogContent := `{"type":"user","uuid":"u1","message":{"role":"user","content":"write test.txt"}}
+{"type":"assistant","uuid":"a1","message":{"role":"assistant","content":[{"type":"tool_use","id":"toolu_1","name":"Write","input":{"file_path":"test.txt","content":"metadata content"}}]}}
+{"type":"user","uuid":"u2","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"toolu_1","content":"ok"}]}}
+{"type":"assistant","uuid":"a2","message":{"role":"assistant","content":[{"type":"text","text":"Done."}]}}
+`
+	saveResumeTestCheckpoint(t, tmpDir, sessionID, sessionLogContent, "metadata content")
+
+	if err := resumeFromCurrentBranch("master", false, agent.AgentNameGemini); err != nil {
+		t.Fatalf("resumeFromCurrentBranch() returned error: %v", err)
+	}
+
+	// The Claude session isn't restored; a new Gemini session is written instead
+	if _, err := os.Stat(filepath.Join(claudeDir, sessionID+".jsonl")); !os.IsNotExist(err) {
+		t.Errorf("Claude session log should not be restored when converting, stat err = %v", err)
+	}
+	matches, err := filepath.Glob(filepath.Join(geminiDir, "session-*.json"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("expected one converted Gemini session, got %v (err %v)", matches, err)
+	}
+	data, err := os.ReadFile(matches[0])
+	if err != nil {
+		t.Fatalf("Failed to read converted session: %v", err)
+	}
+	if prompt, err := geminicli.ExtractLastUserPrompt(data); err != nil || prompt != "write test.txt" {
+		t.Errorf("converted session last prompt = %q, %v", prompt, err)
+	}
+	if files, err := geminicli.ExtractModifiedFiles(data); err != nil || len(files) != 1 || files[0] != "test.txt" {
+		t.Errorf("converted session modified files = %v, %v", files, err)
+	}
+}
+
+// saveResumeTestCheckpoint commits a change to test.txt with the auto-commit
+// strategy, creating a Claude Code checkpoint for sessionID with the given
+// transcript. Returns the checkpoint ID.
+func saveResumeTestCheckpoint(t *testing.T, tmpDir, sessionID, transcript, fileContent string) id.CheckpointID {
+	t.Helper()
+
+	strat := strategy.NewAutoCommitStrategy()
+	if err := strat.EnsureSetup(); err != nil {
+		t.Fatalf("Failed to ensure setup: %v", err)
+	}
+
+	metadataDir := filepath.Join(tmpDir, paths.EntireMetadataDir, sessionID)
+	if err := os.MkdirAll(metadataDir, 0o755); err != nil {
+		t.Fatalf("Failed to create metadata dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(metadataDir, paths.TranscriptFileName), []byte(transcript), 0o644); err != nil {
+		t.Fatalf("Failed to write log file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tmpDir, "test.txt"), []byte(fileContent), 0o644); err != nil {
+		t.Fatalf("Failed to write test file: %v", err)
+	}
+
+	ctx := strategy.SaveContext{
+		CommitMessage:  "test commit with checkpoint",
+		MetadataDir:    filepath.Join(paths.EntireMetadataDir, sessionID),
+		MetadataDirAbs: metadataDir,
+		ModifiedFiles:  []string{"test.txt"},
+		AuthorName:     "Test User",
+		AuthorEmail:    "test@example.com",
+		AgentType:      agent.AgentTypeClaudeCode,
+	}
+	if err := strat.SaveChanges(ctx); err != nil {
+		t.Fatalf("Failed to save changes: %v", err)
+	}
+
+	repo, err := git.PlainOpen(tmpDir)
+	if err != nil {
+		t.Fatalf("Failed to open repo: %v", err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		t.Fatalf("Failed to get HEAD commit: %v", err)
+	}
+	cpID, found := trailers.ParseCheckpoint(commit.Message)
+	if !found {
+		t.Fatalf("HEAD commit has no checkpoint trailer:\n%s", commit.Message)
+	}
+	return cpID
+}
+
+func TestRunResumeAt(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Chdir(tmpDir)
+
+	claudeDir := t.TempDir()
+	t.Setenv("ENTIRE_TEST_CLAUDE_PROJECT_DIR", claudeDir)
+
+	repo, _, _ := setupResumeTestRepo(t, tmpDir, false)
+
+	oldSession := "11111111-1111-4111-8111-111111111111"
+	oldLog := `{"type":"user","uuid":"u1","message":{"role":"user","content":"old work"}}` + "\n"
+	oldCheckpoint := saveResumeTestCheckpoint(t, tmpDir, oldSession, oldLog, "old")
+	oldHead, err := repo.Head()
+	if err != nil {
+		t.Fatalf("Failed to get HEAD: %v", err)
+	}
+	oldCommit := oldHead.Hash().String()
+
+	newSession := "22222222-2222-4222-8222-222222222222"
+	newLog := `{"type":"user","uuid":"u1","message":{"role":"user","content":"new work"}}` + "\n"
+	saveResumeTestCheckpoint(t, tmpDir, newSession, newLog, "new")
+
+	readRestored := func(t *testing.T, sessionID string) string {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(claudeDir, sessionID+".jsonl"))
+		if err != nil {
+			t.Fatalf("session %s was not restored: %v", sessionID, err)
+		}
+		return string(data)
+	}
+
+	t.Run("by checkpoint prefix", func(t *testing.T) {
+		if err := runResumeAt(resumeAtOptions{checkpoint: oldCheckpoint.String()[:6]}, true, ""); err != nil {
+			t.Fatalf("runResumeAt() error = %v", err)
+		}
+		if got := readRestored(t, oldSession); got != oldLog {
+			t.Errorf("restored log = %q, want %q", got, oldLog)
+		}
+		if branch, err := GetCurrentBranch(); err != nil || branch != "master" {
+			t.Errorf("current branch = %q (%v), want master (no checkout without --new-branch)", branch, err)
+		}
+	})
+
+	t.Run("by commit on a new branch", func(t *testing.T) {
+		// The helper's session metadata dirs would count as uncommitted changes
+		if err := os.RemoveAll(filepath.Join(tmpDir, paths.EntireDir)); err != nil {
+			t.Fatalf("Failed to remove .entire: %v", err)
+		}
+		if err := runResumeAt(resumeAtOptions{commit: oldCommit[:7], newBranch: "retry"}, true, ""); err != nil {
+			t.Fatalf("runResumeAt() error = %v", err)
+		}
+		branch, err := GetCurrentBranch()
+		if err != nil || branch != "retry" {
+			t.Fatalf("current branch = %q (%v), want retry", branch, err)
+		}
+		data, err := os.ReadFile(filepath.Join(tmpDir, "test.txt"))
+		if err != nil || string(data) != "old" {
+			t.Errorf("test.txt = %q (%v), want the linked commit's content", data, err)
+		}
+		if err := CheckoutBranch("master"); err != nil {
+			t.Fatalf("CheckoutBranch() error = %v", err)
+		}
+	})
+
+	t.Run("by checkpoint in a new worktree", func(t *testing.T) {
+		t.Chdir(tmpDir)
+		worktreeDir := filepath.Join(t.TempDir(), "wt")
+		if err := runResumeAt(resumeAtOptions{checkpoint: oldCheckpoint.String(), worktree: worktreeDir}, true, ""); err != nil {
+			t.Fatalf("runResumeAt() error = %v", err)
+		}
+		data, err := os.ReadFile(filepath.Join(worktreeDir, "test.txt"))
+		if err != nil || string(data) != "old" {
+			t.Errorf("worktree test.txt = %q (%v), want the linked commit's content", data, err)
+		}
+		readRestored(t, oldSession)
+	})
+
+	t.Run("commit without checkpoint", func(t *testing.T) {
+		t.Chdir(tmpDir)
+		err := runResumeAt(resumeAtOptions{commit: "HEAD~2"}, true, "")
+		if err == nil || !strings.Contains(err.Error(), "no Entire-Checkpoint trailer") {
+			t.Errorf("runResumeAt() error = %v, want missing trailer error", err)
+		}
+	})
+
+	t.Run("unknown checkpoint", func(t *testing.T) {
+		t.Chdir(tmpDir)
+		err := runResumeAt(resumeAtOptions{checkpoint: "zzz"}, true, "")
+		if err == nil || !strings.Contains(err.Error(), "checkpoint not found") {
+			t.Errorf("runResumeAt() error = %v, want not found error", err)
+		}
+	})
+}
+
+func TestResumeAtOptions_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		opts    resumeAtOptions
+		args    []string
+		wantErr bool
+	}{
+		{"branch", resumeAtOptions{}, []string{"feature"}, false},
+		{"checkpoint", resumeAtOptions{checkpoint: "abc"}, nil, false},
+		{"commit with worktree", resumeAtOptions{commit: "HEAD", worktree: "../wt"}, nil, false},
+		{"nothing", resumeAtOptions{}, nil, true},
+		{"branch and checkpoint", resumeAtOptions{checkpoint: "abc"}, []string{"feature"}, true},
+		{"new branch without checkpoint", resumeAtOptions{newBranch: "x"}, []string{"feature"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := tt.opts.validate(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResumeCmd_InvalidAgent(t *testing.T) {
+	cmd := newResumeCmd()
+	cmd.SetArgs([]string{"--agent", "nope", "feature"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	if err == nil || !strings.Contains(err.Error(), "invalid --agent") {
+		t.Errorf("Execute() error = %v, want invalid --agent error", err)
+	}
+}
+
 func TestRunResume_AlreadyOnBranch(t *testing.T) {
 	tmpDir := t.TempDir()
 	t.Chdir(tmpDir)
@@ -265,7 +499,7 @@ func TestRunResume_AlreadyOnBranch(t *testing.T) {
 	}
 
 	// Run resume on the branch we're already on - should skip checkout
-	err := runResume("feature", false)
+	err := runResume("feature", false, "")
 	// Should not error (no session, but shouldn't error)
 	if err != nil {
 		t.Errorf("runResume() returned error when already on branch: %v", err)
@@ -279,7 +513,7 @@ func TestRunResume_BranchDoesNotExist(t *testing.T) {
 	setupResumeTestRepo(t, tmpDir, false)
 
 	// Run resume on a branch that doesn't exist
-	err := runResume("nonexistent", false)
+	err := runResume("nonexistent", false, "")
 	if err == nil {
 		t.Error("runResume() expected error for nonexistent branch, got nil")
 	}
@@ -298,7 +532,7 @@ func TestRunResume_UncommittedChanges(t *testing.T) {
 	}
 
 	// Run resume - should fail due to uncommitted changes
-	err := runResume("feature", false)
+	err := runResume("feature", false, "")
 	if err == nil {
 		t.Error("runResume() expected error for uncommitted changes, got nil")
 	}
@@ -501,7 +735,7 @@ func TestCheckRemoteMetadata_MetadataExistsOnRemote(t *testing.T) {
 	// Call checkRemoteMetadata - should find it on remote and attempt to fetch
 	// In this test environment without a real origin remote, the fetch will fail
 	// but it should return a SilentError (user-friendly error message already printed)
-	err = checkRemoteMetadata(repo, checkpointID)
+	err = checkRemoteMetadata(repo, checkpointID, "")
 	if err == nil {
 		t.Error("checkRemoteMetadata() should return SilentError when fetch fails")
 	} else {
@@ -526,7 +760,7 @@ func TestCheckRemoteMetadata_NoRemoteMetadataBranch(t *testing.T) {
 	// Don't create any remote ref - simulating no remote entire/checkpoints/v1
 
 	// Call checkRemoteMetadata - should handle gracefully (no remote branch)
-	err := checkRemoteMetadata(repo, "nonexistent123")
+	err := checkRemoteMetadata(repo, "nonexistent123", "")
 	if err != nil {
 		t.Errorf("checkRemoteMetadata() returned error when no remote branch: %v", err)
 	}
@@ -561,7 +795,7 @@ func TestCheckRemoteMetadata_CheckpointNotOnRemote(t *testing.T) {
 	}
 
 	// Call checkRemoteMetadata with a DIFFERENT checkpoint ID (not on remote)
-	err = checkRemoteMetadata(repo, "abcd12345678")
+	err = checkRemoteMetadata(repo, "abcd12345678", "")
 	if err != nil {
 		t.Errorf("checkRemoteMetadata() returned error for missing checkpoint: %v", err)
 	}
@@ -622,7 +856,7 @@ func TestResumeFromCurrentBranch_FallsBackToRemote(t *testing.T) {
 	// Run resumeFromCurrentBranch - should fall back to remote and attempt fetch
 	// In this test environment without a real origin remote, the fetch will fail
 	// but it should return a SilentError (user-friendly error message already printed)
-	err = resumeFromCurrentBranch("master", false)
+	err = resumeFromCurrentBranch("master", false, "")
 	if err == nil {
 		t.Error("resumeFromCurrentBranch() should return SilentError when fetch fails")
 	} else {
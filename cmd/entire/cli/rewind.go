@@ -40,9 +40,12 @@ func getAgent(agentType agentpkg.AgentType) (agentpkg.Agent, error) {
 
 func newRewindCmd() *cobra.Command {
 	var listFlag bool
+	var diffFlag bool
 	var toFlag string
 	var logsOnlyFlag bool
 	var resetFlag bool
+	var pathsFlag []string
+	var undoFlag bool
 
 	cmd := &cobra.Command{
 		Use:   "rewind",
@@ -51,15 +54,51 @@ func newRewindCmd() *cobra.Command {
 
 This command will show you an interactive list of recent checkpoints.  You'll be
 able to select one for Entire to rewind your branch state, including your code and
-your agent's context.`,
+your agent's context.
+
+To keep some of the agent's later changes, restore only selected files, either
+by choosing "Choose files..." in the interactive list or with --paths:
+
+  entire rewind --to <id> --paths a.go,internal/
+
+A partial rewind only changes the selected paths. The rest of the working tree
+and the session transcript are left as they are.
+
+Before each rewind, Entire snapshots the working tree, including untracked files
+that aren't ignored. Run "entire rewind --undo" to go back to the state before
+the last rewind, and "entire rewind --undo --list" to see the undo history. The
+last 5 snapshots are kept per worktree.
+
+Add --diff to --list to include the changes a rewind to each point would make.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if Entire is disabled
 			if checkDisabledGuard(cmd.OutOrStdout()) {
 				return nil
 			}
 
+			if undoFlag {
+				if toFlag != "" || len(pathsFlag) > 0 || logsOnlyFlag || resetFlag {
+					return errors.New("--undo cannot be combined with --to, --paths, --logs-only or --reset")
+				}
+				if listFlag {
+					return runRewindUndoList()
+				}
+				return runRewindUndo()
+			}
+			if diffFlag && !listFlag {
+				return errors.New("--diff requires --list")
+			}
 			if listFlag {
-				return runRewindList()
+				return runRewindList(diffFlag)
+			}
+			if len(pathsFlag) > 0 {
+				if toFlag == "" {
+					return errors.New("--paths requires --to")
+				}
+				if logsOnlyFlag || resetFlag {
+					return errors.New("--paths cannot be combined with --logs-only or --reset")
+				}
+				return runRewindPaths(toFlag, pathsFlag)
 			}
 			if toFlag != "" {
 				return runRewindToWithOptions(toFlag, logsOnlyFlag, resetFlag)
@@ -69,9 +108,12 @@ your agent's context.`,
 	}
 
 	cmd.Flags().BoolVar(&listFlag, "list", false, "List available rewind points (JSON output)")
+	cmd.Flags().BoolVar(&diffFlag, "diff", false, "With --list, include the changes a rewind to each point would make")
 	cmd.Flags().StringVar(&toFlag, "to", "", "Rewind to specific commit ID (non-interactive)")
 	cmd.Flags().BoolVar(&logsOnlyFlag, "logs-only", false, "Only restore logs, don't modify working directory (for logs-only points)")
 	cmd.Flags().BoolVar(&resetFlag, "reset", false, "Reset branch to commit (destructive, for logs-only points)")
+	cmd.Flags().BoolVar(&undoFlag, "undo", false, "Undo the last rewind in this worktree (with --list, show undo history)")
+	cmd.Flags().StringSliceVar(&pathsFlag, "paths", nil, "Only restore these files or directories (comma-separated, requires --to)")
 
 	return cmd
 }
@@ -135,6 +177,9 @@ func runRewindInteractive() error { //nolint:maintidx // already present in code
 		case p.IsTaskCheckpoint:
 			// Task checkpoint (uncommitted) - no sha shown
 			label = fmt.Sprintf("        (%s) [Task] %s%s", timestamp, sanitizeForTerminal(p.Message), sessionLabel)
+		case p.IsToolCheckpoint:
+			// Fine-grained checkpoint - indented under its turn's checkpoint
+			label = fmt.Sprintf("        (%s)   ↳ %s%s", timestamp, sanitizeForTerminal(p.Message), sessionLabel)
 		default:
 			// Shadow checkpoint (uncommitted) - no sha shown (internal commit)
 			label = fmt.Sprintf("        (%s) %s%s", timestamp, sanitizeForTerminal(p.Message), sessionLabel)
@@ -190,6 +235,9 @@ func runRewindInteractive() error { //nolint:maintidx // already present in code
 	case selectedPoint.IsTaskCheckpoint:
 		// Task checkpoint - no sha
 		fmt.Printf("\nSelected: [Task] %s\n", sanitizeForTerminal(selectedPoint.Message))
+	case selectedPoint.IsToolCheckpoint:
+		// Fine-grained checkpoint - no sha
+		fmt.Printf("\nSelected: [Mid-turn] %s\n", sanitizeForTerminal(selectedPoint.Message))
 	default:
 		// Shadow checkpoint - no sha
 		fmt.Printf("\nSelected: %s\n", sanitizeForTerminal(selectedPoint.Message))
@@ -210,6 +258,48 @@ func runRewindInteractive() error { //nolint:maintidx // already present in code
 		fmt.Fprintf(os.Stderr, "\n")
 	}
 
+	// Let the user review the changes or restore only some files before confirming
+	pathRewinder, canPickPaths := start.(strategy.PathRewinder)
+	var diffs []strategy.FileDiff
+	if previewErr == nil && preview != nil {
+		diffs = preview.Diffs
+	}
+	if canPickPaths || len(diffs) > 0 {
+		if len(diffs) > 0 {
+			fmt.Fprintf(os.Stderr, "%s\n\n", summarizeRewindDiffs(diffs))
+		}
+		options := []huh.Option[string]{huh.NewOption("All files", "all")}
+		if canPickPaths {
+			options = append(options, huh.NewOption("Choose files...", "pick"))
+		}
+		if len(diffs) > 0 {
+			options = append(options, huh.NewOption("View changes", "diff"))
+		}
+
+		for {
+			var scope string
+			scopeForm := NewAccessibleForm(
+				huh.NewGroup(
+					huh.NewSelect[string]().
+						Title("What do you want to restore?").
+						Options(options...).
+						Value(&scope),
+				),
+			)
+			if err := scopeForm.Run(); err != nil {
+				return fmt.Errorf("selection cancelled: %w", err)
+			}
+			if scope == "diff" {
+				outputWithPager(os.Stdout, formatRewindDiffs(diffs))
+				continue
+			}
+			if scope == "pick" {
+				return runRewindFilePicker(pathRewinder, *selectedPoint, shortID)
+			}
+			break
+		}
+	}
+
 	// Confirm rewind
 	var confirm bool
 	description := fmt.Sprintf("This will reset to: %s\nChanges after this point may be lost!", selectedPoint.Message)
@@ -260,6 +350,11 @@ func runRewindInteractive() error { //nolint:maintidx // already present in code
 		slog.String("checkpoint_id", selectedPoint.ID),
 	)
 
+	if selectedPoint.IsToolCheckpoint {
+		printToolCheckpointRewound(*selectedPoint, shortID, agent)
+		return nil
+	}
+
 	// Handle transcript restoration differently for task checkpoints
 	var sessionID string
 	var transcriptFile string
@@ -325,10 +420,14 @@ func runRewindInteractive() error { //nolint:maintidx // already present in code
 	}
 
 	fmt.Printf("Rewound to %s. %s\n", shortID, agent.FormatResumeCommand(sessionID))
+	fmt.Println("To undo, run: entire rewind --undo")
 	return nil
 }
 
-func runRewindList() error {
+// runRewindList prints the rewind points as JSON. Previewing a rewind diffs
+// the working tree against the point's tree, so the changes are only included
+// when withDiffs is set.
+func runRewindList(withDiffs bool) error {
 	start := GetStrategy()
 
 	points, err := start.GetRewindPoints(20)
@@ -344,10 +443,17 @@ func runRewindList() error {
 		Date             string `json:"date"`
 		IsTaskCheckpoint bool   `json:"is_task_checkpoint"`
 		ToolUseID        string `json:"tool_use_id,omitempty"`
+		TurnID           string `json:"turn_id,omitempty"`
+		IsToolCheckpoint bool   `json:"is_tool_checkpoint,omitempty"`
+		ToolName         string `json:"tool_name,omitempty"`
 		IsLogsOnly       bool   `json:"is_logs_only"`
 		CondensationID   string `json:"condensation_id,omitempty"`
 		SessionID        string `json:"session_id,omitempty"`
 		SessionPrompt    string `json:"session_prompt,omitempty"`
+
+		// Changes a rewind would make to the working tree (only with --diff, omitted for logs-only points)
+		FilesToDelete []string            `json:"files_to_delete,omitempty"`
+		Diffs         []strategy.FileDiff `json:"diffs,omitempty"`
 	}
 
 	output := make([]jsonPoint, len(points))
@@ -359,11 +465,20 @@ func runRewindList() error {
 			Date:             p.Date.Format(time.RFC3339),
 			IsTaskCheckpoint: p.IsTaskCheckpoint,
 			ToolUseID:        p.ToolUseID,
+			TurnID:           p.TurnID,
+			IsToolCheckpoint: p.IsToolCheckpoint,
+			ToolName:         p.ToolName,
 			IsLogsOnly:       p.IsLogsOnly,
 			CondensationID:   p.CheckpointID.String(),
 			SessionID:        p.SessionID,
 			SessionPrompt:    p.SessionPrompt,
 		}
+		if withDiffs && !p.IsLogsOnly {
+			if preview, previewErr := start.PreviewRewind(p); previewErr == nil && preview != nil {
+				output[i].FilesToDelete = preview.FilesToDelete
+				output[i].Diffs = preview.Diffs
+			}
+		}
 	}
 
 	// Print as JSON
@@ -375,6 +490,19 @@ func runRewindList() error {
 	return nil
 }
 
+// printToolCheckpointRewound reports a rewind to a fine-grained checkpoint.
+// These are taken in the middle of a turn, before the turn's transcript is
+// saved, so only files are restored and the session transcript is left as is.
+func printToolCheckpointRewound(point strategy.RewindPoint, shortID string, agent agentpkg.Agent) {
+	sessionID := point.SessionID
+	if sessionID == "" {
+		sessionID = filepath.Base(point.MetadataDir)
+	}
+	fmt.Printf("Rewound files to %s (mid-turn, after %s). The session transcript was left as is.\n", shortID, point.ToolName)
+	fmt.Println(agent.FormatResumeCommand(sessionID))
+	fmt.Println("To undo, run: entire rewind --undo")
+}
+
 func runRewindToWithOptions(commitID string, logsOnly bool, reset bool) error {
 	return runRewindToInternal(commitID, logsOnly, reset)
 }
@@ -464,6 +592,11 @@ func runRewindToInternal(commitID string, logsOnly bool, reset bool) error {
 		slog.String("checkpoint_id", selectedPoint.ID),
 	)
 
+	if selectedPoint.IsToolCheckpoint {
+		printToolCheckpointRewound(*selectedPoint, selectedPoint.ID[:7], agent)
+		return nil
+	}
+
 	// Handle transcript restoration
 	var sessionID string
 	var transcriptFile string
@@ -524,6 +657,182 @@ func runRewindToInternal(commitID string, logsOnly bool, reset bool) error {
 	}
 
 	fmt.Printf("Rewound to %s. %s\n", selectedPoint.ID[:7], agent.FormatResumeCommand(sessionID))
+	fmt.Println("To undo, run: entire rewind --undo")
+	return nil
+}
+
+// runRewindUndo restores the working tree to the snapshot taken before the last rewind.
+func runRewindUndo() error {
+	point, result, err := strategy.UndoLastRewind()
+	if errors.Is(err, strategy.ErrNoUndoPoint) {
+		return errors.New("no rewind to undo in this worktree")
+	}
+	if result != nil {
+		for _, path := range result.Restored {
+			fmt.Fprintf(os.Stderr, "  Restored: %s\n", path)
+		}
+		for _, path := range result.Deleted {
+			fmt.Fprintf(os.Stderr, "  Deleted: %s\n", path)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to undo rewind: %w", err)
+	}
+
+	fmt.Printf("Undid rewind: %s (%s).\n", point.Message, point.Date.Local().Format("2006-01-02 15:04"))
+	fmt.Println("Your agent's session transcript was not changed back.")
+	fmt.Println("To go back to where you were, run: entire rewind --undo")
+	return nil
+}
+
+// runRewindUndoList prints the undo history for this worktree as JSON.
+func runRewindUndoList() error {
+	points, err := strategy.ListUndoPoints()
+	if err != nil {
+		return fmt.Errorf("failed to list undo points: %w", err)
+	}
+	if points == nil {
+		points = []strategy.UndoPoint{}
+	}
+	data, err := jsonutil.MarshalIndentWithNewline(points, "", "  ")
+	if err != nil {
+		return err //nolint:wrapcheck // same as runRewindList
+	}
+	fmt.Println(string(data))
+	return nil
+}
+
+// summarizeRewindDiffs returns a one-line count of the files a rewind changes.
+func summarizeRewindDiffs(diffs []strategy.FileDiff) string {
+	var modified, added, deleted int
+	for _, d := range diffs {
+		switch d.Status {
+		case strategy.FileDiffAdded:
+			added++
+		case strategy.FileDiffDeleted:
+			deleted++
+		case strategy.FileDiffModified:
+			modified++
+		}
+	}
+	return fmt.Sprintf("This rewind changes %d file(s): %d modified, %d added, %d deleted.",
+		len(diffs), modified, added, deleted)
+}
+
+// formatRewindDiffs renders preview diffs for the pager. Files without a
+// diff (binary or too large) are listed with the reason.
+func formatRewindDiffs(diffs []strategy.FileDiff) string {
+	var sb strings.Builder
+	sb.WriteString("Changes from your working tree (a/) to the checkpoint (b/):\n\n")
+	for _, d := range diffs {
+		switch {
+		case d.Binary:
+			fmt.Fprintf(&sb, "%s: binary file %s\n\n", d.Path, d.Status)
+		case d.Diff == "":
+			fmt.Fprintf(&sb, "%s: %s, too large to show\n\n", d.Path, d.Status)
+		default:
+			sb.WriteString(d.Diff)
+			if d.Truncated {
+				fmt.Fprintf(&sb, "... diff truncated at %d KB\n", strategy.MaxPreviewDiffBytes/1024)
+			}
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// runRewindPaths restores only the selected paths from a rewind point.
+// HEAD, the shadow branch and the session transcript are left untouched.
+func runRewindPaths(commitID string, selected []string) error {
+	start := GetStrategy()
+	pathRewinder, ok := start.(strategy.PathRewinder)
+	if !ok {
+		return fmt.Errorf("strategy %s does not support restoring selected paths", start.Name())
+	}
+
+	points, err := start.GetRewindPoints(20)
+	if err != nil {
+		return fmt.Errorf("failed to find rewind points: %w", err)
+	}
+
+	var selectedPoint *strategy.RewindPoint
+	for _, p := range points {
+		if p.ID == commitID || (len(commitID) >= 7 && len(p.ID) >= 7 && strings.HasPrefix(p.ID, commitID)) {
+			pointCopy := p
+			selectedPoint = &pointCopy
+			break
+		}
+	}
+	if selectedPoint == nil {
+		return fmt.Errorf("rewind point not found: %s", commitID)
+	}
+
+	return applyRewindPaths(pathRewinder, *selectedPoint, selected)
+}
+
+// runRewindFilePicker lets the user choose which changed files to restore from a point.
+func runRewindFilePicker(pathRewinder strategy.PathRewinder, point strategy.RewindPoint, shortID string) error {
+	changed, err := pathRewinder.ChangedPaths(point)
+	if err != nil {
+		return fmt.Errorf("failed to list changed files: %w", err)
+	}
+	if len(changed) == 0 {
+		fmt.Printf("Your files already match %s.\n", shortID)
+		return nil
+	}
+
+	options := make([]huh.Option[string], 0, len(changed))
+	for _, path := range changed {
+		options = append(options, huh.NewOption(path, path))
+	}
+
+	var selected []string
+	form := NewAccessibleForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Select files to restore from " + shortID).
+				Description("Files that differ from the checkpoint. Files missing from it will be deleted.").
+				Options(options...).
+				Value(&selected),
+		),
+	)
+	if err := form.Run(); err != nil {
+		return fmt.Errorf("selection cancelled: %w", err)
+	}
+	if len(selected) == 0 {
+		fmt.Println("No files selected. Rewind cancelled.")
+		return nil
+	}
+
+	return applyRewindPaths(pathRewinder, point, selected)
+}
+
+// applyRewindPaths restores the selected paths and reports what changed.
+func applyRewindPaths(pathRewinder strategy.PathRewinder, point strategy.RewindPoint, selected []string) error {
+	shortID := point.ID
+	if len(shortID) > 7 {
+		shortID = shortID[:7]
+	}
+
+	result, err := pathRewinder.RewindPaths(point, selected)
+	if err != nil {
+		return fmt.Errorf("failed to restore paths: %w", err)
+	}
+
+	for _, path := range result.Restored {
+		fmt.Fprintf(os.Stderr, "  Restored: %s\n", path)
+	}
+	for _, path := range result.Deleted {
+		fmt.Fprintf(os.Stderr, "  Deleted: %s\n", path)
+	}
+
+	changed := len(result.Restored) + len(result.Deleted)
+	if changed == 0 {
+		fmt.Printf("Selected paths already match %s.\n", shortID)
+		return nil
+	}
+	fmt.Printf("Rewound %d file(s) to %s. The session transcript was not changed.\n", changed, shortID)
+	fmt.Println("To undo, run: entire rewind --undo")
 	return nil
 }
 
@@ -816,17 +1125,26 @@ func createContextFileMinimal(contextFile, commitMessage, sessionID, promptFile,
 func handleLogsOnlyRewindInteractive(start strategy.Strategy, point strategy.RewindPoint, shortID string) error {
 	var action string
 
+	options := []huh.Option[string]{
+		huh.NewOption("Restore logs only (keep current files)", "logs"),
+	}
+	// Restoring only some files from the commit leaves the session logs alone
+	pathRewinder, canPickPaths := start.(strategy.PathRewinder)
+	if canPickPaths {
+		options = append(options, huh.NewOption("Choose files...", "pick"))
+	}
+	options = append(options,
+		huh.NewOption("Checkout commit (detached HEAD, for viewing)", "checkout"),
+		huh.NewOption("Reset branch to this commit (destructive!)", "reset"),
+		huh.NewOption("Cancel", "cancel"),
+	)
+
 	form := NewAccessibleForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
-				Title("Logs-only point: "+shortID).
+				Title("Logs-only point: " + shortID).
 				Description("This commit has session logs but no checkpoint state. Choose an action:").
-				Options(
-					huh.NewOption("Restore logs only (keep current files)", "logs"),
-					huh.NewOption("Checkout commit (detached HEAD, for viewing)", "checkout"),
-					huh.NewOption("Reset branch to this commit (destructive!)", "reset"),
-					huh.NewOption("Cancel", "cancel"),
-				).
+				Options(options...).
 				Value(&action),
 		),
 	)
@@ -838,6 +1156,8 @@ func handleLogsOnlyRewindInteractive(start strategy.Strategy, point strategy.Rew
 	switch action {
 	case "logs":
 		return handleLogsOnlyRestore(start, point)
+	case "pick":
+		return runRewindFilePicker(pathRewinder, point, shortID)
 	case "checkout":
 		return handleLogsOnlyCheckout(start, point, shortID)
 	case "reset":
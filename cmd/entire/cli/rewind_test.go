@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+)
+
+func TestRewindCmd_PathsFlagValidation(t *testing.T) {
+	setupCITestRepo(t)
+
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr string
+	}{
+		{name: "requires to", args: []string{"--paths", "a.go"}, wantErr: "--paths requires --to"},
+		{name: "no logs-only", args: []string{"--to", "abc1234", "--paths", "a.go", "--logs-only"}, wantErr: "cannot be combined"},
+		{name: "no reset", args: []string{"--to", "abc1234", "--paths", "a.go", "--reset"}, wantErr: "cannot be combined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newRewindCmd()
+			cmd.SetOut(io.Discard)
+			cmd.SetErr(io.Discard)
+			cmd.SetArgs(tt.args)
+
+			err := cmd.Execute()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Execute() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFormatRewindDiffs(t *testing.T) {
+	t.Parallel()
+
+	diffs := []strategy.FileDiff{
+		{Path: "a.go", Status: strategy.FileDiffModified, Diff: "diff --git a/a.go b/a.go\n-x\n+y\n", Truncated: true},
+		{Path: "logo.png", Status: strategy.FileDiffAdded, Binary: true},
+		{Path: "huge.json", Status: strategy.FileDiffDeleted, Truncated: true},
+	}
+
+	got := formatRewindDiffs(diffs)
+	for _, want := range []string{"+y\n", "diff truncated at 32 KB", "logo.png: binary file added", "huge.json: deleted, too large to show"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("formatRewindDiffs() missing %q:\n%s", want, got)
+		}
+	}
+
+	if summary := summarizeRewindDiffs(diffs); summary != "This rewind changes 3 file(s): 1 modified, 1 added, 1 deleted." {
+		t.Errorf("summarizeRewindDiffs() = %q", summary)
+	}
+}
+
+func TestRewindCmd_UndoFlagValidation(t *testing.T) {
+	setupCITestRepo(t)
+
+	cmd := newRewindCmd()
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--undo", "--to", "abc1234"})
+
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--undo cannot be combined") {
+		t.Errorf("Execute() error = %v, want combination error", err)
+	}
+}
+
+func TestRunRewindUndo_NothingToUndo(t *testing.T) {
+	setupCITestRepo(t)
+
+	err := runRewindUndo()
+	if err == nil || !strings.Contains(err.Error(), "no rewind to undo") {
+		t.Errorf("runRewindUndo() error = %v, want no rewind to undo", err)
+	}
+}
@@ -73,16 +73,27 @@ func NewRootCmd() *cobra.Command {
 	// Add subcommands here
 	cmd.AddCommand(newRewindCmd())
 	cmd.AddCommand(newResumeCmd())
+	cmd.AddCommand(newLandCmd())
 	cmd.AddCommand(newCleanCmd())
+	cmd.AddCommand(newPruneCmd())
+	cmd.AddCommand(newVerifyCmd())
+	cmd.AddCommand(newCICmd())
+	cmd.AddCommand(newPRCmd())
+	cmd.AddCommand(newChangelogCmd())
+	cmd.AddCommand(newBrowseCmd())
 	cmd.AddCommand(newResetCmd())
 	cmd.AddCommand(newEnableCmd())
 	cmd.AddCommand(newDisableCmd())
 	cmd.AddCommand(newStatusCmd())
+	cmd.AddCommand(newStatuslineCmd())
+	cmd.AddCommand(newConfigCmd())
 	cmd.AddCommand(newHooksCmd())
 	cmd.AddCommand(newVersionCmd())
 	cmd.AddCommand(newExplainCmd())
 	cmd.AddCommand(newDebugCmd())
 	cmd.AddCommand(newDoctorCmd())
+	cmd.AddCommand(newLogsCmd())
+	cmd.AddCommand(newTelemetryCmd())
 	cmd.AddCommand(newSendAnalyticsCmd())
 	cmd.AddCommand(newCurlBashPostInstallCmd())
 
@@ -71,12 +71,23 @@ type State struct {
 	// JSON tag kept as "checkpoint_count" for backward compatibility with existing state files.
 	StepCount int `json:"checkpoint_count"`
 
+	// TurnID identifies the current agent turn. A new one is generated at each
+	// turn start and written to the shadow checkpoints of that turn (including
+	// fine-grained ones taken after file-editing tool calls), so rewind can
+	// group them.
+	TurnID string `json:"turn_id,omitempty"`
+
 	// CheckpointTranscriptStart is the transcript line offset where the current
 	// checkpoint cycle began. Set to 0 at session start, updated to current
 	// transcript length after each condensation. Used to scope the transcript
 	// for checkpoint condensation: "everything since last checkpoint".
 	CheckpointTranscriptStart int `json:"checkpoint_transcript_start,omitempty"`
 
+	// CheckpointCommandsStart is the number of records in the session's
+	// commands.jsonl that were condensed into earlier checkpoints. Updated
+	// alongside CheckpointTranscriptStart after each condensation.
+	CheckpointCommandsStart int `json:"checkpoint_commands_start,omitempty"`
+
 	// Deprecated: CondensedTranscriptLines is replaced by CheckpointTranscriptStart.
 	// Kept for backward compatibility with existing state files.
 	// Use NormalizeAfterLoad() to migrate.
@@ -329,6 +340,11 @@ func (s *StateStore) List(ctx context.Context) ([]*State, error) {
 	return states, nil
 }
 
+// Dir returns the directory where session state files are stored.
+func (s *StateStore) Dir() string {
+	return s.stateDir
+}
+
 // stateFilePath returns the path to a session state file.
 func (s *StateStore) stateFilePath(sessionID string) string {
 	return filepath.Join(s.stateDir, sessionID+".json")
@@ -0,0 +1,382 @@
+package settings
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SchemaURL is where the settings JSON Schema is published. Settings files
+// can reference it with a "$schema" key so editors offer completion.
+const SchemaURL = "https://raw.githubusercontent.com/entireio/cli/main/docs/settings.schema.json"
+
+// StrategyNames are the strategies accepted by the strategy setting.
+// Duplicated from the strategy package to avoid an import cycle.
+var StrategyNames = []string{"manual-commit", "auto-commit", "worktree", "squash-commit"}
+
+// Setting describes one configurable value. Settings drive value parsing
+// and validation in `entire config` and the generated JSON Schema.
+type Setting struct {
+	// Key is the dotted JSON path, e.g. "retention.max_age"
+	Key string
+
+	// Type is the JSON Schema type: "string", "boolean", "integer" or "number"
+	Type string
+
+	// Enum lists the allowed values of a string setting, if restricted
+	Enum []string
+
+	// Description is shown by editors and `entire config list --all`
+	Description string
+
+	// Minimum and Maximum bound numeric settings, if set
+	Minimum *float64
+	Maximum *float64
+
+	// check validates a parsed value beyond its type and enum
+	check func(value any) error
+}
+
+func bound(v float64) *float64 { return &v }
+
+// knownSettings lists every setting in display order. Objects (retention,
+// ci, tracing, strategy_options) are implied by the dotted keys.
+var knownSettings = []Setting{
+	{Key: "strategy", Type: "string", Enum: StrategyNames, Description: "Session capture strategy"},
+	{Key: "enabled", Type: "boolean", Description: "Enable or disable Entire in this repository"},
+	{Key: "local_dev", Type: "boolean", Description: "Run hooks with 'go run' instead of the entire binary (development only)"},
+	{Key: "log_level", Type: "string", Enum: []string{"debug", "info", "warn", "error"}, Description: "Logging verbosity (overridden by ENTIRE_LOG_LEVEL)"},
+	{Key: "telemetry", Type: "boolean", Description: "Send anonymous usage statistics (turned off by DO_NOT_TRACK, ENTIRE_TELEMETRY=0 or ENTIRE_TELEMETRY_OPTOUT)"},
+	{Key: "strategy_options.push_sessions", Type: "boolean", Description: "Push the entire/checkpoints/v1 branch on git push"},
+	{Key: "strategy_options.summarize.enabled", Type: "boolean", Description: "Generate AI summaries for checkpoints at commit time"},
+	{Key: "strategy_options.fine_grained_checkpoints", Type: "boolean", Description: "Also checkpoint after every file-editing tool call, not just at turn end (manual-commit only)"},
+	{Key: "retention.max_age", Type: "string", Description: "Prune checkpoints older than this age, e.g. 90d, 2w, 720h",
+		check: func(v any) error { _, err := ParseAge(v.(string)); return err }}, //nolint:forcetypeassert // Type is checked before check runs
+	{Key: "retention.max_total_size", Type: "string", Description: "Prune the oldest checkpoints beyond this total size, e.g. 500MB, 1GiB",
+		check: func(v any) error { _, err := ParseByteSize(v.(string)); return err }}, //nolint:forcetypeassert // Type is checked before check runs
+	{Key: "retention.keep_last_per_branch", Type: "integer", Minimum: bound(0), Description: "Keep only the N newest checkpoints per branch"},
+	{Key: "retention.keep_summaries", Type: "boolean", Description: "Drop transcripts from pruned checkpoints but keep metadata and summaries"},
+	{Key: "ci.require_trailers", Type: "boolean", Description: "Fail 'entire ci check' on commits without an Entire-Checkpoint trailer"},
+	{Key: "ci.max_agent_percentage", Type: "number", Minimum: bound(0), Maximum: bound(100), Description: "Fail 'entire ci check' when the agent share of added lines is above this percentage"},
+	{Key: "ci.min_agent_percentage", Type: "number", Minimum: bound(0), Maximum: bound(100), Description: "Fail 'entire ci check' when the agent share of added lines is below this percentage"},
+	{Key: "tracing.endpoint", Type: "string", Description: "OTLP/HTTP collector URL for hook traces, e.g. http://localhost:4318 (overridden by ENTIRE_TRACING_ENDPOINT)"},
+}
+
+// KnownSettings returns every configurable setting in display order.
+func KnownSettings() []Setting {
+	return slices.Clone(knownSettings)
+}
+
+// LookupSetting returns the setting with the given dotted key.
+func LookupSetting(key string) (Setting, bool) {
+	for _, s := range knownSettings {
+		if s.Key == key {
+			return s, true
+		}
+	}
+	return Setting{}, false
+}
+
+// UnknownSettingError is returned for keys that are not in KnownSettings.
+type UnknownSettingError struct {
+	Key string
+}
+
+func (e *UnknownSettingError) Error() string {
+	// Suggest settings that share the first path segment
+	prefix, _, _ := strings.Cut(e.Key, ".")
+	var similar []string
+	for _, s := range knownSettings {
+		if strings.HasPrefix(s.Key, prefix) {
+			similar = append(similar, s.Key)
+		}
+	}
+	if len(similar) > 0 {
+		return fmt.Sprintf("unknown setting %q (did you mean %s?)", e.Key, strings.Join(similar, ", "))
+	}
+	return fmt.Sprintf("unknown setting %q (run 'entire config list --all' for the known settings)", e.Key)
+}
+
+// Parse converts a command-line value to the setting's type and validates it.
+func (s Setting) Parse(raw string) (any, error) {
+	var value any
+	switch s.Type {
+	case "boolean":
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be true or false, got %q", s.Key, raw)
+		}
+		value = b
+	case "integer":
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number, got %q", s.Key, raw)
+		}
+		value = float64(n)
+	case "number":
+		n, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number, got %q", s.Key, raw)
+		}
+		value = n
+	default:
+		value = raw
+	}
+	if err := s.Validate(value); err != nil {
+		return nil, err
+	}
+	if s.Type == "integer" {
+		return int(value.(float64)), nil //nolint:forcetypeassert // set above
+	}
+	return value, nil
+}
+
+// Validate checks a value decoded from JSON (numbers are float64) against
+// the setting's type, enum, bounds and format.
+func (s Setting) Validate(value any) error {
+	switch s.Type {
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be true or false, got %s", s.Key, formatJSON(value))
+		}
+	case "integer", "number":
+		n, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("%s must be a number, got %s", s.Key, formatJSON(value))
+		}
+		if s.Type == "integer" && n != float64(int64(n)) {
+			return fmt.Errorf("%s must be a whole number, got %s", s.Key, formatJSON(value))
+		}
+		if (s.Minimum != nil && n < *s.Minimum) || (s.Maximum != nil && n > *s.Maximum) {
+			return fmt.Errorf("%s must be %s, got %g", s.Key, s.rangeText(), n)
+		}
+	default:
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("%s must be a string, got %s", s.Key, formatJSON(value))
+		}
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, str) {
+			return fmt.Errorf("%s must be one of %s, got %q", s.Key, strings.Join(s.Enum, ", "), str)
+		}
+	}
+	if s.check != nil {
+		if err := s.check(value); err != nil {
+			return fmt.Errorf("%s: %w", s.Key, err)
+		}
+	}
+	return nil
+}
+
+func (s Setting) rangeText() string {
+	switch {
+	case s.Minimum != nil && s.Maximum != nil:
+		return fmt.Sprintf("between %g and %g", *s.Minimum, *s.Maximum)
+	case s.Minimum != nil:
+		return fmt.Sprintf("at least %g", *s.Minimum)
+	default:
+		return fmt.Sprintf("at most %g", *s.Maximum)
+	}
+}
+
+func formatJSON(value any) string {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// ValidateJSON checks settings file content against the known settings and
+// returns every problem found, one per key. Unknown keys are reported except
+// under strategy_options, which may hold options of other tools.
+func ValidateJSON(data []byte) []error {
+	var raw map[string]any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return []error{fmt.Errorf("invalid JSON: %w", err)}
+	}
+
+	var errs []error
+	var walk func(prefix string, obj map[string]any)
+	walk = func(prefix string, obj map[string]any) {
+		keys := make([]string, 0, len(obj))
+		for k := range obj {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			key := prefix + k
+			if key == "$schema" {
+				continue
+			}
+			if setting, ok := LookupSetting(key); ok {
+				if err := setting.Validate(obj[k]); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			if child, ok := obj[k].(map[string]any); ok && isSettingsObject(key) {
+				walk(key+".", child)
+				continue
+			}
+			if !strings.HasPrefix(key, "strategy_options.") {
+				errs = append(errs, &UnknownSettingError{Key: key})
+			}
+		}
+	}
+	walk("", raw)
+
+	if len(errs) == 0 {
+		// Cross-field rules
+		var s EntireSettings
+		if err := json.Unmarshal(data, &s); err == nil {
+			if err := s.CI.Validate(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs
+}
+
+// isSettingsObject reports whether key is an object holding known settings.
+func isSettingsObject(key string) bool {
+	for _, s := range knownSettings {
+		if strings.HasPrefix(s.Key, key+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// Schema returns the JSON Schema for settings files, generated from
+// KnownSettings. strategy_options allows additional properties; every other
+// object is closed.
+func Schema() map[string]any {
+	root := schemaObject("Entire settings", false)
+	root["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	root["$id"] = SchemaURL
+	properties := root["properties"].(map[string]any) //nolint:forcetypeassert // set by schemaObject
+	properties["$schema"] = map[string]any{"type": "string", "description": "JSON Schema reference for editor support"}
+
+	for _, s := range knownSettings {
+		parts := strings.Split(s.Key, ".")
+		obj := properties
+		for i, part := range parts[:len(parts)-1] {
+			child, ok := obj[part].(map[string]any)
+			if !ok {
+				child = schemaObject(objectDescriptions[strings.Join(parts[:i+1], ".")], part == "strategy_options")
+				obj[part] = child
+			}
+			obj = child["properties"].(map[string]any) //nolint:forcetypeassert // set by schemaObject
+		}
+
+		prop := map[string]any{"type": s.Type, "description": s.Description}
+		if len(s.Enum) > 0 {
+			prop["enum"] = s.Enum
+		}
+		if s.Minimum != nil {
+			prop["minimum"] = *s.Minimum
+		}
+		if s.Maximum != nil {
+			prop["maximum"] = *s.Maximum
+		}
+		obj[parts[len(parts)-1]] = prop
+	}
+	return root
+}
+
+// objectDescriptions describes the objects implied by dotted keys.
+var objectDescriptions = map[string]string{
+	"strategy_options":           "Strategy-specific options",
+	"strategy_options.summarize": "AI summary generation",
+	"retention":                  "Retention rules enforced by 'entire prune'",
+	"ci":                         "Provenance policy enforced by 'entire ci check'",
+	"tracing":                    "OpenTelemetry trace export for hook execution",
+}
+
+func schemaObject(description string, open bool) map[string]any {
+	obj := map[string]any{
+		"type":                 "object",
+		"properties":           map[string]any{},
+		"additionalProperties": open,
+	}
+	if description != "" {
+		obj["description"] = description
+	}
+	return obj
+}
+
+// SchemaJSON returns the JSON Schema as indented JSON, as published in
+// docs/settings.schema.json.
+func SchemaJSON() ([]byte, error) {
+	data, err := json.MarshalIndent(Schema(), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// errNotObject is returned when a dotted key runs through a non-object value.
+var errNotObject = errors.New("is not an object")
+
+// GetPath returns the value at a dotted key in a decoded settings file.
+func GetPath(raw map[string]any, key string) (any, bool) {
+	parts := strings.Split(key, ".")
+	var cur any = raw
+	for _, part := range parts {
+		obj, ok := cur.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// SetPath sets the value at a dotted key, creating objects along the way.
+func SetPath(raw map[string]any, key string, value any) error {
+	parts := strings.Split(key, ".")
+	obj := raw
+	for i, part := range parts[:len(parts)-1] {
+		next, ok := obj[part]
+		if !ok {
+			child := map[string]any{}
+			obj[part] = child
+			obj = child
+			continue
+		}
+		child, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%s %w", strings.Join(parts[:i+1], "."), errNotObject)
+		}
+		obj = child
+	}
+	obj[parts[len(parts)-1]] = value
+	return nil
+}
+
+// DeletePath removes the value at a dotted key and any objects left empty.
+// Returns false if the key was not set.
+func DeletePath(raw map[string]any, key string) bool {
+	parts := strings.SplitN(key, ".", 2)
+	if len(parts) == 1 {
+		if _, ok := raw[key]; !ok {
+			return false
+		}
+		delete(raw, key)
+		return true
+	}
+	child, ok := raw[parts[0]].(map[string]any)
+	if !ok || !DeletePath(child, parts[1]) {
+		return false
+	}
+	if len(child) == 0 {
+		delete(raw, parts[0])
+	}
+	return true
+}
@@ -0,0 +1,150 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSchemaFileUpToDate(t *testing.T) {
+	t.Parallel()
+
+	want, err := SchemaJSON()
+	if err != nil {
+		t.Fatalf("SchemaJSON() error = %v", err)
+	}
+	got, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "docs", "settings.schema.json"))
+	if err != nil {
+		t.Fatalf("failed to read published schema: %v", err)
+	}
+	if string(got) != string(want) {
+		t.Error("docs/settings.schema.json is out of date; run 'mise run schema'")
+	}
+}
+
+// TestKnownSettingsCoverEntireSettings keeps the schema in sync with the
+// settings structs: every JSON field must be a known setting or an object of them.
+func TestKnownSettingsCoverEntireSettings(t *testing.T) {
+	t.Parallel()
+
+	var check func(prefix string, typ reflect.Type)
+	check = func(prefix string, typ reflect.Type) {
+		for i := range typ.NumField() {
+			name, _, _ := strings.Cut(typ.Field(i).Tag.Get("json"), ",")
+			key := prefix + name
+			if key == "$schema" || key == "strategy_options" {
+				continue
+			}
+			fieldType := typ.Field(i).Type
+			if fieldType.Kind() == reflect.Pointer {
+				fieldType = fieldType.Elem()
+			}
+			if fieldType.Kind() == reflect.Struct {
+				check(key+".", fieldType)
+				continue
+			}
+			if _, ok := LookupSetting(key); !ok {
+				t.Errorf("settings field %q has no entry in knownSettings", key)
+			}
+		}
+	}
+	check("", reflect.TypeOf(EntireSettings{}))
+}
+
+func TestSetting_Parse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		key     string
+		raw     string
+		want    any
+		wantErr string
+	}{
+		{key: "enabled", raw: "false", want: false},
+		{key: "enabled", raw: "nope", wantErr: "must be true or false"},
+		{key: "strategy", raw: "auto-commit", want: "auto-commit"},
+		{key: "strategy", raw: "yolo", wantErr: "must be one of manual-commit"},
+		{key: "retention.keep_last_per_branch", raw: "10", want: 10},
+		{key: "retention.keep_last_per_branch", raw: "-1", wantErr: "at least 0"},
+		{key: "retention.keep_last_per_branch", raw: "1.5", wantErr: "whole number"},
+		{key: "retention.max_age", raw: "90d", want: "90d"},
+		{key: "retention.max_age", raw: "soon", wantErr: `invalid age "soon"`},
+		{key: "retention.max_total_size", raw: "1GiB", want: "1GiB"},
+		{key: "ci.max_agent_percentage", raw: "80", want: 80.0},
+		{key: "ci.max_agent_percentage", raw: "120", wantErr: "between 0 and 100"},
+	}
+	for _, tt := range tests {
+		setting, ok := LookupSetting(tt.key)
+		if !ok {
+			t.Fatalf("LookupSetting(%q) not found", tt.key)
+		}
+		got, err := setting.Parse(tt.raw)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Parse(%s=%q) error = %v, want %q", tt.key, tt.raw, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%s=%q) = %v (%T), %v; want %v", tt.key, tt.raw, got, got, err, tt.want)
+		}
+	}
+}
+
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+
+	valid := `{"$schema": "x", "strategy": "worktree", "retention": {"max_age": "2w"}, "strategy_options": {"custom": 1, "summarize": {"enabled": true}}}`
+	if errs := ValidateJSON([]byte(valid)); len(errs) != 0 {
+		t.Errorf("ValidateJSON(valid) = %v", errs)
+	}
+
+	errs := ValidateJSON([]byte(`{"strategy": "nope", "log_level": 3, "retention": {"max_ag": "1d"}, "colour": true}`))
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	joined := strings.Join(messages, "\n")
+	for _, want := range []string{
+		`unknown setting "colour"`,
+		"log_level must be a string",
+		`unknown setting "retention.max_ag" (did you mean retention.max_age`,
+		"strategy must be one of",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("ValidateJSON() errors missing %q:\n%s", want, joined)
+		}
+	}
+
+	errs = ValidateJSON([]byte(`{"ci": {"min_agent_percentage": 60, "max_agent_percentage": 40}}`))
+	if len(errs) != 1 || !strings.Contains(errs[0].Error(), "greater than") {
+		t.Errorf("ValidateJSON(min > max) = %v", errs)
+	}
+}
+
+func TestSetPathAndDeletePath(t *testing.T) {
+	t.Parallel()
+
+	raw := map[string]any{"strategy": "manual-commit"}
+	if err := SetPath(raw, "strategy_options.summarize.enabled", true); err != nil {
+		t.Fatalf("SetPath() error = %v", err)
+	}
+	if v, ok := GetPath(raw, "strategy_options.summarize.enabled"); !ok || v != true {
+		t.Errorf("GetPath() = %v, %v", v, ok)
+	}
+	if err := SetPath(raw, "strategy.nested", 1); err == nil {
+		t.Error("SetPath() through a string succeeded, want error")
+	}
+
+	if !DeletePath(raw, "strategy_options.summarize.enabled") {
+		t.Fatal("DeletePath() = false, want true")
+	}
+	if _, ok := raw["strategy_options"]; ok {
+		t.Errorf("empty parent objects not removed: %v", raw)
+	}
+	if DeletePath(raw, "log_level") {
+		t.Error("DeletePath() of unset key = true")
+	}
+}
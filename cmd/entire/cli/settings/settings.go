@@ -5,12 +5,19 @@ package settings
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/entireio/cli/cmd/entire/cli/jsonutil"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 )
 
@@ -27,6 +34,9 @@ const (
 
 // EntireSettings represents the .entire/settings.json configuration
 type EntireSettings struct {
+	// Schema is an optional JSON Schema reference for editors (see SchemaURL)
+	Schema string `json:"$schema,omitempty"`
+
 	// Strategy is the name of the git strategy to use
 	Strategy string `json:"strategy"`
 
@@ -49,13 +59,180 @@ type EntireSettings struct {
 	// Telemetry controls anonymous usage analytics.
 	// nil = not asked yet (show prompt), true = opted in, false = opted out
 	Telemetry *bool `json:"telemetry,omitempty"`
+
+	// Retention controls how long committed checkpoints are kept on the
+	// entire/checkpoints/v1 branch. Enforced by "entire prune".
+	Retention *RetentionSettings `json:"retention,omitempty"`
+
+	// CI holds the provenance policy enforced by "entire ci check".
+	CI *CISettings `json:"ci,omitempty"`
+
+	// Tracing configures OpenTelemetry trace export for hook execution.
+	Tracing *TracingSettings `json:"tracing,omitempty"`
+}
+
+// RetentionSettings holds the retention rules for committed checkpoints.
+// All rules are optional; an empty value disables the rule.
+type RetentionSettings struct {
+	// MaxAge prunes checkpoints older than this age (e.g. "90d", "2w", "720h").
+	MaxAge string `json:"max_age,omitempty"`
+
+	// MaxTotalSize prunes the oldest checkpoints until the metadata branch
+	// content fits within this size (e.g. "500MB", "2GB").
+	MaxTotalSize string `json:"max_total_size,omitempty"`
+
+	// KeepLastPerBranch keeps only the N most recent checkpoints for each branch.
+	KeepLastPerBranch int `json:"keep_last_per_branch,omitempty"`
+
+	// KeepSummaries drops only transcripts, prompts and context from pruned
+	// checkpoints, keeping their metadata.json records (including AI summaries).
+	// When false, pruned checkpoints are removed entirely unless a commit
+	// still references them.
+	KeepSummaries bool `json:"keep_summaries,omitempty"`
 }
 
-// Load loads the Entire settings from .entire/settings.json,
-// then applies any overrides from .entire/settings.local.json if it exists.
-// Returns default settings if neither file exists.
+// CISettings holds the policy thresholds for "entire ci check".
+// Referenced checkpoints that do not exist always fail the check.
+type CISettings struct {
+	// RequireTrailers fails the check when a non-merge commit in the range
+	// has no Entire-Checkpoint trailer.
+	RequireTrailers bool `json:"require_trailers,omitempty"`
+
+	// MaxAgentPercentage fails the check when the agent share of lines added
+	// in the range exceeds this percentage (0-100). nil disables the rule.
+	MaxAgentPercentage *float64 `json:"max_agent_percentage,omitempty"`
+
+	// MinAgentPercentage fails the check when the agent share of lines added
+	// in the range is below this percentage (0-100). nil disables the rule.
+	MinAgentPercentage *float64 `json:"min_agent_percentage,omitempty"`
+}
+
+// TracingSettings configures OTLP/HTTP trace export. Tracing is off unless
+// an endpoint is set.
+type TracingSettings struct {
+	// Endpoint is the OTLP/HTTP collector URL, e.g. "http://localhost:4318".
+	// /v1/traces is appended when the URL has no path.
+	Endpoint string `json:"endpoint,omitempty"`
+}
+
+// Setting sources, from lowest to highest precedence.
+const (
+	SourceDefault = "default"
+	SourceUser    = "user"
+	SourceProject = "project"
+	SourceLocal   = "local"
+	SourceEnv     = "env"
+)
+
+// Environment variables that override settings.
+const (
+	// LogLevelEnvVar overrides log_level. Duplicated from the logging package,
+	// which reads it directly.
+	LogLevelEnvVar = "ENTIRE_LOG_LEVEL"
+	// TelemetryOptOutEnvVar turns telemetry off when set to any value.
+	TelemetryOptOutEnvVar = "ENTIRE_TELEMETRY_OPTOUT"
+	// TelemetryEnvVar turns telemetry off when set to 0, false or off.
+	TelemetryEnvVar = "ENTIRE_TELEMETRY"
+	// DoNotTrackEnvVar is the cross-tool opt-out (consoledonottrack.com). It
+	// turns telemetry off when set to anything but 0 or false.
+	DoNotTrackEnvVar = "DO_NOT_TRACK"
+	// TracingEndpointEnvVar overrides tracing.endpoint.
+	TracingEndpointEnvVar = "ENTIRE_TRACING_ENDPOINT"
+)
+
+// Sources records which layer set each effective setting. Keys are top-level
+// JSON keys, with strategy_options entries as "strategy_options.<key>"; values
+// are Source* constants, with env overrides as "env <VARIABLE>". Keys that no
+// layer set are absent, meaning the default applies.
+type Sources map[string]string
+
+// Source returns the layer that set key, or SourceDefault. Nested keys such
+// as "retention.max_age" report the source of their enclosing object.
+func (s Sources) Source(key string) string {
+	for {
+		if src, ok := s[key]; ok {
+			return src
+		}
+		i := strings.LastIndex(key, ".")
+		if i < 0 {
+			return SourceDefault
+		}
+		key = key[:i]
+	}
+}
+
+// UserSettingsPath returns the user-level settings file,
+// $XDG_CONFIG_HOME/entire/settings.json, falling back to
+// ~/.config/entire/settings.json when XDG_CONFIG_HOME is unset.
+func UserSettingsPath() (string, error) {
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("finding home directory: %w", err)
+		}
+		configHome = filepath.Join(home, ".config")
+	}
+	return filepath.Join(configHome, "entire", "settings.json"), nil
+}
+
+// Load loads the effective Entire settings: the user-level settings file,
+// then .entire/settings.json, then .entire/settings.local.json, then
+// environment overrides, each layer overriding the one before.
+// Returns default settings if none of the files exist.
 // Works correctly from any subdirectory within the repository.
 func Load() (*EntireSettings, error) {
+	settings, _, err := LoadWithSources()
+	return settings, err
+}
+
+// LoadWithSources is Load, also reporting which layer set each value.
+func LoadWithSources() (*EntireSettings, Sources, error) {
+	settings := &EntireSettings{
+		Strategy: DefaultStrategyName,
+		Enabled:  true, // Default to enabled
+	}
+	sources := Sources{}
+
+	if userPath, err := UserSettingsPath(); err == nil {
+		if err := mergeFile(settings, sources, userPath, SourceUser); err != nil {
+			return nil, nil, fmt.Errorf("reading user settings file: %w", err)
+		}
+	}
+	// A telemetry opt-out in the user settings file applies to every
+	// repository, even ones whose settings turn telemetry on
+	userOptOut := settings.Telemetry != nil && !*settings.Telemetry
+	if err := mergeRepoFiles(settings, sources); err != nil {
+		return nil, nil, err
+	}
+	if userOptOut {
+		off := false
+		settings.Telemetry = &off
+		sources["telemetry"] = SourceUser
+	}
+	applyEnvOverrides(settings, sources)
+	applyDefaults(settings)
+
+	return settings, sources, nil
+}
+
+// LoadRepo loads only .entire/settings.json and .entire/settings.local.json,
+// without user settings or environment overrides. Use it when settings are
+// written back to the repository, so personal defaults aren't copied into it.
+func LoadRepo() (*EntireSettings, error) {
+	settings := &EntireSettings{
+		Strategy: DefaultStrategyName,
+		Enabled:  true, // Default to enabled
+	}
+	if err := mergeRepoFiles(settings, Sources{}); err != nil {
+		return nil, err
+	}
+	applyDefaults(settings)
+	return settings, nil
+}
+
+// mergeRepoFiles merges the project settings file, then the local one.
+func mergeRepoFiles(settings *EntireSettings, sources Sources) error {
 	// Get absolute paths for settings files
 	settingsFileAbs, err := paths.AbsPath(EntireSettingsFile)
 	if err != nil {
@@ -66,28 +243,91 @@ func Load() (*EntireSettings, error) {
 		localSettingsFileAbs = EntireSettingsLocalFile // Fallback to relative
 	}
 
-	// Load base settings
-	settings, err := loadFromFile(settingsFileAbs)
-	if err != nil {
-		return nil, fmt.Errorf("reading settings file: %w", err)
+	if err := mergeFile(settings, sources, settingsFileAbs, SourceProject); err != nil {
+		return fmt.Errorf("reading settings file: %w", err)
+	}
+	if err := mergeFile(settings, sources, localSettingsFileAbs, SourceLocal); err != nil {
+		return fmt.Errorf("reading local settings file: %w", err)
 	}
+	return nil
+}
 
-	// Apply local overrides if they exist
-	localData, err := os.ReadFile(localSettingsFileAbs) //nolint:gosec // path is from AbsPath or constant
+// mergeFile merges a settings file into settings, recording the keys it sets
+// as coming from source. A missing file is not an error.
+func mergeFile(settings *EntireSettings, sources Sources, filePath, source string) error {
+	data, err := os.ReadFile(filePath) //nolint:gosec // path is from AbsPath, UserSettingsPath or constant
 	if err != nil {
-		if !os.IsNotExist(err) {
-			return nil, fmt.Errorf("reading local settings file: %w", err)
+		if os.IsNotExist(err) {
+			return nil
 		}
-		// Local file doesn't exist, continue without overrides
-	} else {
-		if err := mergeJSON(settings, localData); err != nil {
-			return nil, fmt.Errorf("merging local settings: %w", err)
+		return fmt.Errorf("%w", err)
+	}
+	if err := mergeJSON(settings, data); err != nil {
+		return fmt.Errorf("parsing settings file: %w", err)
+	}
+	warnInvalidSettings(filePath, data)
+
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parsing settings file: %w", err)
+	}
+	for key, value := range raw {
+		switch key {
+		case "$schema":
+			continue
+		case "strategy", "log_level":
+			// Empty strings don't override (see mergeJSON)
+			if string(value) == `""` {
+				continue
+			}
+		case "strategy_options":
+			var opts map[string]json.RawMessage
+			if json.Unmarshal(value, &opts) == nil {
+				for opt := range opts {
+					sources["strategy_options."+opt] = source
+				}
+			}
+			continue
 		}
+		sources[key] = source
 	}
+	return nil
+}
 
-	applyDefaults(settings)
+// applyEnvOverrides applies settings set through environment variables.
+func applyEnvOverrides(settings *EntireSettings, sources Sources) {
+	if level := os.Getenv(LogLevelEnvVar); level != "" {
+		settings.LogLevel = level
+		sources["log_level"] = SourceEnv + " " + LogLevelEnvVar
+	}
+	if envVar := TelemetryOptOutEnv(); envVar != "" {
+		off := false
+		settings.Telemetry = &off
+		sources["telemetry"] = SourceEnv + " " + envVar
+	}
+	if endpoint := os.Getenv(TracingEndpointEnvVar); endpoint != "" {
+		settings.Tracing = &TracingSettings{Endpoint: endpoint}
+		sources["tracing"] = SourceEnv + " " + TracingEndpointEnvVar
+	}
+}
 
-	return settings, nil
+// TelemetryOptOutEnv returns the environment variable that turns telemetry
+// off (ENTIRE_TELEMETRY_OPTOUT, ENTIRE_TELEMETRY or DO_NOT_TRACK), or "" if
+// none does.
+func TelemetryOptOutEnv() string {
+	if os.Getenv(TelemetryOptOutEnvVar) != "" {
+		return TelemetryOptOutEnvVar
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(TelemetryEnvVar))) {
+	case "0", "false", "off":
+		return TelemetryEnvVar
+	}
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(DoNotTrackEnvVar))) {
+	case "", "0", "false":
+	default:
+		return DoNotTrackEnvVar
+	}
+	return ""
 }
 
 // LoadFromFile loads settings from a specific file path without merging local overrides.
@@ -118,11 +358,41 @@ func loadFromFile(filePath string) (*EntireSettings, error) {
 	if err := dec.Decode(settings); err != nil {
 		return nil, fmt.Errorf("parsing settings file: %w", err)
 	}
+	warnInvalidSettings(filePath, data)
 	applyDefaults(settings)
 
 	return settings, nil
 }
 
+// warnedFiles holds the settings files warnInvalidSettings has reported, so
+// each file is only reported once per process.
+var warnedFiles sync.Map
+
+// warnInvalidSettings logs a warning for each problem ValidateJSON finds in a
+// settings file. Invalid values don't stop the settings from loading, since
+// every command and hook loads them; `entire config` and `entire doctor`
+// report the same problems. Settings are first loaded while logging.Init
+// reads the log level, so the warnings wait for the logger rather than going
+// to stderr, where they would end up in agent hook output.
+func warnInvalidSettings(filePath string, data []byte) {
+	errs := ValidateJSON(data)
+	if len(errs) == 0 {
+		return
+	}
+	if _, warned := warnedFiles.LoadOrStore(filePath, true); warned {
+		return
+	}
+	logging.AfterInit(func() {
+		ctx := logging.WithComponent(context.Background(), "settings")
+		for _, err := range errs {
+			logging.Warn(ctx, "invalid setting",
+				slog.String("file", filePath),
+				slog.String("error", err.Error()),
+			)
+		}
+	})
+}
+
 // mergeJSON merges JSON data into existing settings.
 // Only non-zero values from the JSON override existing settings.
 func mergeJSON(settings *EntireSettings, data []byte) error {
@@ -140,6 +410,13 @@ func mergeJSON(settings *EntireSettings, data []byte) error {
 		return fmt.Errorf("parsing JSON: %w", err)
 	}
 
+	// Keep the schema reference so it survives saving
+	if schemaRaw, ok := raw["$schema"]; ok {
+		if err := json.Unmarshal(schemaRaw, &settings.Schema); err != nil {
+			return fmt.Errorf("parsing $schema field: %w", err)
+		}
+	}
+
 	// Override strategy if present and non-empty
 	if strategyRaw, ok := raw["strategy"]; ok {
 		var s string
@@ -204,6 +481,33 @@ func mergeJSON(settings *EntireSettings, data []byte) error {
 		settings.Telemetry = &t
 	}
 
+	// Override retention if present
+	if retentionRaw, ok := raw["retention"]; ok {
+		var r RetentionSettings
+		if err := json.Unmarshal(retentionRaw, &r); err != nil {
+			return fmt.Errorf("parsing retention field: %w", err)
+		}
+		settings.Retention = &r
+	}
+
+	// Override CI policy if present
+	if ciRaw, ok := raw["ci"]; ok {
+		var c CISettings
+		if err := json.Unmarshal(ciRaw, &c); err != nil {
+			return fmt.Errorf("parsing ci field: %w", err)
+		}
+		settings.CI = &c
+	}
+
+	// Override tracing if present
+	if tracingRaw, ok := raw["tracing"]; ok {
+		var t TracingSettings
+		if err := json.Unmarshal(tracingRaw, &t); err != nil {
+			return fmt.Errorf("parsing tracing field: %w", err)
+		}
+		settings.Tracing = &t
+	}
+
 	return nil
 }
 
@@ -239,6 +543,26 @@ func (s *EntireSettings) IsSummarizeEnabled() bool {
 	return enabled
 }
 
+// IsFineGrainedCheckpointsEnabled checks if checkpoints should also be taken
+// after each file-editing tool call. Returns false by default if settings
+// cannot be loaded or the key is missing.
+func IsFineGrainedCheckpointsEnabled() bool {
+	settings, err := Load()
+	if err != nil {
+		return false
+	}
+	return settings.IsFineGrainedCheckpointsEnabled()
+}
+
+// IsFineGrainedCheckpointsEnabled checks if fine-grained checkpoints are enabled in this settings instance.
+func (s *EntireSettings) IsFineGrainedCheckpointsEnabled() bool {
+	if s.StrategyOptions == nil {
+		return false
+	}
+	enabled, ok := s.StrategyOptions["fine_grained_checkpoints"].(bool)
+	return ok && enabled
+}
+
 // IsPushSessionsDisabled checks if push_sessions is disabled in settings.
 // Returns true if push_sessions is explicitly set to false.
 func (s *EntireSettings) IsPushSessionsDisabled() bool {
@@ -255,6 +579,89 @@ func (s *EntireSettings) IsPushSessionsDisabled() bool {
 	return false
 }
 
+// Validate checks that the percentage thresholds are within 0-100 and consistent.
+func (c *CISettings) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for name, v := range map[string]*float64{
+		"ci.max_agent_percentage": c.MaxAgentPercentage,
+		"ci.min_agent_percentage": c.MinAgentPercentage,
+	} {
+		if v != nil && (*v < 0 || *v > 100) {
+			return fmt.Errorf("%s must be between 0 and 100, got %g", name, *v)
+		}
+	}
+	if c.MaxAgentPercentage != nil && c.MinAgentPercentage != nil && *c.MinAgentPercentage > *c.MaxAgentPercentage {
+		return fmt.Errorf("ci.min_agent_percentage (%g) is greater than ci.max_agent_percentage (%g)",
+			*c.MinAgentPercentage, *c.MaxAgentPercentage)
+	}
+	return nil
+}
+
+// MaxAgeDuration parses MaxAge. Returns 0 when the rule is not set.
+func (r *RetentionSettings) MaxAgeDuration() (time.Duration, error) {
+	if r == nil || r.MaxAge == "" {
+		return 0, nil
+	}
+	return ParseAge(r.MaxAge)
+}
+
+// MaxTotalSizeBytes parses MaxTotalSize. Returns 0 when the rule is not set.
+func (r *RetentionSettings) MaxTotalSizeBytes() (int64, error) {
+	if r == nil || r.MaxTotalSize == "" {
+		return 0, nil
+	}
+	return ParseByteSize(r.MaxTotalSize)
+}
+
+// ParseAge parses an age such as "90d", "2w" or any time.ParseDuration value ("36h").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if num, ok := strings.CutSuffix(s, suffix); ok {
+			n, err := strconv.Atoi(num)
+			if err != nil || n < 0 {
+				return 0, fmt.Errorf("invalid age %q", s)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+	return d, nil
+}
+
+// ParseByteSize parses a size such as "500MB", "1.5GB", "64KiB" or a plain byte count.
+// Decimal (KB, MB, GB) and binary (KiB, MiB, GiB) units are both accepted.
+func ParseByteSize(s string) (int64, error) {
+	trimmed := strings.TrimSpace(s)
+	upper := strings.ToUpper(trimmed)
+	units := []struct {
+		suffix string
+		size   float64
+	}{
+		{"KIB", 1 << 10}, {"MIB", 1 << 20}, {"GIB", 1 << 30},
+		{"KB", 1e3}, {"MB", 1e6}, {"GB", 1e9},
+		{"B", 1},
+	}
+	multiplier := 1.0
+	for _, u := range units {
+		if num, ok := strings.CutSuffix(upper, u.suffix); ok {
+			upper = num
+			multiplier = u.size
+			break
+		}
+	}
+	n, err := strconv.ParseFloat(strings.TrimSpace(upper), 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", trimmed)
+	}
+	return int64(n * multiplier), nil
+}
+
 // Save saves the settings to .entire/settings.json.
 func Save(settings *EntireSettings) error {
 	return saveToFile(settings, EntireSettingsFile)
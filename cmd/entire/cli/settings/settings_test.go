@@ -1,10 +1,16 @@
 package settings
 
 import (
+	"bytes"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
 )
 
 func TestLoad_RejectsUnknownKeys(t *testing.T) {
@@ -135,8 +141,376 @@ func TestLoad_LocalSettingsRejectsUnknownKeys(t *testing.T) {
 	}
 }
 
+func TestLoad_LocalRetentionOverridesProject(t *testing.T) {
+	tmpDir := t.TempDir()
+	entireDir := filepath.Join(tmpDir, ".entire")
+	if err := os.MkdirAll(entireDir, 0755); err != nil {
+		t.Fatalf("failed to create .entire directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(entireDir, "settings.json"), []byte(`{"retention": {"max_age": "90d"}}`), 0644); err != nil {
+		t.Fatalf("failed to write settings file: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(entireDir, "settings.local.json"), []byte(`{"retention": {"keep_last_per_branch": 10}}`), 0644); err != nil {
+		t.Fatalf("failed to write local settings file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create .git directory: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	settings, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.Retention == nil {
+		t.Fatal("expected retention settings")
+	}
+	// The local retention block replaces the project one as a whole
+	if settings.Retention.MaxAge != "" || settings.Retention.KeepLastPerBranch != 10 {
+		t.Errorf("unexpected retention settings: %+v", settings.Retention)
+	}
+}
+
+func TestLoad_CISettings(t *testing.T) {
+	tmpDir := t.TempDir()
+	entireDir := filepath.Join(tmpDir, ".entire")
+	if err := os.MkdirAll(entireDir, 0755); err != nil {
+		t.Fatalf("failed to create .entire directory: %v", err)
+	}
+	settingsContent := `{"ci": {"require_trailers": true, "max_agent_percentage": 80}}`
+	if err := os.WriteFile(filepath.Join(entireDir, "settings.json"), []byte(settingsContent), 0644); err != nil {
+		t.Fatalf("failed to write settings file: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create .git directory: %v", err)
+	}
+	t.Chdir(tmpDir)
+
+	settings, err := Load()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if settings.CI == nil || !settings.CI.RequireTrailers {
+		t.Fatalf("expected ci.require_trailers, got %+v", settings.CI)
+	}
+	if settings.CI.MaxAgentPercentage == nil || *settings.CI.MaxAgentPercentage != 80 {
+		t.Errorf("expected ci.max_agent_percentage 80, got %v", settings.CI.MaxAgentPercentage)
+	}
+	if settings.CI.MinAgentPercentage != nil {
+		t.Errorf("expected ci.min_agent_percentage unset, got %v", *settings.CI.MinAgentPercentage)
+	}
+}
+
+func TestCISettings_Validate(t *testing.T) {
+	t.Parallel()
+	pct := func(v float64) *float64 { return &v }
+	tests := []struct {
+		name    string
+		ci      *CISettings
+		wantErr bool
+	}{
+		{"nil", nil, false},
+		{"empty", &CISettings{}, false},
+		{"valid range", &CISettings{MinAgentPercentage: pct(10), MaxAgentPercentage: pct(90)}, false},
+		{"above 100", &CISettings{MaxAgentPercentage: pct(120)}, true},
+		{"negative", &CISettings{MinAgentPercentage: pct(-1)}, true},
+		{"min above max", &CISettings{MinAgentPercentage: pct(60), MaxAgentPercentage: pct(40)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.ci.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseAge(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"90d", 90 * 24 * time.Hour, false},
+		{"2w", 14 * 24 * time.Hour, false},
+		{"36h", 36 * time.Hour, false},
+		{"1h30m", 90 * time.Minute, false},
+		{"-1d", 0, true},
+		{"soon", 0, true},
+		{"d", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseAge(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseAge(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseAge(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		in      string
+		want    int64
+		wantErr bool
+	}{
+		{"1024", 1024, false},
+		{"500MB", 500_000_000, false},
+		{"1.5GB", 1_500_000_000, false},
+		{"64KiB", 64 << 10, false},
+		{"2 gib", 2 << 30, false},
+		{"10B", 10, false},
+		{"lots", 0, true},
+		{"-5MB", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseByteSize(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseByteSize(%q) = %d, want %d", tt.in, got, tt.want)
+		}
+	}
+}
+
 // containsUnknownField checks if the error message indicates an unknown field
 func containsUnknownField(msg string) bool {
 	// Go's json package reports unknown fields with this message format
 	return strings.Contains(msg, "unknown field")
 }
+
+func TestUserSettingsPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	if got, err := UserSettingsPath(); err != nil || got != filepath.Join("/tmp/xdg", "entire", "settings.json") {
+		t.Errorf("UserSettingsPath() = %q, %v", got, err)
+	}
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("HOME", "/tmp/home")
+	if got, err := UserSettingsPath(); err != nil || got != filepath.Join("/tmp/home", ".config", "entire", "settings.json") {
+		t.Errorf("UserSettingsPath() without XDG_CONFIG_HOME = %q, %v", got, err)
+	}
+}
+
+// setupLayeredSettings writes user, project and local settings files.
+func setupLayeredSettings(t *testing.T, user, project, local string) {
+	t.Helper()
+	tmpDir := t.TempDir()
+	configHome := filepath.Join(tmpDir, "config")
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv(LogLevelEnvVar, "")
+	t.Setenv(TelemetryOptOutEnvVar, "")
+	t.Setenv(TelemetryEnvVar, "")
+	t.Setenv(DoNotTrackEnvVar, "")
+	t.Setenv(TracingEndpointEnvVar, "")
+
+	repoDir := filepath.Join(tmpDir, "repo")
+	for path, content := range map[string]string{
+		filepath.Join(configHome, "entire", "settings.json"):     user,
+		filepath.Join(repoDir, ".entire", "settings.json"):       project,
+		filepath.Join(repoDir, ".entire", "settings.local.json"): local,
+	} {
+		if content == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+	if err := os.MkdirAll(filepath.Join(repoDir, ".git"), 0755); err != nil {
+		t.Fatalf("failed to create .git directory: %v", err)
+	}
+	t.Chdir(repoDir)
+}
+
+func TestLoadWithSources_Precedence(t *testing.T) {
+	setupLayeredSettings(t,
+		`{"strategy": "auto-commit", "log_level": "debug", "telemetry": true, "strategy_options": {"summarize": {"enabled": true}}}`,
+		`{"strategy": "manual-commit", "strategy_options": {"push_sessions": false}}`,
+		`{"log_level": "warn"}`)
+	t.Setenv(TelemetryOptOutEnvVar, "1")
+
+	settings, sources, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if settings.Strategy != "manual-commit" || settings.LogLevel != "warn" {
+		t.Errorf("strategy = %q, log_level = %q; want project and local values", settings.Strategy, settings.LogLevel)
+	}
+	if settings.Telemetry == nil || *settings.Telemetry {
+		t.Errorf("telemetry = %v, want env opt-out to win", settings.Telemetry)
+	}
+	if !settings.IsSummarizeEnabled() || !settings.IsPushSessionsDisabled() {
+		t.Errorf("strategy_options = %v, want user and project options merged", settings.StrategyOptions)
+	}
+
+	want := map[string]string{
+		"strategy":                       SourceProject,
+		"log_level":                      SourceLocal,
+		"telemetry":                      SourceEnv + " " + TelemetryOptOutEnvVar,
+		"strategy_options.summarize":     SourceUser,
+		"strategy_options.push_sessions": SourceProject,
+		"enabled":                        SourceDefault,
+	}
+	for key, src := range want {
+		if got := sources.Source(key); got != src {
+			t.Errorf("Source(%q) = %q, want %q", key, got, src)
+		}
+	}
+
+	t.Setenv(LogLevelEnvVar, "error")
+	settings, sources, err = LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if settings.LogLevel != "error" || sources.Source("log_level") != SourceEnv+" "+LogLevelEnvVar {
+		t.Errorf("log_level = %q from %q, want env override", settings.LogLevel, sources.Source("log_level"))
+	}
+}
+
+func TestLoadWithSources_UserTelemetryOptOutWins(t *testing.T) {
+	setupLayeredSettings(t, `{"telemetry": false}`, `{"telemetry": true}`, `{"telemetry": true}`)
+
+	settings, sources, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if settings.Telemetry == nil || *settings.Telemetry || sources.Source("telemetry") != SourceUser {
+		t.Errorf("telemetry = %v from %q, want the user opt-out to win", settings.Telemetry, sources.Source("telemetry"))
+	}
+
+	// A user opt-in doesn't override a repository opt-out
+	setupLayeredSettings(t, `{"telemetry": true}`, `{"telemetry": false}`, "")
+	settings, err = Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if settings.Telemetry == nil || *settings.Telemetry {
+		t.Errorf("telemetry = %v, want the project opt-out", settings.Telemetry)
+	}
+}
+
+func TestTelemetryOptOutEnv(t *testing.T) {
+	tests := []struct {
+		name                          string
+		optOut, telemetry, doNotTrack string
+		want                          string
+	}{
+		{name: "unset", want: ""},
+		{name: "optout", optOut: "1", want: TelemetryOptOutEnvVar},
+		{name: "telemetry 0", telemetry: "0", want: TelemetryEnvVar},
+		{name: "telemetry off", telemetry: "OFF", want: TelemetryEnvVar},
+		{name: "telemetry 1", telemetry: "1", want: ""},
+		{name: "do not track", doNotTrack: "1", want: DoNotTrackEnvVar},
+		{name: "do not track true", doNotTrack: "true", want: DoNotTrackEnvVar},
+		{name: "do not track 0", doNotTrack: "0", want: ""},
+		{name: "do not track false", doNotTrack: "false", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(TelemetryOptOutEnvVar, tt.optOut)
+			t.Setenv(TelemetryEnvVar, tt.telemetry)
+			t.Setenv(DoNotTrackEnvVar, tt.doNotTrack)
+			if got := TelemetryOptOutEnv(); got != tt.want {
+				t.Errorf("TelemetryOptOutEnv() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoad_UserSettingsRejectsUnknownKeys(t *testing.T) {
+	setupLayeredSettings(t, `{"unknown_key": true}`, "", "")
+
+	if _, err := Load(); err == nil || !containsUnknownField(err.Error()) {
+		t.Errorf("Load() error = %v, want unknown field error", err)
+	}
+}
+
+func TestLoadRepo_IgnoresUserSettingsAndEnv(t *testing.T) {
+	setupLayeredSettings(t, `{"log_level": "debug", "enabled": false}`, `{"strategy": "auto-commit"}`, "")
+	t.Setenv(TelemetryOptOutEnvVar, "1")
+
+	settings, err := LoadRepo()
+	if err != nil {
+		t.Fatalf("LoadRepo() error = %v", err)
+	}
+	if settings.Strategy != "auto-commit" || settings.LogLevel != "" || !settings.Enabled || settings.Telemetry != nil {
+		t.Errorf("LoadRepo() = %+v, want project settings only", settings)
+	}
+}
+
+func TestLoadWithSources_TracingEndpoint(t *testing.T) {
+	setupLayeredSettings(t, "", `{"tracing": {"endpoint": "http://collector:4318"}}`, "")
+
+	settings, sources, err := LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if settings.Tracing == nil || settings.Tracing.Endpoint != "http://collector:4318" || sources.Source("tracing.endpoint") != SourceProject {
+		t.Errorf("tracing = %+v from %q, want project endpoint", settings.Tracing, sources.Source("tracing.endpoint"))
+	}
+
+	t.Setenv(TracingEndpointEnvVar, "http://localhost:4318")
+	settings, sources, err = LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if settings.Tracing.Endpoint != "http://localhost:4318" || sources.Source("tracing") != SourceEnv+" "+TracingEndpointEnvVar {
+		t.Errorf("tracing = %+v from %q, want env override", settings.Tracing, sources.Source("tracing"))
+	}
+}
+
+func TestLoad_WarnsAboutInvalidValues(t *testing.T) {
+	setupLayeredSettings(t, "", `{"strategy": "nope"}`, "")
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	t.Cleanup(func() { slog.SetDefault(previous) })
+
+	// Invalid values are reported once, without failing the load
+	for range 2 {
+		settings, err := Load()
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if settings.Strategy != "nope" {
+			t.Errorf("Strategy = %q, want the configured value", settings.Strategy)
+		}
+	}
+	// Nothing goes to stderr before logging is initialized
+	if logs.Len() != 0 {
+		t.Errorf("expected no warnings before logging.Init, got:\n%s", logs.String())
+	}
+
+	paths.ClearRepoRootCache()
+	if err := logging.Init(""); err != nil {
+		t.Fatalf("logging.Init() error = %v", err)
+	}
+	logging.Close()
+	data, err := os.ReadFile(filepath.Join(logging.LogsDir, "entire.log"))
+	if err != nil {
+		t.Fatalf("failed to read log file: %v", err)
+	}
+	if got := strings.Count(string(data), "invalid setting"); got != 1 {
+		t.Errorf("expected 1 invalid setting warning, got %d:\n%s", got, data)
+	}
+	if !strings.Contains(string(data), "strategy") {
+		t.Errorf("warning should name the setting, got:\n%s", data)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("expected no warnings on stderr, got:\n%s", logs.String())
+	}
+}
@@ -23,6 +23,8 @@ import (
 const (
 	strategyDisplayManualCommit = "manual-commit"
 	strategyDisplayAutoCommit   = "auto-commit"
+	strategyDisplayWorktree     = "worktree"
+	strategyDisplaySquashCommit = "squash-commit"
 )
 
 // Config path display strings
@@ -35,12 +37,16 @@ const (
 var strategyDisplayToInternal = map[string]string{
 	strategyDisplayManualCommit: strategy.StrategyNameManualCommit,
 	strategyDisplayAutoCommit:   strategy.StrategyNameAutoCommit,
+	strategyDisplayWorktree:     strategy.StrategyNameWorktree,
+	strategyDisplaySquashCommit: strategy.StrategyNameSquashCommit,
 }
 
 // strategyInternalToDisplay maps internal strategy names to user-friendly names
 var strategyInternalToDisplay = map[string]string{
 	strategy.StrategyNameManualCommit: strategyDisplayManualCommit,
 	strategy.StrategyNameAutoCommit:   strategyDisplayAutoCommit,
+	strategy.StrategyNameWorktree:     strategyDisplayWorktree,
+	strategy.StrategyNameSquashCommit: strategyDisplaySquashCommit,
 }
 
 func newEnableCmd() *cobra.Command {
@@ -53,6 +59,7 @@ func newEnableCmd() *cobra.Command {
 	var forceHooks bool
 	var skipPushSessions bool
 	var telemetry bool
+	var statusLine bool
 
 	cmd := &cobra.Command{
 		Use:   "enable",
@@ -63,7 +70,7 @@ Uses the manual-commit strategy by default. To use a different strategy:
 
   entire enable --strategy auto-commit
 
-Strategies: manual-commit (default), auto-commit`,
+Strategies: manual-commit (default), auto-commit, worktree, squash-commit`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			// Check if we're in a git repository first - this is a prerequisite error,
 			// not a usage error, so we silence Cobra's output and use SilentError
@@ -96,13 +103,13 @@ Strategies: manual-commit (default), auto-commit`,
 					printWrongAgentError(cmd.ErrOrStderr(), agentName)
 					return NewSilentError(errors.New("wrong agent name"))
 				}
-				return setupAgentHooksNonInteractive(cmd.OutOrStdout(), ag, strategyFlag, localDev, forceHooks, skipPushSessions, telemetry)
+				return setupAgentHooksNonInteractive(cmd.OutOrStdout(), ag, strategyFlag, localDev, forceHooks, skipPushSessions, telemetry, statusLine)
 			}
 			// If strategy is specified via flag, skip interactive selection
 			if strategyFlag != "" {
-				return runEnableWithStrategy(cmd.OutOrStdout(), strategyFlag, localDev, ignoreUntracked, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry)
+				return runEnableWithStrategy(cmd.OutOrStdout(), strategyFlag, localDev, ignoreUntracked, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry, statusLine)
 			}
-			return runEnableInteractive(cmd.OutOrStdout(), localDev, ignoreUntracked, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry)
+			return runEnableInteractive(cmd.OutOrStdout(), localDev, ignoreUntracked, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry, statusLine)
 		},
 	}
 
@@ -113,13 +120,14 @@ Strategies: manual-commit (default), auto-commit`,
 	cmd.Flags().BoolVar(&useLocalSettings, "local", false, "Write settings to .entire/settings.local.json instead of .entire/settings.json")
 	cmd.Flags().BoolVar(&useProjectSettings, "project", false, "Write settings to .entire/settings.json even if it already exists")
 	cmd.Flags().StringVar(&agentName, "agent", "", "Agent to setup hooks for (e.g., claude-code). Enables non-interactive mode.")
-	cmd.Flags().StringVar(&strategyFlag, "strategy", "", "Strategy to use (manual-commit or auto-commit)")
+	cmd.Flags().StringVar(&strategyFlag, "strategy", "", "Strategy to use (manual-commit, auto-commit, worktree or squash-commit)")
 	cmd.Flags().BoolVarP(&forceHooks, "force", "f", false, "Force reinstall hooks (removes existing Entire hooks first)")
 	cmd.Flags().BoolVar(&skipPushSessions, "skip-push-sessions", false, "Disable automatic pushing of session logs on git push")
 	cmd.Flags().BoolVar(&telemetry, "telemetry", true, "Enable anonymous usage analytics")
+	cmd.Flags().BoolVar(&statusLine, "statusline", false, "Show Entire session info in Claude Code's status line")
 	//nolint:errcheck,gosec // completion is optional, flag is defined above
 	cmd.RegisterFlagCompletionFunc("strategy", func(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
-		return []string{strategyDisplayManualCommit, strategyDisplayAutoCommit}, cobra.ShellCompDirectiveNoFileComp
+		return []string{strategyDisplayManualCommit, strategyDisplayAutoCommit, strategyDisplayWorktree, strategyDisplaySquashCommit}, cobra.ShellCompDirectiveNoFileComp
 	})
 
 	// Provide a helpful error when --agent is used without a value
@@ -221,7 +229,7 @@ func isFullyEnabled() (enabled bool, agentDesc string, configPath string) {
 // runEnableWithStrategy enables Entire with a specified strategy (non-interactive).
 // The selectedStrategy can be either a display name (manual-commit, auto-commit)
 // or an internal name (manual-commit, auto-commit).
-func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry bool) error {
+func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry, statusLine bool) error {
 	// Map the strategy to internal name if it's a display name
 	internalStrategy := selectedStrategy
 	if mapped, ok := strategyDisplayToInternal[selectedStrategy]; ok {
@@ -231,7 +239,7 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 	// Validate the strategy exists
 	strat, err := strategy.Get(internalStrategy)
 	if err != nil {
-		return fmt.Errorf("unknown strategy: %s (use manual-commit or auto-commit)", selectedStrategy)
+		return fmt.Errorf("unknown strategy: %s (use manual-commit, auto-commit, worktree or squash-commit)", selectedStrategy)
 	}
 
 	// Detect default agent
@@ -243,7 +251,7 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 	fmt.Fprintf(w, "Agent: %s (use --agent to change)\n\n", agentType)
 
 	// Setup Claude Code hooks (agent hooks don't depend on settings)
-	if _, err := setupClaudeCodeHook(localDev, forceHooks); err != nil {
+	if _, err := setupClaudeCodeHook(localDev, forceHooks, statusLine); err != nil {
 		return fmt.Errorf("failed to setup Claude Code hooks: %w", err)
 	}
 
@@ -253,7 +261,7 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 	}
 
 	// Load existing settings to preserve other options (like strategy_options.push)
-	settings, err := LoadEntireSettings()
+	settings, err := LoadEntireRepoSettings()
 	if err != nil {
 		// If we can't load, start with defaults
 		settings = &EntireSettings{}
@@ -273,7 +281,7 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 
 	// Handle telemetry for non-interactive mode
 	// Note: if telemetry is nil (not configured), it defaults to disabled
-	if !telemetry || os.Getenv("ENTIRE_TELEMETRY_OPTOUT") != "" {
+	if !telemetry || telemetryOptOutEnv() != "" {
 		f := false
 		settings.Telemetry = &f
 	}
@@ -306,7 +314,7 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 	if _, err := strategy.InstallGitHook(true); err != nil {
 		return fmt.Errorf("failed to install git hooks: %w", err)
 	}
-	fmt.Fprintln(w, "✓ Hooks installed")
+	printGitHooksInstalled(w)
 	fmt.Fprintf(w, "✓ Project configured (%s)\n", configDisplay)
 
 	// Let the strategy handle its own setup requirements
@@ -320,10 +328,10 @@ func runEnableWithStrategy(w io.Writer, selectedStrategy string, localDev, _, us
 }
 
 // runEnableInteractive runs the interactive enable flow.
-func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry bool) error {
+func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProjectSettings, forceHooks, skipPushSessions, telemetry, statusLine bool) error {
 	// Check if already fully enabled — show summary and return early.
 	// Skip early return if any configuration flags are set (user wants to reconfigure).
-	hasConfigFlags := forceHooks || skipPushSessions || !telemetry || useLocalSettings || useProjectSettings || localDev
+	hasConfigFlags := forceHooks || skipPushSessions || !telemetry || useLocalSettings || useProjectSettings || localDev || statusLine
 	if !hasConfigFlags {
 		if fullyEnabled, agentDesc, configPath := isFullyEnabled(); fullyEnabled {
 			fmt.Fprintln(w, "Already enabled. Everything looks good.")
@@ -343,7 +351,7 @@ func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProject
 	fmt.Fprintf(w, "Agent: %s (use --agent to change)\n\n", agentType)
 
 	// Setup Claude Code hooks (agent hooks don't depend on settings)
-	if _, err := setupClaudeCodeHook(localDev, forceHooks); err != nil {
+	if _, err := setupClaudeCodeHook(localDev, forceHooks, statusLine); err != nil {
 		return fmt.Errorf("failed to setup Claude Code hooks: %w", err)
 	}
 
@@ -356,7 +364,7 @@ func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProject
 	internalStrategy := strategy.DefaultStrategyName
 
 	// Load existing settings to preserve other options (like strategy_options.push)
-	settings, err := LoadEntireSettings()
+	settings, err := LoadEntireRepoSettings()
 	if err != nil {
 		// If we can't load, start with defaults
 		settings = &EntireSettings{}
@@ -404,7 +412,7 @@ func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProject
 	if _, err := strategy.InstallGitHook(true); err != nil {
 		return fmt.Errorf("failed to install git hooks: %w", err)
 	}
-	fmt.Fprintln(w, "✓ Hooks installed")
+	printGitHooksInstalled(w)
 
 	configDisplay := configDisplayProject
 	if shouldUseLocal {
@@ -438,7 +446,7 @@ func runEnableInteractive(w io.Writer, localDev, _, useLocalSettings, useProject
 
 // runEnable is a simple enable that just sets the enabled flag (for programmatic use).
 func runEnable(w io.Writer) error {
-	settings, err := LoadEntireSettings()
+	settings, err := LoadEntireRepoSettings()
 	if err != nil {
 		return fmt.Errorf("failed to load settings: %w", err)
 	}
@@ -453,7 +461,7 @@ func runEnable(w io.Writer) error {
 }
 
 func runDisable(w io.Writer, useProjectSettings bool) error {
-	settings, err := LoadEntireSettings()
+	settings, err := LoadEntireRepoSettings()
 	if err != nil {
 		return fmt.Errorf("failed to load settings: %w", err)
 	}
@@ -498,7 +506,7 @@ func checkDisabledGuard(w io.Writer) bool {
 // setupClaudeCodeHook sets up Claude Code hooks.
 // This is a convenience wrapper that uses the agent package.
 // Returns the number of hooks installed (0 if already installed).
-func setupClaudeCodeHook(localDev, forceHooks bool) (int, error) { //nolint:unparam // already present in codebase
+func setupClaudeCodeHook(localDev, forceHooks, statusLine bool) (int, error) { //nolint:unparam // already present in codebase
 	ag, err := agent.Get(agent.AgentNameClaudeCode)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get claude-code agent: %w", err)
@@ -513,10 +521,35 @@ func setupClaudeCodeHook(localDev, forceHooks bool) (int, error) { //nolint:unpa
 	if err != nil {
 		return 0, fmt.Errorf("failed to install claude-code hooks: %w", err)
 	}
+	if statusLine {
+		installed, err := installAgentStatusLine(io.Discard, ag, localDev)
+		if err != nil {
+			return 0, err
+		}
+		if installed {
+			count++
+		}
+	}
 
 	return count, nil
 }
 
+// installAgentStatusLine registers `entire statusline` with an agent that has
+// a status line, and notes on w that --statusline is ignored for other agents.
+// Returns true if the agent's settings changed.
+func installAgentStatusLine(w io.Writer, ag agent.Agent, localDev bool) (bool, error) {
+	installer, ok := ag.(agent.StatusLineInstaller)
+	if !ok {
+		fmt.Fprintf(w, "Note: %s has no status line, ignoring --statusline\n", ag.Description())
+		return false, nil
+	}
+	installed, err := installer.InstallStatusLine(localDev)
+	if err != nil {
+		return false, fmt.Errorf("failed to install status line for %s: %w", ag.Name(), err)
+	}
+	return installed, nil
+}
+
 // printAgentError writes an error message followed by available agents and usage.
 func printAgentError(w io.Writer, message string) {
 	agents := agent.List()
@@ -545,7 +578,7 @@ func printWrongAgentError(w io.Writer, name string) {
 
 // setupAgentHooksNonInteractive sets up hooks for a specific agent non-interactively.
 // If strategyName is provided, it sets the strategy; otherwise uses default.
-func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName string, localDev, forceHooks, skipPushSessions, telemetry bool) error {
+func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName string, localDev, forceHooks, skipPushSessions, telemetry, statusLine bool) error {
 	agentName := ag.Name()
 	// Check if agent supports hooks
 	hookAgent, ok := ag.(agent.HookSupport)
@@ -560,6 +593,15 @@ func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName str
 	if err != nil {
 		return fmt.Errorf("failed to install hooks for %s: %w", agentName, err)
 	}
+	if statusLine {
+		installed, err := installAgentStatusLine(w, ag, localDev)
+		if err != nil {
+			return err
+		}
+		if installed {
+			installedHooks++
+		}
+	}
 
 	// Setup .entire directory
 	if _, err := setupEntireDirectory(); err != nil {
@@ -567,7 +609,7 @@ func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName str
 	}
 
 	// Load existing settings to preserve other options (like strategy_options.push)
-	settings, err := LoadEntireSettings()
+	settings, err := LoadEntireRepoSettings()
 	if err != nil {
 		// If we can't load, start with defaults
 		settings = &EntireSettings{Strategy: strategy.DefaultStrategyName}
@@ -594,14 +636,14 @@ func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName str
 		}
 		// Validate the strategy exists
 		if _, err := strategy.Get(internalStrategy); err != nil {
-			return fmt.Errorf("unknown strategy: %s (use manual-commit or auto-commit)", strategyName)
+			return fmt.Errorf("unknown strategy: %s (use manual-commit, auto-commit, worktree or squash-commit)", strategyName)
 		}
 		settings.Strategy = internalStrategy
 	}
 
 	// Handle telemetry for non-interactive mode
 	// Note: if telemetry is nil (not configured), it defaults to disabled
-	if !telemetry || os.Getenv("ENTIRE_TELEMETRY_OPTOUT") != "" {
+	if !telemetry || telemetryOptOutEnv() != "" {
 		f := false
 		settings.Telemetry = &f
 	}
@@ -614,6 +656,7 @@ func setupAgentHooksNonInteractive(w io.Writer, ag agent.Agent, strategyName str
 	if _, err := strategy.InstallGitHook(true); err != nil {
 		return fmt.Errorf("failed to install git hooks: %w", err)
 	}
+	printHookManagerSnippet(w)
 
 	if installedHooks == 0 {
 		msg := fmt.Sprintf("Hooks for %s already installed", ag.Description())
@@ -708,6 +751,33 @@ func setupEntireDirectory() (bool, error) { //nolint:unparam // already present
 	return created, nil
 }
 
+// printGitHooksInstalled reports the outcome of InstallGitHook: which git
+// hooks Entire installed, and what to add to the hook manager (Husky,
+// lefthook, pre-commit) for the hooks it owns.
+func printGitHooksInstalled(w io.Writer) {
+	setup, err := strategy.DetectHookSetup()
+	if err != nil || len(setup.ManagedHooks) == 0 || setup.ManagerRunsEntireHooks() {
+		fmt.Fprintln(w, "✓ Hooks installed")
+		return
+	}
+	if !setup.ManagesAllHooks() {
+		fmt.Fprintf(w, "✓ Hooks installed, except the %s hooks %s manages\n", strings.Join(setup.ManagedHooks, ", "), setup.Manager)
+	}
+	printHookManagerSnippet(w)
+}
+
+// printHookManagerSnippet tells the user how to run Entire's git hooks from
+// the hook manager (Husky, lefthook, pre-commit) that owns some of them,
+// unless it already does.
+func printHookManagerSnippet(w io.Writer) {
+	setup, err := strategy.DetectHookSetup()
+	if err != nil || len(setup.ManagedHooks) == 0 || setup.ManagerRunsEntireHooks() {
+		return
+	}
+	fmt.Fprintf(w, "%s manages this repository's %s hooks, so Entire didn't install them.\n", setup.Manager, strings.Join(setup.ManagedHooks, ", "))
+	fmt.Fprintf(w, "Add them to %s:\n\n%s\n", setup.ManagerConfig, setup.ManagerSnippet())
+}
+
 // setupGitHook installs the prepare-commit-msg hook for context trailers.
 func setupGitHook() error {
 	// Use shared implementation from strategy package
@@ -882,8 +952,8 @@ func promptTelemetryConsent(settings *EntireSettings, telemetryFlag bool) error
 		return nil
 	}
 
-	// Skip if env var disables telemetry (record as disabled)
-	if os.Getenv("ENTIRE_TELEMETRY_OPTOUT") != "" {
+	// Skip if an env var disables telemetry (record as disabled)
+	if telemetryOptOutEnv() != "" {
 		f := false
 		settings.Telemetry = &f
 		return nil
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/entireio/cli/cmd/entire/cli/agent"
 	"github.com/entireio/cli/cmd/entire/cli/jsonutil"
 	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/strategy"
@@ -86,10 +88,16 @@ func CapturePrePromptState(sessionID, transcriptPath string) error {
 		return fmt.Errorf("failed to get untracked files: %w", err)
 	}
 
-	// Get transcript position (last UUID and line count)
+	// Get transcript position (last UUID and line count), counting any
+	// conversation from before a context compaction
 	var transcriptPos TranscriptPosition
 	if transcriptPath != "" {
-		transcriptPos, err = GetTranscriptPosition(transcriptPath)
+		data, readErr := strategy.ReadFullTranscript(sessionID, transcriptPath, agent.AgentTypeClaudeCode)
+		if readErr == nil {
+			transcriptPos, err = transcriptPositionFromReader(bytes.NewReader(data))
+		} else if !errors.Is(readErr, os.ErrNotExist) {
+			err = readErr
+		}
 		if err != nil {
 			// Log warning but don't fail - transcript position is optional
 			fmt.Fprintf(os.Stderr, "Warning: failed to get transcript position: %v\n", err)
@@ -145,12 +153,13 @@ func CaptureGeminiPrePromptState(sessionID, transcriptPath string) error {
 		return fmt.Errorf("failed to get untracked files: %w", err)
 	}
 
-	// Get transcript position (message count and last message ID) for Gemini
+	// Get transcript position (message count and last message ID) for Gemini,
+	// counting any conversation from before a context compaction
 	var startMessageIndex int
 	var lastMessageID string
 	if transcriptPath != "" {
 		// Read transcript and extract both message count and last message ID
-		if data, readErr := os.ReadFile(transcriptPath); readErr == nil && len(data) > 0 { //nolint:gosec // Reading from controlled transcript path
+		if data, readErr := strategy.ReadFullTranscript(sessionID, transcriptPath, agent.AgentTypeGemini); readErr == nil && len(data) > 0 {
 			var transcript struct {
 				Messages []struct {
 					ID string `json:"id"`
@@ -530,3 +539,59 @@ func GetNextCheckpointSequence(sessionID, taskToolUseID string) int {
 
 	return count + 1
 }
+
+// PreCommandState stores when the agent started a shell command, so the
+// post-tool hook can work out how long it ran.
+type PreCommandState struct {
+	Command   string    `json:"command"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// CapturePreCommandState saves the start of a shell command under key.
+func CapturePreCommandState(key, command string) error {
+	tmpDirAbs, err := paths.AbsPath(paths.EntireTmpDir)
+	if err != nil {
+		tmpDirAbs = paths.EntireTmpDir // Fallback to relative
+	}
+	if err := os.MkdirAll(tmpDirAbs, 0o750); err != nil {
+		return fmt.Errorf("failed to create tmp directory: %w", err)
+	}
+
+	data, err := json.Marshal(PreCommandState{Command: command, StartedAt: time.Now().UTC()})
+	if err != nil {
+		return fmt.Errorf("failed to marshal state: %w", err)
+	}
+	if err := os.WriteFile(preCommandStateFile(key), data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// LoadPreCommandState loads and removes the start of a shell command saved
+// under key. Returns nil if none was saved.
+func LoadPreCommandState(key string) (*PreCommandState, error) {
+	stateFile := preCommandStateFile(key)
+	data, err := os.ReadFile(stateFile) //nolint:gosec // Reading from controlled git metadata path
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil //nolint:nilnil // No state means the pre-tool hook didn't run
+		}
+		return nil, fmt.Errorf("failed to read state file: %w", err)
+	}
+	_ = os.Remove(stateFile)
+
+	var state PreCommandState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal state: %w", err)
+	}
+	return &state, nil
+}
+
+// preCommandStateFile returns the absolute path to the pre-command state file for key.
+func preCommandStateFile(key string) string {
+	tmpDirAbs, err := paths.AbsPath(paths.EntireTmpDir)
+	if err != nil {
+		tmpDirAbs = paths.EntireTmpDir // Fallback to relative
+	}
+	return filepath.Join(tmpDirAbs, fmt.Sprintf("pre-command-%s.json", key))
+}
@@ -21,18 +21,36 @@ import (
 )
 
 func newStatusCmd() *cobra.Command {
-	var detailed bool
+	var detailed, jsonOutput, watch bool
 
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Show Entire status",
-		Long:  "Show whether Entire is currently enabled or disabled",
+		Long: `Show whether Entire is currently enabled or disabled, and list active sessions.
+
+Use --json for machine-readable output covering effective settings, agents,
+hook health and active sessions. Use --watch to keep the output up to date as
+sessions change; with --json, each refresh is written as one line of JSON.`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			return runStatus(cmd.OutOrStdout(), detailed)
+			render := func(w io.Writer) error {
+				return runStatus(w, detailed)
+			}
+			if jsonOutput {
+				render = func(w io.Writer) error {
+					return runStatusJSON(w, !watch)
+				}
+			}
+			if watch {
+				return runStatusWatch(cmd.Context(), cmd.OutOrStdout(), render, !jsonOutput)
+			}
+			return render(cmd.OutOrStdout())
 		},
 	}
 
 	cmd.Flags().BoolVar(&detailed, "detailed", false, "Show detailed status for each settings file")
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Output status as JSON")
+	cmd.Flags().BoolVar(&watch, "watch", false, "Refresh the status whenever sessions change")
+	cmd.MarkFlagsMutuallyExclusive("detailed", "json")
 
 	return cmd
 }
@@ -44,27 +62,12 @@ func runStatus(w io.Writer, detailed bool) error {
 		return nil //nolint:nilerr // Not being in a git repo is a valid status, not an error
 	}
 
-	// Get absolute paths for settings files
-	settingsPath, err := paths.AbsPath(EntireSettingsFile)
-	if err != nil {
-		settingsPath = EntireSettingsFile
-	}
-	localSettingsPath, err := paths.AbsPath(EntireSettingsLocalFile)
+	files, err := statSettingsFiles()
 	if err != nil {
-		localSettingsPath = EntireSettingsLocalFile
-	}
-
-	// Check which settings files exist
-	_, projectErr := os.Stat(settingsPath)
-	if projectErr != nil && !errors.Is(projectErr, fs.ErrNotExist) {
-		return fmt.Errorf("cannot access project settings file: %w", projectErr)
+		return err
 	}
-	_, localErr := os.Stat(localSettingsPath)
-	if localErr != nil && !errors.Is(localErr, fs.ErrNotExist) {
-		return fmt.Errorf("cannot access local settings file: %w", localErr)
-	}
-	projectExists := projectErr == nil
-	localExists := localErr == nil
+	settingsPath, localSettingsPath := files.projectPath, files.localPath
+	projectExists, localExists := files.projectExists, files.localExists
 
 	if !projectExists && !localExists {
 		fmt.Fprintln(w, "○ not set up (run `entire enable` to get started)")
@@ -90,16 +93,67 @@ func runStatus(w io.Writer, detailed bool) error {
 	return nil
 }
 
-// runStatusDetailed shows the effective status plus detailed status for each settings file.
+// settingsFiles records where the repository settings files live and
+// whether they exist.
+type settingsFiles struct {
+	projectPath   string
+	localPath     string
+	projectExists bool
+	localExists   bool
+}
+
+// statSettingsFiles checks which repository settings files exist.
+func statSettingsFiles() (settingsFiles, error) {
+	// Get absolute paths for settings files
+	settingsPath, err := paths.AbsPath(EntireSettingsFile)
+	if err != nil {
+		settingsPath = EntireSettingsFile
+	}
+	localSettingsPath, err := paths.AbsPath(EntireSettingsLocalFile)
+	if err != nil {
+		localSettingsPath = EntireSettingsLocalFile
+	}
+
+	// Check which settings files exist
+	_, projectErr := os.Stat(settingsPath)
+	if projectErr != nil && !errors.Is(projectErr, fs.ErrNotExist) {
+		return settingsFiles{}, fmt.Errorf("cannot access project settings file: %w", projectErr)
+	}
+	_, localErr := os.Stat(localSettingsPath)
+	if localErr != nil && !errors.Is(localErr, fs.ErrNotExist) {
+		return settingsFiles{}, fmt.Errorf("cannot access local settings file: %w", localErr)
+	}
+
+	return settingsFiles{
+		projectPath:   settingsPath,
+		localPath:     localSettingsPath,
+		projectExists: projectErr == nil,
+		localExists:   localErr == nil,
+	}, nil
+}
+
+// runStatusDetailed shows the effective status plus detailed status for each
+// settings file, and where each effective setting comes from.
 func runStatusDetailed(w io.Writer, settingsPath, localSettingsPath string, projectExists, localExists bool) error {
 	// First show the effective/merged status
-	effectiveSettings, err := LoadEntireSettings()
+	effectiveSettings, sources, err := settings.LoadWithSources()
 	if err != nil {
 		return fmt.Errorf("failed to load settings: %w", err)
 	}
 	fmt.Fprintln(w, formatSettingsStatusShort(effectiveSettings))
 	fmt.Fprintln(w) // blank line
 
+	// Show user settings if they exist
+	if userSettingsPath, err := settings.UserSettingsPath(); err == nil {
+		if _, statErr := os.Stat(userSettingsPath); statErr == nil {
+			userSettings, err := settings.LoadFromFile(userSettingsPath)
+			if err != nil {
+				return fmt.Errorf("failed to load user settings: %w", err)
+			}
+			fmt.Fprintln(w, formatSettingsStatus("User", userSettings))
+		}
+	}
+
 	// Show project settings if it exists
 	if projectExists {
 		projectSettings, err := settings.LoadFromFile(settingsPath)
@@ -118,6 +172,9 @@ func runStatusDetailed(w io.Writer, settingsPath, localSettingsPath string, proj
 		fmt.Fprintln(w, formatSettingsStatus("Local", localSettings))
 	}
 
+	fmt.Fprintln(w)
+	writeSettingsSources(w, effectiveSettings, sources)
+
 	if effectiveSettings.Enabled {
 		writeActiveSessions(w)
 	}
@@ -125,6 +182,17 @@ func runStatusDetailed(w io.Writer, settingsPath, localSettingsPath string, proj
 	return nil
 }
 
+// writeSettingsSources lists each effective setting with the layer it came
+// from (default, user, project, local or an environment variable).
+func writeSettingsSources(w io.Writer, s *EntireSettings, sources settings.Sources) {
+	raw, err := settingsToRaw(s)
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(w, "Settings:")
+	writeSettingRows(w, "  ", flattenSettings("", raw, sources))
+}
+
 // formatSettingsStatusShort formats a short settings status line.
 // Output format: "Enabled (manual-commit)" or "Disabled (auto-commit)"
 func formatSettingsStatusShort(settings *EntireSettings) string {
@@ -180,25 +248,30 @@ type worktreeGroup struct {
 
 const unknownPlaceholder = "(unknown)"
 
-// writeActiveSessions writes active session information grouped by worktree.
-func writeActiveSessions(w io.Writer) {
+// listActiveSessions returns the sessions that have not been ended.
+func listActiveSessions() []*session.State {
 	store, err := session.NewStateStore()
 	if err != nil {
-		return
+		return nil
 	}
 
 	states, err := store.List(context.Background())
-	if err != nil || len(states) == 0 {
-		return
+	if err != nil {
+		return nil
 	}
 
-	// Filter to active sessions only
 	var active []*session.State
 	for _, s := range states {
 		if s.EndedAt == nil {
 			active = append(active, s)
 		}
 	}
+	return active
+}
+
+// writeActiveSessions writes active session information grouped by worktree.
+func writeActiveSessions(w io.Writer) {
+	active := listActiveSessions()
 	if len(active) == 0 {
 		return
 	}
@@ -0,0 +1,249 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+
+	"golang.org/x/term"
+)
+
+// statusWatchInterval is how often --watch checks the session state directory.
+const statusWatchInterval = 500 * time.Millisecond
+
+// clearScreen moves the cursor home and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+// statusReport is the JSON form of "entire status", for editor extensions
+// and shell prompts.
+type statusReport struct {
+	GitRepository  bool              `json:"git_repository"`
+	SetUp          bool              `json:"set_up"`
+	Enabled        bool              `json:"enabled"`
+	Strategy       string            `json:"strategy,omitempty"`
+	Settings       map[string]any    `json:"settings,omitempty"`
+	SettingSources map[string]string `json:"setting_sources,omitempty"`
+	Agents         []statusAgent     `json:"agents"`
+	GitHooks       []statusGitHook   `json:"git_hooks"`
+	Sessions       []statusSession   `json:"sessions"`
+}
+
+// statusAgent reports whether an agent's hooks are installed.
+type statusAgent struct {
+	Name           agent.AgentName `json:"name"`
+	Type           agent.AgentType `json:"type"`
+	HooksInstalled bool            `json:"hooks_installed"`
+}
+
+// statusGitHook reports whether a managed git hook is installed.
+type statusGitHook struct {
+	Name      string `json:"name"`
+	Installed bool   `json:"installed"`
+}
+
+// statusSession is an active session in the JSON status.
+type statusSession struct {
+	SessionID         string            `json:"session_id"`
+	Agent             agent.AgentType   `json:"agent,omitempty"`
+	Phase             session.Phase     `json:"phase"`
+	Worktree          string            `json:"worktree,omitempty"`
+	Branch            string            `json:"branch,omitempty"`
+	StartedAt         time.Time         `json:"started_at"`
+	LastInteractionAt *time.Time        `json:"last_interaction_at,omitempty"`
+	FirstPrompt       string            `json:"first_prompt,omitempty"`
+	StepCount         int               `json:"step_count"`
+	FilesTouched      []string          `json:"files_touched"`
+	TokenUsage        *agent.TokenUsage `json:"token_usage,omitempty"`
+}
+
+// runStatusJSON writes the status as JSON. Indented output is for people;
+// compact output puts each report on a single line.
+func runStatusJSON(w io.Writer, indent bool) error {
+	report, err := buildStatusReport()
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	if indent {
+		enc.SetIndent("", "  ")
+	}
+	if err := enc.Encode(report); err != nil {
+		return fmt.Errorf("failed to write status: %w", err)
+	}
+	return nil
+}
+
+// buildStatusReport gathers the effective settings, agent and git hook
+// health, and active sessions.
+func buildStatusReport() (*statusReport, error) {
+	report := &statusReport{
+		Agents:   []statusAgent{},
+		GitHooks: []statusGitHook{},
+		Sessions: []statusSession{},
+	}
+	if _, err := paths.RepoRoot(); err != nil {
+		return report, nil //nolint:nilerr // Not being in a git repo is a valid status, not an error
+	}
+	report.GitRepository = true
+
+	files, err := statSettingsFiles()
+	if err != nil {
+		return nil, err
+	}
+	report.SetUp = files.projectExists || files.localExists
+
+	effective, sources, err := settings.LoadWithSources()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load settings: %w", err)
+	}
+	raw, err := settingsToRaw(effective)
+	if err != nil {
+		return nil, err
+	}
+	report.Enabled = report.SetUp && effective.Enabled
+	report.Strategy = effective.Strategy
+	report.Settings = raw
+	report.SettingSources = make(map[string]string)
+	for _, row := range flattenSettings("", raw, sources) {
+		report.SettingSources[row.key] = row.source
+	}
+
+	for _, name := range agent.List() {
+		ag, err := agent.Get(name)
+		if err != nil {
+			continue
+		}
+		hs, ok := ag.(agent.HookSupport)
+		if !ok {
+			continue
+		}
+		report.Agents = append(report.Agents, statusAgent{
+			Name:           name,
+			Type:           ag.Type(),
+			HooksInstalled: hs.AreHooksInstalled(),
+		})
+	}
+
+	if hookStatus, err := strategy.GitHookStatus(); err == nil {
+		for _, name := range strategy.ManagedGitHookNames() {
+			report.GitHooks = append(report.GitHooks, statusGitHook{Name: name, Installed: hookStatus[name]})
+		}
+	}
+
+	active := listActiveSessions()
+	sort.Slice(active, func(i, j int) bool {
+		return active[i].StartedAt.After(active[j].StartedAt)
+	})
+	branches := make(map[string]string)
+	for _, st := range active {
+		branch, ok := branches[st.WorktreePath]
+		if !ok && st.WorktreePath != "" {
+			branch = resolveWorktreeBranch(st.WorktreePath)
+			branches[st.WorktreePath] = branch
+		}
+		filesTouched := st.FilesTouched
+		if filesTouched == nil {
+			filesTouched = []string{}
+		}
+		report.Sessions = append(report.Sessions, statusSession{
+			SessionID:         st.SessionID,
+			Agent:             st.AgentType,
+			Phase:             session.PhaseFromString(string(st.Phase)),
+			Worktree:          st.WorktreePath,
+			Branch:            branch,
+			StartedAt:         st.StartedAt,
+			LastInteractionAt: st.LastInteractionTime,
+			FirstPrompt:       st.FirstPrompt,
+			StepCount:         st.StepCount,
+			FilesTouched:      filesTouched,
+			TokenUsage:        st.TokenUsage,
+		})
+	}
+
+	return report, nil
+}
+
+// runStatusWatch renders the status, then renders it again each time the
+// session state directory changes, until ctx is cancelled. Text output
+// clears the terminal between refreshes.
+func runStatusWatch(ctx context.Context, w io.Writer, render func(io.Writer) error, text bool) error {
+	store, err := session.NewStateStore()
+	if err != nil {
+		return fmt.Errorf("failed to open session state: %w", err)
+	}
+	clearTerminal := text && isTerminalWriter(w)
+
+	ticker := time.NewTicker(statusWatchInterval)
+	defer ticker.Stop()
+
+	rendered := false
+	var last string
+	for {
+		if current := sessionStateFingerprint(store.Dir()); !rendered || current != last {
+			var buf bytes.Buffer
+			if err := render(&buf); err != nil {
+				return err
+			}
+			switch {
+			case clearTerminal:
+				fmt.Fprint(w, clearScreen)
+			case text && rendered:
+				fmt.Fprintln(w)
+			}
+			if _, err := w.Write(buf.Bytes()); err != nil {
+				return fmt.Errorf("failed to write status: %w", err)
+			}
+			rendered, last = true, current
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sessionStateFingerprint summarizes the names, sizes and modification times
+// of the files in the session state directory, so changes can be detected
+// without reading every file.
+func sessionStateFingerprint(dir string) string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return ""
+	}
+	var sb strings.Builder
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		sb.WriteString(entry.Name())
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatInt(info.Size(), 10))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.FormatInt(info.ModTime().UnixNano(), 10))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// isTerminalWriter reports whether w is a terminal.
+func isTerminalWriter(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
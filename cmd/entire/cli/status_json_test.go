@@ -0,0 +1,154 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+)
+
+func TestRunStatusJSON(t *testing.T) {
+	setupTestRepo(t)
+	dir, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Getwd() error = %v", err)
+	}
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv(settings.LogLevelEnvVar, "")
+	t.Setenv(settings.TelemetryOptOutEnvVar, "")
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true}`)
+
+	store, err := session.NewStateStore()
+	if err != nil {
+		t.Fatalf("NewStateStore() error = %v", err)
+	}
+	endedAt := time.Now()
+	for _, st := range []*session.State{
+		{
+			SessionID:    "active-session",
+			WorktreePath: dir,
+			StartedAt:    time.Now().Add(-time.Hour),
+			Phase:        session.PhaseActive,
+			AgentType:    agent.AgentType("Claude Code"),
+			StepCount:    3,
+			FilesTouched: []string{"main.go"},
+			TokenUsage:   &agent.TokenUsage{InputTokens: 100, OutputTokens: 20},
+		},
+		{SessionID: "ended-session", StartedAt: time.Now().Add(-2 * time.Hour), EndedAt: &endedAt},
+	} {
+		if err := store.Save(context.Background(), st); err != nil {
+			t.Fatalf("Save() error = %v", err)
+		}
+	}
+
+	var out bytes.Buffer
+	if err := runStatusJSON(&out, true); err != nil {
+		t.Fatalf("runStatusJSON() error = %v", err)
+	}
+	var report statusReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, out.String())
+	}
+
+	if !report.GitRepository || !report.SetUp || !report.Enabled || report.Strategy != "manual-commit" {
+		t.Errorf("report = %+v", report)
+	}
+	if report.SettingSources["strategy"] != settings.SourceProject {
+		t.Errorf("strategy source = %q, want %q", report.SettingSources["strategy"], settings.SourceProject)
+	}
+	if len(report.Agents) == 0 || len(report.GitHooks) == 0 {
+		t.Errorf("agents = %v, git hooks = %v; want health entries", report.Agents, report.GitHooks)
+	}
+	if len(report.Sessions) != 1 {
+		t.Fatalf("sessions = %+v, want only the active session", report.Sessions)
+	}
+	got := report.Sessions[0]
+	if got.SessionID != "active-session" || got.Phase != session.PhaseActive || got.StepCount != 3 ||
+		got.Worktree != dir || len(got.FilesTouched) != 1 || got.TokenUsage == nil || got.TokenUsage.InputTokens != 100 {
+		t.Errorf("session = %+v", got)
+	}
+}
+
+func TestRunStatusJSON_NotGitRepository(t *testing.T) {
+	setupTestDir(t)
+
+	var out bytes.Buffer
+	if err := runStatusJSON(&out, false); err != nil {
+		t.Fatalf("runStatusJSON() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != `{"git_repository":false,"set_up":false,"enabled":false,"agents":[],"git_hooks":[],"sessions":[]}` {
+		t.Errorf("runStatusJSON() = %s", got)
+	}
+}
+
+// syncBuffer is a bytes.Buffer safe for concurrent writes and reads.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p) //nolint:wrapcheck // test helper
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestRunStatusWatch_RefreshesOnSessionChange(t *testing.T) {
+	setupTestRepo(t)
+	store, err := session.NewStateStore()
+	if err != nil {
+		t.Fatalf("NewStateStore() error = %v", err)
+	}
+
+	var out syncBuffer
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- runStatusWatch(ctx, &out, func(w io.Writer) error {
+			_, err := io.WriteString(w, "render\n")
+			return err //nolint:wrapcheck // test helper
+		}, false)
+	}()
+
+	waitFor := func(n int) {
+		t.Helper()
+		deadline := time.Now().Add(5 * time.Second)
+		for strings.Count(out.String(), "render") < n {
+			if time.Now().After(deadline) {
+				t.Fatalf("timed out waiting for %d renders, got %q", n, out.String())
+			}
+			time.Sleep(20 * time.Millisecond)
+		}
+	}
+
+	waitFor(1)
+	if err := store.Save(context.Background(), &session.State{SessionID: "new-session", StartedAt: time.Now()}); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	waitFor(2)
+
+	// Without changes, the status is not rendered again
+	time.Sleep(3 * statusWatchInterval)
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("runStatusWatch() error = %v", err)
+	}
+	if got := strings.Count(out.String(), "render"); got != 2 {
+		t.Errorf("rendered %d times, want 2", got)
+	}
+}
@@ -3,6 +3,8 @@ package cli
 import (
 	"bytes"
 	"context"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -139,6 +141,50 @@ func TestRunStatus_BothProjectAndLocal_Short(t *testing.T) {
 	}
 }
 
+func TestRunStatus_DetailedShowsSettingSources(t *testing.T) {
+	setupTestRepo(t)
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("ENTIRE_LOG_LEVEL", "")
+	t.Setenv("ENTIRE_TELEMETRY_OPTOUT", "")
+	t.Setenv("ENTIRE_TELEMETRY", "")
+	t.Setenv("DO_NOT_TRACK", "")
+	userPath := filepath.Join(configHome, "entire", "settings.json")
+	if err := os.MkdirAll(filepath.Dir(userPath), 0o755); err != nil {
+		t.Fatalf("failed to create user config dir: %v", err)
+	}
+	if err := os.WriteFile(userPath, []byte(`{"log_level": "debug", "telemetry": false}`), 0o644); err != nil {
+		t.Fatalf("failed to write user settings: %v", err)
+	}
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true}`)
+
+	var stdout bytes.Buffer
+	if err := runStatus(&stdout, true); err != nil {
+		t.Fatalf("runStatus() error = %v", err)
+	}
+
+	output := stdout.String()
+	if !strings.Contains(output, "User, enabled (manual-commit)") {
+		t.Errorf("Expected output to show user settings, got: %s", output)
+	}
+	sources := make(map[string]string)
+	for _, line := range strings.Split(output, "\n") {
+		if fields := strings.Fields(line); len(fields) == 3 {
+			sources[fields[0]] = fields[1] + " " + fields[2]
+		}
+	}
+	for key, want := range map[string]string{
+		"strategy":  `"manual-commit" (project)`,
+		"enabled":   "true (project)",
+		"log_level": `"debug" (user)`,
+		"telemetry": "false (user)",
+	} {
+		if sources[key] != want {
+			t.Errorf("%s = %q, want %q; output: %s", key, sources[key], want, output)
+		}
+	}
+}
+
 func TestRunStatus_ShowsStrategy(t *testing.T) {
 	setupTestRepo(t)
 	writeSettings(t, `{"strategy": "auto-commit", "enabled": true}`)
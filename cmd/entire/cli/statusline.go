@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/agent/claudecode"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+
+	"github.com/spf13/cobra"
+)
+
+// statuslineSeparator separates the parts of the status line.
+const statuslineSeparator = " · "
+
+func newStatuslineCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "statusline",
+		Short: "Print session info for Claude Code's status line",
+		Long: `Print a compact line describing the current Entire session, for use as
+Claude Code's status line command. Reads the JSON Claude Code writes to stdin
+and shows the session's phase, checkpoint count, uncommitted files touched,
+running cost, and whether a condensation is pending.
+
+Register it with 'entire enable --statusline', or add it to .claude/settings.json:
+
+  "statusLine": {"type": "command", "command": "entire statusline"}`,
+		Args: cobra.NoArgs,
+		// Hidden like the hook commands: Claude Code runs it on every refresh,
+		// so it must skip telemetry and version check output
+		Hidden: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runStatusline(cmd.Context(), cmd.InOrStdin(), cmd.OutOrStdout())
+		},
+	}
+}
+
+func runStatusline(ctx context.Context, r io.Reader, w io.Writer) error {
+	var input claudecode.StatusLineInput
+	if err := json.NewDecoder(r).Decode(&input); err != nil {
+		return fmt.Errorf("failed to parse status line input: %w", err)
+	}
+
+	// Stay quiet when Entire isn't tracking this repository
+	if enabled, err := IsEnabled(); err != nil || !enabled {
+		return nil //nolint:nilerr // A status line has nothing to show outside enabled repos
+	}
+
+	var state *session.State
+	if input.SessionID != "" {
+		if store, err := session.NewStateStore(); err == nil {
+			state, _ = store.Load(ctx, input.SessionID) //nolint:errcheck // A missing or unreadable state shows as untracked
+		}
+	}
+
+	uncommitted := 0
+	if state != nil && len(state.FilesTouched) > 0 {
+		changed := uncommittedPaths(ctx)
+		for _, f := range state.FilesTouched {
+			if changed[f] {
+				uncommitted++
+			}
+		}
+	}
+
+	fmt.Fprintln(w, formatStatusline(state, input.Cost.TotalCostUSD, uncommitted))
+	return nil
+}
+
+// formatStatusline builds the status line, e.g.
+// "entire · active · 3 checkpoints · 2 uncommitted · $0.42 · condense pending".
+func formatStatusline(state *session.State, costUSD float64, uncommitted int) string {
+	if state == nil {
+		return "entire" + statuslineSeparator + "not tracked yet"
+	}
+
+	phase := session.PhaseFromString(string(state.Phase))
+	label := string(phase)
+	if phase == session.PhaseActiveCommitted {
+		label = string(session.PhaseActive)
+	}
+	parts := []string{"entire", label}
+
+	if state.StepCount == 1 {
+		parts = append(parts, "1 checkpoint")
+	} else {
+		parts = append(parts, fmt.Sprintf("%d checkpoints", state.StepCount))
+	}
+	if uncommitted > 0 {
+		parts = append(parts, fmt.Sprintf("%d uncommitted", uncommitted))
+	}
+
+	switch {
+	case costUSD > 0:
+		parts = append(parts, fmt.Sprintf("$%.2f", costUSD))
+	case state.TokenUsage != nil:
+		parts = append(parts, formatTokenCount(totalTokens(state.TokenUsage))+" tokens")
+	}
+
+	// A commit during the turn defers condensation to the end of the turn
+	if phase == session.PhaseActiveCommitted {
+		parts = append(parts, "condense pending")
+	}
+
+	return strings.Join(parts, statuslineSeparator)
+}
+
+// totalTokens sums all token counts, including subagents.
+func totalTokens(t *agent.TokenUsage) int {
+	if t == nil {
+		return 0
+	}
+	return t.InputTokens + t.CacheCreationTokens + t.CacheReadTokens + t.OutputTokens + totalTokens(t.SubagentTokens)
+}
+
+// formatTokenCount abbreviates a token count, e.g. 950, 12.3k or 1.2M.
+func formatTokenCount(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	default:
+		return strconv.Itoa(n)
+	}
+}
+
+// uncommittedPaths returns the repo-relative paths with staged, unstaged or
+// untracked changes. Uses git CLI so global gitignore is respected.
+func uncommittedPaths(ctx context.Context) map[string]bool {
+	output, err := exec.CommandContext(ctx, "git", "status", "--porcelain", "-z", "--untracked-files=all").Output()
+	if err != nil {
+		return nil
+	}
+	changed := make(map[string]bool)
+	entries := strings.Split(string(output), "\x00")
+	for i := 0; i < len(entries); i++ {
+		entry := entries[i]
+		if len(entry) < 4 {
+			continue
+		}
+		changed[entry[3:]] = true
+		// Renames and copies are followed by the original path
+		if entry[0] == 'R' || entry[0] == 'C' {
+			i++
+		}
+	}
+	return changed
+}
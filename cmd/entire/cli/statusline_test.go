@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+)
+
+func TestFormatStatusline(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		state       *session.State
+		cost        float64
+		uncommitted int
+		want        string
+	}{
+		{
+			name: "no session",
+			want: "entire · not tracked yet",
+		},
+		{
+			name:  "idle with one checkpoint",
+			state: &session.State{StepCount: 1},
+			want:  "entire · idle · 1 checkpoint",
+		},
+		{
+			name:        "active with cost",
+			state:       &session.State{Phase: session.PhaseActive, StepCount: 3},
+			cost:        0.4213,
+			uncommitted: 2,
+			want:        "entire · active · 3 checkpoints · 2 uncommitted · $0.42",
+		},
+		{
+			name: "tokens without cost and condensation pending",
+			state: &session.State{
+				Phase:      session.PhaseActiveCommitted,
+				StepCount:  2,
+				TokenUsage: &agent.TokenUsage{InputTokens: 12000, OutputTokens: 300, SubagentTokens: &agent.TokenUsage{OutputTokens: 1000}},
+			},
+			want: "entire · active · 2 checkpoints · 13.3k tokens · condense pending",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := formatStatusline(tt.state, tt.cost, tt.uncommitted); got != tt.want {
+				t.Errorf("formatStatusline() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunStatusline(t *testing.T) {
+	setupTestRepo(t)
+	writeSettings(t, testSettingsEnabled)
+
+	store, err := session.NewStateStore()
+	if err != nil {
+		t.Fatalf("NewStateStore() error = %v", err)
+	}
+	state := &session.State{
+		SessionID:    "statusline-session",
+		StartedAt:    time.Now(),
+		Phase:        session.PhaseActive,
+		StepCount:    2,
+		FilesTouched: []string{"changed.go", "committed.go"},
+	}
+	if err := store.Save(context.Background(), state); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	if err := os.WriteFile("changed.go", []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	var out bytes.Buffer
+	input := `{"session_id": "statusline-session", "cwd": ".", "model": {"id": "claude", "display_name": "Claude"}, "cost": {"total_cost_usd": 1.5}}`
+	if err := runStatusline(context.Background(), strings.NewReader(input), &out); err != nil {
+		t.Fatalf("runStatusline() error = %v", err)
+	}
+	if got, want := out.String(), "entire · active · 2 checkpoints · 1 uncommitted · $1.50\n"; got != want {
+		t.Errorf("runStatusline() = %q, want %q", got, want)
+	}
+
+	out.Reset()
+	if err := runStatusline(context.Background(), strings.NewReader(`{"session_id": "other"}`), &out); err != nil {
+		t.Fatalf("runStatusline(unknown session) error = %v", err)
+	}
+	if got := out.String(); got != "entire · not tracked yet\n" {
+		t.Errorf("runStatusline(unknown session) = %q", got)
+	}
+}
+
+func TestRunStatusline_Disabled(t *testing.T) {
+	setupTestRepo(t)
+	writeSettings(t, testSettingsDisabled)
+
+	var out bytes.Buffer
+	if err := runStatusline(context.Background(), strings.NewReader(`{"session_id": "s"}`), &out); err != nil {
+		t.Fatalf("runStatusline() error = %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("runStatusline() = %q, want no output when disabled", out.String())
+	}
+}
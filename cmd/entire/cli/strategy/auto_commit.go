@@ -54,6 +54,10 @@ func commitOrHead(repo *git.Repository, worktree *git.Worktree, msg string, auth
 	return commitHash, nil
 }
 
+// Compile-time check that AutoCommitStrategy (and squash-commit, which embeds
+// it) commits every turn
+var _ TurnCommitter = (*AutoCommitStrategy)(nil)
+
 // AutoCommitStrategy implements the auto-commit strategy:
 // - Code changes are committed to the active branch (like commit strategy)
 // - Session logs are committed to a shadow branch (like manual-commit strategy)
@@ -96,6 +100,12 @@ func (s *AutoCommitStrategy) Description() string {
 	return "Auto-commits code to active branch with metadata on entire/checkpoints/v1"
 }
 
+// CommitsEachTurn reports that every turn is committed in SaveChanges, for
+// both auto-commit and squash-commit.
+func (s *AutoCommitStrategy) CommitsEachTurn() bool {
+	return true
+}
+
 func (s *AutoCommitStrategy) ValidateRepository() error {
 	repo, err := OpenRepository()
 	if err != nil {
@@ -154,7 +164,7 @@ func (s *AutoCommitStrategy) SaveChanges(ctx SaveContext) error {
 
 	// Step 2: Commit metadata to entire/checkpoints/v1 branch using sharded path
 	// Path is <checkpointID[:2]>/<checkpointID[2:]>/ for direct lookup
-	_, err = s.commitMetadataToMetadataBranch(repo, ctx, cpID)
+	_, err = s.commitMetadataToMetadataBranch(repo, ctx, cpID, StrategyNameAutoCommit)
 	if err != nil {
 		return fmt.Errorf("failed to commit metadata to entire/checkpoints/v1 branch: %w", err)
 	}
@@ -233,8 +243,9 @@ func (s *AutoCommitStrategy) commitCodeToActive(repo *git.Repository, ctx SaveCo
 // commitMetadataToMetadataBranch commits session metadata to the entire/checkpoints/v1 branch.
 // Metadata is stored at sharded path: <checkpointID[:2]>/<checkpointID[2:]>/
 // This allows direct lookup from the checkpoint ID trailer on the code commit.
-// Uses checkpoint.WriteCommitted for git operations.
-func (s *AutoCommitStrategy) commitMetadataToMetadataBranch(repo *git.Repository, ctx SaveContext, checkpointID id.CheckpointID) (plumbing.Hash, error) {
+// Uses checkpoint.WriteCommitted for git operations. strategyName is recorded
+// in the checkpoint metadata.
+func (s *AutoCommitStrategy) commitMetadataToMetadataBranch(repo *git.Repository, ctx SaveContext, checkpointID id.CheckpointID, strategyName string) (plumbing.Hash, error) {
 	store, err := s.getCheckpointStore()
 	if err != nil {
 		return plumbing.ZeroHash, fmt.Errorf("failed to get checkpoint store: %w", err)
@@ -253,7 +264,7 @@ func (s *AutoCommitStrategy) commitMetadataToMetadataBranch(repo *git.Repository
 	err = store.WriteCommitted(context.Background(), checkpoint.WriteCommittedOptions{
 		CheckpointID:                checkpointID,
 		SessionID:                   sessionID,
-		Strategy:                    StrategyNameAutoCommit, // Use new strategy name
+		Strategy:                    strategyName,
 		Branch:                      branchName,
 		MetadataDir:                 ctx.MetadataDirAbs, // Copy all files from metadata dir
 		AuthorName:                  ctx.AuthorName,
@@ -419,7 +430,17 @@ func (s *AutoCommitStrategy) findTaskMetadataPathForCommit(repo *git.Repository,
 }
 
 func (s *AutoCommitStrategy) Rewind(point RewindPoint) error {
+	repo, err := OpenRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	// Snapshot the working tree so the rewind can be undone
 	commitHash := plumbing.NewHash(point.ID)
+	if err := saveUndoPoint(repo, "Before rewind to "+commitHash.String()[:7], "", commitHash); err != nil {
+		return fmt.Errorf("failed to save undo point: %w", err)
+	}
+
 	shortID, err := HardResetWithProtection(commitHash)
 	if err != nil {
 		return err
@@ -432,16 +453,33 @@ func (s *AutoCommitStrategy) Rewind(point RewindPoint) error {
 	return nil
 }
 
+// ChangedPaths returns the files a partial rewind to the point would change.
+func (s *AutoCommitStrategy) ChangedPaths(point RewindPoint) ([]string, error) {
+	return changedPathsForPoint(point, committedPointDeleteRule)
+}
+
+// RewindPaths restores only the selected paths from the checkpoint commit,
+// as "git reset --hard" would for those paths. HEAD is not moved, so the
+// restored files show up as uncommitted changes.
+func (s *AutoCommitStrategy) RewindPaths(point RewindPoint, paths []string) (*PathRewindResult, error) {
+	return rewindPathsForPoint(point, paths, committedPointDeleteRule)
+}
+
 func (s *AutoCommitStrategy) CanRewind() (bool, string, error) {
 	return checkCanRewind()
 }
 
 // PreviewRewind returns what will happen if rewinding to the given point.
-// For auto-commit strategy, this returns nil since git reset doesn't delete untracked files.
-func (s *AutoCommitStrategy) PreviewRewind(_ RewindPoint) (*RewindPreview, error) {
+// For auto-commit strategy, no untracked files are listed for deletion since
+// git reset doesn't delete untracked files; only the diffs are filled in.
+func (s *AutoCommitStrategy) PreviewRewind(point RewindPoint) (*RewindPreview, error) {
 	// Auto-commit uses git reset --hard which doesn't affect untracked files
-	// Return empty preview to indicate no untracked files will be deleted
-	return &RewindPreview{}, nil
+	diffs, err := previewDiffsForPoint(point, committedPointDeleteRule)
+	if err != nil {
+		// Diffs are informational; an empty preview is still accurate about deletions
+		return &RewindPreview{}, nil //nolint:nilerr // Partial result is still useful
+	}
+	return &RewindPreview{Diffs: diffs}, nil
 }
 
 // EnsureSetup ensures the strategy's required setup is in place.
@@ -980,53 +1018,43 @@ func (s *AutoCommitStrategy) ListOrphanedItems() ([]CleanupItem, error) {
 		return nil, fmt.Errorf("failed to get checkpoint store: %w", err)
 	}
 
+	return listUnreferencedCheckpoints(cpStore, StrategyNameAutoCommit, findReferencedCheckpoints(repo)), nil
+}
+
+// listUnreferencedCheckpoints returns cleanup items for checkpoints created by
+// strategyName that are not in referenced.
+func listUnreferencedCheckpoints(cpStore *checkpoint.GitStore, strategyName string, referenced map[string]bool) []CleanupItem {
 	// Get all checkpoints from entire/checkpoints/v1 branch
 	checkpoints, err := cpStore.ListCommitted(context.Background())
-	if err != nil {
-		return []CleanupItem{}, nil //nolint:nilerr // No checkpoints is not an error for cleanup
+	if err != nil || len(checkpoints) == 0 {
+		return []CleanupItem{} // No checkpoints is not an error for cleanup
 	}
 
-	if len(checkpoints) == 0 {
-		return []CleanupItem{}, nil
-	}
-
-	// Filter to only auto-commit checkpoints (identified by strategy in metadata)
-	autoCommitCheckpoints := make(map[string]bool)
+	// Only consider checkpoints created by this strategy (identified by strategy in metadata)
+	items := []CleanupItem{}
 	for _, cp := range checkpoints {
 		summary, readErr := cpStore.ReadCommitted(context.Background(), cp.CheckpointID)
-		if readErr != nil || summary == nil {
+		if readErr != nil || summary == nil || summary.Strategy != strategyName {
 			continue
 		}
-		// Only consider checkpoints created by this strategy
-		if summary.Strategy == StrategyNameAutoCommit {
-			autoCommitCheckpoints[cp.CheckpointID.String()] = true
-		}
-	}
-
-	if len(autoCommitCheckpoints) == 0 {
-		return []CleanupItem{}, nil
-	}
-
-	// Find checkpoint IDs referenced in commits
-	referencedCheckpoints := s.findReferencedCheckpoints(repo)
-
-	// Find orphaned checkpoints
-	var items []CleanupItem
-	for checkpointID := range autoCommitCheckpoints {
-		if !referencedCheckpoints[checkpointID] {
+		if !referenced[cp.CheckpointID.String()] {
 			items = append(items, CleanupItem{
 				Type:   CleanupTypeCheckpoint,
-				ID:     checkpointID,
+				ID:     cp.CheckpointID.String(),
 				Reason: "no commit references this checkpoint",
 			})
 		}
 	}
-
-	return items, nil
+	return items
 }
 
-// findReferencedCheckpoints scans commits for Entire-Checkpoint trailers.
-func (s *AutoCommitStrategy) findReferencedCheckpoints(repo *git.Repository) map[string]bool {
+// findReferencedCheckpoints returns the checkpoints named by an
+// Entire-Checkpoint trailer in the history of any ref: local and
+// remote-tracking branches, tags, and the entire/squash/* branches of
+// sessions that haven't landed yet. The whole history is read, since old
+// checkpoints are the ones retention prunes. Only the metadata branch is
+// skipped, as its commits describe checkpoints rather than reference them.
+func findReferencedCheckpoints(repo *git.Repository) map[string]bool {
 	referenced := make(map[string]bool)
 
 	refs, err := repo.References()
@@ -1035,44 +1063,53 @@ func (s *AutoCommitStrategy) findReferencedCheckpoints(repo *git.Repository) map
 	}
 
 	visited := make(map[plumbing.Hash]bool)
-
 	_ = refs.ForEach(func(ref *plumbing.Reference) error { //nolint:errcheck // Best effort
-		if !ref.Name().IsBranch() {
+		if ref.Type() != plumbing.HashReference || isMetadataBranchRef(ref.Name()) {
 			return nil
 		}
-		// Skip entire/* branches
-		branchName := strings.TrimPrefix(ref.Name().String(), "refs/heads/")
-		if strings.HasPrefix(branchName, "entire/") {
-			return nil
-		}
-
-		iter, iterErr := repo.Log(&git.LogOptions{From: ref.Hash()})
-		if iterErr != nil {
-			return nil //nolint:nilerr // Best effort
+		start := ref.Hash()
+		if tag, tagErr := repo.TagObject(start); tagErr == nil {
+			start = tag.Target
 		}
 
-		count := 0
-		_ = iter.ForEach(func(c *object.Commit) error { //nolint:errcheck // Best effort
-			count++
-			if count > 1000 {
-				return errors.New("limit reached")
+		pending := []plumbing.Hash{start}
+		for len(pending) > 0 {
+			hash := pending[len(pending)-1]
+			pending = pending[:len(pending)-1]
+			if visited[hash] {
+				continue
 			}
-			if visited[c.Hash] {
-				return nil
-			}
-			visited[c.Hash] = true
+			visited[hash] = true
 
-			if cpID, found := trailers.ParseCheckpoint(c.Message); found {
+			c, commitErr := repo.CommitObject(hash)
+			if commitErr != nil {
+				continue // Not a commit, e.g. a tag of a tree
+			}
+			// Squashed commits carry one trailer per checkpoint
+			for _, cpID := range trailers.ParseAllCheckpoints(c.Message) {
 				referenced[cpID.String()] = true
 			}
-			return nil
-		})
+			pending = append(pending, c.ParentHashes...)
+		}
 		return nil
 	})
 
 	return referenced
 }
 
+// isMetadataBranchRef reports whether name is the local or a remote-tracking
+// metadata branch.
+func isMetadataBranchRef(name plumbing.ReferenceName) bool {
+	switch {
+	case name.IsBranch():
+		return name.Short() == paths.MetadataBranchName
+	case name.IsRemote():
+		_, branch, ok := strings.Cut(name.Short(), "/")
+		return ok && branch == paths.MetadataBranchName
+	}
+	return false
+}
+
 //nolint:gochecknoinits // Standard pattern for strategy registration
 func init() {
 	// Register auto-commit as the primary strategy name
@@ -27,6 +27,23 @@ func TestAutoCommitStrategy_Registration(t *testing.T) {
 	}
 }
 
+func TestTurnCommitter(t *testing.T) {
+	t.Parallel()
+
+	for _, name := range List() {
+		s, err := Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) error = %v", name, err)
+		}
+		committer, ok := s.(TurnCommitter)
+		got := ok && committer.CommitsEachTurn()
+		want := name == StrategyNameAutoCommit || name == StrategyNameSquashCommit
+		if got != want {
+			t.Errorf("%s commits each turn = %v, want %v", name, got, want)
+		}
+	}
+}
+
 func TestAutoCommitStrategy_SaveChanges_CommitHasMetadataRef(t *testing.T) {
 	// Setup temp git repo
 	dir := t.TempDir()
@@ -32,6 +32,12 @@ const (
 	CleanupTypeShadowBranch CleanupType = "shadow-branch"
 	CleanupTypeSessionState CleanupType = "session-state"
 	CleanupTypeCheckpoint   CleanupType = "checkpoint"
+	// CleanupTypeSessionWorktree is a worktree strategy session branch, ID'd
+	// by branch name, together with its worktree if it still has one.
+	CleanupTypeSessionWorktree CleanupType = "session-worktree"
+	// CleanupTypeSquashBranch is a squash-commit entire/squash/<session-id>
+	// branch with nothing left to land.
+	CleanupTypeSquashBranch CleanupType = "squash-branch"
 )
 
 // CleanupItem represents an orphaned item that can be cleaned up.
@@ -43,12 +49,16 @@ type CleanupItem struct {
 
 // CleanupResult contains the results of a cleanup operation.
 type CleanupResult struct {
-	ShadowBranches    []string // Deleted shadow branches
-	SessionStates     []string // Deleted session state files
-	Checkpoints       []string // Deleted checkpoint metadata
-	FailedBranches    []string // Shadow branches that failed to delete
-	FailedStates      []string // Session states that failed to delete
-	FailedCheckpoints []string // Checkpoints that failed to delete
+	ShadowBranches       []string // Deleted shadow branches
+	SessionStates        []string // Deleted session state files
+	Checkpoints          []string // Deleted checkpoint metadata
+	SessionWorktrees     []string // Deleted session branches and their worktrees
+	SquashBranches       []string // Deleted squash branches
+	FailedBranches       []string // Shadow branches that failed to delete
+	FailedStates         []string // Session states that failed to delete
+	FailedCheckpoints    []string // Checkpoints that failed to delete
+	FailedWorktrees      []string // Session branches that failed to delete
+	FailedSquashBranches []string // Squash branches that failed to delete
 }
 
 // shadowBranchPattern matches shadow branch names in both old and new formats:
@@ -389,7 +399,7 @@ func DeleteAllCleanupItems(items []CleanupItem) (*CleanupResult, error) {
 	}
 
 	// Group items by type
-	var branches, states, checkpoints []string
+	var branches, states, checkpoints, worktrees, squashBranches []string
 	for _, item := range items {
 		switch item.Type {
 		case CleanupTypeShadowBranch:
@@ -398,6 +408,10 @@ func DeleteAllCleanupItems(items []CleanupItem) (*CleanupResult, error) {
 			states = append(states, item.ID)
 		case CleanupTypeCheckpoint:
 			checkpoints = append(checkpoints, item.ID)
+		case CleanupTypeSessionWorktree:
+			worktrees = append(worktrees, item.ID)
+		case CleanupTypeSquashBranch:
+			squashBranches = append(squashBranches, item.ID)
 		}
 	}
 
@@ -482,17 +496,71 @@ func DeleteAllCleanupItems(items []CleanupItem) (*CleanupResult, error) {
 		}
 	}
 
+	// Delete session worktrees
+	if len(worktrees) > 0 {
+		deleted, failed, err := DeleteSessionWorktrees(worktrees)
+		if err != nil {
+			return result, err
+		}
+		result.SessionWorktrees = deleted
+		result.FailedWorktrees = failed
+
+		for _, id := range deleted {
+			logging.Info(logCtx, "deleted orphaned session worktree",
+				slog.String("type", string(CleanupTypeSessionWorktree)),
+				slog.String("id", id),
+				slog.String("reason", reasonMap[id]),
+			)
+		}
+		for _, id := range failed {
+			logging.Warn(logCtx, "failed to delete orphaned session worktree",
+				slog.String("type", string(CleanupTypeSessionWorktree)),
+				slog.String("id", id),
+				slog.String("reason", reasonMap[id]),
+			)
+		}
+	}
+
+	// Delete squash branches, the same way as shadow branches
+	if len(squashBranches) > 0 {
+		deleted, failed, err := DeleteShadowBranches(squashBranches)
+		if err != nil {
+			return result, err
+		}
+		result.SquashBranches = deleted
+		result.FailedSquashBranches = failed
+
+		for _, id := range deleted {
+			logging.Info(logCtx, "deleted orphaned squash branch",
+				slog.String("type", string(CleanupTypeSquashBranch)),
+				slog.String("id", id),
+				slog.String("reason", reasonMap[id]),
+			)
+		}
+		for _, id := range failed {
+			logging.Warn(logCtx, "failed to delete orphaned squash branch",
+				slog.String("type", string(CleanupTypeSquashBranch)),
+				slog.String("id", id),
+				slog.String("reason", reasonMap[id]),
+			)
+		}
+	}
+
 	// Log summary
-	totalDeleted := len(result.ShadowBranches) + len(result.SessionStates) + len(result.Checkpoints)
-	totalFailed := len(result.FailedBranches) + len(result.FailedStates) + len(result.FailedCheckpoints)
+	totalDeleted := len(result.ShadowBranches) + len(result.SessionStates) + len(result.Checkpoints) + len(result.SessionWorktrees) + len(result.SquashBranches)
+	totalFailed := len(result.FailedBranches) + len(result.FailedStates) + len(result.FailedCheckpoints) + len(result.FailedWorktrees) + len(result.FailedSquashBranches)
 	if totalDeleted > 0 || totalFailed > 0 {
 		logging.Info(logCtx, "cleanup completed",
 			slog.Int("deleted_branches", len(result.ShadowBranches)),
 			slog.Int("deleted_session_states", len(result.SessionStates)),
 			slog.Int("deleted_checkpoints", len(result.Checkpoints)),
+			slog.Int("deleted_session_worktrees", len(result.SessionWorktrees)),
+			slog.Int("deleted_squash_branches", len(result.SquashBranches)),
 			slog.Int("failed_branches", len(result.FailedBranches)),
 			slog.Int("failed_session_states", len(result.FailedStates)),
 			slog.Int("failed_checkpoints", len(result.FailedCheckpoints)),
+			slog.Int("failed_session_worktrees", len(result.FailedWorktrees)),
+			slog.Int("failed_squash_branches", len(result.FailedSquashBranches)),
 		)
 	}
 
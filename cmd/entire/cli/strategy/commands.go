@@ -0,0 +1,85 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	cpkg "github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// AppendSessionCommand records a shell command the agent ran in the
+// session's commands.jsonl. The metadata directory is written into every
+// shadow checkpoint, so the file carries each checkpoint's commands; the
+// commands already condensed are skipped using CheckpointCommandsStart.
+func AppendSessionCommand(sessionID string, record cpkg.CommandRecord) error {
+	dirAbs, err := paths.AbsPath(paths.SessionMetadataDirFromSessionID(sessionID))
+	if err != nil {
+		return fmt.Errorf("failed to resolve session metadata directory: %w", err)
+	}
+	if err := os.MkdirAll(dirAbs, 0o750); err != nil {
+		return fmt.Errorf("failed to create session metadata directory: %w", err)
+	}
+
+	line, err := cpkg.MarshalCommands([]cpkg.CommandRecord{record})
+	if err != nil {
+		return err //nolint:wrapcheck // already wrapped by MarshalCommands
+	}
+	f, err := os.OpenFile(filepath.Join(dirAbs, paths.CommandsFileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is built from the repo root and session ID
+	if err != nil {
+		return fmt.Errorf("failed to open commands file: %w", err)
+	}
+	defer f.Close()
+	if _, err := f.Write(line); err != nil {
+		return fmt.Errorf("failed to write command record: %w", err)
+	}
+	return nil
+}
+
+// ClearSessionCommands empties the session's commands.jsonl. Strategies
+// that copy the whole metadata directory into each committed checkpoint
+// call it once the checkpoint is written, so the next one starts afresh.
+func ClearSessionCommands(sessionID string) error {
+	dirAbs, err := paths.AbsPath(paths.SessionMetadataDirFromSessionID(sessionID))
+	if err != nil {
+		return fmt.Errorf("failed to resolve session metadata directory: %w", err)
+	}
+	if err := os.Remove(filepath.Join(dirAbs, paths.CommandsFileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to clear commands file: %w", err)
+	}
+	return nil
+}
+
+// ReadCommandsFromTree reads the shell commands recorded in a checkpoint's
+// commands.jsonl in a git tree. Returns nil if there are none.
+func ReadCommandsFromTree(tree *object.Tree, checkpointPath string) []cpkg.CommandRecord {
+	file, err := tree.File(checkpointPath + "/" + paths.CommandsFileName)
+	if err != nil {
+		return nil
+	}
+	content, err := file.Contents()
+	if err != nil {
+		return nil
+	}
+	return cpkg.ParseCommands([]byte(content))
+}
+
+// readSessionCommands returns the commands recorded for a session. The live
+// file is preferred, as commands run since the last shadow checkpoint (such
+// as the agent's own git commit) are only there; tree is the fallback.
+func readSessionCommands(tree *object.Tree, sessionID string) []cpkg.CommandRecord {
+	metadataDir := paths.SessionMetadataDirFromSessionID(sessionID)
+	if dirAbs, err := paths.AbsPath(metadataDir); err == nil {
+		if data, readErr := os.ReadFile(filepath.Join(dirAbs, paths.CommandsFileName)); readErr == nil { //nolint:gosec // path is built from the repo root and session ID
+			return cpkg.ParseCommands(data)
+		}
+	}
+	if tree == nil {
+		return nil
+	}
+	return ReadCommandsFromTree(tree, metadataDir)
+}
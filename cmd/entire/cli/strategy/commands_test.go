@@ -0,0 +1,48 @@
+package strategy
+
+import (
+	"testing"
+
+	cpkg "github.com/entireio/cli/cmd/entire/cli/checkpoint"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestSessionCommands_ScopedToCheckpoint(t *testing.T) {
+	tmpDir, _ := initHooksTestRepo(t)
+	repo, err := git.PlainOpen(tmpDir)
+	if err != nil {
+		t.Fatalf("failed to open repo: %v", err)
+	}
+	sessionID := "2026-01-01-sess"
+
+	for _, command := range []string{"go build ./...", "go test ./...", "git commit -m wip"} {
+		if err := AppendSessionCommand(sessionID, cpkg.CommandRecord{Command: command}); err != nil {
+			t.Fatalf("AppendSessionCommand(%q) error = %v", command, err)
+		}
+	}
+
+	// The first command was condensed into an earlier checkpoint
+	state := &SessionState{SessionID: sessionID, CheckpointCommandsStart: 1}
+	data := &ExtractedSessionData{}
+	extractSessionCommands(repo, plumbing.ZeroHash, state, data)
+
+	if data.TotalCommands != 3 {
+		t.Errorf("TotalCommands = %d, want 3", data.TotalCommands)
+	}
+	if len(data.Commands) != 2 || data.Commands[0].Command != "go test ./..." || data.Commands[1].Command != "git commit -m wip" {
+		t.Errorf("Commands = %+v, want the two commands since the last condensation", data.Commands)
+	}
+
+	if err := ClearSessionCommands(sessionID); err != nil {
+		t.Fatalf("ClearSessionCommands() error = %v", err)
+	}
+	if commands := readSessionCommands(nil, sessionID); len(commands) != 0 {
+		t.Errorf("readSessionCommands() after clear = %+v, want none", commands)
+	}
+	// Clearing twice is fine
+	if err := ClearSessionCommands(sessionID); err != nil {
+		t.Errorf("second ClearSessionCommands() error = %v", err)
+	}
+}
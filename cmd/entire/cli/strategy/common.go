@@ -727,6 +727,7 @@ func EnsureEntireGitignore() error {
 		"settings.local.json",
 		"metadata/",
 		"logs/",
+		"worktrees/",
 	}
 
 	// Track what needs to be added
@@ -0,0 +1,125 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+)
+
+// SnapshotTranscriptBeforeCompaction saves the live transcript into the
+// session's metadata directory before the agent compacts its context, and
+// returns the snapshot's path. Each snapshot is merged with the previous one,
+// so it holds the whole conversation however often the agent compacts. The
+// metadata directory is written into every shadow checkpoint, so the snapshot
+// travels with the session's checkpoints.
+func SnapshotTranscriptBeforeCompaction(sessionID, transcriptPath string, agentType agent.AgentType) (string, error) {
+	live, err := os.ReadFile(transcriptPath) //nolint:gosec // path comes from agent hook input
+	if err != nil {
+		return "", fmt.Errorf("failed to read transcript: %w", err)
+	}
+
+	dirAbs, err := paths.AbsPath(paths.SessionMetadataDirFromSessionID(sessionID))
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve session metadata directory: %w", err)
+	}
+	if err := os.MkdirAll(dirAbs, 0o750); err != nil {
+		return "", fmt.Errorf("failed to create session metadata directory: %w", err)
+	}
+
+	snapshotPath := filepath.Join(dirAbs, paths.PreCompactionFileName)
+	previous, err := os.ReadFile(snapshotPath) //nolint:gosec // path is built from the repo root and session ID
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("failed to read previous snapshot: %w", err)
+	}
+	merged, err := agent.MergeTranscripts(previous, live, agentType)
+	if err != nil {
+		return "", fmt.Errorf("failed to merge with previous snapshot: %w", err)
+	}
+	if err := os.WriteFile(snapshotPath, merged, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return snapshotPath, nil
+}
+
+// ReadFullTranscript returns a session's transcript. Once the agent has
+// compacted its context, the live transcript may no longer hold the earlier
+// conversation, so it's returned merged with the pre-compaction snapshot.
+// The merged transcript only ever grows, so transcript offsets taken before
+// the compaction still point at the same content. Nothing is written, so it's
+// safe to call from hooks that only read the transcript; the stop hooks write
+// the result into the session's metadata directory themselves.
+func ReadFullTranscript(sessionID, transcriptPath string, agentType agent.AgentType) ([]byte, error) {
+	snapshot, err := readPreCompactionSnapshot(sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := os.ReadFile(transcriptPath) //nolint:gosec // path comes from agent hook input or session state
+	if err != nil && (snapshot == nil || !errors.Is(err, os.ErrNotExist)) {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	if snapshot == nil {
+		return live, nil
+	}
+	merged, err := agent.MergeTranscripts(snapshot, live, agentType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge transcript with pre-compaction snapshot: %w", err)
+	}
+	return merged, nil
+}
+
+// WithFullTranscript calls fn with the path of a file holding the session's
+// full transcript, for transcript readers that take a path. Without a
+// pre-compaction snapshot that's the live transcript; otherwise the merged
+// transcript is written to a temporary file that's removed once fn returns.
+func WithFullTranscript(sessionID, transcriptPath string, agentType agent.AgentType, fn func(path string) error) error {
+	snapshot, err := readPreCompactionSnapshot(sessionID)
+	if err != nil {
+		return err
+	}
+	if snapshot == nil {
+		return fn(transcriptPath)
+	}
+
+	merged, err := ReadFullTranscript(sessionID, transcriptPath, agentType)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp("", "entire-transcript-*"+filepath.Ext(transcriptPath))
+	if err != nil {
+		return fmt.Errorf("failed to create temporary transcript: %w", err)
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	_, err = tmp.Write(merged)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write temporary transcript: %w", err)
+	}
+	return fn(tmp.Name())
+}
+
+// readPreCompactionSnapshot returns the session's pre-compaction snapshot,
+// or nil if the agent hasn't compacted its context.
+func readPreCompactionSnapshot(sessionID string) ([]byte, error) {
+	if sessionID == "" {
+		return nil, nil
+	}
+	dirAbs, err := paths.AbsPath(paths.SessionMetadataDirFromSessionID(sessionID))
+	if err != nil {
+		return nil, nil //nolint:nilerr // Not in a repo, so there's no snapshot
+	}
+	snapshot, err := os.ReadFile(filepath.Join(dirAbs, paths.PreCompactionFileName)) //nolint:gosec // path is built from the repo root and session ID
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read pre-compaction snapshot: %w", err)
+	}
+	return snapshot, nil
+}
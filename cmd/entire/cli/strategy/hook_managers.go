@@ -0,0 +1,314 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HookManager is a tool that owns a repository's git hooks. Hooks Entire
+// writes into a directory a manager owns are overwritten or never run, so
+// Entire's hooks are added to the manager's configuration instead.
+type HookManager string
+
+const (
+	HookManagerHusky     HookManager = "Husky"
+	HookManagerLefthook  HookManager = "lefthook"
+	HookManagerPreCommit HookManager = "pre-commit"
+)
+
+// huskyDir is where Husky keeps the hook scripts it runs.
+const huskyDir = ".husky"
+
+// lefthookConfigFiles are the lefthook configuration files, in the order
+// lefthook looks for them.
+var lefthookConfigFiles = []string{"lefthook.yml", ".lefthook.yml", "lefthook.yaml", ".lefthook.yaml"}
+
+// preCommitConfigFile is the pre-commit framework's configuration file.
+const preCommitConfigFile = ".pre-commit-config.yaml"
+
+// Hook shim signatures. Managers install small scripts under the git hook
+// names that call back into the manager; finding one means the manager runs
+// that hook and would overwrite an Entire hook written in its place.
+const (
+	lefthookShimSignature  = "lefthook"
+	preCommitShimSignature = "File generated by pre-commit"
+)
+
+// HookSetup describes where git runs a repository's hooks from.
+type HookSetup struct {
+	// RepoDir is the repository's worktree root.
+	RepoDir string
+	// HooksDir is the directory git runs hooks from, honouring core.hooksPath.
+	HooksDir string
+	// HooksPath is core.hooksPath as configured, or "" if unset.
+	HooksPath string
+	// Manager is the tool that owns some of Entire's git hooks, or "" if
+	// none does.
+	Manager HookManager
+	// ManagerConfig is the manager's configuration file (a directory for
+	// Husky), relative to RepoDir.
+	ManagerConfig string
+	// ManagedHooks are the Entire git hooks the manager owns, in
+	// gitHookNames order. Entire installs the others itself.
+	ManagedHooks []string
+}
+
+// DetectHookSetup inspects the current repository's hook setup.
+func DetectHookSetup() (*HookSetup, error) {
+	return detectHookSetupInPath(".")
+}
+
+// detectHookSetupInPath inspects the hook setup of the repository at dir.
+func detectHookSetupInPath(dir string) (*HookSetup, error) {
+	ctx := context.Background()
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--show-toplevel", "--git-path", "hooks")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, errors.New("not a git repository")
+	}
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	if len(lines) != 2 {
+		return nil, fmt.Errorf("unexpected git rev-parse output: %q", output)
+	}
+
+	// git rev-parse --git-path returns paths relative to the working directory
+	hooksDir := lines[1]
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	if abs, err := filepath.Abs(hooksDir); err == nil {
+		hooksDir = abs
+	}
+	setup := &HookSetup{
+		RepoDir:  filepath.Clean(lines[0]),
+		HooksDir: filepath.Clean(hooksDir),
+	}
+
+	configCmd := exec.CommandContext(ctx, "git", "config", "--get", "core.hooksPath")
+	configCmd.Dir = dir
+	if value, err := configCmd.Output(); err == nil {
+		setup.HooksPath = strings.TrimSpace(string(value))
+	}
+
+	detectHookManager(setup)
+	return setup, nil
+}
+
+// detectHookManager fills in the tool that owns Entire's git hooks, its
+// configuration and the hooks it owns. Husky owns every hook when
+// core.hooksPath points into .husky; lefthook and pre-commit own the hooks
+// whose files in HooksDir are their shims.
+func detectHookManager(setup *HookSetup) {
+	if setup.HooksPath != "" {
+		rel, err := filepath.Rel(evalSymlinks(setup.RepoDir), evalSymlinks(setup.HooksDir))
+		if err == nil && (rel == huskyDir || strings.HasPrefix(rel, huskyDir+string(filepath.Separator))) {
+			setup.Manager, setup.ManagerConfig = HookManagerHusky, huskyDir
+			setup.ManagedHooks = append([]string(nil), gitHookNames...)
+			return
+		}
+	}
+	for _, hook := range gitHookNames {
+		manager := hookShimManager(filepath.Join(setup.HooksDir, hook))
+		if manager == "" || (setup.Manager != "" && manager != setup.Manager) {
+			continue
+		}
+		setup.Manager = manager
+		setup.ManagedHooks = append(setup.ManagedHooks, hook)
+	}
+	switch setup.Manager {
+	case HookManagerLefthook:
+		setup.ManagerConfig = lefthookConfigFiles[0]
+		for _, name := range lefthookConfigFiles {
+			if fileExists(filepath.Join(setup.RepoDir, name)) {
+				setup.ManagerConfig = name
+				break
+			}
+		}
+	case HookManagerPreCommit:
+		setup.ManagerConfig = preCommitConfigFile
+	}
+}
+
+// hookShimManager returns the manager whose shim is the hook file at
+// hookPath, or "" if it's missing, Entire's own hook or anything else.
+func hookShimManager(hookPath string) HookManager {
+	data, err := os.ReadFile(hookPath) //nolint:gosec // path is built from the hooks dir and fixed names
+	if err != nil {
+		return ""
+	}
+	content := string(data)
+	switch {
+	case strings.Contains(content, entireHookMarker):
+		return ""
+	case strings.Contains(content, preCommitShimSignature):
+		return HookManagerPreCommit
+	case strings.Contains(strings.ToLower(content), lefthookShimSignature):
+		return HookManagerLefthook
+	}
+	return ""
+}
+
+// managesHook reports whether the manager owns the given git hook.
+func (s *HookSetup) managesHook(hook string) bool {
+	return slices.Contains(s.ManagedHooks, hook)
+}
+
+// ManagesAllHooks reports whether the manager owns every Entire git hook,
+// leaving none for Entire to install itself.
+func (s *HookSetup) ManagesAllHooks() bool {
+	return len(s.ManagedHooks) == len(gitHookNames)
+}
+
+// managerRunsHook reports whether the manager's configuration runs Entire's
+// handler for the given git hook.
+func (s *HookSetup) managerRunsHook(hook string) bool {
+	path := filepath.Join(s.RepoDir, s.ManagerConfig)
+	if s.Manager == HookManagerHusky {
+		path = filepath.Join(path, hook)
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path is built from the repo root and fixed names
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), "hooks git "+hook)
+}
+
+// hookInstalled reports whether Entire's handler runs for the given git hook.
+func (s *HookSetup) hookInstalled(hook string) bool {
+	if s.managesHook(hook) {
+		return s.managerRunsHook(hook)
+	}
+	return isEntireHook(filepath.Join(s.HooksDir, hook))
+}
+
+// lefthookSnippets are the lefthook configuration entries for each git hook,
+// formatted with the command prefix.
+var lefthookSnippets = map[string]string{
+	"prepare-commit-msg": `prepare-commit-msg:
+  commands:
+    entire:
+      run: %s hooks git prepare-commit-msg {1} {2} 2>/dev/null || true
+`,
+	"commit-msg": `commit-msg:
+  commands:
+    entire:
+      run: %s hooks git commit-msg {1}
+`,
+	"post-commit": `post-commit:
+  commands:
+    entire:
+      run: %s hooks git post-commit 2>/dev/null || true
+`,
+	"pre-push": `pre-push:
+  commands:
+    entire:
+      run: %s hooks git pre-push {1} || true
+`,
+}
+
+// preCommitSnippets are the pre-commit local hook entries for each git hook,
+// formatted with the command prefix.
+var preCommitSnippets = map[string]string{
+	"prepare-commit-msg": `    - id: entire-prepare-commit-msg
+      name: entire prepare-commit-msg
+      entry: sh -c '%s hooks git prepare-commit-msg "$1" "$PRE_COMMIT_COMMIT_MSG_SOURCE" 2>/dev/null || true' --
+      language: system
+      stages: [prepare-commit-msg]
+      always_run: true
+`,
+	"commit-msg": `    - id: entire-commit-msg
+      name: entire commit-msg
+      entry: %s hooks git commit-msg
+      language: system
+      stages: [commit-msg]
+      always_run: true
+`,
+	"post-commit": `    - id: entire-post-commit
+      name: entire post-commit
+      entry: sh -c '%s hooks git post-commit 2>/dev/null || true'
+      language: system
+      stages: [post-commit]
+      always_run: true
+      pass_filenames: false
+`,
+	"pre-push": `    - id: entire-pre-push
+      name: entire pre-push
+      entry: sh -c '%s hooks git pre-push "$PRE_COMMIT_REMOTE_NAME" || true'
+      language: system
+      stages: [pre-push]
+      always_run: true
+      pass_filenames: false
+`,
+}
+
+// ManagerSnippet returns what to add to the hook manager's configuration
+// so it runs Entire's handlers for the hooks it owns. Returns "" if no
+// manager owns any of them.
+func (s *HookSetup) ManagerSnippet() string {
+	if len(s.ManagedHooks) == 0 {
+		return ""
+	}
+	cmdPrefix := "entire"
+	if isLocalDev() {
+		cmdPrefix = "go run ./cmd/entire/main.go"
+	}
+
+	var sb strings.Builder
+	switch s.Manager {
+	case HookManagerHusky:
+		// Husky runs .husky/<hook> with the git hook's arguments, so the
+		// body of each Entire hook works as is
+		for _, spec := range buildHookSpecs(cmdPrefix) {
+			if !s.managesHook(spec.name) {
+				continue
+			}
+			body := strings.TrimPrefix(spec.content, "#!/bin/sh\n")
+			fmt.Fprintf(&sb, "%s:\n%s\n", filepath.Join(huskyDir, spec.name), body)
+		}
+	case HookManagerLefthook:
+		fmt.Fprintf(&sb, "# %s\n", entireHookMarker)
+		for _, hook := range s.ManagedHooks {
+			fmt.Fprintf(&sb, lefthookSnippets[hook], cmdPrefix)
+		}
+	case HookManagerPreCommit:
+		hookTypes := make([]string, 0, len(s.ManagedHooks))
+		for _, hook := range s.ManagedHooks {
+			hookTypes = append(hookTypes, "--hook-type "+hook)
+		}
+		fmt.Fprintf(&sb, "# %s\n# Install with: pre-commit install %s\n- repo: local\n  hooks:\n", entireHookMarker, strings.Join(hookTypes, " "))
+		for _, hook := range s.ManagedHooks {
+			fmt.Fprintf(&sb, preCommitSnippets[hook], cmdPrefix)
+		}
+	}
+	return sb.String()
+}
+
+// ManagerRunsEntireHooks reports whether the hook manager runs Entire's
+// handlers for all the hooks it owns. Always false when no manager owns any.
+func (s *HookSetup) ManagerRunsEntireHooks() bool {
+	if len(s.ManagedHooks) == 0 {
+		return false
+	}
+	for _, hook := range s.ManagedHooks {
+		if !s.managerRunsHook(hook) {
+			return false
+		}
+	}
+	return true
+}
+
+// evalSymlinks resolves symlinks in path, or returns it unchanged if it
+// can't be resolved (for example, because it doesn't exist yet).
+func evalSymlinks(path string) string {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	return path
+}
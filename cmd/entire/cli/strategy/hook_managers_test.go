@@ -0,0 +1,227 @@
+package strategy
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// gitConfig sets a git config value in the current repository.
+func gitConfig(t *testing.T, key, value string) {
+	t.Helper()
+	if output, err := exec.CommandContext(context.Background(), "git", "config", key, value).CombinedOutput(); err != nil {
+		t.Fatalf("git config %s %s failed: %v: %s", key, value, err, output)
+	}
+}
+
+func TestDetectHookSetup_Default(t *testing.T) {
+	_, hooksDir := initHooksTestRepo(t)
+
+	setup, err := DetectHookSetup()
+	if err != nil {
+		t.Fatalf("DetectHookSetup() error = %v", err)
+	}
+	if setup.Manager != "" || setup.HooksPath != "" {
+		t.Errorf("setup = %+v, want no manager or hooksPath", setup)
+	}
+	if !sameFile(t, setup.HooksDir, hooksDir) {
+		t.Errorf("HooksDir = %s, want %s", setup.HooksDir, hooksDir)
+	}
+}
+
+func TestInstallGitHook_CoreHooksPath(t *testing.T) {
+	tmpDir, hooksDir := initHooksTestRepo(t)
+	gitConfig(t, "core.hooksPath", ".githooks")
+
+	customHookPath := filepath.Join(tmpDir, ".githooks", "pre-push")
+	if err := os.MkdirAll(filepath.Dir(customHookPath), 0o755); err != nil {
+		t.Fatalf("failed to create hooks dir: %v", err)
+	}
+	if err := os.WriteFile(customHookPath, []byte("#!/bin/sh\necho 'my custom hook'\n"), 0o755); err != nil {
+		t.Fatalf("failed to create custom hook: %v", err)
+	}
+
+	if _, err := InstallGitHook(true); err != nil {
+		t.Fatalf("InstallGitHook() error = %v", err)
+	}
+	if !IsGitHookInstalled() {
+		t.Error("IsGitHookInstalled() = false after install into core.hooksPath")
+	}
+	if _, err := os.Stat(filepath.Join(hooksDir, "post-commit")); err == nil {
+		t.Error("hooks should not be installed in .git/hooks when core.hooksPath is set")
+	}
+
+	// Existing hooks in core.hooksPath are chained like in .git/hooks
+	data, err := os.ReadFile(customHookPath)
+	if err != nil {
+		t.Fatalf("failed to read hook: %v", err)
+	}
+	if !strings.Contains(string(data), chainComment) || !fileExists(customHookPath+backupSuffix) {
+		t.Error("existing pre-push in core.hooksPath should be backed up and chained")
+	}
+
+	removed, err := RemoveGitHook()
+	if err != nil {
+		t.Fatalf("RemoveGitHook() error = %v", err)
+	}
+	if removed != len(gitHookNames) {
+		t.Errorf("RemoveGitHook() removed %d hooks, want %d", removed, len(gitHookNames))
+	}
+	if fileExists(customHookPath + backupSuffix) {
+		t.Error("backup should be restored on removal")
+	}
+}
+
+// writeHookShim writes a hook manager's shim for each hook into hooksDir.
+func writeHookShim(t *testing.T, hooksDir, content string, hooks ...string) {
+	t.Helper()
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for _, hook := range hooks {
+		if err := os.WriteFile(filepath.Join(hooksDir, hook), []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+const (
+	testLefthookShim  = "#!/bin/sh\n\ncall_lefthook()\n{\n  lefthook \"$@\"\n}\n\ncall_lefthook run \"$(basename \"$0\")\" \"$@\"\n"
+	testPreCommitShim = "#!/usr/bin/env bash\n# File generated by pre-commit: https://pre-commit.com\nexec python3 -mpre_commit hook-impl \"$@\"\n"
+)
+
+func TestDetectHookSetup_Managers(t *testing.T) {
+	tests := []struct {
+		name        string
+		setup       func(t *testing.T, dir, hooksDir string)
+		want        HookManager
+		wantConfig  string
+		wantManaged []string
+	}{
+		{
+			name: "husky",
+			setup: func(t *testing.T, dir, _ string) {
+				t.Helper()
+				if err := os.MkdirAll(filepath.Join(dir, ".husky", "_"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				gitConfig(t, "core.hooksPath", ".husky/_")
+			},
+			want:        HookManagerHusky,
+			wantConfig:  ".husky",
+			wantManaged: gitHookNames,
+		},
+		{
+			name: "lefthook",
+			setup: func(t *testing.T, dir, hooksDir string) {
+				t.Helper()
+				if err := os.WriteFile(filepath.Join(dir, ".lefthook.yml"), []byte("{}\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				writeHookShim(t, hooksDir, testLefthookShim, "commit-msg", "post-commit")
+			},
+			want:        HookManagerLefthook,
+			wantConfig:  ".lefthook.yml",
+			wantManaged: []string{"commit-msg", "post-commit"},
+		},
+		{
+			name: "pre-commit",
+			setup: func(t *testing.T, dir, hooksDir string) {
+				t.Helper()
+				if err := os.WriteFile(filepath.Join(dir, preCommitConfigFile), []byte("repos: []\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+				writeHookShim(t, hooksDir, testPreCommitShim, "pre-commit", "pre-push")
+			},
+			want:        HookManagerPreCommit,
+			wantConfig:  preCommitConfigFile,
+			wantManaged: []string{"pre-push"},
+		},
+		{
+			name: "config without shims",
+			setup: func(t *testing.T, dir, _ string) {
+				t.Helper()
+				if err := os.WriteFile(filepath.Join(dir, "lefthook.yml"), []byte("{}\n"), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir, hooksDir := initHooksTestRepo(t)
+			tt.setup(t, tmpDir, hooksDir)
+
+			setup, err := DetectHookSetup()
+			if err != nil {
+				t.Fatalf("DetectHookSetup() error = %v", err)
+			}
+			if setup.Manager != tt.want || setup.ManagerConfig != tt.wantConfig {
+				t.Errorf("Manager = %q (%s), want %q (%s)", setup.Manager, setup.ManagerConfig, tt.want, tt.wantConfig)
+			}
+			if !slices.Equal(setup.ManagedHooks, tt.wantManaged) {
+				t.Errorf("ManagedHooks = %v, want %v", setup.ManagedHooks, tt.wantManaged)
+			}
+
+			// Hooks the manager owns are skipped; the rest are installed
+			count, err := InstallGitHook(true)
+			if err != nil || count != len(gitHookNames)-len(tt.wantManaged) {
+				t.Errorf("InstallGitHook() = %d, %v; want %d, nil", count, err, len(gitHookNames)-len(tt.wantManaged))
+			}
+			for _, hook := range gitHookNames {
+				if got := setup.hookInstalled(hook); got == slices.Contains(tt.wantManaged, hook) {
+					t.Errorf("hookInstalled(%s) = %v after install", hook, got)
+				}
+			}
+			if len(tt.wantManaged) == 0 {
+				if !IsGitHookInstalled() || setup.ManagerSnippet() != "" {
+					t.Error("all hooks should be installed when no manager owns any")
+				}
+				return
+			}
+			if IsGitHookInstalled() || setup.ManagerRunsEntireHooks() {
+				t.Error("hooks should not count as installed before the snippet is added")
+			}
+
+			snippet := setup.ManagerSnippet()
+			for _, hook := range gitHookNames {
+				if got := strings.Contains(snippet, "hooks git "+hook); got != slices.Contains(tt.wantManaged, hook) {
+					t.Errorf("snippet runs %s = %v:\n%s", hook, got, snippet)
+				}
+			}
+		})
+	}
+}
+
+func TestIsGitHookInstalled_Husky(t *testing.T) {
+	tmpDir, _ := initHooksTestRepo(t)
+	if err := os.MkdirAll(filepath.Join(tmpDir, ".husky", "_"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	gitConfig(t, "core.hooksPath", ".husky/_")
+
+	for _, spec := range buildHookSpecs("entire") {
+		content := "npx lint-staged\n" + strings.TrimPrefix(spec.content, "#!/bin/sh\n")
+		if err := os.WriteFile(filepath.Join(tmpDir, ".husky", spec.name), []byte(content), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if !IsGitHookInstalled() {
+		t.Error("IsGitHookInstalled() = false with Entire's hooks in .husky")
+	}
+}
+
+// sameFile reports whether two paths name the same file, following symlinks.
+func sameFile(t *testing.T, a, b string) bool {
+	t.Helper()
+	ra, errA := filepath.EvalSymlinks(a)
+	rb, errB := filepath.EvalSymlinks(b)
+	if errA != nil || errB != nil {
+		return filepath.Clean(a) == filepath.Clean(b)
+	}
+	return ra == rb
+}
@@ -62,40 +62,107 @@ func getGitDirInPath(dir string) (string, error) {
 	return filepath.Clean(gitDir), nil
 }
 
-// IsGitHookInstalled checks if all generic Entire CLI hooks are installed.
+// IsGitHookInstalled checks if all generic Entire CLI hooks are installed,
+// either in the directory git runs hooks from or in the hook manager's
+// configuration.
 func IsGitHookInstalled() bool {
-	gitDir, err := GetGitDir()
+	setup, err := DetectHookSetup()
 	if err != nil {
 		return false
 	}
-	return isGitHookInstalledInGitDir(gitDir)
+	return isGitHookInstalledInSetup(setup)
 }
 
 // IsGitHookInstalledInDir checks if all Entire CLI hooks are installed in the given repo directory.
 // This is useful for tests that need to check hooks without changing the working directory.
 func IsGitHookInstalledInDir(repoDir string) bool {
-	gitDir, err := getGitDirInPath(repoDir)
+	setup, err := detectHookSetupInPath(repoDir)
 	if err != nil {
 		return false
 	}
-	return isGitHookInstalledInGitDir(gitDir)
+	return isGitHookInstalledInSetup(setup)
 }
 
-// isGitHookInstalledInGitDir checks if all hooks are installed in the given .git directory.
-func isGitHookInstalledInGitDir(gitDir string) bool {
+// isGitHookInstalledInSetup checks if all hooks are installed in the given hook setup.
+func isGitHookInstalledInSetup(setup *HookSetup) bool {
 	for _, hook := range gitHookNames {
-		hookPath := filepath.Join(gitDir, "hooks", hook)
-		data, err := os.ReadFile(hookPath) //nolint:gosec // Path is constructed from constants
-		if err != nil {
-			return false
-		}
-		if !strings.Contains(string(data), entireHookMarker) {
+		if !setup.hookInstalled(hook) {
 			return false
 		}
 	}
 	return true
 }
 
+// GitHookStatus reports, for each managed git hook, whether the Entire hook is installed.
+func GitHookStatus() (map[string]bool, error) {
+	setup, err := DetectHookSetup()
+	if err != nil {
+		return nil, err
+	}
+	status := make(map[string]bool, len(gitHookNames))
+	for _, hook := range gitHookNames {
+		status[hook] = setup.hookInstalled(hook)
+	}
+	return status, nil
+}
+
+// GitHookDiagnosis describes one managed git hook, for `entire doctor`.
+type GitHookDiagnosis struct {
+	Name string
+	Path string
+	// Installed is true if the hook file was written by Entire CLI.
+	Installed bool
+	// Foreign is true if a hook from another tool is in the Entire hook's place.
+	Foreign bool
+	// BackupPath is the .pre-entire backup of the hook Entire replaced.
+	BackupPath string
+	// HasBackup is true if BackupPath exists.
+	HasBackup bool
+	// Chained is true if the Entire hook runs the backup.
+	Chained bool
+}
+
+// DiagnoseGitHooks reports the state of each managed git hook and its
+// .pre-entire backup in the directory git runs hooks from. InstallGitHook
+// repairs missing hooks and chains. Hooks owned by a hook manager are left
+// out; HookSetup.ManagerRunsEntireHooks diagnoses those.
+func DiagnoseGitHooks() ([]GitHookDiagnosis, error) {
+	setup, err := DetectHookSetup()
+	if err != nil {
+		return nil, err
+	}
+	diagnoses := make([]GitHookDiagnosis, 0, len(gitHookNames))
+	for _, hook := range gitHookNames {
+		if setup.managesHook(hook) {
+			continue
+		}
+		hookPath := filepath.Join(setup.HooksDir, hook)
+		d := GitHookDiagnosis{
+			Name:       hook,
+			Path:       hookPath,
+			BackupPath: hookPath + backupSuffix,
+			HasBackup:  fileExists(hookPath + backupSuffix),
+		}
+		if data, err := os.ReadFile(hookPath); err == nil { //nolint:gosec // Path is constructed from constants
+			content := string(data)
+			d.Installed = strings.Contains(content, entireHookMarker)
+			d.Foreign = !d.Installed
+			d.Chained = d.Installed && strings.Contains(content, hook+backupSuffix)
+		}
+		diagnoses = append(diagnoses, d)
+	}
+	return diagnoses, nil
+}
+
+// isEntireHook checks if the hook file at hookPath was written by Entire CLI.
+func isEntireHook(hookPath string) bool {
+	data, err := os.ReadFile(hookPath) //nolint:gosec // Path is constructed from constants
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(data), entireHookMarker)
+}
+
 // buildHookSpecs returns the hook specifications for all managed hooks.
 func buildHookSpecs(cmdPrefix string) []hookSpec {
 	return []hookSpec{
@@ -136,15 +203,24 @@ func buildHookSpecs(cmdPrefix string) []hookSpec {
 
 // InstallGitHook installs generic git hooks that delegate to `entire hook` commands.
 // These hooks work with any strategy - the strategy is determined at runtime.
+// Hooks are installed in the directory git runs hooks from, which core.hooksPath
+// may move. Hooks a hook manager (Husky, lefthook, pre-commit) owns are
+// skipped; see HookSetup.ManagerSnippet.
 // If silent is true, no output is printed (except backup notifications, which always print).
 // Returns the number of hooks that were installed (0 if all already up to date).
 func InstallGitHook(silent bool) (int, error) {
-	gitDir, err := GetGitDir()
+	setup, err := DetectHookSetup()
 	if err != nil {
 		return 0, err
 	}
+	if setup.ManagesAllHooks() {
+		if !silent && !setup.ManagerRunsEntireHooks() {
+			printManagerSnippet(setup)
+		}
+		return 0, nil
+	}
 
-	hooksDir := filepath.Join(gitDir, "hooks")
+	hooksDir := setup.HooksDir
 	if err := os.MkdirAll(hooksDir, 0o755); err != nil { //nolint:gosec // Git hooks require executable permissions
 		return 0, fmt.Errorf("failed to create hooks directory: %w", err)
 	}
@@ -159,8 +235,13 @@ func InstallGitHook(silent bool) (int, error) {
 
 	specs := buildHookSpecs(cmdPrefix)
 	installedCount := 0
+	var installedNames []string
 
 	for _, spec := range specs {
+		if setup.managesHook(spec.name) {
+			continue
+		}
+		installedNames = append(installedNames, spec.name)
 		hookPath := filepath.Join(hooksDir, spec.name)
 		backupPath := hookPath + backupSuffix
 		backupExists := fileExists(backupPath)
@@ -195,13 +276,23 @@ func InstallGitHook(silent bool) (int, error) {
 	}
 
 	if !silent {
-		fmt.Println("✓ Installed git hooks (prepare-commit-msg, commit-msg, post-commit, pre-push)")
+		fmt.Printf("✓ Installed git hooks (%s)\n", strings.Join(installedNames, ", "))
 		fmt.Println("  Hooks delegate to the current strategy at runtime")
+		if len(setup.ManagedHooks) > 0 && !setup.ManagerRunsEntireHooks() {
+			printManagerSnippet(setup)
+		}
 	}
 
 	return installedCount, nil
 }
 
+// printManagerSnippet tells the user which hooks the manager owns and what to
+// add to its configuration to run Entire's handlers for them.
+func printManagerSnippet(setup *HookSetup) {
+	fmt.Printf("%s manages this repository's %s hooks. Add Entire's hooks to %s:\n\n%s\n",
+		setup.Manager, strings.Join(setup.ManagedHooks, ", "), setup.ManagerConfig, setup.ManagerSnippet())
+}
+
 // writeHookFile writes a hook file if it doesn't exist or has different content.
 // Returns true if the file was written, false if it already had the same content.
 func writeHookFile(path, content string) (bool, error) {
@@ -218,16 +309,17 @@ func writeHookFile(path, content string) (bool, error) {
 	return true, nil
 }
 
-// RemoveGitHook removes all Entire CLI git hooks from the repository.
-// If a .pre-entire backup exists, it is restored.
+// RemoveGitHook removes all Entire CLI git hooks from the directory git runs
+// hooks from. If a .pre-entire backup exists, it is restored. Hooks added to a
+// hook manager's configuration are left for the user to remove.
 // Returns the number of hooks removed.
 func RemoveGitHook() (int, error) {
-	gitDir, err := GetGitDir()
+	setup, err := DetectHookSetup()
 	if err != nil {
 		return 0, err
 	}
 
-	hooksDir := filepath.Join(gitDir, "hooks")
+	hooksDir := setup.HooksDir
 	removed := 0
 	var removeErrors []string
 
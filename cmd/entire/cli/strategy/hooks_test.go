@@ -314,6 +314,35 @@ func TestIsGitSequenceOperation_Worktree(t *testing.T) {
 	}
 }
 
+func TestGitHookStatus(t *testing.T) {
+	_, hooksDir := initHooksTestRepo(t)
+
+	status, err := GitHookStatus()
+	if err != nil {
+		t.Fatalf("GitHookStatus() error = %v", err)
+	}
+	for _, hook := range gitHookNames {
+		if status[hook] {
+			t.Errorf("GitHookStatus()[%s] = true before install", hook)
+		}
+	}
+
+	if _, err := InstallGitHook(true); err != nil {
+		t.Fatalf("InstallGitHook() error = %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(hooksDir, "pre-push"), []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("failed to overwrite pre-push: %v", err)
+	}
+
+	status, err = GitHookStatus()
+	if err != nil {
+		t.Fatalf("GitHookStatus() error = %v", err)
+	}
+	if !status["post-commit"] || status["pre-push"] {
+		t.Errorf("GitHookStatus() = %v, want post-commit installed and pre-push replaced", status)
+	}
+}
+
 func TestInstallGitHook_Idempotent(t *testing.T) {
 	_, hooksDir := initHooksTestRepo(t)
 
@@ -905,3 +934,38 @@ func TestRemoveGitHook_PermissionDenied(t *testing.T) {
 		t.Errorf("error should mention 'failed to remove hooks', got: %v", err)
 	}
 }
+
+func TestDiagnoseGitHooks(t *testing.T) {
+	_, hooksDir := initHooksTestRepo(t)
+
+	customHookPath := filepath.Join(hooksDir, "pre-push")
+	if err := os.WriteFile(customHookPath, []byte("#!/bin/sh\necho 'my custom hook'\n"), 0o755); err != nil {
+		t.Fatalf("failed to create custom hook: %v", err)
+	}
+
+	diagnoses, err := DiagnoseGitHooks()
+	if err != nil {
+		t.Fatalf("DiagnoseGitHooks() error = %v", err)
+	}
+	for _, d := range diagnoses {
+		if d.Installed || d.Foreign != (d.Name == "pre-push") {
+			t.Errorf("before install: %+v", d)
+		}
+	}
+
+	if _, err := InstallGitHook(true); err != nil {
+		t.Fatalf("InstallGitHook() error = %v", err)
+	}
+	diagnoses, err = DiagnoseGitHooks()
+	if err != nil {
+		t.Fatalf("DiagnoseGitHooks() error = %v", err)
+	}
+	for _, d := range diagnoses {
+		if !d.Installed || d.Foreign {
+			t.Errorf("after install: %+v", d)
+		}
+		if wantChain := d.Name == "pre-push"; d.HasBackup != wantChain || d.Chained != wantChain {
+			t.Errorf("after install: %s HasBackup = %v, Chained = %v, want %v", d.Name, d.HasBackup, d.Chained, wantChain)
+		}
+	}
+}
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"os"
 	"strings"
 
 	"github.com/entireio/cli/cmd/entire/cli/agent"
@@ -127,6 +126,7 @@ func (s *ManualCommitStrategy) CondenseSession(repo *git.Repository, checkpointI
 	if err != nil {
 		return nil, fmt.Errorf("failed to extract session data: %w", err)
 	}
+	extractSessionCommands(repo, ref.Hash(), state, sessionData)
 
 	// Get checkpoint store
 	store, err := s.getCheckpointStore()
@@ -179,6 +179,7 @@ func (s *ManualCommitStrategy) CondenseSession(repo *git.Repository, checkpointI
 		Transcript:                  sessionData.Transcript,
 		Prompts:                     sessionData.Prompts,
 		Context:                     sessionData.Context,
+		Commands:                    sessionData.Commands,
 		FilesTouched:                sessionData.FilesTouched,
 		CheckpointsCount:            state.StepCount,
 		EphemeralBranch:             shadowBranchName,
@@ -201,9 +202,24 @@ func (s *ManualCommitStrategy) CondenseSession(repo *git.Repository, checkpointI
 		CheckpointsCount:     state.StepCount,
 		FilesTouched:         sessionData.FilesTouched,
 		TotalTranscriptLines: sessionData.FullTranscriptLines,
+		TotalCommands:        sessionData.TotalCommands,
 	}, nil
 }
 
+// extractSessionCommands adds the shell commands run since the session's
+// last condensation to data.
+func extractSessionCommands(repo *git.Repository, shadowRef plumbing.Hash, state *SessionState, data *ExtractedSessionData) {
+	var tree *object.Tree
+	if commit, err := repo.CommitObject(shadowRef); err == nil {
+		tree, _ = commit.Tree() //nolint:errcheck // the live commands file is read first; a nil tree skips the fallback
+	}
+	commands := readSessionCommands(tree, state.SessionID)
+	data.TotalCommands = len(commands)
+	if state.CheckpointCommandsStart < len(commands) {
+		data.Commands = commands[state.CheckpointCommandsStart:]
+	}
+}
+
 func calculateSessionAttributions(repo *git.Repository, shadowRef *plumbing.Reference, sessionData *ExtractedSessionData, state *SessionState) *cpkg.InitialAttribution {
 	// Calculate initial attribution using accumulated prompt attribution data.
 	// This uses user edits captured at each prompt start (before agent works),
@@ -325,7 +341,9 @@ func (s *ManualCommitStrategy) extractSessionData(repo *git.Repository, shadowRe
 	// (SaveChanges is only called when there are file modifications).
 	var fullTranscript string
 	if liveTranscriptPath != "" {
-		if liveData, readErr := os.ReadFile(liveTranscriptPath); readErr == nil && len(liveData) > 0 { //nolint:gosec // path from session state
+		// After a context compaction, read the live transcript merged with the
+		// pre-compaction snapshot so offsets into it stay valid
+		if liveData, readErr := ReadFullTranscript(sessionID, liveTranscriptPath, agentType); readErr == nil && len(liveData) > 0 {
 			fullTranscript = string(liveData)
 		}
 	}
@@ -601,6 +619,7 @@ func (s *ManualCommitStrategy) CondenseSessionByID(sessionID string) error {
 	// Update session state: reset step count and transition to idle
 	state.StepCount = 0
 	state.CheckpointTranscriptStart = result.TotalTranscriptLines
+	state.CheckpointCommandsStart = result.TotalCommands
 	state.Phase = session.PhaseIdle
 	state.LastCheckpointID = checkpointID
 	state.PendingCheckpointID = "" // Clear after condensation (amend handler uses LastCheckpointID)
@@ -94,6 +94,7 @@ func (s *ManualCommitStrategy) SaveChanges(ctx SaveContext) error {
 		AuthorName:        ctx.AuthorName,
 		AuthorEmail:       ctx.AuthorEmail,
 		IsFirstCheckpoint: isFirstCheckpointOfSession,
+		TurnID:            state.TurnID,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to write temporary checkpoint: %w", err)
@@ -290,6 +291,100 @@ func (s *ManualCommitStrategy) SaveTaskCheckpoint(ctx TaskCheckpointContext) err
 	return nil
 }
 
+// Compile-time check that ManualCommitStrategy implements ToolCheckpointSaver
+var _ ToolCheckpointSaver = (*ManualCommitStrategy)(nil)
+
+// SaveToolCheckpoint saves a fine-grained checkpoint to the shadow branch
+// after a file-editing tool call. Uses checkpoint.GitStore.WriteTemporary,
+// which skips the checkpoint if the tree matches the last one.
+func (s *ManualCommitStrategy) SaveToolCheckpoint(ctx ToolCheckpointContext) error {
+	repo, err := OpenRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	// Load session state
+	state, err := s.loadSessionState(ctx.SessionID)
+	if err != nil || state == nil || state.BaseCommit == "" {
+		agentType := resolveAgentType(ctx.AgentType, state)
+		state, err = s.initializeSession(repo, ctx.SessionID, agentType, "", "") // No transcript/prompt in fallback
+		if err != nil {
+			return fmt.Errorf("failed to initialize session for tool checkpoint: %w", err)
+		}
+	}
+
+	// Check if HEAD has changed (e.g., the agent committed via a tool call) and migrate if needed
+	if err := s.migrateAndPersistIfNeeded(repo, state); err != nil {
+		return err
+	}
+
+	store, err := s.getCheckpointStore()
+	if err != nil {
+		return fmt.Errorf("failed to get checkpoint store: %w", err)
+	}
+	shadowBranchName := checkpoint.ShadowBranchNameForCommit(state.BaseCommit, state.WorktreeID)
+
+	// The metadata directory only exists once a turn has ended
+	metadataDir := paths.SessionMetadataDirFromSessionID(ctx.SessionID)
+	metadataDirAbs, err := paths.AbsPath(metadataDir)
+	if err != nil {
+		return fmt.Errorf("failed to resolve metadata directory: %w", err)
+	}
+	if err := os.MkdirAll(metadataDirAbs, 0o750); err != nil {
+		return fmt.Errorf("failed to create metadata directory: %w", err)
+	}
+
+	result, err := store.WriteTemporary(context.Background(), checkpoint.WriteTemporaryOptions{
+		SessionID:         ctx.SessionID,
+		BaseCommit:        state.BaseCommit,
+		WorktreeID:        state.WorktreeID,
+		ModifiedFiles:     ctx.ModifiedFiles,
+		NewFiles:          ctx.NewFiles,
+		DeletedFiles:      ctx.DeletedFiles,
+		MetadataDir:       metadataDir,
+		MetadataDirAbs:    metadataDirAbs,
+		CommitMessage:     FormatToolCheckpointMessage(ctx.ToolName, ctx.EditedFile),
+		AuthorName:        ctx.AuthorName,
+		AuthorEmail:       ctx.AuthorEmail,
+		IsFirstCheckpoint: state.StepCount == 0,
+		TurnID:            state.TurnID,
+		ToolName:          ctx.ToolName,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write tool checkpoint: %w", err)
+	}
+
+	logCtx := logging.WithComponent(context.Background(), "checkpoint")
+	if result.Skipped {
+		logging.Debug(logCtx, "tool checkpoint skipped (no changes)",
+			slog.String("strategy", "manual-commit"),
+			slog.String("tool", ctx.ToolName),
+			slog.String("shadow_branch", shadowBranchName),
+		)
+		return nil
+	}
+
+	// Track touched files (modified, new, and deleted). StepCount and prompt
+	// attribution are left to the turn-end checkpoint.
+	state.FilesTouched = mergeFilesTouched(state.FilesTouched, ctx.ModifiedFiles, ctx.NewFiles, ctx.DeletedFiles)
+	if err := s.saveSessionState(state); err != nil {
+		return fmt.Errorf("failed to save session state: %w", err)
+	}
+
+	logging.Info(logCtx, "tool checkpoint saved",
+		slog.String("strategy", "manual-commit"),
+		slog.String("checkpoint_type", "tool"),
+		slog.String("tool", ctx.ToolName),
+		slog.String("turn_id", state.TurnID),
+		slog.Int("modified_files", len(ctx.ModifiedFiles)),
+		slog.Int("new_files", len(ctx.NewFiles)),
+		slog.Int("deleted_files", len(ctx.DeletedFiles)),
+		slog.String("shadow_branch", shadowBranchName),
+	)
+
+	return nil
+}
+
 // mergeFilesTouched merges multiple file lists into existing touched files, deduplicating.
 func mergeFilesTouched(existing []string, fileLists ...[]string) []string {
 	seen := make(map[string]bool)
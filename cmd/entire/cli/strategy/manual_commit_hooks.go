@@ -799,6 +799,7 @@ func (s *ManualCommitStrategy) condenseAndUpdateState(
 	state.AttributionBaseCommit = newHead
 	state.StepCount = 0
 	state.CheckpointTranscriptStart = result.TotalTranscriptLines
+	state.CheckpointCommandsStart = result.TotalCommands
 
 	// Clear attribution tracking — condensation already used these values
 	state.PromptAttributions = nil
@@ -985,8 +986,22 @@ func (s *ManualCommitStrategy) sessionHasNewContentFromLiveTranscript(repo *git.
 		return false, nil // Agent doesn't support transcript analysis
 	}
 
-	// Get current transcript position
-	currentPos, err := analyzer.GetTranscriptPosition(state.TranscriptPath)
+	// Offsets are positions in the transcript merged with any pre-compaction snapshot
+	var currentPos int
+	var modifiedFiles []string
+	err = WithFullTranscript(state.SessionID, state.TranscriptPath, state.AgentType, func(transcriptPath string) error {
+		// Get current transcript position
+		var posErr error
+		currentPos, posErr = analyzer.GetTranscriptPosition(transcriptPath)
+		if posErr != nil || currentPos <= state.CheckpointTranscriptStart {
+			return posErr //nolint:wrapcheck // only checked for failure
+		}
+
+		// Transcript has grown - check if there are file modifications in the new portion
+		var extractErr error
+		modifiedFiles, _, extractErr = analyzer.ExtractModifiedFilesFromOffset(transcriptPath, state.CheckpointTranscriptStart)
+		return extractErr //nolint:wrapcheck // only checked for failure
+	})
 	if err != nil {
 		return false, nil //nolint:nilerr // Error reading transcript, fail gracefully
 	}
@@ -1001,12 +1016,6 @@ func (s *ManualCommitStrategy) sessionHasNewContentFromLiveTranscript(repo *git.
 		return false, nil // No new content
 	}
 
-	// Transcript has grown - check if there are file modifications in the new portion
-	modifiedFiles, _, err := analyzer.ExtractModifiedFilesFromOffset(state.TranscriptPath, state.CheckpointTranscriptStart)
-	if err != nil {
-		return false, nil //nolint:nilerr // Error parsing transcript, fail gracefully
-	}
-
 	// No file modifications means no new content to checkpoint
 	if len(modifiedFiles) == 0 {
 		logging.Debug(logCtx, "live transcript check: transcript grew but no file modifications",
@@ -1223,6 +1232,7 @@ func (s *ManualCommitStrategy) InitializeSession(sessionID string, agentType age
 	if state != nil && state.BaseCommit != "" {
 		// Session is fully initialized — apply phase transition for TurnStart
 		TransitionAndLog(state, session.EventTurnStart, session.TransitionContext{})
+		state.TurnID = newTurnID()
 
 		// Backfill AgentType if empty or set to the generic default "Agent"
 		if !isSpecificAgentType(state.AgentType) && agentType != "" {
@@ -1279,6 +1289,7 @@ func (s *ManualCommitStrategy) InitializeSession(sessionID string, agentType age
 
 	// Apply phase transition: new session starts as ACTIVE
 	TransitionAndLog(state, session.EventTurnStart, session.TransitionContext{})
+	state.TurnID = newTurnID()
 
 	// Calculate attribution for pre-prompt edits
 	// This captures any user edits made before the first prompt
@@ -1292,6 +1303,17 @@ func (s *ManualCommitStrategy) InitializeSession(sessionID string, agentType age
 	return nil
 }
 
+// newTurnID returns an identifier for a new agent turn, recorded in the
+// turn's shadow checkpoints. Returns "" if no ID can be generated, which
+// only loses the grouping of the turn's checkpoints in rewind.
+func newTurnID() string {
+	turnID, err := id.Generate()
+	if err != nil {
+		return ""
+	}
+	return turnID.String()
+}
+
 // calculatePromptAttributionAtStart calculates attribution at prompt start (before agent runs).
 // This captures user changes since the last checkpoint - no filtering needed since
 // the agent hasn't made any changes yet.
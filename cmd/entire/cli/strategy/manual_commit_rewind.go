@@ -77,6 +77,9 @@ func (s *ManualCommitStrategy) GetRewindPoints(limit int) ([]RewindPoint, error)
 				Date:             cp.Timestamp,
 				IsTaskCheckpoint: cp.IsTaskCheckpoint,
 				ToolUseID:        cp.ToolUseID,
+				TurnID:           cp.TurnID,
+				IsToolCheckpoint: cp.ToolName != "",
+				ToolName:         cp.ToolName,
 				SessionID:        cp.SessionID,
 				SessionPrompt:    sessionPrompt,
 				Agent:            state.AgentType,
@@ -120,7 +123,48 @@ func (s *ManualCommitStrategy) GetRewindPoints(limit int) ([]RewindPoint, error)
 		}
 	}
 
-	return allPoints, nil
+	return groupRewindPointsByTurn(allPoints), nil
+}
+
+// groupRewindPointsByTurn reorders points, sorted most recent first, so that
+// the fine-grained checkpoints of a turn directly follow the turn's own
+// checkpoint. A turn that hasn't ended yet only has fine-grained checkpoints,
+// which stay together at the position of the newest one. Points without a
+// turn keep their position.
+func groupRewindPointsByTurn(points []RewindPoint) []RewindPoint {
+	turnKey := func(p RewindPoint) string { return p.SessionID + "/" + p.TurnID }
+
+	turns := make(map[string][]RewindPoint)
+	for _, p := range points {
+		if p.TurnID != "" {
+			turns[turnKey(p)] = append(turns[turnKey(p)], p)
+		}
+	}
+
+	grouped := make([]RewindPoint, 0, len(points))
+	emitted := make(map[string]bool)
+	for _, p := range points {
+		if p.TurnID == "" {
+			grouped = append(grouped, p)
+			continue
+		}
+		key := turnKey(p)
+		if emitted[key] {
+			continue
+		}
+		emitted[key] = true
+		for _, member := range turns[key] {
+			if !member.IsToolCheckpoint {
+				grouped = append(grouped, member)
+			}
+		}
+		for _, member := range turns[key] {
+			if member.IsToolCheckpoint {
+				grouped = append(grouped, member)
+			}
+		}
+	}
+	return grouped
 }
 
 // GetLogsOnlyRewindPoints finds commits in the current branch's history that have
@@ -267,6 +311,11 @@ func (s *ManualCommitStrategy) Rewind(point RewindPoint) error {
 		return fmt.Errorf("failed to get tree: %w", err)
 	}
 
+	// Snapshot the working tree and shadow branch so the rewind can be undone
+	if err := saveUndoPoint(repo, "Before rewind to "+commitHash.String()[:7], s.shadowBranchForCheckpoint(commit), plumbing.ZeroHash); err != nil {
+		return fmt.Errorf("failed to save undo point: %w", err)
+	}
+
 	// Reset the shadow branch to the rewound checkpoint
 	// This ensures the next checkpoint will only include prompts from this point forward
 	if err := s.resetShadowBranchToCheckpoint(repo, commit); err != nil {
@@ -461,6 +510,20 @@ func (s *ManualCommitStrategy) resetShadowBranchToCheckpoint(repo *git.Repositor
 	return nil
 }
 
+// shadowBranchForCheckpoint returns the shadow branch of the checkpoint's
+// session, or empty if the session state can't be found.
+func (s *ManualCommitStrategy) shadowBranchForCheckpoint(commit *object.Commit) string {
+	sessionID, found := trailers.ParseSession(commit.Message)
+	if !found {
+		return ""
+	}
+	state, err := s.loadSessionState(sessionID)
+	if err != nil || state == nil {
+		return ""
+	}
+	return getShadowBranchNameForCommit(state.BaseCommit, state.WorktreeID)
+}
+
 // CanRewind checks if rewinding is possible.
 // For manual-commit strategy, rewind restores files from a checkpoint - uncommitted changes are expected
 // and will be replaced by the checkpoint contents. Returns true with a warning message showing
@@ -605,9 +668,16 @@ func (s *ManualCommitStrategy) PreviewRewind(point RewindPoint) (*RewindPreview,
 	sort.Strings(filesToRestore)
 	sort.Strings(filesToDelete)
 
+	// Diffs are informational, so a failure here doesn't fail the preview
+	diffs, diffErr := previewDiffsForPoint(point, s.pathDeleteRule(point))
+	if diffErr != nil {
+		diffs = nil
+	}
+
 	return &RewindPreview{
 		FilesToRestore: filesToRestore,
 		FilesToDelete:  filesToDelete,
+		Diffs:          diffs,
 	}, nil
 }
 
@@ -1002,3 +1072,47 @@ func PromptOverwriteNewerLogs(sessions []SessionRestoreInfo) (bool, error) {
 
 	return confirmed, nil
 }
+
+// ChangedPaths returns the files a partial rewind to the point would change.
+func (s *ManualCommitStrategy) ChangedPaths(point RewindPoint) ([]string, error) {
+	return changedPathsForPoint(point, s.pathDeleteRule(point))
+}
+
+// RewindPaths restores only the selected paths from the checkpoint.
+// The shadow branch is not reset: the rest of the working tree keeps its later
+// state, so the next checkpoint continues from the current session.
+func (s *ManualCommitStrategy) RewindPaths(point RewindPoint, paths []string) (*PathRewindResult, error) {
+	return rewindPathsForPoint(point, paths, s.pathDeleteRule(point))
+}
+
+// pathDeleteRule returns the delete rule for a partial rewind. Committed
+// points are regular commits. For shadow checkpoints the rule matches Rewind:
+// only untracked files created after the session started are deleted.
+func (s *ManualCommitStrategy) pathDeleteRule(point RewindPoint) func(*git.Repository) (pathDeleteRule, error) {
+	if point.IsLogsOnly {
+		return committedPointDeleteRule
+	}
+	return func(repo *git.Repository) (pathDeleteRule, error) {
+		tracked, err := headTrackedFiles(repo)
+		if err != nil {
+			return nil, err
+		}
+
+		preserved := make(map[string]bool)
+		commit, err := repo.CommitObject(plumbing.NewHash(point.ID))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get commit: %w", err)
+		}
+		if sessionID, ok := trailers.ParseSession(commit.Message); ok {
+			if state, stateErr := s.loadSessionState(sessionID); stateErr == nil && state != nil {
+				for _, f := range state.UntrackedFilesAtStart {
+					preserved[f] = true
+				}
+			}
+		}
+
+		return func(relPath string) bool {
+			return !tracked[relPath] && !preserved[relPath]
+		}, nil
+	}
+}
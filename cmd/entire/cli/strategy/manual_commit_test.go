@@ -2948,3 +2948,101 @@ func TestCondenseSession_GeminiMultiCheckpoint(t *testing.T) {
 		t.Error("Prompts should contain second prompt")
 	}
 }
+
+func TestShadowStrategy_SaveToolCheckpoint(t *testing.T) {
+	_, dir := setupPathRewindRepo(t)
+
+	s := &ManualCommitStrategy{}
+	sessionID := "2026-01-15-tool-checkpoints"
+	if err := s.InitializeSession(sessionID, agent.AgentTypeClaudeCode, "", "refactor a.go"); err != nil {
+		t.Fatalf("InitializeSession() error = %v", err)
+	}
+	state, err := s.loadSessionState(sessionID)
+	if err != nil {
+		t.Fatalf("loadSessionState() error = %v", err)
+	}
+	firstTurn := state.TurnID
+	if firstTurn == "" {
+		t.Fatal("InitializeSession() should start a turn")
+	}
+
+	saveEdit := func(file string, newFiles []string) {
+		t.Helper()
+		err := s.SaveToolCheckpoint(ToolCheckpointContext{
+			SessionID:     sessionID,
+			ToolName:      "Edit",
+			EditedFile:    file,
+			ModifiedFiles: []string{file},
+			NewFiles:      newFiles,
+			AuthorName:    "Test",
+			AuthorEmail:   "test@test.com",
+		})
+		if err != nil {
+			t.Fatalf("SaveToolCheckpoint() error = %v", err)
+		}
+	}
+
+	writeFiles(t, dir, map[string]string{"a.go": "a1"})
+	saveEdit("a.go", []string{"a.go"})
+	saveEdit("a.go", []string{"a.go"}) // Same tree: deduplicated
+	writeFiles(t, dir, map[string]string{"b.go": "b1"})
+	saveEdit("b.go", []string{"a.go", "b.go"})
+
+	state, err = s.loadSessionState(sessionID)
+	if err != nil {
+		t.Fatalf("loadSessionState() error = %v", err)
+	}
+	if state.StepCount != 0 {
+		t.Errorf("StepCount = %d, want 0 (tool checkpoints aren't steps)", state.StepCount)
+	}
+	if len(state.FilesTouched) != 2 {
+		t.Errorf("FilesTouched = %v, want a.go and b.go", state.FilesTouched)
+	}
+
+	// Turn end
+	metadataDir := paths.SessionMetadataDirFromSessionID(sessionID)
+	writeFiles(t, dir, map[string]string{metadataDir + "/" + paths.TranscriptFileName: "{}\n"})
+	if err := s.SaveChanges(SaveContext{
+		SessionID:      sessionID,
+		ModifiedFiles:  []string{"a.go", "b.go"},
+		MetadataDir:    metadataDir,
+		MetadataDirAbs: filepath.Join(dir, metadataDir),
+		CommitMessage:  "refactor a.go",
+		AuthorName:     "Test",
+		AuthorEmail:    "test@test.com",
+	}); err != nil {
+		t.Fatalf("SaveChanges() error = %v", err)
+	}
+
+	// Next turn, still in progress
+	if err := s.InitializeSession(sessionID, agent.AgentTypeClaudeCode, "", ""); err != nil {
+		t.Fatalf("InitializeSession() error = %v", err)
+	}
+	writeFiles(t, dir, map[string]string{"a.go": "a2"})
+	saveEdit("a.go", nil)
+
+	points, err := s.GetRewindPoints(20)
+	if err != nil {
+		t.Fatalf("GetRewindPoints() error = %v", err)
+	}
+	if len(points) != 4 {
+		t.Fatalf("GetRewindPoints() returned %d points, want 4: %+v", len(points), points)
+	}
+
+	// The in-progress turn comes first, then the first turn's own checkpoint
+	// followed by its two edits
+	if !points[0].IsToolCheckpoint || points[0].TurnID == firstTurn || points[0].TurnID == "" {
+		t.Errorf("points[0] = %+v, want an edit from the second turn", points[0])
+	}
+	if points[1].IsToolCheckpoint || points[1].TurnID != firstTurn || points[1].Message != "refactor a.go" {
+		t.Errorf("points[1] = %+v, want the first turn's checkpoint", points[1])
+	}
+	for _, p := range points[2:] {
+		if !p.IsToolCheckpoint || p.TurnID != firstTurn || p.ToolName != "Edit" {
+			t.Errorf("point %+v, want an Edit checkpoint from the first turn", p)
+		}
+		if !strings.HasPrefix(p.Message, "Edit: ") {
+			t.Errorf("tool checkpoint message = %q, want \"Edit: <file>\"", p.Message)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/entireio/cli/cmd/entire/cli/agent"
+	cpkg "github.com/entireio/cli/cmd/entire/cli/checkpoint"
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
 	"github.com/entireio/cli/cmd/entire/cli/session"
 	"github.com/entireio/cli/cmd/entire/cli/stringutil"
@@ -52,6 +53,7 @@ type CondenseResult struct {
 	CheckpointsCount     int
 	FilesTouched         []string
 	TotalTranscriptLines int // Total lines in transcript after this condensation
+	TotalCommands        int // Total records in the session's commands.jsonl after this condensation
 }
 
 // ExtractedSessionData contains data extracted from a shadow branch.
@@ -61,5 +63,7 @@ type ExtractedSessionData struct {
 	Prompts             []string // All user prompts from this portion
 	Context             []byte   // Generated context.md content
 	FilesTouched        []string
-	TokenUsage          *agent.TokenUsage // Token usage calculated from transcript (since CheckpointTranscriptStart)
+	TokenUsage          *agent.TokenUsage    // Token usage calculated from transcript (since CheckpointTranscriptStart)
+	Commands            []cpkg.CommandRecord // Shell commands run since CheckpointCommandsStart
+	TotalCommands       int                  // Total records in the session's commands.jsonl
 }
@@ -88,6 +88,16 @@ func FormatIncrementalMessage(todoContent string, sequence int, toolUseID string
 	return fmt.Sprintf("%s (%s)", todoContent, toolUseID)
 }
 
+// FormatToolCheckpointMessage formats the commit message for a fine-grained
+// checkpoint taken after a file-editing tool call.
+// Format: "<tool>: <file>", or just "<tool>" if the file is unknown.
+func FormatToolCheckpointMessage(toolName, file string) string {
+	if file == "" {
+		return toolName
+	}
+	return fmt.Sprintf("%s: %s", toolName, file)
+}
+
 // todoItem represents a single item in the TodoWrite tool_input.todos array.
 type todoItem struct {
 	Content    string `json:"content"`
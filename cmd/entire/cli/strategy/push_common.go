@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
 	"github.com/entireio/cli/cmd/entire/cli/settings"
 
 	"github.com/go-git/go-git/v5"
@@ -123,6 +124,12 @@ func tryPushSessionsCommon(remote, branchName string) error {
 	return nil
 }
 
+// MergeRemoteSessionsBranch fetches the metadata branch from remote and
+// merges it into the local branch, so the next push fast-forwards.
+func MergeRemoteSessionsBranch(remote string) error {
+	return fetchAndMergeSessionsCommon(remote, paths.MetadataBranchName)
+}
+
 // fetchAndMergeSessionsCommon fetches remote sessions and merges into local using go-git.
 // Since session logs are append-only (unique cond-* directories), we just combine trees.
 func fetchAndMergeSessionsCommon(remote, branchName string) error {
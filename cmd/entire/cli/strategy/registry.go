@@ -53,6 +53,8 @@ func List() []string {
 const (
 	StrategyNameManualCommit = "manual-commit"
 	StrategyNameAutoCommit   = "auto-commit"
+	StrategyNameWorktree     = "worktree"
+	StrategyNameSquashCommit = "squash-commit"
 )
 
 // DefaultStrategyName is the name of the default strategy.
@@ -0,0 +1,716 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// ErrNoRetentionPolicy is returned when prune runs without any retention rule.
+var ErrNoRetentionPolicy = errors.New("no retention rules configured")
+
+// RetentionPolicy is the parsed form of settings.RetentionSettings.
+// Zero values disable the corresponding rule.
+type RetentionPolicy struct {
+	MaxAge            time.Duration
+	MaxTotalSize      int64
+	KeepLastPerBranch int
+	KeepSummaries     bool
+}
+
+// RetentionPolicyFromSettings parses the retention rules from settings.
+// Returns an empty policy when no retention is configured.
+func RetentionPolicyFromSettings(r *settings.RetentionSettings) (RetentionPolicy, error) {
+	if r == nil {
+		return RetentionPolicy{}, nil
+	}
+	maxAge, err := r.MaxAgeDuration()
+	if err != nil {
+		return RetentionPolicy{}, fmt.Errorf("retention.max_age: %w", err)
+	}
+	maxSize, err := r.MaxTotalSizeBytes()
+	if err != nil {
+		return RetentionPolicy{}, fmt.Errorf("retention.max_total_size: %w", err)
+	}
+	if r.KeepLastPerBranch < 0 {
+		return RetentionPolicy{}, fmt.Errorf("retention.keep_last_per_branch must not be negative, got %d", r.KeepLastPerBranch)
+	}
+	return RetentionPolicy{
+		MaxAge:            maxAge,
+		MaxTotalSize:      maxSize,
+		KeepLastPerBranch: r.KeepLastPerBranch,
+		KeepSummaries:     r.KeepSummaries,
+	}, nil
+}
+
+// IsEmpty returns true if the policy has no rules that could select a checkpoint.
+func (p RetentionPolicy) IsEmpty() bool {
+	return p.MaxAge == 0 && p.MaxTotalSize == 0 && p.KeepLastPerBranch == 0
+}
+
+// PruneAction describes what prune does to a selected checkpoint.
+type PruneAction string
+
+const (
+	// PruneActionDelete removes the checkpoint directory entirely.
+	PruneActionDelete PruneAction = "delete"
+
+	// PruneActionStrip removes transcripts, prompts, context and task data but
+	// keeps the root and per-session metadata.json records (including summaries),
+	// so commits that reference the checkpoint still resolve in "entire explain".
+	PruneActionStrip PruneAction = "strip"
+)
+
+// PruneItem is a checkpoint selected by the retention policy.
+type PruneItem struct {
+	CheckpointID id.CheckpointID
+	Branch       string
+	CreatedAt    time.Time
+	Action       PruneAction
+	Reason       string
+	FreedBytes   int64 // Bytes removed from the current branch tree
+}
+
+// PrunePlan lists the checkpoints that prune would modify.
+type PrunePlan struct {
+	Items      []PruneItem
+	TotalBytes int64 // Size of all checkpoint content before pruning
+	FreedBytes int64 // Size removed by applying the plan
+}
+
+// PruneResult describes a metadata branch rewrite performed by ApplyPrunePlan.
+type PruneResult struct {
+	OldTip           plumbing.Hash
+	NewTip           plumbing.Hash
+	RewrittenCommits int
+}
+
+// retentionCandidate is a committed checkpoint considered by the retention rules.
+type retentionCandidate struct {
+	id         id.CheckpointID
+	branch     string
+	createdAt  time.Time
+	totalBytes int64 // All blobs under the checkpoint directory
+	metaBytes  int64 // metadata.json blobs kept by PruneActionStrip
+	referenced bool
+}
+
+// strippableBytes is the size removed by PruneActionStrip.
+func (c retentionCandidate) strippableBytes() int64 {
+	return c.totalBytes - c.metaBytes
+}
+
+// PlanPrune evaluates the retention policy against the local entire/checkpoints/v1
+// branch and returns the checkpoints that would be pruned. It does not modify anything.
+// Returns an empty plan if the metadata branch does not exist.
+func PlanPrune(repo *git.Repository, policy RetentionPolicy, now time.Time) (*PrunePlan, error) {
+	plan := &PrunePlan{}
+
+	refName := plumbing.NewBranchReferenceName(paths.MetadataBranchName)
+	ref, err := repo.Reference(refName, true)
+	if err != nil {
+		return plan, nil //nolint:nilerr // No metadata branch means nothing to prune
+	}
+	tipCommit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata branch commit: %w", err)
+	}
+	tree, err := tipCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get metadata branch tree: %w", err)
+	}
+
+	candidates, err := collectRetentionCandidates(repo, tree)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range candidates {
+		plan.TotalBytes += c.totalBytes
+	}
+	if policy.IsEmpty() || len(candidates) == 0 {
+		return plan, nil
+	}
+
+	referenced := findReferencedCheckpoints(repo)
+	for i := range candidates {
+		candidates[i].referenced = referenced[candidates[i].id.String()]
+	}
+
+	// Newest first: keep-last-N and the size budget both favour recent checkpoints
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].createdAt.After(candidates[j].createdAt)
+	})
+
+	reasons := make(map[id.CheckpointID]string)
+
+	if policy.MaxAge > 0 {
+		cutoff := now.Add(-policy.MaxAge)
+		for _, c := range candidates {
+			if !c.createdAt.IsZero() && c.createdAt.Before(cutoff) {
+				reasons[c.id] = "older than " + formatAge(policy.MaxAge)
+			}
+		}
+	}
+
+	if policy.KeepLastPerBranch > 0 {
+		seen := make(map[string]int)
+		for _, c := range candidates {
+			seen[c.branch]++
+			if seen[c.branch] > policy.KeepLastPerBranch {
+				if _, selected := reasons[c.id]; !selected {
+					reasons[c.id] = fmt.Sprintf("beyond last %d on %s", policy.KeepLastPerBranch, branchLabel(c.branch))
+				}
+			}
+		}
+	}
+
+	// Size budget: account for what the other rules already free, then prune
+	// the oldest remaining checkpoints until the branch fits.
+	if policy.MaxTotalSize > 0 {
+		remaining := plan.TotalBytes
+		for _, c := range candidates {
+			if _, selected := reasons[c.id]; selected {
+				remaining -= freedBytes(c, pruneActionFor(c, policy))
+			}
+		}
+		for i := len(candidates) - 1; i >= 0 && remaining > policy.MaxTotalSize; i-- {
+			c := candidates[i]
+			if _, selected := reasons[c.id]; selected {
+				continue
+			}
+			freed := freedBytes(c, pruneActionFor(c, policy))
+			if freed == 0 {
+				continue
+			}
+			reasons[c.id] = "metadata branch exceeds " + FormatByteSize(policy.MaxTotalSize)
+			remaining -= freed
+		}
+	}
+
+	for _, c := range candidates {
+		reason, selected := reasons[c.id]
+		if !selected {
+			continue
+		}
+		action := pruneActionFor(c, policy)
+		freed := freedBytes(c, action)
+		if action == PruneActionStrip && freed == 0 {
+			continue // Already stripped by a previous prune
+		}
+		plan.Items = append(plan.Items, PruneItem{
+			CheckpointID: c.id,
+			Branch:       c.branch,
+			CreatedAt:    c.createdAt,
+			Action:       action,
+			Reason:       reason,
+			FreedBytes:   freed,
+		})
+		plan.FreedBytes += freed
+	}
+
+	// Present oldest first
+	sort.SliceStable(plan.Items, func(i, j int) bool {
+		return plan.Items[i].CreatedAt.Before(plan.Items[j].CreatedAt)
+	})
+
+	return plan, nil
+}
+
+// pruneActionFor decides how a selected checkpoint is pruned. Checkpoints still
+// referenced by an Entire-Checkpoint trailer always keep their metadata.json records.
+func pruneActionFor(c retentionCandidate, policy RetentionPolicy) PruneAction {
+	if policy.KeepSummaries || c.referenced {
+		return PruneActionStrip
+	}
+	return PruneActionDelete
+}
+
+func freedBytes(c retentionCandidate, action PruneAction) int64 {
+	if action == PruneActionStrip {
+		return c.strippableBytes()
+	}
+	return c.totalBytes
+}
+
+// collectRetentionCandidates scans the sharded checkpoint layout of a metadata
+// branch tree and gathers the branch, creation time and sizes of each checkpoint.
+func collectRetentionCandidates(repo *git.Repository, tree *object.Tree) ([]retentionCandidate, error) {
+	var candidates []retentionCandidate
+
+	for _, bucketEntry := range tree.Entries {
+		if bucketEntry.Mode != filemode.Dir || len(bucketEntry.Name) != 2 {
+			continue
+		}
+		bucketTree, err := repo.TreeObject(bucketEntry.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read bucket %s: %w", bucketEntry.Name, err)
+		}
+		for _, cpEntry := range bucketTree.Entries {
+			if cpEntry.Mode != filemode.Dir {
+				continue
+			}
+			cpID, err := id.NewCheckpointID(bucketEntry.Name + cpEntry.Name)
+			if err != nil {
+				continue // Not a checkpoint directory
+			}
+			cpTree, err := repo.TreeObject(cpEntry.Hash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+			}
+
+			c := retentionCandidate{id: cpID}
+			if err := sumCheckpointSizes(repo, cpTree, "", &c); err != nil {
+				return nil, fmt.Errorf("failed to size checkpoint %s: %w", cpID, err)
+			}
+			readRetentionMetadata(cpTree, &c)
+			candidates = append(candidates, c)
+		}
+	}
+
+	return candidates, nil
+}
+
+// sumCheckpointSizes walks a checkpoint tree, adding blob sizes to c.
+// metadata.json files at the checkpoint root and in session subdirectories
+// are counted separately since PruneActionStrip keeps them.
+func sumCheckpointSizes(repo *git.Repository, tree *object.Tree, prefix string, c *retentionCandidate) error {
+	for _, entry := range tree.Entries {
+		path := prefix + entry.Name
+		if entry.Mode == filemode.Dir {
+			sub, err := repo.TreeObject(entry.Hash)
+			if err != nil {
+				return fmt.Errorf("failed to read tree %s: %w", path, err)
+			}
+			if err := sumCheckpointSizes(repo, sub, path+"/", c); err != nil {
+				return err
+			}
+			continue
+		}
+		size, err := repo.Storer.EncodedObjectSize(entry.Hash)
+		if err != nil {
+			return fmt.Errorf("failed to get size of %s: %w", path, err)
+		}
+		c.totalBytes += size
+		if isKeptByStrip(path) {
+			c.metaBytes += size
+		}
+	}
+	return nil
+}
+
+// isKeptByStrip reports whether a path relative to a checkpoint directory
+// survives PruneActionStrip: "metadata.json" and "<session-index>/metadata.json".
+func isKeptByStrip(relPath string) bool {
+	if relPath == paths.MetadataFileName {
+		return true
+	}
+	dir, file, found := strings.Cut(relPath, "/")
+	return found && file == paths.MetadataFileName && isSessionDir(dir)
+}
+
+func isSessionDir(name string) bool {
+	_, err := strconv.Atoi(name)
+	return err == nil
+}
+
+// readRetentionMetadata fills in the branch and creation time of a checkpoint.
+// The creation time is the most recent session's created_at, so a checkpoint
+// that gained a session recently is treated as recent.
+func readRetentionMetadata(cpTree *object.Tree, c *retentionCandidate) {
+	summaryFile, err := cpTree.File(paths.MetadataFileName)
+	if err != nil {
+		return
+	}
+	content, err := summaryFile.Contents()
+	if err != nil {
+		return
+	}
+	var summary checkpoint.CheckpointSummary
+	if err := json.Unmarshal([]byte(content), &summary); err != nil {
+		return
+	}
+	c.branch = summary.Branch
+
+	for i := range summary.Sessions {
+		sessionFile, err := cpTree.File(strconv.Itoa(i) + "/" + paths.MetadataFileName)
+		if err != nil {
+			continue
+		}
+		sessionContent, err := sessionFile.Contents()
+		if err != nil {
+			continue
+		}
+		var meta checkpoint.CommittedMetadata
+		if err := json.Unmarshal([]byte(sessionContent), &meta); err != nil {
+			continue
+		}
+		if meta.CreatedAt.After(c.createdAt) {
+			c.createdAt = meta.CreatedAt
+		}
+		if c.branch == "" {
+			c.branch = meta.Branch
+		}
+	}
+}
+
+// ApplyPrunePlan rewrites the history of the local entire/checkpoints/v1 branch so
+// that no commit contains the pruned data, which lets git gc reclaim the space.
+// Commits keep their author, committer and message; commits whose tree is
+// unaffected (and whose parents were not rewritten) keep their original hash.
+func ApplyPrunePlan(repo *git.Repository, plan *PrunePlan) (*PruneResult, error) {
+	refName := plumbing.NewBranchReferenceName(paths.MetadataBranchName)
+	ref, err := repo.Reference(refName, true)
+	if err != nil {
+		return nil, fmt.Errorf("metadata branch not found: %w", err)
+	}
+	result := &PruneResult{OldTip: ref.Hash(), NewTip: ref.Hash()}
+	if plan == nil || len(plan.Items) == 0 {
+		return result, nil
+	}
+
+	rw := newPruneRewriter(repo, plan.Items)
+
+	commits, err := metadataCommitsParentsFirst(repo, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	rewritten := make(map[plumbing.Hash]plumbing.Hash, len(commits))
+	for _, c := range commits {
+		newTree, err := rw.rewriteRoot(c.TreeHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rewrite tree of %s: %w", c.Hash, err)
+		}
+
+		changed := newTree != c.TreeHash
+		parents := make([]plumbing.Hash, len(c.ParentHashes))
+		for i, p := range c.ParentHashes {
+			parents[i] = rewritten[p]
+			if parents[i] != p {
+				changed = true
+			}
+		}
+		if !changed {
+			rewritten[c.Hash] = c.Hash
+			continue
+		}
+
+		newCommit := &object.Commit{
+			Author:       c.Author,
+			Committer:    c.Committer,
+			Message:      c.Message,
+			TreeHash:     newTree,
+			ParentHashes: parents,
+		}
+		obj := repo.Storer.NewEncodedObject()
+		if err := newCommit.Encode(obj); err != nil {
+			return nil, fmt.Errorf("failed to encode commit: %w", err)
+		}
+		newHash, err := repo.Storer.SetEncodedObject(obj)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store commit: %w", err)
+		}
+		rewritten[c.Hash] = newHash
+		result.RewrittenCommits++
+	}
+
+	result.NewTip = rewritten[ref.Hash()]
+	if result.NewTip != result.OldTip {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(refName, result.NewTip)); err != nil {
+			return nil, fmt.Errorf("failed to update metadata branch: %w", err)
+		}
+	}
+
+	logCtx := logging.WithComponent(context.Background(), "prune")
+	for _, item := range plan.Items {
+		logging.Info(logCtx, "pruned checkpoint",
+			slog.String("checkpoint_id", item.CheckpointID.String()),
+			slog.String("action", string(item.Action)),
+			slog.String("reason", item.Reason),
+			slog.Int64("freed_bytes", item.FreedBytes),
+		)
+	}
+	logging.Info(logCtx, "metadata branch rewritten",
+		slog.String("old_tip", result.OldTip.String()),
+		slog.String("new_tip", result.NewTip.String()),
+		slog.Int("rewritten_commits", result.RewrittenCommits),
+	)
+
+	return result, nil
+}
+
+// metadataCommitsParentsFirst returns all commits reachable from tip, ordered
+// so that every commit appears after all of its parents.
+func metadataCommitsParentsFirst(repo *git.Repository, tip plumbing.Hash) ([]*object.Commit, error) {
+	var ordered []*object.Commit
+	visited := make(map[plumbing.Hash]bool)
+
+	type frame struct {
+		commit   *object.Commit
+		expanded bool
+	}
+
+	tipCommit, err := repo.CommitObject(tip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", tip, err)
+	}
+	stack := []frame{{commit: tipCommit}}
+	for len(stack) > 0 {
+		top := &stack[len(stack)-1]
+		if visited[top.commit.Hash] {
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		if top.expanded {
+			visited[top.commit.Hash] = true
+			ordered = append(ordered, top.commit)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		top.expanded = true
+		commit := top.commit
+		for i := len(commit.ParentHashes) - 1; i >= 0; i-- {
+			parentHash := commit.ParentHashes[i]
+			if visited[parentHash] {
+				continue
+			}
+			parent, err := repo.CommitObject(parentHash)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get commit %s: %w", parentHash, err)
+			}
+			stack = append(stack, frame{commit: parent})
+		}
+	}
+
+	return ordered, nil
+}
+
+// pruneRewriter rewrites metadata branch trees according to a prune plan.
+// Results are memoized by tree hash since most commits share most subtrees.
+type pruneRewriter struct {
+	repo    *git.Repository
+	actions map[string]map[string]PruneAction // bucket -> remaining ID -> action
+
+	rootMemo   map[plumbing.Hash]plumbing.Hash
+	bucketMemo map[string]plumbing.Hash // bucket name + tree hash -> rewritten hash
+	stripMemo  map[plumbing.Hash]plumbing.Hash
+}
+
+func newPruneRewriter(repo *git.Repository, items []PruneItem) *pruneRewriter {
+	rw := &pruneRewriter{
+		repo:       repo,
+		actions:    make(map[string]map[string]PruneAction),
+		rootMemo:   make(map[plumbing.Hash]plumbing.Hash),
+		bucketMemo: make(map[string]plumbing.Hash),
+		stripMemo:  make(map[plumbing.Hash]plumbing.Hash),
+	}
+	for _, item := range items {
+		bucket, rest, _ := strings.Cut(item.CheckpointID.Path(), "/")
+		if rw.actions[bucket] == nil {
+			rw.actions[bucket] = make(map[string]PruneAction)
+		}
+		rw.actions[bucket][rest] = item.Action
+	}
+	return rw
+}
+
+func (rw *pruneRewriter) rewriteRoot(treeHash plumbing.Hash) (plumbing.Hash, error) {
+	if h, ok := rw.rootMemo[treeHash]; ok {
+		return h, nil
+	}
+	tree, err := rw.repo.TreeObject(treeHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to read tree: %w", err)
+	}
+
+	entries := make([]object.TreeEntry, 0, len(tree.Entries))
+	changed := false
+	for _, entry := range tree.Entries {
+		if _, affected := rw.actions[entry.Name]; !affected || entry.Mode != filemode.Dir {
+			entries = append(entries, entry)
+			continue
+		}
+		newHash, empty, err := rw.rewriteBucket(entry.Name, entry.Hash)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if newHash == entry.Hash {
+			entries = append(entries, entry)
+			continue
+		}
+		changed = true
+		if !empty {
+			entries = append(entries, object.TreeEntry{Name: entry.Name, Mode: filemode.Dir, Hash: newHash})
+		}
+	}
+
+	result := treeHash
+	if changed {
+		result, err = storeTree(rw.repo, entries)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+	rw.rootMemo[treeHash] = result
+	return result, nil
+}
+
+// rewriteBucket applies the plan to one shard directory. Returns whether the
+// rewritten bucket is empty and should be dropped from its parent.
+func (rw *pruneRewriter) rewriteBucket(bucket string, treeHash plumbing.Hash) (plumbing.Hash, bool, error) {
+	memoKey := bucket + treeHash.String()
+	if h, ok := rw.bucketMemo[memoKey]; ok {
+		return h, h == plumbing.ZeroHash, nil
+	}
+	tree, err := rw.repo.TreeObject(treeHash)
+	if err != nil {
+		return plumbing.ZeroHash, false, fmt.Errorf("failed to read bucket %s: %w", bucket, err)
+	}
+
+	entries := make([]object.TreeEntry, 0, len(tree.Entries))
+	changed := false
+	for _, entry := range tree.Entries {
+		action, selected := rw.actions[bucket][entry.Name]
+		if !selected || entry.Mode != filemode.Dir {
+			entries = append(entries, entry)
+			continue
+		}
+		switch action {
+		case PruneActionDelete:
+			changed = true
+		case PruneActionStrip:
+			stripped, err := rw.stripCheckpoint(entry.Hash)
+			if err != nil {
+				return plumbing.ZeroHash, false, err
+			}
+			if stripped != entry.Hash {
+				changed = true
+			}
+			if stripped == plumbing.ZeroHash {
+				continue // Nothing left worth keeping
+			}
+			entries = append(entries, object.TreeEntry{Name: entry.Name, Mode: filemode.Dir, Hash: stripped})
+		}
+	}
+
+	if !changed {
+		rw.bucketMemo[memoKey] = treeHash
+		return treeHash, false, nil
+	}
+	if len(entries) == 0 {
+		rw.bucketMemo[memoKey] = plumbing.ZeroHash
+		return plumbing.ZeroHash, true, nil
+	}
+	newHash, err := storeTree(rw.repo, entries)
+	if err != nil {
+		return plumbing.ZeroHash, false, err
+	}
+	rw.bucketMemo[memoKey] = newHash
+	return newHash, false, nil
+}
+
+// stripCheckpoint keeps only metadata.json and <session-index>/metadata.json.
+func (rw *pruneRewriter) stripCheckpoint(treeHash plumbing.Hash) (plumbing.Hash, error) {
+	if h, ok := rw.stripMemo[treeHash]; ok {
+		return h, nil
+	}
+	tree, err := rw.repo.TreeObject(treeHash)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to read checkpoint tree: %w", err)
+	}
+
+	var entries []object.TreeEntry
+	for _, entry := range tree.Entries {
+		switch {
+		case entry.Mode != filemode.Dir && entry.Name == paths.MetadataFileName:
+			entries = append(entries, entry)
+		case entry.Mode == filemode.Dir && isSessionDir(entry.Name):
+			sessionTree, err := rw.repo.TreeObject(entry.Hash)
+			if err != nil {
+				return plumbing.ZeroHash, fmt.Errorf("failed to read session tree: %w", err)
+			}
+			for _, sessionEntry := range sessionTree.Entries {
+				if sessionEntry.Mode != filemode.Dir && sessionEntry.Name == paths.MetadataFileName {
+					sessionHash, err := storeTree(rw.repo, []object.TreeEntry{sessionEntry})
+					if err != nil {
+						return plumbing.ZeroHash, err
+					}
+					entries = append(entries, object.TreeEntry{Name: entry.Name, Mode: filemode.Dir, Hash: sessionHash})
+					break
+				}
+			}
+		}
+	}
+
+	if len(entries) == 0 {
+		rw.stripMemo[treeHash] = plumbing.ZeroHash
+		return plumbing.ZeroHash, nil
+	}
+	newHash, err := storeTree(rw.repo, entries)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	rw.stripMemo[treeHash] = newHash
+	return newHash, nil
+}
+
+// storeTree writes a tree object. Entries must already be in git tree order.
+func storeTree(repo *git.Repository, entries []object.TreeEntry) (plumbing.Hash, error) {
+	tree := &object.Tree{Entries: entries}
+	obj := repo.Storer.NewEncodedObject()
+	if err := tree.Encode(obj); err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to encode tree: %w", err)
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to store tree: %w", err)
+	}
+	return hash, nil
+}
+
+// FormatByteSize formats a byte count for display (e.g. "1.5 MB").
+func FormatByteSize(n int64) string {
+	const unit = 1000
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "kMGTPE"[exp])
+}
+
+// formatAge formats a retention age, preferring whole days.
+func formatAge(d time.Duration) string {
+	day := 24 * time.Hour
+	if d >= day && d%day == 0 {
+		return fmt.Sprintf("%dd", d/day)
+	}
+	return d.String()
+}
+
+func branchLabel(branch string) string {
+	if branch == "" {
+		return "(no branch)"
+	}
+	return branch
+}
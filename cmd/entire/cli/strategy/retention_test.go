@@ -0,0 +1,250 @@
+package strategy
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/require"
+)
+
+// writeRetentionCheckpoint writes a committed checkpoint with a transcript and summary.
+func writeRetentionCheckpoint(t *testing.T, repo *git.Repository, cpID, branch string) id.CheckpointID {
+	t.Helper()
+	checkpointID := id.MustCheckpointID(cpID)
+	store := checkpoint.NewGitStore(repo)
+	err := store.WriteCommitted(context.Background(), checkpoint.WriteCommittedOptions{
+		CheckpointID:     checkpointID,
+		SessionID:        "session-" + cpID,
+		Strategy:         StrategyNameManualCommit,
+		Branch:           branch,
+		Transcript:       []byte(`{"type":"user","message":{"content":"hello ` + cpID + `"}}` + "\n"),
+		Prompts:          []string{"hello"},
+		CheckpointsCount: 1,
+		Summary:          &checkpoint.Summary{Intent: "intent " + cpID},
+		AuthorName:       "Test",
+		AuthorEmail:      "test@test.com",
+	})
+	require.NoError(t, err)
+	return checkpointID
+}
+
+func setupRetentionRepo(t *testing.T) *git.Repository {
+	t.Helper()
+	dir := t.TempDir()
+	initTestRepo(t, dir)
+	t.Chdir(dir)
+	paths.ClearRepoRootCache()
+
+	repo, err := git.PlainOpen(dir)
+	require.NoError(t, err)
+	return repo
+}
+
+func TestRetentionPolicyFromSettings(t *testing.T) {
+	t.Parallel()
+
+	policy, err := RetentionPolicyFromSettings(&settings.RetentionSettings{
+		MaxAge:            "30d",
+		MaxTotalSize:      "10MB",
+		KeepLastPerBranch: 5,
+		KeepSummaries:     true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 30*24*time.Hour, policy.MaxAge)
+	require.Equal(t, int64(10_000_000), policy.MaxTotalSize)
+	require.Equal(t, 5, policy.KeepLastPerBranch)
+	require.True(t, policy.KeepSummaries)
+
+	_, err = RetentionPolicyFromSettings(&settings.RetentionSettings{MaxAge: "soon"})
+	require.Error(t, err)
+
+	empty, err := RetentionPolicyFromSettings(nil)
+	require.NoError(t, err)
+	require.True(t, empty.IsEmpty())
+}
+
+func TestPlanPrune_NoMetadataBranch(t *testing.T) {
+	repo := setupRetentionRepo(t)
+
+	plan, err := PlanPrune(repo, RetentionPolicy{MaxAge: time.Hour}, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, plan.Items)
+}
+
+func TestPlanPrune_MaxAge(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	writeRetentionCheckpoint(t, repo, "a1a1a1a1a1a1", "main")
+	writeRetentionCheckpoint(t, repo, "b2b2b2b2b2b2", "main")
+
+	// Nothing is old yet
+	plan, err := PlanPrune(repo, RetentionPolicy{MaxAge: 24 * time.Hour}, time.Now())
+	require.NoError(t, err)
+	require.Empty(t, plan.Items)
+
+	// Everything is old ten days from now
+	plan, err = PlanPrune(repo, RetentionPolicy{MaxAge: 24 * time.Hour}, time.Now().Add(10*24*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 2)
+	for _, item := range plan.Items {
+		require.Equal(t, PruneActionDelete, item.Action)
+		require.Equal(t, "older than 1d", item.Reason)
+		require.Positive(t, item.FreedBytes)
+	}
+	require.Equal(t, plan.TotalBytes, plan.FreedBytes)
+}
+
+func TestPlanPrune_KeepLastPerBranch(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	oldest := writeRetentionCheckpoint(t, repo, "a1a1a1a1a1a1", "feature")
+	writeRetentionCheckpoint(t, repo, "b2b2b2b2b2b2", "feature")
+	writeRetentionCheckpoint(t, repo, "c3c3c3c3c3c3", "main")
+
+	plan, err := PlanPrune(repo, RetentionPolicy{KeepLastPerBranch: 1}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 1)
+	require.Equal(t, oldest, plan.Items[0].CheckpointID)
+	require.Equal(t, "feature", plan.Items[0].Branch)
+}
+
+func TestPlanPrune_MaxTotalSizePrunesOldestFirst(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	oldest := writeRetentionCheckpoint(t, repo, "a1a1a1a1a1a1", "main")
+	writeRetentionCheckpoint(t, repo, "b2b2b2b2b2b2", "main")
+
+	all, err := PlanPrune(repo, RetentionPolicy{}, time.Now())
+	require.NoError(t, err)
+
+	// Budget just under the current size: removing the oldest checkpoint is enough
+	plan, err := PlanPrune(repo, RetentionPolicy{MaxTotalSize: all.TotalBytes - 1}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 1)
+	require.Equal(t, oldest, plan.Items[0].CheckpointID)
+}
+
+func TestPlanPrune_ReferencedCheckpointIsStripped(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	cpID := writeRetentionCheckpoint(t, repo, "a1a1a1a1a1a1", "master")
+
+	// Link the checkpoint from a commit on master
+	head, err := repo.Head()
+	require.NoError(t, err)
+	headCommit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	msg := "Add feature\n\n" + trailers.CheckpointTrailerKey + ": " + cpID.String() + "\n"
+	linked, err := createCommit(repo, headCommit.TreeHash, head.Hash(), msg, "Test", "test@test.com")
+	require.NoError(t, err)
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(head.Name(), linked)))
+
+	plan, err := PlanPrune(repo, RetentionPolicy{MaxAge: time.Hour}, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, plan.Items, 1)
+	require.Equal(t, PruneActionStrip, plan.Items[0].Action)
+}
+
+func TestPlanPrune_ReferencesFromEveryRef(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	remoteCp := writeRetentionCheckpoint(t, repo, "b1b1b1b1b1b1", "feature")
+	squashCp := writeRetentionCheckpoint(t, repo, "c1c1c1c1c1c1", "master")
+	unreferencedCp := writeRetentionCheckpoint(t, repo, "d1d1d1d1d1d1", "master")
+
+	head, err := repo.Head()
+	require.NoError(t, err)
+	headCommit, err := repo.CommitObject(head.Hash())
+	require.NoError(t, err)
+	commitOn := func(parent plumbing.Hash, msg string) plumbing.Hash {
+		hash, commitErr := createCommit(repo, headCommit.TreeHash, parent, msg, "Test", "test@test.com")
+		require.NoError(t, commitErr)
+		return hash
+	}
+
+	// Referenced only from a remote-tracking branch
+	remoteTip := commitOn(head.Hash(), "Remote work\n\n"+trailers.CheckpointTrailerKey+": "+remoteCp.String()+"\n")
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewRemoteReferenceName("origin", "feature"), remoteTip)))
+
+	// Referenced deep in the history of an unlanded squash branch
+	squashTip := commitOn(head.Hash(), "Turn 1\n\n"+trailers.CheckpointTrailerKey+": "+squashCp.String()+"\n")
+	for i := range 1100 {
+		squashTip = commitOn(squashTip, "Turn "+strconv.Itoa(i+2))
+	}
+	require.NoError(t, repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(checkpoint.SquashBranchPrefix+"session"), squashTip)))
+
+	plan, err := PlanPrune(repo, RetentionPolicy{MaxAge: time.Hour}, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	actions := make(map[id.CheckpointID]PruneAction)
+	for _, item := range plan.Items {
+		actions[item.CheckpointID] = item.Action
+	}
+	require.Equal(t, PruneActionStrip, actions[remoteCp])
+	require.Equal(t, PruneActionStrip, actions[squashCp])
+	require.Equal(t, PruneActionDelete, actions[unreferencedCp])
+}
+
+func TestApplyPrunePlan_DeleteAndStrip(t *testing.T) {
+	repo := setupRetentionRepo(t)
+	deleted := writeRetentionCheckpoint(t, repo, "a1a1a1a1a1a1", "main")
+	stripped := writeRetentionCheckpoint(t, repo, "b2b2b2b2b2b2", "main")
+	kept := writeRetentionCheckpoint(t, repo, "c3c3c3c3c3c3", "main")
+
+	plan := &PrunePlan{Items: []PruneItem{
+		{CheckpointID: deleted, Action: PruneActionDelete},
+		{CheckpointID: stripped, Action: PruneActionStrip},
+	}}
+	result, err := ApplyPrunePlan(repo, plan)
+	require.NoError(t, err)
+	require.NotEqual(t, result.OldTip, result.NewTip)
+	require.Positive(t, result.RewrittenCommits)
+
+	store := checkpoint.NewGitStore(repo)
+	ctx := context.Background()
+
+	summary, err := store.ReadCommitted(ctx, deleted)
+	require.NoError(t, err)
+	require.Nil(t, summary, "deleted checkpoint should be gone")
+
+	summary, err = store.ReadCommitted(ctx, stripped)
+	require.NoError(t, err)
+	require.NotNil(t, summary, "stripped checkpoint keeps its metadata.json")
+	content, err := store.ReadSessionContent(ctx, stripped, 0)
+	require.NoError(t, err)
+	require.Empty(t, content.Transcript)
+	require.Empty(t, content.Prompts)
+	require.NotNil(t, content.Metadata.Summary)
+	require.Equal(t, "intent b2b2b2b2b2b2", content.Metadata.Summary.Intent)
+
+	content, err = store.ReadSessionContent(ctx, kept, 0)
+	require.NoError(t, err)
+	require.NotEmpty(t, content.Transcript)
+
+	// History no longer contains the pruned transcripts
+	iter, err := repo.Log(&git.LogOptions{From: result.NewTip})
+	require.NoError(t, err)
+	commitCount := 0
+	require.NoError(t, iter.ForEach(func(c *object.Commit) error {
+		commitCount++
+		tree, treeErr := c.Tree()
+		require.NoError(t, treeErr)
+		_, fileErr := tree.File(deleted.Path() + "/0/" + paths.TranscriptFileName)
+		require.Error(t, fileErr, "commit %s still has deleted transcript", c.Hash)
+		_, fileErr = tree.File(stripped.Path() + "/0/" + paths.TranscriptFileName)
+		require.Error(t, fileErr, "commit %s still has stripped transcript", c.Hash)
+		return nil
+	}))
+	// Initialize commit + one commit per checkpoint write
+	require.Equal(t, 4, commitCount)
+
+	// Running the same plan again is a no-op
+	again, err := ApplyPrunePlan(repo, plan)
+	require.NoError(t, err)
+	require.Equal(t, again.OldTip, again.NewTip)
+}
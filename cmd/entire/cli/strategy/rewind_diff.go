@@ -0,0 +1,225 @@
+package strategy
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	fdiff "github.com/go-git/go-git/v5/plumbing/format/diff"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/utils/binary"
+	"github.com/go-git/go-git/v5/utils/diff"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// MaxPreviewDiffBytes caps the unified diff text kept for a single file
+	// in a rewind preview. Longer diffs are cut at a line boundary.
+	MaxPreviewDiffBytes = 32 * 1024
+
+	// maxPreviewDiffInputBytes is the largest combined file size we diff.
+	// Larger files are listed without a diff.
+	maxPreviewDiffInputBytes = 1024 * 1024
+
+	// previewDiffContextLines is the number of context lines around each hunk.
+	previewDiffContextLines = 3
+)
+
+// FileDiffStatus describes what a rewind does to a file.
+type FileDiffStatus string
+
+const (
+	// FileDiffModified means the file's content or mode changes.
+	FileDiffModified FileDiffStatus = "modified"
+	// FileDiffAdded means the file is missing from the working tree and will be created.
+	FileDiffAdded FileDiffStatus = "added"
+	// FileDiffDeleted means the file is missing from the checkpoint and will be removed.
+	FileDiffDeleted FileDiffStatus = "deleted"
+)
+
+// FileDiff is the change a rewind makes to one file, as a unified diff from
+// the current working tree ("a/") to the checkpoint tree ("b/").
+type FileDiff struct {
+	Path   string         `json:"path"`
+	Status FileDiffStatus `json:"status"`
+
+	// Diff is the unified diff. Empty for binary files and files too large to diff.
+	Diff string `json:"diff,omitempty"`
+
+	Binary bool `json:"binary,omitempty"`
+
+	// Truncated is set when the diff was cut at MaxPreviewDiffBytes or the
+	// file was too large to diff.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// previewDiffsForPoint returns the diffs a full rewind to the point would
+// apply, using the same plan as a partial rewind of every path.
+func previewDiffsForPoint(point RewindPoint, rule func(*git.Repository) (pathDeleteRule, error)) ([]FileDiff, error) {
+	plan, repoRoot, err := planPathRewindForPoint(point, nil, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]FileDiff, 0, len(plan.restore)+len(plan.delete))
+	for _, name := range plan.changedPaths() {
+		d, err := diffWorktreeFile(repoRoot, name, plan.restore[name])
+		if err != nil {
+			return nil, err
+		}
+		diffs = append(diffs, d)
+	}
+	return diffs, nil
+}
+
+// diffWorktreeFile diffs the working tree copy of name against the checkpoint
+// file f. A nil f means the file will be deleted.
+func diffWorktreeFile(repoRoot, name string, f *object.File) (FileDiff, error) {
+	result := FileDiff{Path: name, Status: FileDiffModified}
+
+	var from, to *previewFile
+	current, err := os.ReadFile(filepath.Join(repoRoot, filepath.FromSlash(name))) //nolint:gosec // G304: path is inside the repository
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		result.Status = FileDiffAdded
+	case err != nil:
+		return result, fmt.Errorf("failed to read %s: %w", name, err)
+	default:
+		from = &previewFile{path: name, mode: filemode.Regular, content: current}
+		if info, statErr := os.Stat(filepath.Join(repoRoot, filepath.FromSlash(name))); statErr == nil && info.Mode()&0o111 != 0 {
+			from.mode = filemode.Executable
+		}
+	}
+
+	if f == nil {
+		result.Status = FileDiffDeleted
+	}
+
+	size := int64(len(current))
+	if f != nil {
+		size += f.Size
+	}
+	if size > maxPreviewDiffInputBytes {
+		result.Truncated = true
+		return result, nil
+	}
+
+	if f != nil {
+		contents, err := f.Contents()
+		if err != nil {
+			return result, fmt.Errorf("failed to read file %s: %w", name, err)
+		}
+		to = &previewFile{path: name, mode: f.Mode, content: []byte(contents)}
+	}
+
+	for _, pf := range []*previewFile{from, to} {
+		if pf == nil {
+			continue
+		}
+		isBinary, err := binary.IsBinary(bytes.NewReader(pf.content))
+		if err != nil {
+			return result, fmt.Errorf("failed to inspect %s: %w", name, err)
+		}
+		if isBinary {
+			result.Binary = true
+			return result, nil
+		}
+	}
+
+	var buf strings.Builder
+	if err := fdiff.NewUnifiedEncoder(&buf, previewDiffContextLines).Encode(newPreviewPatch(from, to)); err != nil {
+		return result, fmt.Errorf("failed to diff %s: %w", name, err)
+	}
+	result.Diff, result.Truncated = truncateDiff(buf.String(), MaxPreviewDiffBytes)
+	return result, nil
+}
+
+// truncateDiff cuts d to at most limit bytes, ending at a line boundary.
+func truncateDiff(d string, limit int) (string, bool) {
+	if len(d) <= limit {
+		return d, false
+	}
+	cut := d[:limit]
+	if i := strings.LastIndexByte(cut, '\n'); i >= 0 {
+		cut = cut[:i+1]
+	}
+	return cut, true
+}
+
+// previewFile is one side of a preview diff. It implements fdiff.File.
+type previewFile struct {
+	path    string
+	mode    filemode.FileMode
+	content []byte
+}
+
+func (f *previewFile) Hash() plumbing.Hash {
+	return plumbing.ComputeHash(plumbing.BlobObject, f.content)
+}
+func (f *previewFile) Mode() filemode.FileMode { return f.mode }
+func (f *previewFile) Path() string            { return f.path }
+
+// previewChunk implements fdiff.Chunk.
+type previewChunk struct {
+	content string
+	op      fdiff.Operation
+}
+
+func (c previewChunk) Content() string       { return c.content }
+func (c previewChunk) Type() fdiff.Operation { return c.op }
+
+// previewPatch is a single-file patch between two in-memory files. It
+// implements both fdiff.Patch and fdiff.FilePatch.
+type previewPatch struct {
+	from, to *previewFile
+	chunks   []fdiff.Chunk
+}
+
+func newPreviewPatch(from, to *previewFile) *previewPatch {
+	var src, dst string
+	if from != nil {
+		src = string(from.content)
+	}
+	if to != nil {
+		dst = string(to.content)
+	}
+
+	p := &previewPatch{from: from, to: to}
+	for _, d := range diff.Do(src, dst) {
+		var op fdiff.Operation
+		switch d.Type {
+		case diffmatchpatch.DiffEqual:
+			op = fdiff.Equal
+		case diffmatchpatch.DiffInsert:
+			op = fdiff.Add
+		case diffmatchpatch.DiffDelete:
+			op = fdiff.Delete
+		}
+		p.chunks = append(p.chunks, previewChunk{content: d.Text, op: op})
+	}
+	return p
+}
+
+func (p *previewPatch) FilePatches() []fdiff.FilePatch { return []fdiff.FilePatch{p} }
+func (p *previewPatch) Message() string                { return "" }
+func (p *previewPatch) IsBinary() bool                 { return false }
+func (p *previewPatch) Chunks() []fdiff.Chunk          { return p.chunks }
+
+func (p *previewPatch) Files() (fdiff.File, fdiff.File) {
+	// Return untyped nils so the encoder sees a missing side
+	var from, to fdiff.File
+	if p.from != nil {
+		from = p.from
+	}
+	if p.to != nil {
+		to = p.to
+	}
+	return from, to
+}
@@ -0,0 +1,66 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPreviewRewind_Diffs(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	checkpoint := commitAll(t, repo, dir, map[string]string{
+		"a.go":    "package a\n\nfunc A() int { return 1 }\n",
+		"gone.go": "package a\n",
+		"bin.dat": "\x00\x01\x02",
+	}, "Checkpoint")
+	commitAll(t, repo, dir, map[string]string{
+		"a.go":    "package a\n\nfunc A() int { return 2 }\n",
+		"new.go":  "package a\n\nvar New = true\n",
+		"bin.dat": "\x00\x03",
+	}, "Later")
+	if err := os.Remove(filepath.Join(dir, "gone.go")); err != nil {
+		t.Fatalf("failed to remove gone.go: %v", err)
+	}
+
+	preview, err := (&AutoCommitStrategy{}).PreviewRewind(RewindPoint{ID: checkpoint.String()})
+	if err != nil {
+		t.Fatalf("PreviewRewind() error = %v", err)
+	}
+
+	byPath := make(map[string]FileDiff)
+	for _, d := range preview.Diffs {
+		byPath[d.Path] = d
+	}
+	if len(byPath) != 4 {
+		t.Fatalf("expected 4 diffs, got %+v", preview.Diffs)
+	}
+
+	a := byPath["a.go"]
+	if a.Status != FileDiffModified || !strings.Contains(a.Diff, "-func A() int { return 2 }") || !strings.Contains(a.Diff, "+func A() int { return 1 }") {
+		t.Errorf("a.go diff = %+v", a)
+	}
+	if d := byPath["new.go"]; d.Status != FileDiffDeleted || !strings.Contains(d.Diff, "+++ /dev/null") {
+		t.Errorf("new.go diff = %+v", d)
+	}
+	if d := byPath["gone.go"]; d.Status != FileDiffAdded || !strings.Contains(d.Diff, "--- /dev/null") {
+		t.Errorf("gone.go diff = %+v", d)
+	}
+	if d := byPath["bin.dat"]; !d.Binary || d.Diff != "" {
+		t.Errorf("bin.dat diff = %+v", d)
+	}
+}
+
+func TestTruncateDiff(t *testing.T) {
+	t.Parallel()
+
+	d := "line one\nline two\nline three\n"
+	if got, truncated := truncateDiff(d, len(d)); got != d || truncated {
+		t.Errorf("truncateDiff() at limit = %q, %v", got, truncated)
+	}
+	got, truncated := truncateDiff(d, 12)
+	if got != "line one\n" || !truncated {
+		t.Errorf("truncateDiff() = %q, %v, want cut at line boundary", got, truncated)
+	}
+}
@@ -0,0 +1,357 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// pathDeleteRule decides whether a working tree file that is absent from the
+// checkpoint tree should be deleted by a partial rewind.
+type pathDeleteRule func(relPath string) bool
+
+// pathRewindPlan is the set of changes a partial rewind makes.
+type pathRewindPlan struct {
+	restore map[string]*object.File // Repo-relative path -> checkpoint file
+	delete  []string
+}
+
+// changedPaths returns the sorted paths the plan would change.
+func (p *pathRewindPlan) changedPaths() []string {
+	changed := make([]string, 0, len(p.restore)+len(p.delete))
+	for path := range p.restore {
+		changed = append(changed, path)
+	}
+	changed = append(changed, p.delete...)
+	sort.Strings(changed)
+	return changed
+}
+
+// NormalizeRewindPaths cleans user-supplied paths to repo-relative,
+// slash-separated form. Paths must stay inside the repository and may not
+// name Entire or agent infrastructure directories.
+func NormalizeRewindPaths(selected []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var normalized []string
+	for _, p := range selected {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if filepath.IsAbs(p) {
+			return nil, fmt.Errorf("path must be relative to the repository root: %s", p)
+		}
+		clean := filepath.ToSlash(filepath.Clean(p))
+		switch {
+		case clean == ".":
+			return nil, errors.New("path selects the whole repository; rewind without --paths instead")
+		case clean == ".." || strings.HasPrefix(clean, "../"):
+			return nil, fmt.Errorf("path is outside the repository: %s", p)
+		case isProtectedPath(filepath.FromSlash(clean)):
+			return nil, fmt.Errorf("cannot rewind protected path: %s", p)
+		}
+		if !seen[clean] {
+			seen[clean] = true
+			normalized = append(normalized, clean)
+		}
+	}
+	if len(normalized) == 0 {
+		return nil, errors.New("no paths given")
+	}
+	return normalized, nil
+}
+
+// matchesRewindPaths reports whether file is one of the selected paths or
+// inside one of them. An empty selection matches everything.
+func matchesRewindPaths(file string, selected []string) bool {
+	if len(selected) == 0 {
+		return true
+	}
+	for _, sel := range selected {
+		if file == sel || strings.HasPrefix(file, sel+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// planPathRewind compares the checkpoint tree with the working tree under the
+// selected paths (everything if selected is empty). Checkpoint files whose
+// content or mode differs are restored; working tree files missing from the
+// checkpoint are deleted if shouldDelete allows it. Each selected path must
+// exist in the checkpoint or the working tree.
+func planPathRewind(tree *object.Tree, repoRoot string, selected []string, shouldDelete pathDeleteRule) (*pathRewindPlan, error) {
+	matched := make(map[string]bool)
+	markMatched := func(file string) {
+		for _, sel := range selected {
+			if file == sel || strings.HasPrefix(file, sel+"/") {
+				matched[sel] = true
+			}
+		}
+	}
+
+	plan := &pathRewindPlan{restore: make(map[string]*object.File)}
+	inCheckpoint := make(map[string]bool)
+	err := tree.Files().ForEach(func(f *object.File) error {
+		if strings.HasPrefix(f.Name, entireDir) || !matchesRewindPaths(f.Name, selected) {
+			return nil
+		}
+		inCheckpoint[f.Name] = true
+		markMatched(f.Name)
+
+		same, err := worktreeFileMatches(filepath.Join(repoRoot, filepath.FromSlash(f.Name)), f)
+		if err != nil {
+			return err
+		}
+		if !same {
+			plan.restore[f.Name] = f
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list checkpoint files: %w", err)
+	}
+
+	roots := selected
+	if len(roots) == 0 {
+		roots = []string{"."}
+	}
+	for _, root := range roots {
+		walkErr := filepath.WalkDir(filepath.Join(repoRoot, filepath.FromSlash(root)), func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return nil //nolint:nilerr // Missing paths and unreadable entries are skipped
+			}
+			relPath, relErr := filepath.Rel(repoRoot, path)
+			if relErr != nil {
+				return nil //nolint:nilerr // Skip paths we can't make relative
+			}
+			if isProtectedPath(relPath) {
+				if d.IsDir() {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if d.IsDir() {
+				return nil
+			}
+			rel := filepath.ToSlash(relPath)
+			markMatched(rel)
+			if !inCheckpoint[rel] && shouldDelete(rel) {
+				plan.delete = append(plan.delete, rel)
+			}
+			return nil
+		})
+		if walkErr != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", root, walkErr)
+		}
+	}
+	sort.Strings(plan.delete)
+	plan.delete = slices.Compact(plan.delete)
+
+	var missing []string
+	for _, sel := range selected {
+		if !matched[sel] {
+			missing = append(missing, sel)
+		}
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("not found in the checkpoint or working tree: %s", strings.Join(missing, ", "))
+	}
+
+	return plan, nil
+}
+
+// worktreeFileMatches reports whether the file on disk has the checkpoint
+// file's content and executable bit.
+func worktreeFileMatches(path string, f *object.File) (bool, error) {
+	info, err := os.Lstat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+	if !info.Mode().IsRegular() {
+		return false, nil
+	}
+	if (info.Mode()&0o111 != 0) != (f.Mode == filemode.Executable) {
+		return false, nil
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // G304: path is inside the repository
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return plumbing.ComputeHash(plumbing.BlobObject, data) == f.Hash, nil
+}
+
+// applyPathRewind writes the planned files and removes the planned deletions.
+func applyPathRewind(repoRoot string, plan *pathRewindPlan) (*PathRewindResult, error) {
+	result := &PathRewindResult{}
+
+	names := make([]string, 0, len(plan.restore))
+	for name := range plan.restore {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		f := plan.restore[name]
+		contents, err := f.Contents()
+		if err != nil {
+			return result, fmt.Errorf("failed to read file %s: %w", name, err)
+		}
+		target := filepath.Join(repoRoot, filepath.FromSlash(name))
+		//nolint:gosec // G301: Need 0o755 for user directories during rewind
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return result, fmt.Errorf("failed to create directory for %s: %w", name, err)
+		}
+		var perm os.FileMode = 0o644
+		if f.Mode == filemode.Executable {
+			perm = 0o755
+		}
+		if err := os.WriteFile(target, []byte(contents), perm); err != nil {
+			return result, fmt.Errorf("failed to write file %s: %w", name, err)
+		}
+		// WriteFile keeps the mode of existing files
+		if err := os.Chmod(target, perm); err != nil {
+			return result, fmt.Errorf("failed to set mode of %s: %w", name, err)
+		}
+		result.Restored = append(result.Restored, name)
+	}
+
+	for _, name := range plan.delete {
+		if err := os.Remove(filepath.Join(repoRoot, filepath.FromSlash(name))); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return result, fmt.Errorf("failed to delete %s: %w", name, err)
+		}
+		result.Deleted = append(result.Deleted, name)
+	}
+
+	return result, nil
+}
+
+// checkpointTreeForPoint returns the tree of a rewind point's commit.
+func checkpointTreeForPoint(repo *git.Repository, point RewindPoint) (*object.Tree, error) {
+	commit, err := repo.CommitObject(plumbing.NewHash(point.ID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+	return tree, nil
+}
+
+// headTrackedFiles returns the set of files tracked in HEAD.
+func headTrackedFiles(repo *git.Repository) (map[string]bool, error) {
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	headCommit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+	headTree, err := headCommit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get HEAD tree: %w", err)
+	}
+	tracked := make(map[string]bool)
+	err = headTree.Files().ForEach(func(f *object.File) error {
+		tracked[f.Name] = true
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list HEAD files: %w", err)
+	}
+	return tracked, nil
+}
+
+// committedPointDeleteRule is the delete rule for rewind points that are
+// regular commits: like "git reset --hard", files tracked in HEAD but absent
+// from the checkpoint commit are deleted, and untracked files are kept.
+func committedPointDeleteRule(repo *git.Repository) (pathDeleteRule, error) {
+	tracked, err := headTrackedFiles(repo)
+	if err != nil {
+		return nil, err
+	}
+	return func(relPath string) bool {
+		return tracked[relPath]
+	}, nil
+}
+
+// planPathRewindForPoint opens the repository and plans a partial rewind of
+// the point, using the delete rule built by rule.
+func planPathRewindForPoint(point RewindPoint, selected []string, rule func(*git.Repository) (pathDeleteRule, error)) (*pathRewindPlan, string, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to open git repository: %w", err)
+	}
+	tree, err := checkpointTreeForPoint(repo, point)
+	if err != nil {
+		return nil, "", err
+	}
+	shouldDelete, err := rule(repo)
+	if err != nil {
+		return nil, "", err
+	}
+	repoRoot, err := GetWorktreePath()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to get repository root: %w", err)
+	}
+	plan, err := planPathRewind(tree, repoRoot, selected, shouldDelete)
+	if err != nil {
+		return nil, "", err
+	}
+	return plan, repoRoot, nil
+}
+
+// rewindPathsForPoint normalizes the selection, plans and applies a partial rewind.
+func rewindPathsForPoint(point RewindPoint, selected []string, rule func(*git.Repository) (pathDeleteRule, error)) (*PathRewindResult, error) {
+	normalized, err := NormalizeRewindPaths(selected)
+	if err != nil {
+		return nil, err
+	}
+	plan, repoRoot, err := planPathRewindForPoint(point, normalized, rule)
+	if err != nil {
+		return nil, err
+	}
+
+	// Snapshot the working tree so the partial rewind can be undone
+	if len(plan.restore)+len(plan.delete) > 0 {
+		repo, err := OpenRepository()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open git repository: %w", err)
+		}
+		if err := saveUndoPoint(repo, "Before rewinding "+strings.Join(normalized, ", ")+" to "+point.ID[:min(7, len(point.ID))], "", plumbing.ZeroHash); err != nil {
+			return nil, fmt.Errorf("failed to save undo point: %w", err)
+		}
+	}
+
+	return applyPathRewind(repoRoot, plan)
+}
+
+// changedPathsForPoint lists every file a partial rewind of the point could change.
+func changedPathsForPoint(point RewindPoint, rule func(*git.Repository) (pathDeleteRule, error)) ([]string, error) {
+	plan, _, err := planPathRewindForPoint(point, nil, rule)
+	if err != nil {
+		return nil, err
+	}
+	return plan.changedPaths(), nil
+}
+
+// Compile-time checks that both strategies implement PathRewinder
+var (
+	_ PathRewinder = (*ManualCommitStrategy)(nil)
+	_ PathRewinder = (*AutoCommitStrategy)(nil)
+)
@@ -0,0 +1,197 @@
+package strategy
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// writeFiles writes repo-relative files under dir, creating directories.
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("failed to create dir for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// commitAll writes files, stages everything and commits, returning the commit hash.
+func commitAll(t *testing.T, repo *git.Repository, dir string, files map[string]string, message string) plumbing.Hash {
+	t.Helper()
+	writeFiles(t, dir, files)
+	wt, err := repo.Worktree()
+	if err != nil {
+		t.Fatalf("failed to get worktree: %v", err)
+	}
+	for name := range files {
+		if _, err := wt.Add(name); err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+	}
+	hash, err := wt.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{Name: "Test", Email: "test@test.com"},
+	})
+	if err != nil {
+		t.Fatalf("failed to commit: %v", err)
+	}
+	return hash
+}
+
+func readFile(t *testing.T, dir, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", name, err)
+	}
+	return string(data)
+}
+
+func setupPathRewindRepo(t *testing.T) (*git.Repository, string) {
+	t.Helper()
+	dir := t.TempDir()
+	initTestRepo(t, dir)
+	t.Chdir(dir)
+	paths.ClearRepoRootCache()
+
+	repo, err := git.PlainOpen(dir)
+	if err != nil {
+		t.Fatalf("failed to open repo: %v", err)
+	}
+	return repo, dir
+}
+
+func TestNormalizeRewindPaths(t *testing.T) {
+	t.Parallel()
+
+	got, err := NormalizeRewindPaths([]string{"a.go", "./b/", "b", " ", "c/../d.go"})
+	if err != nil {
+		t.Fatalf("NormalizeRewindPaths() error = %v", err)
+	}
+	if want := []string{"a.go", "b", "d.go"}; !slices.Equal(got, want) {
+		t.Errorf("NormalizeRewindPaths() = %v, want %v", got, want)
+	}
+
+	for _, bad := range [][]string{{"."}, {"../x"}, {"/abs/path"}, {".entire/settings.json"}, {".git"}, {}} {
+		if _, err := NormalizeRewindPaths(bad); err == nil {
+			t.Errorf("NormalizeRewindPaths(%v) should fail", bad)
+		}
+	}
+}
+
+func TestAutoCommitStrategy_RewindPaths(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	checkpoint := commitAll(t, repo, dir, map[string]string{"a.go": "a1", "b/x.go": "x1"}, "Checkpoint")
+	commitAll(t, repo, dir, map[string]string{"a.go": "a2", "b/x.go": "x2", "b/new.go": "new"}, "Later")
+	writeFiles(t, dir, map[string]string{"b/untracked.txt": "keep me"})
+
+	s := &AutoCommitStrategy{}
+	point := RewindPoint{ID: checkpoint.String()}
+
+	changed, err := s.ChangedPaths(point)
+	if err != nil {
+		t.Fatalf("ChangedPaths() error = %v", err)
+	}
+	if want := []string{"a.go", "b/new.go", "b/x.go"}; !slices.Equal(changed, want) {
+		t.Errorf("ChangedPaths() = %v, want %v", changed, want)
+	}
+
+	result, err := s.RewindPaths(point, []string{"b/"})
+	if err != nil {
+		t.Fatalf("RewindPaths() error = %v", err)
+	}
+	if !slices.Equal(result.Restored, []string{"b/x.go"}) || !slices.Equal(result.Deleted, []string{"b/new.go"}) {
+		t.Errorf("RewindPaths() = %+v", result)
+	}
+
+	if got := readFile(t, dir, "b/x.go"); got != "x1" {
+		t.Errorf("b/x.go = %q, want x1", got)
+	}
+	if got := readFile(t, dir, "a.go"); got != "a2" {
+		t.Errorf("a.go should be untouched, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "b", "new.go")); !os.IsNotExist(err) {
+		t.Error("b/new.go should be deleted")
+	}
+	if got := readFile(t, dir, "b/untracked.txt"); got != "keep me" {
+		t.Errorf("untracked file should be kept, got %q", got)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if head.Hash() == checkpoint {
+		t.Error("RewindPaths should not move HEAD")
+	}
+}
+
+func TestManualCommitStrategy_RewindPaths_ShadowCheckpoint(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	// A shadow-style checkpoint: a commit whose tree includes Entire metadata.
+	// Branch back afterwards so HEAD only tracks README.md.
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	checkpoint := commitAll(t, repo, dir, map[string]string{
+		"a.go":                           "a1",
+		".entire/metadata/s1/full.jsonl": "{}",
+	}, "Checkpoint")
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), head.Hash())); err != nil {
+		t.Fatalf("failed to reset master: %v", err)
+	}
+	if err := os.RemoveAll(filepath.Join(dir, ".entire", "metadata")); err != nil {
+		t.Fatalf("failed to remove metadata: %v", err)
+	}
+
+	// Later agent work: a.go changed, new untracked files created
+	writeFiles(t, dir, map[string]string{"a.go": "a2", "c.go": "created later", "d.go": "also later"})
+
+	s := &ManualCommitStrategy{}
+	point := RewindPoint{ID: checkpoint.String()}
+
+	result, err := s.RewindPaths(point, []string{"a.go", "c.go"})
+	if err != nil {
+		t.Fatalf("RewindPaths() error = %v", err)
+	}
+	if !slices.Equal(result.Restored, []string{"a.go"}) || !slices.Equal(result.Deleted, []string{"c.go"}) {
+		t.Errorf("RewindPaths() = %+v", result)
+	}
+	if got := readFile(t, dir, "a.go"); got != "a1" {
+		t.Errorf("a.go = %q, want a1", got)
+	}
+	if got := readFile(t, dir, "d.go"); got != "also later" {
+		t.Errorf("unselected d.go should be untouched, got %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".entire", "metadata")); !os.IsNotExist(err) {
+		t.Error("metadata files should never be restored")
+	}
+	if got := readFile(t, dir, "README.md"); got != "# Test" {
+		t.Errorf("tracked README.md should be untouched, got %q", got)
+	}
+}
+
+func TestRewindPaths_UnknownPath(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+	checkpoint := commitAll(t, repo, dir, map[string]string{"a.go": "a1"}, "Checkpoint")
+
+	_, err := (&AutoCommitStrategy{}).RewindPaths(RewindPoint{ID: checkpoint.String()}, []string{"missing.go"})
+	if err == nil || !strings.Contains(err.Error(), "missing.go") {
+		t.Errorf("RewindPaths() error = %v, want not found error naming missing.go", err)
+	}
+}
@@ -340,3 +340,47 @@ func TestResolveAgentForRewind(t *testing.T) {
 		}
 	})
 }
+
+func TestGroupRewindPointsByTurn(t *testing.T) {
+	at := func(sec int) time.Time { return time.Date(2026, 1, 1, 12, 0, sec, 0, time.UTC) }
+
+	// Sorted most recent first, with the current turn (t3) still in progress
+	// and a task checkpoint that has no turn
+	points := []RewindPoint{
+		{ID: "t3-edit-2", TurnID: "t3", IsToolCheckpoint: true, Date: at(50)},
+		{ID: "task", Date: at(45)},
+		{ID: "t3-edit-1", TurnID: "t3", IsToolCheckpoint: true, Date: at(40)},
+		{ID: "t2-end", TurnID: "t2", Date: at(30)},
+		{ID: "t2-edit-1", TurnID: "t2", IsToolCheckpoint: true, Date: at(30)},
+		{ID: "t1-edit-1", TurnID: "t1", IsToolCheckpoint: true, Date: at(10)},
+		{ID: "t1-end", TurnID: "t1", Date: at(10)},
+		{ID: "legacy", Date: at(5)},
+	}
+
+	got := groupRewindPointsByTurn(points)
+	want := []string{"t3-edit-2", "t3-edit-1", "task", "t2-end", "t2-edit-1", "t1-end", "t1-edit-1", "legacy"}
+	if len(got) != len(want) {
+		t.Fatalf("groupRewindPointsByTurn() returned %d points, want %d", len(got), len(want))
+	}
+	for i, p := range got {
+		if p.ID != want[i] {
+			t.Errorf("point %d = %q, want %q", i, p.ID, want[i])
+		}
+	}
+}
+
+func TestGroupRewindPointsByTurn_SameTurnIDDifferentSessions(t *testing.T) {
+	points := []RewindPoint{
+		{ID: "a-edit", SessionID: "a", TurnID: "t1", IsToolCheckpoint: true},
+		{ID: "b-end", SessionID: "b", TurnID: "t1"},
+		{ID: "a-end", SessionID: "a", TurnID: "t1"},
+	}
+
+	got := groupRewindPointsByTurn(points)
+	want := []string{"a-end", "a-edit", "b-end"}
+	for i, p := range got {
+		if p.ID != want[i] {
+			t.Errorf("point %d = %q, want %q", i, p.ID, want[i])
+		}
+	}
+}
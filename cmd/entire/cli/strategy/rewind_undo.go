@@ -0,0 +1,410 @@
+package strategy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// MaxUndoPoints is the number of undo points kept per worktree.
+// Older snapshots are dropped when a new one is saved.
+const MaxUndoPoints = 5
+
+// Trailer keys recorded on undo snapshot commits.
+const (
+	undoHeadTrailerKey   = "Entire-Undo-Head"
+	undoTargetTrailerKey = "Entire-Undo-Target"
+	undoBranchTrailerKey = "Entire-Undo-Branch"
+	undoShadowTrailerKey = "Entire-Undo-Shadow"
+)
+
+// ErrNoUndoPoint is returned when there is no rewind to undo in this worktree.
+var ErrNoUndoPoint = errors.New("no rewind to undo")
+
+// UndoPoint is a snapshot of the working tree taken just before a rewind.
+// Snapshots are commits on the worktree's entire/undo/<worktree-hash> branch,
+// newest first, and include untracked files that aren't ignored.
+type UndoPoint struct {
+	// ID is the snapshot commit hash
+	ID string `json:"id"`
+
+	// Message describes the rewind the snapshot was taken for
+	Message string `json:"message"`
+
+	Date time.Time `json:"date"`
+
+	// Head is the commit HEAD pointed to before the rewind
+	Head string `json:"head"`
+
+	// Target is the commit the rewind moved HEAD to (empty for rewinds that
+	// leave HEAD alone)
+	Target string `json:"target,omitempty"`
+
+	// Branch is the branch that was checked out (empty when HEAD was detached)
+	Branch string `json:"branch,omitempty"`
+
+	// ShadowBranch and ShadowCommit record the shadow branch before a
+	// manual-commit rewind reset it (empty for other rewinds)
+	ShadowBranch string `json:"shadow_branch,omitempty"`
+	ShadowCommit string `json:"shadow_commit,omitempty"`
+}
+
+// undoBranchName returns the undo branch for the current worktree.
+func undoBranchName() (string, error) {
+	worktreePath, err := GetWorktreePath()
+	if err != nil {
+		return "", err
+	}
+	worktreeID, err := paths.GetWorktreeID(worktreePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to get worktree ID: %w", err)
+	}
+	return checkpoint.UndoBranchPrefix + checkpoint.HashWorktreeID(worktreeID), nil
+}
+
+// saveUndoPoint snapshots the working tree before a rewind. shadowBranch is
+// the shadow branch the rewind will reset, or empty if none. target is the
+// commit the rewind will move HEAD to, or the zero hash if it leaves HEAD alone.
+func saveUndoPoint(repo *git.Repository, description, shadowBranch string, target plumbing.Hash) error {
+	repoRoot, err := GetWorktreePath()
+	if err != nil {
+		return fmt.Errorf("failed to get repository root: %w", err)
+	}
+	branchName, err := undoBranchName()
+	if err != nil {
+		return err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return fmt.Errorf("failed to get HEAD: %w", err)
+	}
+
+	treeHash, err := snapshotWorktreeTree(repoRoot)
+	if err != nil {
+		return err
+	}
+
+	var message strings.Builder
+	message.WriteString(description + "\n\n")
+	fmt.Fprintf(&message, "%s: %s\n", undoHeadTrailerKey, head.Hash().String())
+	if target != plumbing.ZeroHash {
+		fmt.Fprintf(&message, "%s: %s\n", undoTargetTrailerKey, target.String())
+	}
+	if head.Name().IsBranch() {
+		fmt.Fprintf(&message, "%s: %s\n", undoBranchTrailerKey, head.Name().Short())
+	}
+	if shadowBranch != "" {
+		if ref, refErr := repo.Reference(plumbing.NewBranchReferenceName(shadowBranch), true); refErr == nil {
+			fmt.Fprintf(&message, "%s: %s@%s\n", undoShadowTrailerKey, shadowBranch, ref.Hash().String())
+		}
+	}
+
+	parent, err := trimUndoHistory(repo, branchName, MaxUndoPoints-1)
+	if err != nil {
+		return err
+	}
+
+	authorName, authorEmail := GetGitAuthorFromRepo(repo)
+	commitHash, err := createCommit(repo, treeHash, parent, message.String(), authorName, authorEmail)
+	if err != nil {
+		return err
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branchName), commitHash)); err != nil {
+		return fmt.Errorf("failed to update undo branch: %w", err)
+	}
+	return nil
+}
+
+// snapshotWorktreeTree writes a tree of the working tree as "git add -A"
+// would stage it, using a temporary index so the real index is untouched.
+func snapshotWorktreeTree(repoRoot string) (plumbing.Hash, error) {
+	return writeTreeWithTempIndex(repoRoot, "HEAD", "add", "-A", "--", ".")
+}
+
+// writeTreeWithTempIndex reads base into a temporary index, runs the git
+// command given by update against it and writes the result as a tree.
+// The real index is untouched.
+func writeTreeWithTempIndex(repoRoot, base string, update ...string) (plumbing.Hash, error) {
+	indexDir, err := os.MkdirTemp("", "entire-index-")
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to create temporary index: %w", err)
+	}
+	defer os.RemoveAll(indexDir)
+
+	env := append(os.Environ(), "GIT_INDEX_FILE="+filepath.Join(indexDir, "index"))
+	run := func(args ...string) (string, error) {
+		cmd := exec.CommandContext(context.Background(), "git", args...)
+		cmd.Dir = repoRoot
+		cmd.Env = env
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		output, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("git %s failed: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+		}
+		return strings.TrimSpace(string(output)), nil
+	}
+
+	if _, err := run("read-tree", base); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, err := run(update...); err != nil {
+		return plumbing.ZeroHash, err
+	}
+	tree, err := run("write-tree")
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	return plumbing.NewHash(tree), nil
+}
+
+// trimUndoHistory keeps at most keep snapshots on the undo branch and returns
+// the newest kept commit (zero if none). Kept snapshots are rewritten onto a
+// shorter parent chain when older ones are dropped.
+func trimUndoHistory(repo *git.Repository, branchName string, keep int) (plumbing.Hash, error) {
+	commits, err := undoCommits(repo, branchName, keep+1)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if len(commits) == 0 || keep <= 0 {
+		return plumbing.ZeroHash, nil
+	}
+	if len(commits) <= keep {
+		return commits[0].Hash, nil
+	}
+
+	// Rewrite the kept commits oldest first so the oldest has no parent
+	parent := plumbing.ZeroHash
+	for i := keep - 1; i >= 0; i-- {
+		c := *commits[i]
+		c.ParentHashes = nil
+		if parent != plumbing.ZeroHash {
+			c.ParentHashes = []plumbing.Hash{parent}
+		}
+		obj := repo.Storer.NewEncodedObject()
+		if err := c.Encode(obj); err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to encode commit: %w", err)
+		}
+		parent, err = repo.Storer.SetEncodedObject(obj)
+		if err != nil {
+			return plumbing.ZeroHash, fmt.Errorf("failed to store commit: %w", err)
+		}
+	}
+	return parent, nil
+}
+
+// undoCommits returns up to limit snapshot commits from the undo branch, newest first.
+func undoCommits(repo *git.Repository, branchName string, limit int) ([]*object.Commit, error) {
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branchName), true)
+	if err != nil {
+		return nil, nil //nolint:nilerr // No undo branch means no undo points
+	}
+
+	var commits []*object.Commit
+	hash := ref.Hash()
+	for len(commits) < limit {
+		c, err := repo.CommitObject(hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read undo point %s: %w", hash.String()[:7], err)
+		}
+		commits = append(commits, c)
+		if len(c.ParentHashes) == 0 {
+			break
+		}
+		hash = c.ParentHashes[0]
+	}
+	return commits, nil
+}
+
+// parseUndoPoint reads an undo point from its snapshot commit.
+func parseUndoPoint(c *object.Commit) UndoPoint {
+	point := UndoPoint{ID: c.Hash.String(), Date: c.Author.When}
+	subject, _, _ := strings.Cut(c.Message, "\n")
+	point.Message = strings.TrimSpace(subject)
+
+	for _, line := range strings.Split(c.Message, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+		switch key {
+		case undoHeadTrailerKey:
+			point.Head = value
+		case undoTargetTrailerKey:
+			point.Target = value
+		case undoBranchTrailerKey:
+			point.Branch = value
+		case undoShadowTrailerKey:
+			if branch, commit, found := strings.Cut(value, "@"); found {
+				point.ShadowBranch, point.ShadowCommit = branch, commit
+			}
+		}
+	}
+	return point
+}
+
+// ListUndoPoints returns the undo points for the current worktree, newest first.
+func ListUndoPoints() ([]UndoPoint, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	branchName, err := undoBranchName()
+	if err != nil {
+		return nil, err
+	}
+	commits, err := undoCommits(repo, branchName, MaxUndoPoints)
+	if err != nil {
+		return nil, err
+	}
+	points := make([]UndoPoint, 0, len(commits))
+	for _, c := range commits {
+		points = append(points, parseUndoPoint(c))
+	}
+	return points, nil
+}
+
+// UndoLastRewind restores the working tree, HEAD and shadow branch to the
+// most recent undo point and removes it from the history. Files created since
+// the snapshot are deleted unless they are ignored. Agent transcripts are not
+// restored.
+//
+// HEAD is only moved back when it is still where the rewind put it; if
+// commits were made since, undoing is refused rather than dropping them. The
+// working tree is snapshotted as a new undo point first, so running
+// UndoLastRewind again returns to the state before the undo.
+func UndoLastRewind() (*UndoPoint, *PathRewindResult, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	branchName, err := undoBranchName()
+	if err != nil {
+		return nil, nil, err
+	}
+	commits, err := undoCommits(repo, branchName, 1)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(commits) == 0 {
+		return nil, nil, ErrNoUndoPoint
+	}
+	snapshot := commits[0]
+	point := parseUndoPoint(snapshot)
+
+	head, err := repo.Head()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get HEAD: %w", err)
+	}
+	if point.Branch != "" && head.Name().Short() != point.Branch {
+		return nil, nil, fmt.Errorf("the last rewind was on branch %s; switch back to it to undo", point.Branch)
+	}
+	moveHead := point.Head != "" && head.Hash().String() != point.Head
+	if moveHead && head.Hash().String() != point.Target {
+		return nil, nil, fmt.Errorf("HEAD moved to %s since the last rewind, so undoing it would drop those commits; "+
+			"restore files from the snapshot instead with: git checkout %s -- <path>", head.Hash().String()[:7], point.ID[:7])
+	}
+
+	// Keep the state being replaced, so the undo can be undone
+	if err := popUndoPoint(repo, branchName, snapshot); err != nil {
+		return nil, nil, err
+	}
+	undoTarget := plumbing.ZeroHash
+	if moveHead {
+		undoTarget = plumbing.NewHash(point.Head)
+	}
+	if err := saveUndoPoint(repo, "Before undoing: "+point.Message, point.ShadowBranch, undoTarget); err != nil {
+		if restoreErr := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branchName), snapshot.Hash)); restoreErr != nil {
+			fmt.Fprintf(os.Stderr, "[entire] Warning: failed to restore undo branch %s: %v\n", branchName, restoreErr)
+		}
+		return nil, nil, fmt.Errorf("failed to save undo point: %w", err)
+	}
+
+	if moveHead {
+		// The rewind moved the branch (auto-commit); move it back first
+		if _, err := HardResetWithProtection(plumbing.NewHash(point.Head)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	repoRoot, err := GetWorktreePath()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+	tree, err := snapshot.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tree: %w", err)
+	}
+	visible, err := visibleWorktreeFiles(repoRoot)
+	if err != nil {
+		return nil, nil, err
+	}
+	plan, err := planPathRewind(tree, repoRoot, nil, func(relPath string) bool {
+		return visible[relPath]
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	result, err := applyPathRewind(repoRoot, plan)
+	if err != nil {
+		return nil, result, err
+	}
+
+	if point.ShadowBranch != "" && point.ShadowCommit != "" {
+		ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(point.ShadowBranch), plumbing.NewHash(point.ShadowCommit))
+		if err := repo.Storer.SetReference(ref); err != nil {
+			fmt.Fprintf(os.Stderr, "[entire] Warning: failed to restore shadow branch %s: %v\n", point.ShadowBranch, err)
+		}
+	}
+	return &point, result, nil
+}
+
+// popUndoPoint moves the undo branch to the snapshot's parent, deleting the
+// branch when no older snapshots remain.
+func popUndoPoint(repo *git.Repository, branchName string, snapshot *object.Commit) error {
+	if len(snapshot.ParentHashes) > 0 {
+		ref := plumbing.NewHashReference(plumbing.NewBranchReferenceName(branchName), snapshot.ParentHashes[0])
+		if err := repo.Storer.SetReference(ref); err != nil {
+			return fmt.Errorf("failed to update undo branch: %w", err)
+		}
+		return nil
+	}
+	// Uses git CLI because go-git's RemoveReference doesn't handle packed refs
+	cmd := exec.CommandContext(context.Background(), "git", "branch", "-D", "--", branchName) //nolint:gosec // branchName is built from a hash
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to delete undo branch %s: %s: %w", branchName, strings.TrimSpace(string(output)), err)
+	}
+	return nil
+}
+
+// visibleWorktreeFiles returns tracked files and untracked files that are not ignored.
+func visibleWorktreeFiles(repoRoot string) (map[string]bool, error) {
+	cmd := exec.CommandContext(context.Background(), "git", "ls-files", "-z", "--cached", "--others", "--exclude-standard")
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list working tree files: %w", err)
+	}
+	files := make(map[string]bool)
+	for _, name := range strings.Split(string(output), "\x00") {
+		if name != "" {
+			files[name] = true
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+func TestUndoLastRewind_AutoCommit(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	checkpoint := commitAll(t, repo, dir, map[string]string{"a.go": "a1"}, "Checkpoint")
+	later := commitAll(t, repo, dir, map[string]string{"a.go": "a2"}, "Later")
+	writeFiles(t, dir, map[string]string{"a.go": "a3 uncommitted", "notes.txt": "untracked"})
+
+	if err := (&AutoCommitStrategy{}).Rewind(RewindPoint{ID: checkpoint.String()}); err != nil {
+		t.Fatalf("Rewind() error = %v", err)
+	}
+	if got := readFile(t, dir, "a.go"); got != "a1" {
+		t.Fatalf("a.go after rewind = %q, want a1", got)
+	}
+
+	point, _, err := UndoLastRewind()
+	if err != nil {
+		t.Fatalf("UndoLastRewind() error = %v", err)
+	}
+	if point.Head != later.String() {
+		t.Errorf("undo point head = %s, want %s", point.Head, later)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if head.Hash() != later {
+		t.Errorf("HEAD after undo = %s, want %s", head.Hash(), later)
+	}
+	if got := readFile(t, dir, "a.go"); got != "a3 uncommitted" {
+		t.Errorf("a.go after undo = %q, want uncommitted change back", got)
+	}
+	if got := readFile(t, dir, "notes.txt"); got != "untracked" {
+		t.Errorf("notes.txt after undo = %q", got)
+	}
+
+	// Undoing again returns to the rewound state
+	if _, _, err := UndoLastRewind(); err != nil {
+		t.Fatalf("second UndoLastRewind() error = %v", err)
+	}
+	head, err = repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if head.Hash() != checkpoint {
+		t.Errorf("HEAD after undoing the undo = %s, want %s", head.Hash(), checkpoint)
+	}
+	if got := readFile(t, dir, "a.go"); got != "a1" {
+		t.Errorf("a.go after undoing the undo = %q, want a1", got)
+	}
+}
+
+func TestUndoLastRewind_RefusesWhenCommittedAfterRewind(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	checkpoint := commitAll(t, repo, dir, map[string]string{"a.go": "a1"}, "Checkpoint")
+	commitAll(t, repo, dir, map[string]string{"a.go": "a2"}, "Later")
+
+	if err := (&AutoCommitStrategy{}).Rewind(RewindPoint{ID: checkpoint.String()}); err != nil {
+		t.Fatalf("Rewind() error = %v", err)
+	}
+	// The user carries on from the rewound state and commits
+	afterRewind := commitAll(t, repo, dir, map[string]string{"b.go": "new work"}, "After rewind")
+	writeFiles(t, dir, map[string]string{"b.go": "uncommitted"})
+
+	_, _, err := UndoLastRewind()
+	if err == nil || errors.Is(err, ErrNoUndoPoint) {
+		t.Fatalf("UndoLastRewind() error = %v, want a refusal", err)
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		t.Fatalf("failed to get HEAD: %v", err)
+	}
+	if head.Hash() != afterRewind {
+		t.Errorf("HEAD after refused undo = %s, want %s", head.Hash(), afterRewind)
+	}
+	if got := readFile(t, dir, "b.go"); got != "uncommitted" {
+		t.Errorf("b.go after refused undo = %q, want the uncommitted change kept", got)
+	}
+	if points, err := ListUndoPoints(); err != nil || len(points) != 1 {
+		t.Errorf("ListUndoPoints() = %d points, %v; want the undo point kept", len(points), err)
+	}
+}
+
+func TestUndoLastRewind_RestoresDeletedUntrackedFiles(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+	writeFiles(t, dir, map[string]string{
+		".gitignore": "build/\n",
+		"draft.md":   "agent notes",
+	})
+
+	if err := saveUndoPoint(repo, "Before rewind to test", "", plumbing.ZeroHash); err != nil {
+		t.Fatalf("saveUndoPoint() error = %v", err)
+	}
+
+	// Simulate a rewind: delete an untracked file and create others
+	if err := os.Remove(filepath.Join(dir, "draft.md")); err != nil {
+		t.Fatalf("failed to remove draft.md: %v", err)
+	}
+	writeFiles(t, dir, map[string]string{"restored.go": "from checkpoint", "build/out.bin": "ignored"})
+
+	_, result, err := UndoLastRewind()
+	if err != nil {
+		t.Fatalf("UndoLastRewind() error = %v", err)
+	}
+	if got := readFile(t, dir, "draft.md"); got != "agent notes" {
+		t.Errorf("draft.md after undo = %q", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "restored.go")); !os.IsNotExist(err) {
+		t.Error("restored.go should be deleted by undo")
+	}
+	if got := readFile(t, dir, "build/out.bin"); got != "ignored" {
+		t.Errorf("ignored file should be kept, got %q", got)
+	}
+	if len(result.Deleted) != 1 || result.Deleted[0] != "restored.go" {
+		t.Errorf("Deleted = %v, want [restored.go]", result.Deleted)
+	}
+}
+
+func TestSaveUndoPoint_KeepsShortHistory(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+
+	for i := range MaxUndoPoints + 2 {
+		writeFiles(t, dir, map[string]string{"a.go": strconv.Itoa(i)})
+		if err := saveUndoPoint(repo, "Before rewind "+strconv.Itoa(i), "", plumbing.ZeroHash); err != nil {
+			t.Fatalf("saveUndoPoint() error = %v", err)
+		}
+	}
+
+	points, err := ListUndoPoints()
+	if err != nil {
+		t.Fatalf("ListUndoPoints() error = %v", err)
+	}
+	if len(points) != MaxUndoPoints {
+		t.Fatalf("expected %d undo points, got %d", MaxUndoPoints, len(points))
+	}
+	if points[0].Message != "Before rewind "+strconv.Itoa(MaxUndoPoints+1) || points[MaxUndoPoints-1].Message != "Before rewind 2" {
+		t.Errorf("unexpected history order: first %q, last %q", points[0].Message, points[MaxUndoPoints-1].Message)
+	}
+
+	branchName, err := undoBranchName()
+	if err != nil {
+		t.Fatalf("undoBranchName() error = %v", err)
+	}
+	commits, err := undoCommits(repo, branchName, MaxUndoPoints+5)
+	if err != nil {
+		t.Fatalf("undoCommits() error = %v", err)
+	}
+	if len(commits) != MaxUndoPoints {
+		t.Errorf("undo branch should only hold %d commits, got %d", MaxUndoPoints, len(commits))
+	}
+}
@@ -0,0 +1,751 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/entireio/cli/cmd/entire/cli/agent"
+	"github.com/entireio/cli/cmd/entire/cli/agent/geminicli"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+	"github.com/entireio/cli/cmd/entire/cli/stringutil"
+	"github.com/entireio/cli/cmd/entire/cli/summarize"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+	"github.com/entireio/cli/cmd/entire/cli/transcript"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// maxSquashSubjectRunes caps the subject line of a squashed commit.
+const maxSquashSubjectRunes = 72
+
+// SquashCommitStrategy auto-commits every agent turn, like auto-commit, but to
+// a hidden entire/squash/<session-id> branch instead of the active branch.
+// The agent's edits stay uncommitted on the active branch until the user runs
+// `entire land`, which squashes the session into one commit there. Each turn still gets its own checkpoint on entire/checkpoints/v1,
+// and the squashed commit carries one Entire-Checkpoint trailer per turn.
+type SquashCommitStrategy struct {
+	*AutoCommitStrategy
+}
+
+// Compile-time check that SquashCommitStrategy supports partial rewinds
+var _ PathRewinder = (*SquashCommitStrategy)(nil)
+
+// NewSquashCommitStrategy creates a new squash-commit strategy instance.
+func NewSquashCommitStrategy() Strategy { //nolint:ireturn // already present in codebase
+	return &SquashCommitStrategy{AutoCommitStrategy: &AutoCommitStrategy{}}
+}
+
+// Name returns the strategy name.
+func (s *SquashCommitStrategy) Name() string {
+	return StrategyNameSquashCommit
+}
+
+// Description returns the strategy description.
+func (s *SquashCommitStrategy) Description() string {
+	return "Auto-commits each turn to a hidden session branch, squashed onto your branch by 'entire land'"
+}
+
+// SquashBranchName returns the hidden branch for a session.
+func SquashBranchName(sessionID string) string {
+	return checkpoint.SquashBranchPrefix + sessionID
+}
+
+// SaveChanges commits the turn's file changes to the session's hidden branch
+// and writes the turn's checkpoint to entire/checkpoints/v1.
+func (s *SquashCommitStrategy) SaveChanges(ctx SaveContext) error {
+	repo, err := OpenRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	sessionID := ctx.SessionID
+	if sessionID == "" {
+		sessionID = filepath.Base(ctx.MetadataDir)
+	}
+
+	cpID, err := id.Generate()
+	if err != nil {
+		return fmt.Errorf("failed to generate checkpoint ID: %w", err)
+	}
+	message := ctx.CommitMessage + "\n\n" +
+		trailers.SessionTrailerKey + ": " + sessionID + "\n" +
+		trailers.CheckpointTrailerKey + ": " + cpID.String() + "\n" +
+		trailers.StrategyTrailerKey + ": " + StrategyNameSquashCommit + "\n"
+
+	commitHash, err := s.commitToSquashBranch(repo, sessionID, mergeFilesTouched(nil, ctx.ModifiedFiles, ctx.NewFiles, ctx.DeletedFiles),
+		message, ctx.AuthorName, ctx.AuthorEmail)
+	if err != nil {
+		return err
+	}
+	logCtx := logging.WithComponent(context.Background(), "checkpoint")
+	if commitHash == plumbing.ZeroHash {
+		logging.Info(logCtx, "checkpoint skipped (no changes)",
+			slog.String("strategy", StrategyNameSquashCommit),
+			slog.String("checkpoint_type", "session"),
+		)
+		fmt.Fprintf(os.Stderr, "Skipped checkpoint (no changes since last turn)\n")
+		return nil
+	}
+
+	// Metadata first, so the branch never points at a checkpoint without metadata
+	if _, err := s.commitMetadataToMetadataBranch(repo, ctx, cpID, StrategyNameSquashCommit); err != nil {
+		return fmt.Errorf("failed to commit metadata to entire/checkpoints/v1 branch: %w", err)
+	}
+	branch := SquashBranchName(sessionID)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), commitHash)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", branch, err)
+	}
+	fmt.Fprintf(os.Stderr, "Committed turn to %s (%s)\n", branch, commitHash.String()[:7])
+
+	logging.Info(logCtx, "checkpoint saved",
+		slog.String("strategy", StrategyNameSquashCommit),
+		slog.String("checkpoint_type", "session"),
+		slog.String("checkpoint_id", cpID.String()),
+		slog.Int("modified_files", len(ctx.ModifiedFiles)),
+		slog.Int("new_files", len(ctx.NewFiles)),
+		slog.Int("deleted_files", len(ctx.DeletedFiles)),
+	)
+	return nil
+}
+
+// SaveTaskCheckpoint adds a completed subagent's file changes to the hidden
+// branch so they are part of the squash. The subagent's transcript is kept
+// with the turn's checkpoint; incremental task checkpoints are skipped.
+func (s *SquashCommitStrategy) SaveTaskCheckpoint(ctx TaskCheckpointContext) error {
+	if ctx.IsIncremental {
+		return nil
+	}
+	repo, err := OpenRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open git repository: %w", err)
+	}
+
+	subject := "Task"
+	if ctx.SubagentType != "" {
+		subject = "Task (" + ctx.SubagentType + ")"
+	}
+	message := subject + "\n\n" + trailers.SessionTrailerKey + ": " + ctx.SessionID + "\n"
+
+	commitHash, err := s.commitToSquashBranch(repo, ctx.SessionID, mergeFilesTouched(nil, ctx.ModifiedFiles, ctx.NewFiles, ctx.DeletedFiles),
+		message, ctx.AuthorName, ctx.AuthorEmail)
+	if err != nil || commitHash == plumbing.ZeroHash {
+		return err
+	}
+	branch := SquashBranchName(ctx.SessionID)
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName(branch), commitHash)); err != nil {
+		return fmt.Errorf("failed to update %s: %w", branch, err)
+	}
+	return nil
+}
+
+// commitToSquashBranch creates (but does not point the branch at) a commit
+// on the session's hidden branch whose tree is the previous turn's tree with
+// files updated from the working tree. Returns a zero hash if nothing changed.
+func (s *SquashCommitStrategy) commitToSquashBranch(repo *git.Repository, sessionID string, files []string, message, authorName, authorEmail string) (plumbing.Hash, error) {
+	repoRoot, err := GetWorktreePath()
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	// Continue the hidden branch, or start it at HEAD
+	var parent *object.Commit
+	if ref, refErr := repo.Reference(plumbing.NewBranchReferenceName(SquashBranchName(sessionID)), true); refErr == nil {
+		parent, err = repo.CommitObject(ref.Hash())
+	} else {
+		var head *plumbing.Reference
+		head, err = repo.Head()
+		if err == nil {
+			parent, err = repo.CommitObject(head.Hash())
+		}
+	}
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to find parent commit: %w", err)
+	}
+
+	var pathspecs []string
+	for _, f := range files {
+		if f != "" && !paths.IsInfrastructurePath(f) {
+			pathspecs = append(pathspecs, f)
+		}
+	}
+	if len(pathspecs) == 0 {
+		return plumbing.ZeroHash, nil
+	}
+
+	treeHash, err := writeTreeWithTempIndex(repoRoot, parent.Hash.String(),
+		append([]string{"update-index", "--add", "--remove", "--"}, pathspecs...)...)
+	if err != nil {
+		return plumbing.ZeroHash, fmt.Errorf("failed to build turn tree: %w", err)
+	}
+	if treeHash == parent.TreeHash {
+		return plumbing.ZeroHash, nil
+	}
+
+	return createCommit(repo, treeHash, parent.Hash, message, authorName, authorEmail)
+}
+
+// SquashSession is a session with turns on a hidden squash branch.
+type SquashSession struct {
+	// SessionID is the agent session
+	SessionID string `json:"session_id"`
+
+	// Branch is the entire/squash/<session-id> branch
+	Branch string `json:"branch"`
+
+	// Base is where the session's turns start: the merge base of the
+	// branch and HEAD
+	Base string `json:"base"`
+
+	// Tip is the latest turn commit
+	Tip string `json:"tip"`
+
+	// Checkpoints are the turns' checkpoint IDs, oldest first
+	Checkpoints []id.CheckpointID `json:"checkpoints"`
+
+	// Turns are the turns' commit subjects, oldest first
+	Turns []string `json:"turns"`
+}
+
+// ListSquashSessions returns all sessions with a hidden squash branch, sorted
+// by session ID.
+func ListSquashSessions() ([]SquashSession, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	refs, err := exec.CommandContext(context.Background(), "git", "for-each-ref", "--format=%(refname:short)",
+		"refs/heads/"+checkpoint.SquashBranchPrefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list squash branches: %w", err)
+	}
+
+	var result []SquashSession
+	for _, branch := range strings.Fields(string(refs)) {
+		sess, err := loadSquashSession(repo, branch)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *sess)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SessionID < result[j].SessionID })
+	return result, nil
+}
+
+// FindSquashSession resolves a session ID, session ID prefix or branch name
+// to a squash session.
+func FindSquashSession(query string) (*SquashSession, error) {
+	all, err := ListSquashSessions()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.TrimPrefix(query, checkpoint.SquashBranchPrefix)
+
+	var matches []SquashSession
+	for _, sess := range all {
+		if sess.SessionID == query {
+			return &sess, nil
+		}
+		if strings.HasPrefix(sess.SessionID, query) {
+			matches = append(matches, sess)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no squash session matches %q", query)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, sess := range matches {
+			ids[i] = sess.SessionID
+		}
+		return nil, fmt.Errorf("session %q is ambiguous: %s", query, strings.Join(ids, ", "))
+	}
+}
+
+// loadSquashSession reads a squash branch's turns back to its merge base with HEAD.
+func loadSquashSession(repo *git.Repository, branch string) (*SquashSession, error) {
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(branch), true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", branch, err)
+	}
+	sess := &SquashSession{
+		SessionID: strings.TrimPrefix(branch, checkpoint.SquashBranchPrefix),
+		Branch:    branch,
+		Tip:       ref.Hash().String(),
+	}
+
+	//nolint:gosec // branch comes from git for-each-ref
+	output, err := exec.CommandContext(context.Background(), "git", "merge-base", "HEAD", branch).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %s: %w", branch, err)
+	}
+	sess.Base = strings.TrimSpace(string(output))
+
+	commit, err := repo.CommitObject(ref.Hash())
+	for err == nil && commit.Hash.String() != sess.Base {
+		if sessionID, ok := trailers.ParseSession(commit.Message); !ok || sessionID != sess.SessionID {
+			break
+		}
+		if cpID, ok := trailers.ParseCheckpoint(commit.Message); ok {
+			sess.Checkpoints = append(sess.Checkpoints, cpID)
+			sess.Turns = append(sess.Turns, strings.SplitN(commit.Message, "\n", 2)[0])
+		}
+		if commit.NumParents() == 0 {
+			break
+		}
+		commit, err = commit.Parent(0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to walk %s: %w", branch, err)
+	}
+	reverseInPlace(sess.Checkpoints)
+	reverseInPlace(sess.Turns)
+	return sess, nil
+}
+
+func reverseInPlace[T any](s []T) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// SquashLandResult describes a landed squash session.
+type SquashLandResult struct {
+	// Commit is the squashed commit (empty if there was nothing to commit)
+	Commit string
+
+	// Message is the squashed commit's message
+	Message string
+
+	// Files are the paths included in the commit
+	Files []string
+}
+
+// LandSquashSession commits the files the session changed, as they are in
+// the working tree now, as a single commit on the current branch. The
+// message comes from the session summary and lists every turn's checkpoint
+// in Entire-Checkpoint trailers. The hidden branch is then deleted unless
+// opts.Keep is set; opts.CherryPick does not apply.
+func LandSquashSession(sess *SquashSession, opts LandOptions) (*SquashLandResult, error) {
+	ctx := context.Background()
+	repoRoot, err := GetWorktreePath()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get repository root: %w", err)
+	}
+
+	files, err := squashSessionFiles(ctx, repoRoot, sess)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SquashLandResult{Files: files}
+	if len(files) > 0 {
+		add := exec.CommandContext(ctx, "git", append([]string{"add", "-A", "--"}, files...)...)
+		add.Dir = repoRoot
+		if output, err := add.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("failed to stage session files: %s: %w", strings.TrimSpace(string(output)), err)
+		}
+
+		staged := exec.CommandContext(ctx, "git", append([]string{"diff", "--cached", "--quiet", "HEAD", "--"}, files...)...)
+		staged.Dir = repoRoot
+		if staged.Run() != nil {
+			var summary *checkpoint.Summary
+			if !opts.NoSummary {
+				summary = summarizeSquashSession(ctx, sess)
+			}
+			result.Message = squashCommitMessage(sess, summary)
+
+			commit := exec.CommandContext(ctx, "git", append([]string{"commit", "-F", "-", "--"}, files...)...)
+			commit.Dir = repoRoot
+			commit.Stdin = strings.NewReader(result.Message)
+			if output, err := commit.CombinedOutput(); err != nil {
+				return nil, fmt.Errorf("failed to commit session: %s: %w", strings.TrimSpace(string(output)), err)
+			}
+			head, err := exec.CommandContext(ctx, "git", "rev-parse", "HEAD").Output()
+			if err != nil {
+				return nil, fmt.Errorf("failed to read new commit: %w", err)
+			}
+			result.Commit = strings.TrimSpace(string(head))
+		}
+	}
+
+	if !opts.Keep {
+		if err := DeleteBranchCLI(sess.Branch); err != nil && !errors.Is(err, ErrBranchNotFound) {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// squashSessionFiles returns the paths the session changed between its base
+// and tip that can be staged: they exist in the working tree or are tracked.
+func squashSessionFiles(ctx context.Context, repoRoot string, sess *SquashSession) ([]string, error) {
+	cmd := exec.CommandContext(ctx, "git", "diff", "--name-only", "-z", sess.Base, sess.Tip) //nolint:gosec // hashes come from git
+	cmd.Dir = repoRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff session branch: %w", err)
+	}
+	changed := strings.Split(strings.TrimRight(string(output), "\x00"), "\x00")
+	if len(changed) == 1 && changed[0] == "" {
+		return nil, nil
+	}
+
+	tracked := make(map[string]bool)
+	lsTree := exec.CommandContext(ctx, "git", append([]string{"ls-tree", "-r", "-z", "--name-only", "HEAD", "--"}, changed...)...)
+	lsTree.Dir = repoRoot
+	if output, err := lsTree.Output(); err == nil {
+		for _, name := range strings.Split(string(output), "\x00") {
+			tracked[name] = true
+		}
+	}
+
+	var files []string
+	for _, name := range changed {
+		if tracked[name] || fileExists(filepath.Join(repoRoot, filepath.FromSlash(name))) {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
+
+// summarizeSquashSession generates a summary of the session's turns from the
+// latest checkpoint's transcript. Returns nil if that isn't possible.
+func summarizeSquashSession(ctx context.Context, sess *SquashSession) *checkpoint.Summary {
+	if len(sess.Checkpoints) == 0 {
+		return nil
+	}
+	logCtx := logging.WithComponent(ctx, "summarize")
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil
+	}
+	store := checkpoint.NewGitStore(repo)
+
+	latest, err := store.ReadLatestSessionContent(ctx, sess.Checkpoints[len(sess.Checkpoints)-1])
+	if err != nil || latest == nil || len(latest.Transcript) == 0 {
+		return nil
+	}
+
+	// Scope the transcript to the squashed turns
+	start := 0
+	if first, err := store.ReadLatestSessionContent(ctx, sess.Checkpoints[0]); err == nil && first != nil {
+		start = first.Metadata.GetTranscriptStart()
+	}
+	var scoped []byte
+	if latest.Metadata.Agent == agent.AgentTypeGemini {
+		scoped = geminicli.SliceFromMessage(latest.Transcript, start)
+	} else {
+		scoped = transcript.SliceFromLine(latest.Transcript, start)
+	}
+	if len(scoped) == 0 {
+		return nil
+	}
+
+	summary, err := summarize.GenerateFromTranscript(logCtx, scoped, latest.Metadata.FilesTouched, latest.Metadata.Agent, nil)
+	if err != nil {
+		logging.Warn(logCtx, "summary generation failed",
+			slog.String("session_id", sess.SessionID),
+			slog.String("error", err.Error()))
+		return nil
+	}
+	return summary
+}
+
+// squashCommitMessage builds the squashed commit's message. The subject and
+// body come from the summary if there is one, otherwise from the turns.
+func squashCommitMessage(sess *SquashSession, summary *checkpoint.Summary) string {
+	var subject, body string
+	switch {
+	case summary != nil && strings.TrimSpace(summary.Intent) != "":
+		subject = summary.Intent
+		body = strings.TrimSpace(summary.Outcome)
+	case len(sess.Turns) > 0:
+		subject = sess.Turns[0]
+		if len(sess.Turns) > 1 {
+			var sb strings.Builder
+			for _, turn := range sess.Turns {
+				sb.WriteString("- " + turn + "\n")
+			}
+			body = strings.TrimSpace(sb.String())
+		}
+	default:
+		subject = "Land session " + sess.SessionID
+	}
+	subject = stringutil.TruncateRunes(stringutil.CollapseWhitespace(subject), maxSquashSubjectRunes, "...")
+
+	var msg strings.Builder
+	msg.WriteString(subject + "\n")
+	if body != "" {
+		msg.WriteString("\n" + body + "\n")
+	}
+	msg.WriteString("\n")
+	for _, cpID := range sess.Checkpoints {
+		fmt.Fprintf(&msg, "%s: %s\n", trailers.CheckpointTrailerKey, cpID)
+	}
+	fmt.Fprintf(&msg, "%s: %s\n", trailers.SessionTrailerKey, sess.SessionID)
+	return msg.String()
+}
+
+// GetRewindPoints returns the turns on every hidden squash branch, newest first.
+func (s *SquashCommitStrategy) GetRewindPoints(limit int) ([]RewindPoint, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open git repository: %w", err)
+	}
+	metadataTree, err := GetMetadataBranchTree(repo)
+	if err != nil {
+		// No metadata branch yet is fine
+		return []RewindPoint{}, nil //nolint:nilerr // Expected when no metadata exists
+	}
+	sessions, err := ListSquashSessions()
+	if err != nil {
+		return nil, err
+	}
+
+	var points []RewindPoint
+	for _, sess := range sessions {
+		commit, err := repo.CommitObject(plumbing.NewHash(sess.Tip))
+		for err == nil && commit.Hash.String() != sess.Base {
+			if sessionID, ok := trailers.ParseSession(commit.Message); !ok || sessionID != sess.SessionID {
+				break
+			}
+			if cpID, ok := trailers.ParseCheckpoint(commit.Message); ok {
+				if metadata, metaErr := ReadCheckpointMetadata(metadataTree, cpID.Path()); metaErr == nil {
+					points = append(points, RewindPoint{
+						ID:            commit.Hash.String(),
+						Message:       strings.SplitN(commit.Message, "\n", 2)[0],
+						MetadataDir:   cpID.Path(),
+						Date:          commit.Author.When,
+						CheckpointID:  cpID,
+						Agent:         metadata.Agent,
+						SessionID:     sess.SessionID,
+						SessionPrompt: ReadSessionPromptFromTree(metadataTree, cpID.Path()),
+					})
+				}
+			}
+			if commit.NumParents() == 0 {
+				break
+			}
+			commit, err = commit.Parent(0)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to walk %s: %w", sess.Branch, err)
+		}
+	}
+
+	sort.SliceStable(points, func(i, j int) bool { return points[i].Date.After(points[j].Date) })
+	if len(points) > limit {
+		points = points[:limit]
+	}
+	return points, nil
+}
+
+// Rewind restores the working tree to a turn's snapshot. Nothing is
+// committed and the hidden branch is unchanged; later turns build on
+// whatever the working tree holds.
+func (s *SquashCommitStrategy) Rewind(point RewindPoint) error {
+	plan, repoRoot, err := planPathRewindForPoint(point, nil, squashPointDeleteRule(point))
+	if err != nil {
+		return err
+	}
+	repo, err := OpenRepository()
+	if err != nil {
+		return fmt.Errorf("failed to open git repository: %w", err)
+	}
+	if err := saveUndoPoint(repo, "Before rewind to "+point.ID[:min(7, len(point.ID))], "", plumbing.ZeroHash); err != nil {
+		return fmt.Errorf("failed to save undo point: %w", err)
+	}
+	if _, err := applyPathRewind(repoRoot, plan); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Printf("Restored files from checkpoint %s\n", point.ID[:min(7, len(point.ID))])
+	fmt.Println()
+	return nil
+}
+
+// CanRewind always allows rewinding: the session's changes are uncommitted
+// by design, so they are reported as a warning rather than a blocker.
+func (s *SquashCommitStrategy) CanRewind() (bool, string, error) {
+	return checkCanRewindWithWarning()
+}
+
+// PreviewRewind returns the diffs of a rewind and the untracked files it deletes.
+func (s *SquashCommitStrategy) PreviewRewind(point RewindPoint) (*RewindPreview, error) {
+	rule := squashPointDeleteRule(point)
+	diffs, err := previewDiffsForPoint(point, rule)
+	if err != nil {
+		// Diffs are informational; an empty preview is still accurate about deletions
+		return &RewindPreview{}, nil //nolint:nilerr // Partial result is still useful
+	}
+
+	preview := &RewindPreview{Diffs: diffs}
+	repo, err := OpenRepository()
+	if err != nil {
+		return preview, nil //nolint:nilerr // Partial result is still useful
+	}
+	tracked, err := headTrackedFiles(repo)
+	if err != nil {
+		return preview, nil //nolint:nilerr // Partial result is still useful
+	}
+	for _, d := range diffs {
+		if d.Status == FileDiffDeleted && !tracked[d.Path] {
+			preview.FilesToDelete = append(preview.FilesToDelete, d.Path)
+		}
+	}
+	return preview, nil
+}
+
+// ChangedPaths returns the files a partial rewind to the point would change.
+func (s *SquashCommitStrategy) ChangedPaths(point RewindPoint) ([]string, error) {
+	return changedPathsForPoint(point, squashPointDeleteRule(point))
+}
+
+// RewindPaths restores only the selected paths from the turn's snapshot.
+func (s *SquashCommitStrategy) RewindPaths(point RewindPoint, paths []string) (*PathRewindResult, error) {
+	return rewindPathsForPoint(point, paths, squashPointDeleteRule(point))
+}
+
+// squashPointDeleteRule returns the delete rule for a squash turn: files
+// missing from the turn's snapshot are deleted if they are tracked in HEAD
+// or were added by a later turn of the session. Other untracked files are kept.
+func squashPointDeleteRule(point RewindPoint) func(*git.Repository) (pathDeleteRule, error) {
+	return func(repo *git.Repository) (pathDeleteRule, error) {
+		tracked, err := headTrackedFiles(repo)
+		if err != nil {
+			return nil, err
+		}
+		if point.SessionID != "" {
+			ref, refErr := repo.Reference(plumbing.NewBranchReferenceName(SquashBranchName(point.SessionID)), true)
+			if refErr == nil {
+				if tip, tipErr := repo.CommitObject(ref.Hash()); tipErr == nil {
+					if tree, treeErr := tip.Tree(); treeErr == nil {
+						_ = tree.Files().ForEach(func(f *object.File) error { //nolint:errcheck // Best effort
+							tracked[f.Name] = true
+							return nil
+						})
+					}
+				}
+			}
+		}
+		return func(relPath string) bool {
+			return tracked[relPath]
+		}, nil
+	}
+}
+
+// ListOrphanedItems returns squash-commit checkpoints that are neither on a
+// hidden squash branch nor referenced by a commit trailer, plus the squash
+// branches of sessions that are no longer active and have nothing left to
+// land: no turns above HEAD, or every turn's checkpoint already named by a
+// commit in HEAD's history (landed with --keep). Branches of ended sessions
+// with unlanded turns are kept for `entire land`.
+func (s *SquashCommitStrategy) ListOrphanedItems() ([]CleanupItem, error) {
+	repo, err := OpenRepository()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open repository: %w", err)
+	}
+	cpStore, err := s.getCheckpointStore()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkpoint store: %w", err)
+	}
+
+	referenced := findReferencedCheckpoints(repo)
+	sessions, err := ListSquashSessions()
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range sessions {
+		for _, cpID := range sess.Checkpoints {
+			referenced[cpID.String()] = true
+		}
+	}
+	items := listUnreferencedCheckpoints(cpStore, StrategyNameSquashCommit, referenced)
+
+	stale, err := listStaleSquashBranches(repo, sessions)
+	if err != nil {
+		return nil, err
+	}
+	return append(items, stale...), nil
+}
+
+// listStaleSquashBranches returns the squash branches of inactive sessions
+// whose turns are all in HEAD, or were all squashed into a commit in HEAD's
+// history.
+func listStaleSquashBranches(repo *git.Repository, sessions []SquashSession) ([]CleanupItem, error) {
+	var items []CleanupItem
+	var landed map[string]bool
+	for _, sess := range sessions {
+		if state, err := LoadSessionState(sess.SessionID); err != nil || (state != nil && state.Phase != session.PhaseEnded) {
+			continue
+		}
+		reason := "no turns above HEAD"
+		if len(sess.Checkpoints) > 0 {
+			if landed == nil {
+				var err error
+				if landed, err = checkpointsInHistory(repo, plumbing.HEAD); err != nil {
+					return nil, err
+				}
+			}
+			allLanded := true
+			for _, cpID := range sess.Checkpoints {
+				allLanded = allLanded && landed[cpID.String()]
+			}
+			if !allLanded {
+				continue
+			}
+			reason = "session already landed in HEAD"
+		}
+		items = append(items, CleanupItem{
+			Type:   CleanupTypeSquashBranch,
+			ID:     sess.Branch,
+			Reason: reason,
+		})
+	}
+	return items, nil
+}
+
+// checkpointsInHistory returns the checkpoints named by an Entire-Checkpoint
+// trailer in the history of ref.
+func checkpointsInHistory(repo *git.Repository, ref plumbing.ReferenceName) (map[string]bool, error) {
+	resolved, err := repo.Reference(ref, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s: %w", ref, err)
+	}
+	iter, err := repo.Log(&git.LogOptions{From: resolved.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", ref, err)
+	}
+	found := make(map[string]bool)
+	err = iter.ForEach(func(c *object.Commit) error {
+		for _, cpID := range trailers.ParseAllCheckpoints(c.Message) {
+			found[cpID.String()] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", ref, err)
+	}
+	return found, nil
+}
+
+//nolint:gochecknoinits // Standard pattern for strategy registration
+func init() {
+	Register(StrategyNameSquashCommit, NewSquashCommitStrategy)
+}
@@ -0,0 +1,279 @@
+package strategy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// setupSquashCommitRepo creates a repo with a metadata branch for the
+// squash-commit strategy. Git hooks are not installed, so landing commits
+// don't need the entire binary.
+func setupSquashCommitRepo(t *testing.T) (*git.Repository, string, *SquashCommitStrategy) {
+	t.Helper()
+	repo, dir := setupPathRewindRepo(t)
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@test.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@test.com")
+
+	s, ok := NewSquashCommitStrategy().(*SquashCommitStrategy)
+	if !ok {
+		t.Fatal("NewSquashCommitStrategy() did not return *SquashCommitStrategy")
+	}
+	if err := EnsureMetadataBranch(repo); err != nil {
+		t.Fatalf("EnsureMetadataBranch() error = %v", err)
+	}
+	return repo, dir, s
+}
+
+// saveSquashTurn writes files to the working tree and saves them as a turn.
+func saveSquashTurn(t *testing.T, s *SquashCommitStrategy, dir, sessionID, prompt string, files map[string]string) {
+	t.Helper()
+	writeFiles(t, dir, files)
+
+	metadataDir := filepath.Join(paths.EntireMetadataDir, sessionID)
+	metadataDirAbs := filepath.Join(dir, metadataDir)
+	if err := os.MkdirAll(metadataDirAbs, 0o750); err != nil {
+		t.Fatalf("failed to create metadata dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(metadataDirAbs, paths.TranscriptFileName), []byte(`{"type":"user"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write transcript: %v", err)
+	}
+
+	var modified []string
+	for name := range files {
+		modified = append(modified, name)
+	}
+	err := s.SaveChanges(SaveContext{
+		SessionID:      sessionID,
+		CommitMessage:  prompt,
+		MetadataDir:    metadataDir,
+		MetadataDirAbs: metadataDirAbs,
+		ModifiedFiles:  modified,
+		AuthorName:     "Test",
+		AuthorEmail:    "test@test.com",
+	})
+	if err != nil {
+		t.Fatalf("SaveChanges() error = %v", err)
+	}
+}
+
+func TestSquashCommitStrategy_Registered(t *testing.T) {
+	t.Parallel()
+
+	s, err := Get(StrategyNameSquashCommit)
+	if err != nil {
+		t.Fatalf("Get(%q) error = %v", StrategyNameSquashCommit, err)
+	}
+	if s.Name() != StrategyNameSquashCommit {
+		t.Errorf("Name() = %q", s.Name())
+	}
+}
+
+func TestSquashCommitStrategy_SaveChanges(t *testing.T) {
+	repo, dir, s := setupSquashCommitRepo(t)
+	headBefore := gitIn(t, dir, "rev-parse", "HEAD")
+
+	saveSquashTurn(t, s, dir, "sess-1", "Add feature", map[string]string{"feature.go": "v1"})
+
+	if got := gitIn(t, dir, "rev-parse", "HEAD"); got != headBefore {
+		t.Errorf("HEAD moved to %s, want the active branch untouched", got)
+	}
+	ref, err := repo.Reference(plumbing.NewBranchReferenceName(SquashBranchName("sess-1")), true)
+	if err != nil {
+		t.Fatalf("squash branch not created: %v", err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatalf("failed to read squash commit: %v", err)
+	}
+	if commit.ParentHashes[0].String() != headBefore {
+		t.Errorf("first turn parent = %s, want HEAD %s", commit.ParentHashes[0], headBefore)
+	}
+	cpID, ok := trailers.ParseCheckpoint(commit.Message)
+	if !ok {
+		t.Fatalf("turn commit has no checkpoint trailer: %q", commit.Message)
+	}
+	summary, err := checkpoint.NewGitStore(repo).ReadCommitted(context.Background(), cpID)
+	if err != nil || summary == nil {
+		t.Fatalf("ReadCommitted() = %v, %v", summary, err)
+	}
+	if summary.Strategy != StrategyNameSquashCommit {
+		t.Errorf("checkpoint strategy = %q, want %q", summary.Strategy, StrategyNameSquashCommit)
+	}
+
+	// A turn without changes is skipped
+	saveSquashTurn(t, s, dir, "sess-1", "Nothing", map[string]string{"feature.go": "v1"})
+	if tip := gitIn(t, dir, "rev-parse", SquashBranchName("sess-1")); tip != ref.Hash().String() {
+		t.Errorf("unchanged turn moved the squash branch to %s", tip)
+	}
+
+	saveSquashTurn(t, s, dir, "sess-1", "Refine feature", map[string]string{"feature.go": "v2"})
+	if parent := gitIn(t, dir, "rev-parse", SquashBranchName("sess-1")+"^"); parent != ref.Hash().String() {
+		t.Errorf("second turn parent = %s, want first turn %s", parent, ref.Hash())
+	}
+}
+
+func TestLandSquashSession(t *testing.T) {
+	_, dir, s := setupSquashCommitRepo(t)
+	saveSquashTurn(t, s, dir, "sess-1", "Add feature", map[string]string{"feature.go": "v1"})
+	saveSquashTurn(t, s, dir, "sess-1", "Add helper", map[string]string{"helper.go": "h1"})
+	writeFiles(t, dir, map[string]string{"unrelated.txt": "mine"})
+
+	sess, err := FindSquashSession("sess")
+	if err != nil {
+		t.Fatalf("FindSquashSession() error = %v", err)
+	}
+	if len(sess.Checkpoints) != 2 || sess.Turns[0] != "Add feature" {
+		t.Fatalf("session = %+v, want two turns oldest first", sess)
+	}
+
+	result, err := LandSquashSession(sess, LandOptions{NoSummary: true})
+	if err != nil {
+		t.Fatalf("LandSquashSession() error = %v", err)
+	}
+	if result.Commit != gitIn(t, dir, "rev-parse", "HEAD") {
+		t.Errorf("result commit %s is not HEAD", result.Commit)
+	}
+
+	message := gitIn(t, dir, "log", "-1", "--format=%B")
+	if !strings.HasPrefix(message, "Add feature\n\n- Add feature\n- Add helper") {
+		t.Errorf("squashed message = %q", message)
+	}
+	got := trailers.ParseAllCheckpoints(message)
+	if len(got) != 2 || got[0] != sess.Checkpoints[0] || got[1] != sess.Checkpoints[1] {
+		t.Errorf("squashed checkpoints = %v, want %v", got, sess.Checkpoints)
+	}
+	if files := gitIn(t, dir, "show", "--name-only", "--format=", "HEAD"); files != "feature.go\nhelper.go" {
+		t.Errorf("squashed files = %q", files)
+	}
+	if status := gitIn(t, dir, "status", "--porcelain", "--", "unrelated.txt"); status != "?? unrelated.txt" {
+		t.Errorf("unrelated.txt status = %q, want untouched", status)
+	}
+	if remaining, err := ListSquashSessions(); err != nil || len(remaining) != 0 {
+		t.Errorf("ListSquashSessions() = %+v, %v; want branch deleted", remaining, err)
+	}
+}
+
+func TestSquashCommitStrategy_Rewind(t *testing.T) {
+	_, dir, s := setupSquashCommitRepo(t)
+	saveSquashTurn(t, s, dir, "sess-1", "Add feature", map[string]string{"feature.go": "v1"})
+	saveSquashTurn(t, s, dir, "sess-1", "Change feature", map[string]string{"feature.go": "v2", "helper.go": "h1"})
+
+	points, err := s.GetRewindPoints(10)
+	if err != nil {
+		t.Fatalf("GetRewindPoints() error = %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("GetRewindPoints() = %d points, want 2", len(points))
+	}
+	first := points[1]
+	if first.Message != "Add feature" || first.SessionID != "sess-1" || first.CheckpointID.IsEmpty() {
+		t.Fatalf("oldest point = %+v", first)
+	}
+
+	if err := s.Rewind(first); err != nil {
+		t.Fatalf("Rewind() error = %v", err)
+	}
+	if got := readFile(t, dir, "feature.go"); got != "v1" {
+		t.Errorf("feature.go = %q, want v1", got)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "helper.go")); !os.IsNotExist(err) {
+		t.Errorf("helper.go from a later turn was not removed: %v", err)
+	}
+}
+
+func TestSquashCommitStrategy_ListOrphanedItems(t *testing.T) {
+	_, dir, s := setupSquashCommitRepo(t)
+	saveSquashTurn(t, s, dir, "sess-1", "Add feature", map[string]string{"feature.go": "v1"})
+
+	items, err := s.ListOrphanedItems()
+	if err != nil || len(items) != 0 {
+		t.Fatalf("ListOrphanedItems() = %+v, %v; want checkpoints on the squash branch kept", items, err)
+	}
+
+	gitIn(t, dir, "branch", "-D", SquashBranchName("sess-1"))
+	items, err = s.ListOrphanedItems()
+	if err != nil || len(items) != 1 || items[0].Type != CleanupTypeCheckpoint {
+		t.Errorf("ListOrphanedItems() = %+v, %v; want the abandoned checkpoint", items, err)
+	}
+}
+
+func TestSquashCommitStrategy_ListOrphanedItems_StaleBranches(t *testing.T) {
+	_, dir, s := setupSquashCommitRepo(t)
+	squashBranches := func() []string {
+		t.Helper()
+		items, err := s.ListOrphanedItems()
+		if err != nil {
+			t.Fatalf("ListOrphanedItems() error = %v", err)
+		}
+		var branches []string
+		for _, item := range items {
+			if item.Type == CleanupTypeSquashBranch {
+				branches = append(branches, item.ID)
+			}
+		}
+		return branches
+	}
+
+	// Landed with --keep while the session is still active
+	saveSquashTurn(t, s, dir, "sess-1", "Add feature", map[string]string{"feature.go": "v1"})
+	if err := SaveSessionState(&SessionState{SessionID: "sess-1", Phase: session.PhaseActive}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	sess, err := FindSquashSession("sess-1")
+	if err != nil {
+		t.Fatalf("FindSquashSession() error = %v", err)
+	}
+	if _, err := LandSquashSession(sess, LandOptions{Keep: true, NoSummary: true}); err != nil {
+		t.Fatalf("LandSquashSession() error = %v", err)
+	}
+	if branches := squashBranches(); len(branches) != 0 {
+		t.Errorf("squash branches = %v, want the active session's branch kept", branches)
+	}
+
+	// Once the session ends, its landed branch is stale
+	if err := SaveSessionState(&SessionState{SessionID: "sess-1", Phase: session.PhaseEnded}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	if branches := squashBranches(); len(branches) != 1 || branches[0] != SquashBranchName("sess-1") {
+		t.Errorf("squash branches = %v, want the landed session's branch", branches)
+	}
+
+	// Unlanded turns keep a session without state
+	saveSquashTurn(t, s, dir, "sess-2", "Add helper", map[string]string{"helper.go": "h1"})
+	if branches := squashBranches(); len(branches) != 1 {
+		t.Errorf("squash branches = %v, want the unlanded session's branch kept", branches)
+	}
+
+	// A branch with no turns above HEAD has nothing left to land
+	gitIn(t, dir, "reset", "--soft", SquashBranchName("sess-2"))
+	if branches := squashBranches(); len(branches) != 2 {
+		t.Fatalf("squash branches = %v, want both sessions' branches", branches)
+	}
+
+	result, err := DeleteAllCleanupItems([]CleanupItem{
+		{Type: CleanupTypeSquashBranch, ID: SquashBranchName("sess-1")},
+		{Type: CleanupTypeSquashBranch, ID: SquashBranchName("sess-2")},
+	})
+	if err != nil {
+		t.Fatalf("DeleteAllCleanupItems() error = %v", err)
+	}
+	if len(result.SquashBranches) != 2 || len(result.FailedSquashBranches) != 0 {
+		t.Errorf("DeleteAllCleanupItems() = %+v, want both squash branches deleted", result)
+	}
+	if remaining, err := ListSquashSessions(); err != nil || len(remaining) != 0 {
+		t.Errorf("ListSquashSessions() = %+v, %v; want no squash branches left", remaining, err)
+	}
+}
@@ -92,6 +92,18 @@ type RewindPoint struct {
 	// ToolUseID is the tool use ID for task checkpoints (empty for session checkpoints)
 	ToolUseID string
 
+	// TurnID identifies the agent turn that created this checkpoint.
+	// Set for shadow checkpoints written since turns were recorded; used to
+	// group fine-grained checkpoints under their turn.
+	TurnID string
+
+	// IsToolCheckpoint indicates a fine-grained checkpoint taken after a
+	// file-editing tool call, in the middle of a turn
+	IsToolCheckpoint bool
+
+	// ToolName is the file-editing tool for fine-grained checkpoints
+	ToolName string
+
 	// IsLogsOnly indicates this is a commit with session logs but no shadow branch state.
 	// The logs can be restored from entire/checkpoints/v1, but file state requires git checkout.
 	IsLogsOnly bool
@@ -141,6 +153,10 @@ type RewindPreview struct {
 	// TrackedChanges are tracked files with uncommitted changes that will be reverted.
 	// These come from the existing CanRewind() warning.
 	TrackedChanges []string
+
+	// Diffs are unified diffs from the current working tree to the checkpoint
+	// for every file the rewind changes, capped at MaxPreviewDiffBytes per file.
+	Diffs []FileDiff
 }
 
 // SaveContext contains all information needed for saving changes.
@@ -268,6 +284,40 @@ type TaskCheckpointContext struct {
 	AgentType agent.AgentType
 }
 
+// ToolCheckpointContext contains the information needed to save a
+// fine-grained checkpoint after a file-editing tool call.
+// This is called by the PostToolUse[Write|Edit|MultiEdit] hook (and Gemini
+// CLI's AfterTool hook for its write tools) when fine-grained checkpoints
+// are enabled.
+type ToolCheckpointContext struct {
+	// SessionID is the agent session identifier
+	SessionID string
+
+	// ToolName is the file-editing tool that was called (e.g. "Edit", "write_file")
+	ToolName string
+
+	// EditedFile is the repo-relative file the tool wrote, used in the commit message
+	EditedFile string
+
+	// ModifiedFiles is the list of files modified by the tool call
+	ModifiedFiles []string
+
+	// NewFiles is the list of files created since the turn started
+	NewFiles []string
+
+	// DeletedFiles is the list of files deleted since the turn started
+	DeletedFiles []string
+
+	// AuthorName is the name to use for commits
+	AuthorName string
+
+	// AuthorEmail is the email to use for commits
+	AuthorEmail string
+
+	// AgentType is the human-readable agent name (e.g., "Claude Code", "Cursor")
+	AgentType agent.AgentType
+}
+
 // TaskCheckpoint contains the checkpoint information written to checkpoint.json
 type TaskCheckpoint struct {
 	SessionID      string `json:"session_id"`
@@ -491,6 +541,53 @@ type LogsOnlyRestorer interface {
 	RestoreLogsOnly(point RewindPoint, force bool) ([]RestoredSession, error)
 }
 
+// PathRewindResult describes the files changed by a partial rewind.
+type PathRewindResult struct {
+	// Restored are files written from the checkpoint tree.
+	Restored []string
+
+	// Deleted are files removed because they don't exist at the checkpoint.
+	Deleted []string
+}
+
+// PathRewinder is an optional interface for strategies that support
+// restoring only some paths from a rewind point.
+// Unlike Rewind, a partial rewind leaves the rest of the working tree, the
+// shadow branch and the session transcript alone, since they still reflect
+// the later state of the session.
+type PathRewinder interface {
+	// ChangedPaths returns the files RewindPaths would change for the point,
+	// i.e. the files whose working tree state differs from the checkpoint.
+	// Used to offer a file picker.
+	ChangedPaths(point RewindPoint) ([]string, error)
+
+	// RewindPaths restores the given repo-relative files and directories from
+	// the rewind point. Files under those paths that don't exist at the
+	// checkpoint are deleted, following the same rules as Rewind.
+	RewindPaths(point RewindPoint, paths []string) (*PathRewindResult, error)
+}
+
+// ToolCheckpointSaver is an optional interface for strategies that can take
+// fine-grained checkpoints after each file-editing tool call, so a long turn
+// can be rewound to its middle. Checkpoints are deduplicated by tree, and are
+// recorded under the current turn so rewind can group them.
+type ToolCheckpointSaver interface {
+	// SaveToolCheckpoint saves the working tree state after a file-editing
+	// tool call. It doesn't count as a step of the session: step counts,
+	// prompt attribution and token usage are still recorded at turn end.
+	SaveToolCheckpoint(ctx ToolCheckpointContext) error
+}
+
+// TurnCommitter is an optional interface for strategies whose SaveChanges
+// commits a checkpoint for every agent turn (auto-commit and squash-commit),
+// instead of accumulating turns until the user commits.
+// Stop hooks use it to advance the session's transcript position and to clear
+// the commands recorded during the turn, which the checkpoint now holds.
+type TurnCommitter interface {
+	// CommitsEachTurn reports whether SaveChanges commits every turn.
+	CommitsEachTurn() bool
+}
+
 // SessionResetter is an optional interface for strategies that support
 // resetting session state and shadow branches.
 // This is used by the "reset" command to clean up shadow branches
@@ -528,6 +625,18 @@ type ConcurrentSessionChecker interface {
 	CountOtherActiveSessionsWithCheckpoints(currentSessionID string) (int, error)
 }
 
+// SessionWorktreeProvider is an optional interface for strategies that run
+// each agent session in its own git worktree.
+// This is used by the prompt hooks to create the worktree and refuse turns in
+// the main checkout, telling the user where to restart the agent.
+type SessionWorktreeProvider interface {
+	// EnsureSessionWorktree creates the worktree and branch for a session if
+	// they don't exist yet. Returns the worktree path and whether this call
+	// created it. Returns an empty path when the current worktree is already a
+	// session worktree, so sessions started inside one reuse it.
+	EnsureSessionWorktree(sessionID string) (path string, created bool, err error)
+}
+
 // SessionSource is an optional interface for strategies that provide additional
 // sessions beyond those stored on the entire/checkpoints/v1 branch.
 // For example, manual-commit strategy provides active sessions from .git/entire-sessions/
@@ -0,0 +1,444 @@
+package strategy
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+)
+
+// sessionWorktreesDir is where the worktree strategy creates session worktrees,
+// relative to the main repository root. It is listed in .entire/.gitignore.
+const sessionWorktreesDir = "worktrees"
+
+// ErrLandConflict is returned by LandSessionWorktree when the merge or
+// cherry-pick stops on conflicts. The operation is left in progress so the
+// user can resolve it.
+var ErrLandConflict = errors.New("conflicts while landing session")
+
+// WorktreeStrategy runs every agent session in a git worktree on an
+// entire/session/<session-id> branch. An agent started in the main checkout
+// gets its first prompt refused with the path of the session's worktree, so
+// the user restarts it there and the session's changes stay apart from other
+// work. Inside the worktree it behaves exactly like manual-commit: checkpoints
+// go to a shadow branch (which is already unique per worktree) and are
+// condensed to entire/checkpoints/v1 when the user commits. When the session
+// is done, `entire land` merges or cherry-picks the session branch back and
+// removes the worktree.
+type WorktreeStrategy struct {
+	*ManualCommitStrategy
+}
+
+// Compile-time check that WorktreeStrategy creates session worktrees
+var _ SessionWorktreeProvider = (*WorktreeStrategy)(nil)
+
+// NewWorktreeStrategy creates a new worktree strategy instance.
+func NewWorktreeStrategy() Strategy { //nolint:ireturn // already present in codebase
+	return &WorktreeStrategy{ManualCommitStrategy: &ManualCommitStrategy{}}
+}
+
+// Name returns the strategy name.
+func (s *WorktreeStrategy) Name() string {
+	return StrategyNameWorktree
+}
+
+// Description returns the strategy description.
+func (s *WorktreeStrategy) Description() string {
+	return "A git worktree per session to run the agent in, checkpointed like manual-commit and landed with 'entire land'"
+}
+
+// ListOrphanedItems returns session worktrees and branches with nothing left
+// to land: the session has ended (or has no state), no other active session
+// is running in the worktree, the branch is contained in HEAD and the
+// worktree, if still there, has no changes. The agent restarted in a worktree
+// gets a new session ID, so the worktree's own session is usually not the one
+// working in it. Shadow branches and session states are shared with
+// manual-commit, which already reports them.
+func (s *WorktreeStrategy) ListOrphanedItems() ([]CleanupItem, error) {
+	ctx := context.Background()
+	current, err := currentBranchCLI(ctx, ".")
+	if err != nil {
+		return nil, err
+	}
+	if strings.HasPrefix(current, checkpoint.SessionBranchPrefix) {
+		// HEAD is a session branch here, so every session looks landed
+		return nil, nil
+	}
+
+	worktrees, err := ListSessionWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	if len(worktrees) == 0 {
+		return nil, nil
+	}
+	states, err := ListSessionStates()
+	if err != nil {
+		return nil, err
+	}
+	inUse := make(map[string]bool)
+	for _, state := range states {
+		if state.Phase != session.PhaseEnded && state.WorktreePath != "" {
+			inUse[filepath.Clean(state.WorktreePath)] = true
+		}
+	}
+
+	var items []CleanupItem
+	for _, sw := range worktrees {
+		if state, err := LoadSessionState(sw.SessionID); err != nil || (state != nil && state.Phase != session.PhaseEnded) {
+			continue
+		}
+		if sw.Path != "" && inUse[filepath.Clean(sw.Path)] {
+			continue
+		}
+		//nolint:gosec // branch comes from ListSessionWorktrees
+		if exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", sw.Branch, "HEAD").Run() != nil {
+			continue
+		}
+		reason := "session branch already in HEAD, worktree removed"
+		if sw.Path != "" {
+			info, err := os.Stat(sw.Path)
+			if err != nil || time.Since(info.ModTime()) < sessionGracePeriod {
+				continue
+			}
+			if dirty, err := hasChangesCLI(ctx, sw.Path); err != nil || dirty {
+				continue
+			}
+			reason = "session branch already in HEAD, worktree unchanged"
+		}
+		items = append(items, CleanupItem{
+			Type:   CleanupTypeSessionWorktree,
+			ID:     sw.Branch,
+			Reason: reason,
+		})
+	}
+	return items, nil
+}
+
+// DeleteSessionWorktrees removes the worktrees and branches of the given
+// session branches.
+func DeleteSessionWorktrees(branches []string) (deleted []string, failed []string, err error) {
+	if len(branches) == 0 {
+		return []string{}, []string{}, nil
+	}
+	worktrees, err := ListSessionWorktrees()
+	if err != nil {
+		return nil, nil, err
+	}
+	byBranch := make(map[string]SessionWorktree, len(worktrees))
+	for _, sw := range worktrees {
+		byBranch[sw.Branch] = sw
+	}
+	for _, branch := range branches {
+		sw, ok := byBranch[branch]
+		if !ok || RemoveSessionWorktree(&sw) != nil {
+			failed = append(failed, branch)
+			continue
+		}
+		deleted = append(deleted, branch)
+	}
+	return deleted, failed, nil
+}
+
+// GetAdditionalSessions returns nothing: active sessions are stored the same
+// way as manual-commit's, which already provides them.
+func (s *WorktreeStrategy) GetAdditionalSessions() ([]*Session, error) {
+	return nil, nil
+}
+
+// EnsureSessionWorktree creates .entire/worktrees/<session-id> in the main
+// repository on a new entire/session/<session-id> branch at HEAD.
+// If the session already has a worktree, its path is returned unchanged, so a
+// resumed session gets the worktree it started with.
+func (s *WorktreeStrategy) EnsureSessionWorktree(sessionID string) (string, bool, error) {
+	if sessionID == "" {
+		return "", false, errors.New("session ID is required")
+	}
+
+	ctx := context.Background()
+	current, err := currentBranchCLI(ctx, ".")
+	if err != nil {
+		return "", false, err
+	}
+	if strings.HasPrefix(current, checkpoint.SessionBranchPrefix) {
+		return "", false, nil
+	}
+
+	existing, err := ListSessionWorktrees()
+	if err != nil {
+		return "", false, err
+	}
+	branch := SessionBranchName(sessionID)
+	for _, sw := range existing {
+		if sw.Branch == branch && sw.Path != "" {
+			return sw.Path, false, nil
+		}
+	}
+
+	if err := EnsureEntireGitignore(); err != nil {
+		return "", false, err
+	}
+	mainRoot, err := GetMainRepoRoot()
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get main repository root: %w", err)
+	}
+	worktreePath := filepath.Join(mainRoot, paths.EntireDir, sessionWorktreesDir, sessionID)
+	if err := os.MkdirAll(filepath.Dir(worktreePath), 0o750); err != nil {
+		return "", false, fmt.Errorf("failed to create worktrees directory: %w", err)
+	}
+
+	// Reuse the branch if a previous worktree was removed by hand
+	args := []string{"worktree", "add", "-b", branch, "--", worktreePath, "HEAD"}
+	if branchExistsCLI(branch) == nil {
+		args = []string{"worktree", "add", "--", worktreePath, branch}
+	}
+	cmd := exec.CommandContext(ctx, "git", args...)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", false, fmt.Errorf("failed to create session worktree: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+	return worktreePath, true, nil
+}
+
+// SessionBranchName returns the worktree branch for a session.
+func SessionBranchName(sessionID string) string {
+	return checkpoint.SessionBranchPrefix + sessionID
+}
+
+// SessionWorktree is a session branch created by the worktree strategy.
+type SessionWorktree struct {
+	// SessionID is the session that created the worktree
+	SessionID string `json:"session_id"`
+
+	// Branch is the entire/session/<session-id> branch
+	Branch string `json:"branch"`
+
+	// Path is the worktree directory (empty if the worktree was removed
+	// but the branch is still there)
+	Path string `json:"path,omitempty"`
+}
+
+// ListSessionWorktrees returns all session branches, sorted by session ID,
+// with the path of the worktree that has each one checked out.
+func ListSessionWorktrees() ([]SessionWorktree, error) {
+	ctx := context.Background()
+	refs, err := exec.CommandContext(ctx, "git", "for-each-ref", "--format=%(refname:short)",
+		"refs/heads/"+checkpoint.SessionBranchPrefix).Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list session branches: %w", err)
+	}
+
+	worktreePaths, err := worktreePathsByBranch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []SessionWorktree
+	for _, branch := range strings.Fields(string(refs)) {
+		result = append(result, SessionWorktree{
+			SessionID: strings.TrimPrefix(branch, checkpoint.SessionBranchPrefix),
+			Branch:    branch,
+			Path:      worktreePaths[branch],
+		})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].SessionID < result[j].SessionID })
+	return result, nil
+}
+
+// FindSessionWorktree resolves a session ID, session ID prefix or branch name
+// to a session branch.
+func FindSessionWorktree(query string) (*SessionWorktree, error) {
+	all, err := ListSessionWorktrees()
+	if err != nil {
+		return nil, err
+	}
+	query = strings.TrimPrefix(query, checkpoint.SessionBranchPrefix)
+
+	var matches []SessionWorktree
+	for _, sw := range all {
+		if sw.SessionID == query {
+			return &sw, nil
+		}
+		if strings.HasPrefix(sw.SessionID, query) {
+			matches = append(matches, sw)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("no session worktree matches %q", query)
+	case 1:
+		return &matches[0], nil
+	default:
+		ids := make([]string, len(matches))
+		for i, sw := range matches {
+			ids[i] = sw.SessionID
+		}
+		return nil, fmt.Errorf("session %q is ambiguous: %s", query, strings.Join(ids, ", "))
+	}
+}
+
+// LandOptions controls how LandSessionWorktree and LandSquashSession bring a
+// session back.
+type LandOptions struct {
+	// CherryPick replays the session's commits instead of merging the branch
+	CherryPick bool
+
+	// Keep leaves the worktree and branch in place after landing
+	Keep bool
+
+	// NoSummary uses the turns' prompts for a squashed commit's message
+	// instead of generating a summary (squash-commit sessions only)
+	NoSummary bool
+}
+
+// LandSessionWorktree merges (or cherry-picks) a session branch into the
+// current branch, then removes the session's worktree and branch.
+// Commits keep their Entire-Checkpoint trailers either way, so the session
+// history stays linked. Returns whether anything was applied; a branch that
+// is already contained in HEAD is just cleaned up.
+func LandSessionWorktree(sw *SessionWorktree, opts LandOptions) (bool, error) {
+	ctx := context.Background()
+
+	current, err := currentBranchCLI(ctx, ".")
+	if err != nil {
+		return false, err
+	}
+	if current == sw.Branch || strings.HasPrefix(current, checkpoint.SessionBranchPrefix) {
+		return false, errors.New("cannot land from inside a session worktree; run this from the worktree you want to land into")
+	}
+	dirty, err := hasTrackedChangesCLI(ctx, ".")
+	if err != nil {
+		return false, err
+	}
+	if dirty {
+		return false, errors.New("you have uncommitted changes; commit or stash them first")
+	}
+	if sw.Path != "" {
+		sessionDirty, err := hasChangesCLI(ctx, sw.Path)
+		if err != nil {
+			return false, err
+		}
+		if sessionDirty {
+			return false, fmt.Errorf("session worktree has uncommitted changes; commit them in %s first", sw.Path)
+		}
+	}
+
+	applied := false
+	//nolint:gosec // branch comes from ListSessionWorktrees
+	if exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", sw.Branch, "HEAD").Run() != nil {
+		var args []string
+		if opts.CherryPick {
+			args = []string{"cherry-pick", "HEAD.." + sw.Branch}
+		} else {
+			args = []string{"merge", "--no-ff", "-m", "Land session " + sw.SessionID, sw.Branch}
+		}
+		cmd := exec.CommandContext(ctx, "git", args...) //nolint:gosec // branch comes from ListSessionWorktrees
+		if output, err := cmd.CombinedOutput(); err != nil {
+			if hasUnmergedPathsCLI(ctx) {
+				return false, fmt.Errorf("%w: resolve them and commit, or run 'git %s --abort'", ErrLandConflict, args[0])
+			}
+			return false, fmt.Errorf("git %s failed: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+		}
+		applied = true
+	}
+
+	if opts.Keep {
+		return applied, nil
+	}
+	if err := RemoveSessionWorktree(sw); err != nil {
+		return applied, err
+	}
+	return applied, nil
+}
+
+// RemoveSessionWorktree removes a session's worktree (if any) and branch.
+func RemoveSessionWorktree(sw *SessionWorktree) error {
+	ctx := context.Background()
+	if sw.Path != "" {
+		cmd := exec.CommandContext(ctx, "git", "worktree", "remove", "--", sw.Path)
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("failed to remove worktree %s: %s: %w", sw.Path, strings.TrimSpace(string(output)), err)
+		}
+	}
+	if err := DeleteBranchCLI(sw.Branch); err != nil && !errors.Is(err, ErrBranchNotFound) {
+		return err
+	}
+	return nil
+}
+
+// worktreePathsByBranch maps each checked-out branch to its worktree path,
+// from `git worktree list --porcelain`.
+func worktreePathsByBranch(ctx context.Context) (map[string]string, error) {
+	output, err := exec.CommandContext(ctx, "git", "worktree", "list", "--porcelain").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list worktrees: %w", err)
+	}
+
+	result := make(map[string]string)
+	var path string
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "worktree "):
+			path = strings.TrimPrefix(line, "worktree ")
+		case strings.HasPrefix(line, "branch "):
+			result[strings.TrimPrefix(strings.TrimPrefix(line, "branch "), "refs/heads/")] = path
+		}
+	}
+	return result, nil
+}
+
+// currentBranchCLI returns the branch checked out in dir, or empty if HEAD is detached.
+func currentBranchCLI(ctx context.Context, dir string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", "branch", "--show-current")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to get current branch: %w", err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// hasChangesCLI reports whether the worktree at dir has modified or untracked files.
+func hasChangesCLI(ctx context.Context, dir string) (bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %w", dir, err)
+	}
+	return len(bytes.TrimSpace(output)) > 0, nil
+}
+
+// hasTrackedChangesCLI reports whether the worktree at dir has modified tracked files.
+func hasTrackedChangesCLI(ctx context.Context, dir string) (bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "status", "--porcelain", "--untracked-files=no")
+	cmd.Dir = dir
+	output, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("failed to get status of %s: %w", dir, err)
+	}
+	return len(bytes.TrimSpace(output)) > 0, nil
+}
+
+// hasUnmergedPathsCLI reports whether the current worktree has conflicted files.
+func hasUnmergedPathsCLI(ctx context.Context) bool {
+	output, err := exec.CommandContext(ctx, "git", "diff", "--name-only", "--diff-filter=U").Output()
+	return err == nil && len(bytes.TrimSpace(output)) > 0
+}
+
+//nolint:gochecknoinits // Standard pattern for strategy registration
+func init() {
+	Register(StrategyNameWorktree, NewWorktreeStrategy)
+}
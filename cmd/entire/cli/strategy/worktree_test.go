@@ -0,0 +1,287 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/session"
+)
+
+// setupWorktreeStrategyRepo creates a repo with a session worktree and
+// returns the main repo dir and the session worktree.
+func setupWorktreeStrategyRepo(t *testing.T, sessionID string) (string, *SessionWorktree) {
+	t.Helper()
+	_, dir := setupPathRewindRepo(t)
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@test.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@test.com")
+
+	s, ok := NewWorktreeStrategy().(*WorktreeStrategy)
+	if !ok {
+		t.Fatal("NewWorktreeStrategy() did not return *WorktreeStrategy")
+	}
+	path, created, err := s.EnsureSessionWorktree(sessionID)
+	if err != nil {
+		t.Fatalf("EnsureSessionWorktree() error = %v", err)
+	}
+	if !created {
+		t.Fatal("EnsureSessionWorktree() created = false on first call")
+	}
+	sw, err := FindSessionWorktree(sessionID)
+	if err != nil {
+		t.Fatalf("FindSessionWorktree() error = %v", err)
+	}
+	if sw.Path != path {
+		t.Fatalf("session worktree path = %q, want %q", sw.Path, path)
+	}
+	return dir, sw
+}
+
+// gitIn runs a git command in dir and fails the test on error.
+func gitIn(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.CommandContext(context.Background(), "git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v failed: %v\n%s", args, err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// commitIn writes a file in dir and commits it with the git CLI.
+func commitIn(t *testing.T, dir, name, content, message string) {
+	t.Helper()
+	writeFiles(t, dir, map[string]string{name: content})
+	gitIn(t, dir, "add", "--", name)
+	gitIn(t, dir, "commit", "-m", message)
+}
+
+func TestWorktreeStrategy_Registered(t *testing.T) {
+	t.Parallel()
+
+	s, err := Get(StrategyNameWorktree)
+	if err != nil {
+		t.Fatalf("Get(%q) error = %v", StrategyNameWorktree, err)
+	}
+	if s.Name() != StrategyNameWorktree {
+		t.Errorf("Name() = %q", s.Name())
+	}
+	if _, ok := s.(SessionWorktreeProvider); !ok {
+		t.Error("worktree strategy does not implement SessionWorktreeProvider")
+	}
+}
+
+func TestEnsureSessionWorktree(t *testing.T) {
+	dir, sw := setupWorktreeStrategyRepo(t, "abc-123")
+
+	if want := filepath.Join(dir, ".entire", "worktrees", "abc-123"); sw.Path != want {
+		t.Errorf("worktree path = %q, want %q", sw.Path, want)
+	}
+	if sw.Branch != "entire/session/abc-123" {
+		t.Errorf("branch = %q", sw.Branch)
+	}
+	if got := gitIn(t, sw.Path, "branch", "--show-current"); got != sw.Branch {
+		t.Errorf("worktree checked out %q, want %q", got, sw.Branch)
+	}
+	if data, err := os.ReadFile(filepath.Join(dir, ".entire", ".gitignore")); err != nil || !strings.Contains(string(data), "worktrees/") {
+		t.Errorf(".entire/.gitignore = %q, %v; want worktrees/ entry", data, err)
+	}
+
+	s := &WorktreeStrategy{ManualCommitStrategy: &ManualCommitStrategy{}}
+	path, created, err := s.EnsureSessionWorktree("abc-123")
+	if err != nil || created || path != sw.Path {
+		t.Errorf("second EnsureSessionWorktree() = %q, %v, %v; want existing path", path, created, err)
+	}
+
+	// Sessions started inside a session worktree reuse it
+	t.Chdir(sw.Path)
+	path, created, err = s.EnsureSessionWorktree("other")
+	if err != nil || created || path != "" {
+		t.Errorf("EnsureSessionWorktree() inside worktree = %q, %v, %v; want no-op", path, created, err)
+	}
+}
+
+func TestListTemporary_SkipsSessionBranches(t *testing.T) {
+	repo, dir := setupPathRewindRepo(t)
+	gitIn(t, dir, "branch", SessionBranchName("s1"))
+
+	infos, err := checkpoint.NewGitStore(repo).ListTemporary(context.Background())
+	if err != nil {
+		t.Fatalf("ListTemporary() error = %v", err)
+	}
+	if len(infos) != 0 {
+		t.Errorf("ListTemporary() = %+v, want session branches skipped", infos)
+	}
+}
+
+func TestFindSessionWorktree(t *testing.T) {
+	_, _ = setupPathRewindRepo(t)
+	for _, name := range []string{"abc-1", "abc-2", "def"} {
+		gitIn(t, ".", "branch", SessionBranchName(name))
+	}
+
+	if sw, err := FindSessionWorktree("de"); err != nil || sw.SessionID != "def" || sw.Path != "" {
+		t.Errorf("FindSessionWorktree(de) = %+v, %v", sw, err)
+	}
+	if sw, err := FindSessionWorktree("entire/session/abc-1"); err != nil || sw.SessionID != "abc-1" {
+		t.Errorf("FindSessionWorktree(branch) = %+v, %v", sw, err)
+	}
+	if _, err := FindSessionWorktree("abc"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("FindSessionWorktree(abc) error = %v, want ambiguous", err)
+	}
+	if _, err := FindSessionWorktree("zzz"); err == nil {
+		t.Error("FindSessionWorktree(zzz) succeeded, want error")
+	}
+}
+
+func TestLandSessionWorktree_Merge(t *testing.T) {
+	dir, sw := setupWorktreeStrategyRepo(t, "s1")
+	commitIn(t, sw.Path, "feature.go", "package feature", "Add feature\n\nEntire-Checkpoint: a1b2c3d4e5f6")
+
+	applied, err := LandSessionWorktree(sw, LandOptions{})
+	if err != nil {
+		t.Fatalf("LandSessionWorktree() error = %v", err)
+	}
+	if !applied {
+		t.Error("applied = false, want true")
+	}
+	if got := readFile(t, dir, "feature.go"); got != "package feature" {
+		t.Errorf("feature.go = %q", got)
+	}
+	if got := gitIn(t, dir, "log", "-1", "--format=%s"); got != "Land session s1" {
+		t.Errorf("HEAD subject = %q, want merge commit", got)
+	}
+	if _, err := os.Stat(sw.Path); !os.IsNotExist(err) {
+		t.Errorf("worktree still exists: %v", err)
+	}
+	if remaining, err := ListSessionWorktrees(); err != nil || len(remaining) != 0 {
+		t.Errorf("ListSessionWorktrees() = %+v, %v; want branch deleted", remaining, err)
+	}
+}
+
+func TestLandSessionWorktree_CherryPickKeep(t *testing.T) {
+	dir, sw := setupWorktreeStrategyRepo(t, "s1")
+	commitIn(t, sw.Path, "feature.go", "package feature", "Add feature\n\nEntire-Checkpoint: a1b2c3d4e5f6")
+
+	if _, err := LandSessionWorktree(sw, LandOptions{CherryPick: true, Keep: true}); err != nil {
+		t.Fatalf("LandSessionWorktree() error = %v", err)
+	}
+	if got := gitIn(t, dir, "log", "-1", "--format=%B"); !strings.Contains(got, "Entire-Checkpoint: a1b2c3d4e5f6") {
+		t.Errorf("cherry-picked commit lost its trailer: %q", got)
+	}
+	if _, err := os.Stat(sw.Path); err != nil {
+		t.Errorf("worktree removed despite Keep: %v", err)
+	}
+
+	// Landing again finds nothing new and cleans up
+	applied, err := LandSessionWorktree(sw, LandOptions{})
+	if err != nil || applied {
+		t.Errorf("second LandSessionWorktree() = %v, %v; want nothing applied", applied, err)
+	}
+}
+
+func TestLandSessionWorktree_RequiresCleanSessionWorktree(t *testing.T) {
+	_, sw := setupWorktreeStrategyRepo(t, "s1")
+	writeFiles(t, sw.Path, map[string]string{"wip.go": "package wip"})
+
+	if _, err := LandSessionWorktree(sw, LandOptions{}); err == nil || !strings.Contains(err.Error(), "uncommitted changes") {
+		t.Errorf("LandSessionWorktree() error = %v, want uncommitted changes", err)
+	}
+}
+
+func TestLandSessionWorktree_Conflict(t *testing.T) {
+	dir, sw := setupWorktreeStrategyRepo(t, "s1")
+	commitIn(t, sw.Path, "README.md", "session", "Session edit")
+	commitIn(t, dir, "README.md", "main", "Main edit")
+
+	_, err := LandSessionWorktree(sw, LandOptions{})
+	if !errors.Is(err, ErrLandConflict) {
+		t.Fatalf("LandSessionWorktree() error = %v, want ErrLandConflict", err)
+	}
+	if _, statErr := os.Stat(sw.Path); statErr != nil {
+		t.Errorf("worktree removed after conflict: %v", statErr)
+	}
+	gitIn(t, dir, "merge", "--abort")
+}
+
+func TestWorktreeStrategy_ListOrphanedItems(t *testing.T) {
+	_, sw := setupWorktreeStrategyRepo(t, "s1")
+	s := &WorktreeStrategy{ManualCommitStrategy: &ManualCommitStrategy{}}
+
+	listOrphans := func() []CleanupItem {
+		t.Helper()
+		items, err := s.ListOrphanedItems()
+		if err != nil {
+			t.Fatalf("ListOrphanedItems() error = %v", err)
+		}
+		return items
+	}
+
+	// A worktree created moments ago may be waiting for its session to start
+	if items := listOrphans(); len(items) != 0 {
+		t.Errorf("ListOrphanedItems() = %+v, want fresh worktree kept", items)
+	}
+	old := time.Now().Add(-2 * sessionGracePeriod)
+	if err := os.Chtimes(sw.Path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	// Active sessions keep their worktree even if unused
+	if err := SaveSessionState(&SessionState{SessionID: "s1", Phase: session.PhaseActive, StartedAt: old}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	if items := listOrphans(); len(items) != 0 {
+		t.Errorf("ListOrphanedItems() = %+v, want active session's worktree kept", items)
+	}
+
+	// The agent restarted in the worktree runs under a new session ID, which
+	// keeps the worktree after the original session has ended
+	if err := SaveSessionState(&SessionState{SessionID: "s1", Phase: session.PhaseEnded, StartedAt: old}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	if err := SaveSessionState(&SessionState{SessionID: "s2", Phase: session.PhaseIdle, StartedAt: old, WorktreePath: sw.Path}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	if items := listOrphans(); len(items) != 0 {
+		t.Errorf("ListOrphanedItems() = %+v, want worktree in use by another session kept", items)
+	}
+
+	// Once no session is working in it, the unused worktree is orphaned
+	if err := SaveSessionState(&SessionState{SessionID: "s2", Phase: session.PhaseEnded, StartedAt: old, WorktreePath: sw.Path}); err != nil {
+		t.Fatalf("SaveSessionState() error = %v", err)
+	}
+	items := listOrphans()
+	if len(items) != 1 || items[0].Type != CleanupTypeSessionWorktree || items[0].ID != sw.Branch {
+		t.Fatalf("ListOrphanedItems() = %+v, want %s", items, sw.Branch)
+	}
+
+	// Unlanded commits keep it
+	commitIn(t, sw.Path, "feature.go", "package feature", "Add feature")
+	if items := listOrphans(); len(items) != 0 {
+		t.Errorf("ListOrphanedItems() = %+v, want unlanded session kept", items)
+	}
+	gitIn(t, sw.Path, "reset", "--hard", "HEAD~1")
+	if err := os.Chtimes(sw.Path, old, old); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := DeleteAllCleanupItems(listOrphans())
+	if err != nil {
+		t.Fatalf("DeleteAllCleanupItems() error = %v", err)
+	}
+	if len(result.SessionWorktrees) != 1 || len(result.FailedWorktrees) != 0 {
+		t.Errorf("DeleteAllCleanupItems() = %+v, want session worktree deleted", result)
+	}
+	if remaining, err := ListSessionWorktrees(); err != nil || len(remaining) != 0 {
+		t.Errorf("ListSessionWorktrees() = %+v, %v; want none left", remaining, err)
+	}
+}
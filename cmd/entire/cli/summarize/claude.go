@@ -29,6 +29,7 @@ Return a JSON object with this exact structure:
 {
   "intent": "What the user was trying to accomplish (1-2 sentences)",
   "outcome": "What was actually achieved (1-2 sentences)",
+  "change_type": "added | changed | fixed | internal",
   "learnings": {
     "repo": ["Codebase-specific patterns, conventions, or gotchas discovered"],
     "code": [{"path": "file/path.go", "line": 42, "end_line": 56, "finding": "What was learned"}],
@@ -43,6 +44,7 @@ Guidelines:
 - Include line numbers for code learnings when the transcript references specific lines
 - Friction should capture both blockers and minor annoyances
 - Open items are things intentionally deferred, not failures
+- change_type is "added" for new functionality, "changed" for changes to existing behavior, "fixed" for bug fixes, and "internal" for refactoring, tests, tooling or docs
 - Empty arrays are fine if a category doesn't apply
 - Return ONLY the JSON object, no markdown formatting or explanation`
 
@@ -268,6 +268,10 @@ func TestBuildSummarizationPrompt(t *testing.T) {
 		t.Error("prompt should contain JSON schema example")
 	}
 
+	if !strings.Contains(prompt, `"change_type"`) {
+		t.Error("prompt should ask for the change type")
+	}
+
 	if !strings.Contains(prompt, "Return ONLY the JSON object") {
 		t.Error("prompt should contain instruction for JSON-only output")
 	}
@@ -11,7 +11,10 @@ import (
 	"github.com/entireio/cli/cmd/entire/cli/agent"
 	"github.com/entireio/cli/cmd/entire/cli/agent/geminicli"
 	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/tracing"
 	"github.com/entireio/cli/cmd/entire/cli/transcript"
+
+	"go.opentelemetry.io/otel/attribute"
 )
 
 // GenerateFromTranscript generates a summary from raw transcript bytes.
@@ -49,7 +52,9 @@ func GenerateFromTranscript(ctx context.Context, transcriptBytes []byte, filesTo
 		generator = &ClaudeGenerator{}
 	}
 
+	ctx, span := tracing.Start(ctx, "summarize.Generate", attribute.Int("transcript_entries", len(condensed)))
 	summary, err := generator.Generate(ctx, input)
+	tracing.End(span, err)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate summary: %w", err)
 	}
@@ -0,0 +1,110 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+)
+
+// AuditLogFileName is the name of the telemetry audit log, kept next to the
+// user settings file.
+const AuditLogFileName = "telemetry.jsonl"
+
+// Audit log results of sending an event.
+const (
+	// AuditResultSent means PostHog accepted the event.
+	AuditResultSent = "sent"
+	// AuditResultFailed means the event could not be delivered; Error says why.
+	AuditResultFailed = "failed"
+)
+
+// AuditEntry is one line of the audit log: the event exactly as it was sent,
+// and whether PostHog accepted it. Entries written before results were
+// recorded have no Result.
+type AuditEntry struct {
+	EventPayload
+
+	Result string `json:"result,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// AuditLogPath returns the telemetry audit log,
+// $XDG_CONFIG_HOME/entire/telemetry.jsonl. Every event is appended to it
+// once the client has flushed it, one JSON AuditEntry per line, so the log
+// shows exactly what was sent and whether it arrived.
+func AuditLogPath() (string, error) {
+	settingsPath, err := settings.UserSettingsPath()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate telemetry audit log: %w", err)
+	}
+	return filepath.Join(filepath.Dir(settingsPath), AuditLogFileName), nil
+}
+
+// openAuditLog opens the audit log for appending. It is opened before the
+// event is sent, so events that can't be recorded are not sent.
+func openAuditLog() (*os.File, error) {
+	path, err := AuditLogPath()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600) //nolint:gosec // path is derived from UserSettingsPath
+	if err != nil {
+		return nil, fmt.Errorf("failed to open telemetry audit log: %w", err)
+	}
+	return f, nil
+}
+
+// writeAuditEntry appends an entry to the audit log opened by openAuditLog
+// and closes it.
+func writeAuditEntry(f *os.File, entry *AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to encode telemetry event: %w", err)
+	}
+	// One write per event, so concurrent senders don't interleave lines
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("failed to write telemetry audit log: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("failed to write telemetry audit log: %w", err)
+	}
+	return nil
+}
+
+// ReadAuditLog returns the entries recorded in the audit log, oldest first.
+// Returns no entries if nothing has been sent yet. Lines that aren't events
+// are skipped.
+func ReadAuditLog() ([]AuditEntry, error) {
+	path, err := AuditLogPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path) //nolint:gosec // path is derived from UserSettingsPath
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read telemetry audit log: %w", err)
+	}
+
+	var events []AuditEntry
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		var event AuditEntry
+		if err := json.Unmarshal(line, &event); err != nil || event.Event == "" {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
@@ -2,11 +2,15 @@ package telemetry
 
 import (
 	"encoding/json"
-	"os"
+	"errors"
+	"fmt"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+
 	"github.com/denisbrodbeck/machineid"
 	"github.com/posthog/posthog-go"
 	"github.com/spf13/cobra"
@@ -87,8 +91,8 @@ func BuildEventPayload(cmd *cobra.Command, strategy, agent string, isEntireEnabl
 // TrackCommandDetached tracks a command execution by spawning a detached subprocess.
 // This returns immediately without blocking the CLI.
 func TrackCommandDetached(cmd *cobra.Command, strategy, agent string, isEntireEnabled bool, version string) {
-	// Check opt-out environment variables
-	if os.Getenv("ENTIRE_TELEMETRY_OPTOUT") != "" {
+	// Check opt-out environment variables (ENTIRE_TELEMETRY_OPTOUT, ENTIRE_TELEMETRY=0, DO_NOT_TRACK)
+	if settings.TelemetryOptOutEnv() != "" {
 		return
 	}
 
@@ -112,25 +116,38 @@ func TrackCommandDetached(cmd *cobra.Command, strategy, agent string, isEntireEn
 
 // SendEvent processes an event payload in the detached subprocess.
 // This is called by the hidden __send_analytics command.
+// The event is recorded in the audit log (see AuditLogPath) with its result
+// once the client has flushed it. It is dropped if the audit log can't be
+// opened.
 func SendEvent(payloadJSON string) {
 	var payload EventPayload
 	if err := json.Unmarshal([]byte(payloadJSON), &payload); err != nil {
 		return
 	}
+	auditLog, err := openAuditLog()
+	if err != nil {
+		return
+	}
+	entry := &AuditEntry{EventPayload: payload}
+	entry.setResult(deliver(&payload))
+	_ = writeAuditEntry(auditLog, entry) //nolint:errcheck // Best effort, the event is already sent
+}
 
+// deliver sends the payload to PostHog and waits for the client to flush it.
+// Returns nil only if PostHog accepted the event.
+func deliver(payload *EventPayload) error {
 	// Create PostHog client - no need for fast timeouts since we're detached
 	// Read API key and endpoint from package-level vars (not passed via argv for security)
+	callback := &deliveryCallback{}
 	client, err := posthog.NewWithConfig(PostHogAPIKey, posthog.Config{
 		Endpoint:     PostHogEndpoint,
 		Logger:       silentLogger{},
 		DisableGeoIP: posthog.Ptr(true),
+		Callback:     callback,
 	})
 	if err != nil {
-		return
+		return fmt.Errorf("failed to create client: %w", err)
 	}
-	defer func() {
-		_ = client.Close()
-	}()
 
 	// Build properties
 	props := posthog.NewProperties()
@@ -138,11 +155,70 @@ func SendEvent(payloadJSON string) {
 		props.Set(k, v)
 	}
 
-	//nolint:errcheck // Best effort telemetry - don't block on result
-	_ = client.Enqueue(posthog.Capture{
+	enqueueErr := client.Enqueue(posthog.Capture{
 		DistinctId: payload.DistinctID,
 		Event:      payload.Event,
 		Properties: props,
 		Timestamp:  payload.Timestamp,
 	})
+	// Close flushes the queue and waits for the callback
+	closeErr := client.Close()
+
+	if enqueueErr != nil {
+		return fmt.Errorf("failed to queue event: %w", enqueueErr)
+	}
+	if err := callback.result(); err != nil {
+		return err
+	}
+	if !callback.delivered() {
+		if closeErr != nil {
+			return fmt.Errorf("failed to flush event: %w", closeErr)
+		}
+		return errors.New("event was not flushed")
+	}
+	return nil
+}
+
+// setResult records the outcome of deliver.
+func (e *AuditEntry) setResult(err error) {
+	if err != nil {
+		e.Result = AuditResultFailed
+		e.Error = err.Error()
+		return
+	}
+	e.Result = AuditResultSent
+}
+
+// deliveryCallback records whether PostHog accepted the queued event.
+type deliveryCallback struct {
+	mu   sync.Mutex
+	sent bool
+	err  error
+}
+
+func (c *deliveryCallback) Success(posthog.APIMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sent = true
+}
+
+func (c *deliveryCallback) Failure(_ posthog.APIMessage, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.err = err
+}
+
+func (c *deliveryCallback) delivered() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.sent
+}
+
+func (c *deliveryCallback) result() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err == nil {
+		return nil
+	}
+	return fmt.Errorf("failed to send event: %w", c.err)
 }
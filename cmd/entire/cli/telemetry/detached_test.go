@@ -2,6 +2,9 @@ package telemetry
 
 import (
 	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -116,3 +119,75 @@ func TestSendEventHandlesInvalidJSON(_ *testing.T) {
 	SendEvent("")
 	SendEvent("{}")
 }
+
+func TestAuditLog_AppendAndRead(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	events, err := ReadAuditLog()
+	if err != nil || len(events) != 0 {
+		t.Fatalf("ReadAuditLog() = %v, %v; want no events", events, err)
+	}
+
+	for _, command := range []string{"entire status", "entire enable"} {
+		entry := &AuditEntry{EventPayload: EventPayload{
+			Event:      "cli_command_executed",
+			DistinctID: "test-machine-id",
+			Properties: map[string]any{"command": command},
+			Timestamp:  time.Date(2026, 1, 28, 12, 0, 0, 0, time.UTC),
+		}}
+		entry.setResult(nil)
+		f, err := openAuditLog()
+		if err != nil {
+			t.Fatalf("openAuditLog() error = %v", err)
+		}
+		if err := writeAuditEntry(f, entry); err != nil {
+			t.Fatalf("writeAuditEntry() error = %v", err)
+		}
+	}
+
+	events, err = ReadAuditLog()
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ReadAuditLog() returned %d events, want 2", len(events))
+	}
+	if events[0].Properties["command"] != "entire status" || events[1].Properties["command"] != "entire enable" {
+		t.Errorf("events out of order: %+v", events)
+	}
+	if events[0].Result != AuditResultSent {
+		t.Errorf("Result = %q, want %q", events[0].Result, AuditResultSent)
+	}
+}
+
+func TestSendEvent_RecordsResult(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	var status atomic.Int32
+	status.Store(http.StatusOK)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(server.Close)
+	previous := PostHogEndpoint
+	PostHogEndpoint = server.URL
+	t.Cleanup(func() { PostHogEndpoint = previous })
+
+	payload := `{"event":"cli_command_executed","distinct_id":"test-machine-id","properties":{"command":"entire status"},"timestamp":"2026-01-28T12:00:00Z"}`
+	SendEvent(payload)
+	status.Store(http.StatusInternalServerError)
+	SendEvent(payload)
+
+	events, err := ReadAuditLog()
+	if err != nil {
+		t.Fatalf("ReadAuditLog() error = %v", err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("ReadAuditLog() returned %d events, want 2", len(events))
+	}
+	if events[0].Result != AuditResultSent || events[0].Error != "" {
+		t.Errorf("accepted event = %+v, want result sent", events[0])
+	}
+	if events[1].Result != AuditResultFailed || events[1].Error == "" {
+		t.Errorf("rejected event = %+v, want result failed with an error", events[1])
+	}
+}
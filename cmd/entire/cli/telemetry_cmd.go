@@ -0,0 +1,97 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+	"github.com/entireio/cli/cmd/entire/cli/telemetry"
+
+	"github.com/spf13/cobra"
+)
+
+func newTelemetryCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "telemetry",
+		Short: "Inspect and turn off anonymous usage analytics",
+		Long: `Inspect and turn off anonymous usage analytics.
+
+Every event is appended to a local audit log once it has been sent, with
+whether it arrived, so 'entire telemetry show' prints exactly what this
+machine sent.
+
+Telemetry is never sent when ` + settings.DoNotTrackEnvVar + ` is set (to anything but 0
+or false), ` + settings.TelemetryEnvVar + `=0 or ` + settings.TelemetryOptOutEnvVar + ` is set. To turn it off
+for every repository on this machine:
+
+  entire telemetry off --global`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	cmd.AddCommand(newTelemetryShowCmd())
+	cmd.AddCommand(newTelemetryOffCmd())
+
+	return cmd
+}
+
+func newTelemetryShowCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "show",
+		Short: "Print the telemetry events sent from this machine",
+		Long: `Print the telemetry events sent from this machine, oldest first, one JSON
+record per line, exactly as they were sent. Each record's "result" is "sent"
+if the event was accepted, or "failed" with the "error" that stopped it.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runTelemetryShow(cmd.OutOrStdout(), cmd.ErrOrStderr())
+		},
+	}
+}
+
+func newTelemetryOffCmd() *cobra.Command {
+	var scope configScope
+
+	cmd := &cobra.Command{
+		Use:   "off",
+		Short: "Turn off anonymous usage analytics",
+		Long: `Turn off anonymous usage analytics by setting telemetry to false.
+
+With --global the opt-out is written to the user settings file and applies to
+every repository, even ones whose settings turn telemetry on.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return runConfigSet(cmd.OutOrStdout(), "telemetry", "false", scope)
+		},
+	}
+	scope.addFlags(cmd)
+	return cmd
+}
+
+// runTelemetryShow writes the audit log records to w and where they come
+// from to errW, so w can be piped to jq.
+func runTelemetryShow(w, errW io.Writer) error {
+	path, err := telemetry.AuditLogPath()
+	if err != nil {
+		return err //nolint:wrapcheck // already wrapped by telemetry
+	}
+	events, err := telemetry.ReadAuditLog()
+	if err != nil {
+		return err //nolint:wrapcheck // already wrapped by telemetry
+	}
+	if len(events) == 0 {
+		fmt.Fprintf(errW, "No telemetry events have been sent (audit log: %s)\n", path)
+		return nil
+	}
+
+	fmt.Fprintf(errW, "%d telemetry event(s) from %s\n", len(events), path)
+	enc := json.NewEncoder(w)
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return fmt.Errorf("failed to write telemetry event: %w", err)
+		}
+	}
+	return nil
+}
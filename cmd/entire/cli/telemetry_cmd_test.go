@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/settings"
+)
+
+func TestRunTelemetryShow(t *testing.T) {
+	configHome := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+
+	var out, errOut bytes.Buffer
+	if err := runTelemetryShow(&out, &errOut); err != nil {
+		t.Fatalf("runTelemetryShow() error = %v", err)
+	}
+	if out.Len() != 0 || !strings.Contains(errOut.String(), "No telemetry events") {
+		t.Errorf("empty log: stdout %q, stderr %q", out.String(), errOut.String())
+	}
+
+	logPath := filepath.Join(configHome, "entire", "telemetry.jsonl")
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	record := `{"event":"cli_command_executed","distinct_id":"id","properties":{"command":"entire status"},"timestamp":"2026-01-28T12:00:00Z","result":"sent"}`
+	if err := os.WriteFile(logPath, []byte(record+"\nnot json\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	out.Reset()
+	errOut.Reset()
+	if err := runTelemetryShow(&out, &errOut); err != nil {
+		t.Fatalf("runTelemetryShow() error = %v", err)
+	}
+	if got := strings.TrimSpace(out.String()); got != record {
+		t.Errorf("stdout = %q, want %q", got, record)
+	}
+	if !strings.Contains(errOut.String(), "1 telemetry event(s)") {
+		t.Errorf("stderr = %q, want the event count", errOut.String())
+	}
+}
+
+func TestTelemetryOff_Global(t *testing.T) {
+	userPath := setupConfigTestRepo(t)
+	writeSettings(t, `{"strategy": "manual-commit", "enabled": true, "telemetry": true}`)
+
+	cmd := newTelemetryOffCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--global"})
+	if err := cmd.Execute(); err != nil {
+		t.Fatalf("telemetry off --global error = %v", err)
+	}
+	if !strings.Contains(out.String(), userPath) {
+		t.Errorf("output = %q, want it to name %s", out.String(), userPath)
+	}
+
+	s, sources, err := settings.LoadWithSources()
+	if err != nil {
+		t.Fatalf("LoadWithSources() error = %v", err)
+	}
+	if s.Telemetry == nil || *s.Telemetry || sources.Source("telemetry") != settings.SourceUser {
+		t.Errorf("telemetry = %v from %q, want the global opt-out", s.Telemetry, sources.Source("telemetry"))
+	}
+}
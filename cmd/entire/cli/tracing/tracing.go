@@ -0,0 +1,192 @@
+// Package tracing exports OpenTelemetry traces of hook execution over
+// OTLP/HTTP. Tracing is off unless a collector endpoint is configured; until
+// Init is called with one, spans are no-ops.
+//
+// Usage:
+//
+//	shutdown, err := tracing.Init(endpoint)
+//	if err != nil {
+//	    // handle error
+//	}
+//	defer shutdown()
+//
+//	ctx, span := tracing.StartHook(ctx, "stop")
+//	err := handle(ctx)
+//	tracing.End(span, err)
+//
+// Each hook runs in its own short-lived process, and much of the strategy
+// layer builds contexts from context.Background(). Spans started from a
+// context without a span are therefore parented to the current hook span, so
+// one hook invocation exports as a single trace. WithParent narrows that
+// fallback to a nested span for the length of a call.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/entireio/cli/cmd/entire/cli/buildinfo"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// tracerName is the instrumentation scope of all Entire spans.
+const tracerName = "github.com/entireio/cli"
+
+// tracesPath is appended to endpoints given without a path, following the
+// OTLP/HTTP convention.
+const tracesPath = "/v1/traces"
+
+// exportTimeout bounds each export and the final flush. Hooks run on the
+// agent's critical path, so an unreachable collector must not stall them.
+const exportTimeout = 2 * time.Second
+
+var (
+	// tracer creates spans; a no-op tracer until Init configures an exporter
+	tracer trace.Tracer = noop.NewTracerProvider().Tracer(tracerName)
+
+	// hookCtx carries the current hook span, the parent of spans started
+	// from contexts without one
+	hookCtx context.Context
+
+	// mu protects tracer and hookCtx
+	mu sync.RWMutex
+)
+
+// Init starts exporting spans to an OTLP/HTTP collector at endpoint, e.g.
+// http://localhost:4318. An empty endpoint leaves tracing off. The returned
+// function flushes pending spans and must be called before the process exits.
+func Init(endpoint string) (func(), error) {
+	if endpoint == "" {
+		return func() {}, nil
+	}
+	endpointURL, err := tracesURL(endpoint)
+	if err != nil {
+		return func() {}, err
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(endpointURL),
+		otlptracehttp.WithTimeout(exportTimeout),
+		otlptracehttp.WithRetry(otlptracehttp.RetryConfig{Enabled: false}),
+	)
+	if err != nil {
+		return func() {}, fmt.Errorf("failed to create trace exporter: %w", err)
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", "entire"),
+			attribute.String("service.version", buildinfo.Version),
+		)),
+	)
+
+	mu.Lock()
+	tracer = provider.Tracer(tracerName)
+	mu.Unlock()
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+		_ = provider.Shutdown(ctx) //nolint:errcheck // best effort, tracing must never fail a hook
+
+		mu.Lock()
+		tracer = noop.NewTracerProvider().Tracer(tracerName)
+		hookCtx = nil
+		mu.Unlock()
+	}, nil
+}
+
+// tracesURL returns the traces URL for a collector endpoint, appending
+// /v1/traces when the endpoint has no path.
+func tracesURL(endpoint string) (string, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return "", fmt.Errorf("invalid tracing endpoint %q (expected a URL like http://localhost:4318)", endpoint)
+	}
+	if strings.Trim(u.Path, "/") == "" {
+		u.Path = tracesPath
+	}
+	return u.String(), nil
+}
+
+// StartHook starts the span for a hook invocation. Spans started later from
+// contexts without a span become its children until it ends.
+func StartHook(ctx context.Context, hookName string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	ctx, span := Start(ctx, "hook "+hookName, append([]attribute.KeyValue{attribute.String("hook", hookName)}, attrs...)...)
+
+	mu.Lock()
+	hookCtx = ctx
+	mu.Unlock()
+	return ctx, span
+}
+
+// Start starts a span with the given name. The span carries the logging
+// attributes of ctx (session, tool call, component, agent) in addition to
+// attrs. If ctx has no span, the span is parented to the current hook span.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	mu.RLock()
+	t, parent := tracer, hookCtx
+	mu.RUnlock()
+
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if !trace.SpanContextFromContext(ctx).IsValid() && parent != nil {
+		ctx = trace.ContextWithSpan(ctx, trace.SpanFromContext(parent))
+	}
+
+	logAttrs := logging.Attrs(ctx)
+	all := make([]attribute.KeyValue, 0, len(logAttrs)+len(attrs))
+	for _, a := range logAttrs {
+		all = append(all, attribute.String(a.Key, a.Value.String()))
+	}
+	all = append(all, attrs...)
+
+	return t.Start(ctx, name, trace.WithAttributes(all...))
+}
+
+// WithParent calls fn with the span in ctx as the parent of spans started
+// from contexts without one, for calls that can't take ctx (such as strategy
+// methods). The previous parent is restored when fn returns.
+func WithParent(ctx context.Context, fn func() error) error {
+	mu.Lock()
+	prev := hookCtx
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		hookCtx = ctx
+	}
+	mu.Unlock()
+	defer func() {
+		mu.Lock()
+		hookCtx = prev
+		mu.Unlock()
+	}()
+	return fn()
+}
+
+// End records err on the span, if any, and ends it. Ending the current hook
+// span stops parenting new spans to it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	mu.Lock()
+	if hookCtx != nil && trace.SpanContextFromContext(hookCtx).Equal(span.SpanContext()) {
+		hookCtx = nil
+	}
+	mu.Unlock()
+}
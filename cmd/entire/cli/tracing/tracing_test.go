@@ -0,0 +1,202 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+
+	"go.opentelemetry.io/otel/attribute"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// testCollector is a local OTLP/HTTP collector that records exported spans.
+type testCollector struct {
+	mu    sync.Mutex
+	paths []string
+	spans []*tracepb.Span
+}
+
+func newTestCollector(t *testing.T) (*testCollector, string) {
+	t.Helper()
+	c := &testCollector{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var req coltracepb.ExportTraceServiceRequest
+		if err := proto.Unmarshal(body, &req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		c.mu.Lock()
+		c.paths = append(c.paths, r.URL.Path)
+		for _, rs := range req.GetResourceSpans() {
+			for _, ss := range rs.GetScopeSpans() {
+				c.spans = append(c.spans, ss.GetSpans()...)
+			}
+		}
+		c.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-protobuf")
+	}))
+	t.Cleanup(server.Close)
+	return c, server.URL
+}
+
+func (c *testCollector) span(t *testing.T, name string) *tracepb.Span {
+	t.Helper()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.spans {
+		if s.GetName() == name {
+			return s
+		}
+	}
+	t.Fatalf("span %q not exported, got %d spans", name, len(c.spans))
+	return nil
+}
+
+func spanAttr(s *tracepb.Span, key string) string {
+	for _, kv := range s.GetAttributes() {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
+
+func TestInit_ExportsHookTrace(t *testing.T) {
+	collector, endpoint := newTestCollector(t)
+
+	shutdown, err := Init(endpoint)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	ctx := logging.WithComponent(logging.WithSession(context.Background(), "2026-01-15-session"), "hooks")
+	_, hookSpan := StartHook(ctx, "stop", attribute.String("hook_type", "agent"))
+
+	// Spans started without a span in ctx are parented to the hook span
+	_, childSpan := Start(context.Background(), "checkpoint.WriteCommitted")
+	End(childSpan, errors.New("write failed"))
+	End(hookSpan, nil)
+
+	// After the hook span ends, new spans start their own trace
+	_, orphan := Start(context.Background(), "orphan")
+	End(orphan, nil)
+
+	shutdown()
+
+	hook := collector.span(t, "hook stop")
+	if got := spanAttr(hook, "session_id"); got != "2026-01-15-session" {
+		t.Errorf("hook span session_id = %q", got)
+	}
+	if spanAttr(hook, "component") != "hooks" || spanAttr(hook, "hook") != "stop" || spanAttr(hook, "hook_type") != "agent" {
+		t.Errorf("hook span attributes = %v", hook.GetAttributes())
+	}
+
+	child := collector.span(t, "checkpoint.WriteCommitted")
+	if string(child.GetParentSpanId()) != string(hook.GetSpanId()) || string(child.GetTraceId()) != string(hook.GetTraceId()) {
+		t.Error("child span is not parented to the hook span")
+	}
+	if child.GetStatus().GetCode() != tracepb.Status_STATUS_CODE_ERROR || child.GetStatus().GetMessage() != "write failed" {
+		t.Errorf("child span status = %v, want error", child.GetStatus())
+	}
+
+	if orphan := collector.span(t, "orphan"); len(orphan.GetParentSpanId()) != 0 {
+		t.Error("span started after the hook ended should have no parent")
+	}
+
+	collector.mu.Lock()
+	defer collector.mu.Unlock()
+	for _, path := range collector.paths {
+		if path != tracesPath {
+			t.Errorf("exported to %q, want %q", path, tracesPath)
+		}
+	}
+}
+
+func TestWithParent(t *testing.T) {
+	collector, endpoint := newTestCollector(t)
+
+	shutdown, err := Init(endpoint)
+	if err != nil {
+		t.Fatalf("Init() error = %v", err)
+	}
+
+	_, hookSpan := StartHook(context.Background(), "stop")
+	methodCtx, methodSpan := Start(context.Background(), "strategy.SaveChanges")
+	err = WithParent(methodCtx, func() error {
+		// Code that can't take methodCtx still nests under the method span
+		_, inner := Start(context.Background(), "checkpoint.WriteTemporary")
+		End(inner, nil)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithParent() error = %v", err)
+	}
+	End(methodSpan, nil)
+
+	// The hook span is the fallback parent again afterwards
+	_, sibling := Start(context.Background(), "checkpoint.UpdateSummary")
+	End(sibling, nil)
+	End(hookSpan, nil)
+	shutdown()
+
+	hook := collector.span(t, "hook stop")
+	method := collector.span(t, "strategy.SaveChanges")
+	if string(method.GetParentSpanId()) != string(hook.GetSpanId()) {
+		t.Error("method span is not parented to the hook span")
+	}
+	if inner := collector.span(t, "checkpoint.WriteTemporary"); string(inner.GetParentSpanId()) != string(method.GetSpanId()) {
+		t.Error("span started inside WithParent is not parented to the method span")
+	}
+	if sibling := collector.span(t, "checkpoint.UpdateSummary"); string(sibling.GetParentSpanId()) != string(hook.GetSpanId()) {
+		t.Error("span started after WithParent is not parented to the hook span")
+	}
+}
+
+func TestInit_NoEndpoint(t *testing.T) {
+	shutdown, err := Init("")
+	if err != nil {
+		t.Fatalf("Init(\"\") error = %v", err)
+	}
+	defer shutdown()
+
+	_, span := Start(context.Background(), "noop")
+	if span.SpanContext().IsValid() {
+		t.Error("Start() without an endpoint should return a no-op span")
+	}
+	End(span, nil)
+}
+
+func TestTracesURL(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		endpoint string
+		want     string
+		wantErr  bool
+	}{
+		{endpoint: "http://localhost:4318", want: "http://localhost:4318/v1/traces"},
+		{endpoint: "https://collector.example.com/", want: "https://collector.example.com/v1/traces"},
+		{endpoint: "http://localhost:4318/custom/traces", want: "http://localhost:4318/custom/traces"},
+		{endpoint: "localhost:4318", wantErr: true},
+		{endpoint: "ftp://localhost", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := tracesURL(tt.endpoint)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("tracesURL(%q) = %q, %v; want %q, error %v", tt.endpoint, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -48,6 +48,16 @@ const (
 	// AgentTrailerKey identifies the agent that created a checkpoint.
 	// Format: human-readable agent name e.g. "Claude Code", "Cursor"
 	AgentTrailerKey = "Entire-Agent"
+
+	// TurnTrailerKey identifies the agent turn (one prompt and its response)
+	// that created a manual-commit shadow checkpoint.
+	// Format: 12 hex characters e.g. "a3b2c4d5e6f7"
+	TurnTrailerKey = "Entire-Turn"
+
+	// ToolTrailerKey marks a fine-grained shadow checkpoint taken after a
+	// file-editing tool call, and names the tool.
+	// Format: the agent's tool name e.g. "Edit", "write_file"
+	ToolTrailerKey = "Entire-Tool"
 )
 
 // Pre-compiled regexes for trailer parsing.
@@ -60,6 +70,8 @@ var (
 	condensationTrailerRegex = regexp.MustCompile(CondensationTrailerKey + `:\s*(.+)`)
 	sessionTrailerRegex      = regexp.MustCompile(SessionTrailerKey + `:\s*(.+)`)
 	checkpointTrailerRegex   = regexp.MustCompile(CheckpointTrailerKey + `:\s*(` + checkpointID.Pattern + `)(?:\s|$)`)
+	turnTrailerRegex         = regexp.MustCompile(TurnTrailerKey + `:\s*(.+)`)
+	toolTrailerRegex         = regexp.MustCompile(ToolTrailerKey + `:\s*(.+)`)
 )
 
 // ParseStrategy extracts strategy from commit message.
@@ -124,6 +136,26 @@ func ParseSession(commitMessage string) (string, bool) {
 	return "", false
 }
 
+// ParseTurn extracts the turn ID from a shadow commit message.
+// Returns the turn ID and true if found, empty string and false otherwise.
+func ParseTurn(commitMessage string) (string, bool) {
+	matches := turnTrailerRegex.FindStringSubmatch(commitMessage)
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1]), true
+	}
+	return "", false
+}
+
+// ParseTool extracts the tool name from a fine-grained shadow commit message.
+// Returns the tool name and true if found, empty string and false otherwise.
+func ParseTool(commitMessage string) (string, bool) {
+	matches := toolTrailerRegex.FindStringSubmatch(commitMessage)
+	if len(matches) > 1 {
+		return strings.TrimSpace(matches[1]), true
+	}
+	return "", false
+}
+
 // ParseCheckpoint extracts the checkpoint ID from a commit message.
 // Returns the CheckpointID and true if found, empty ID and false otherwise.
 func ParseCheckpoint(commitMessage string) (checkpointID.CheckpointID, bool) {
@@ -138,6 +170,30 @@ func ParseCheckpoint(commitMessage string) (checkpointID.CheckpointID, bool) {
 	return checkpointID.EmptyCheckpointID, false
 }
 
+// ParseAllCheckpoints extracts all checkpoint IDs from a commit message, in order.
+// Duplicate IDs are deduplicated. This is useful for squashed commits that
+// carry one Entire-Checkpoint trailer per squashed checkpoint.
+func ParseAllCheckpoints(commitMessage string) []checkpointID.CheckpointID {
+	matches := checkpointTrailerRegex.FindAllStringSubmatch(commitMessage, -1)
+	if len(matches) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool)
+	ids := make([]checkpointID.CheckpointID, 0, len(matches))
+	for _, match := range matches {
+		idStr := strings.TrimSpace(match[1])
+		if seen[idStr] {
+			continue
+		}
+		if cpID, err := checkpointID.NewCheckpointID(idStr); err == nil {
+			seen[idStr] = true
+			ids = append(ids, cpID)
+		}
+	}
+	return ids
+}
+
 // ParseAllSessions extracts all session IDs from a commit message.
 // Returns a slice of session IDs (may be empty if none found).
 // Duplicate session IDs are deduplicated while preserving order.
@@ -209,6 +265,21 @@ func FormatShadowCommit(message, metadataDir, sessionID string) string {
 	return sb.String()
 }
 
+// AppendShadowTurn adds the Entire-Turn trailer, and for fine-grained
+// checkpoints the Entire-Tool trailer, to a message built by FormatShadowCommit.
+// Empty values are left out.
+func AppendShadowTurn(shadowCommitMessage, turnID, toolName string) string {
+	var sb strings.Builder
+	sb.WriteString(shadowCommitMessage)
+	if turnID != "" {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", TurnTrailerKey, turnID))
+	}
+	if toolName != "" {
+		sb.WriteString(fmt.Sprintf("%s: %s\n", ToolTrailerKey, toolName))
+	}
+	return sb.String()
+}
+
 // FormatShadowTaskCommit creates a commit message for manual-commit task checkpoints.
 // Includes Entire-Metadata-Task, Entire-Session, and Entire-Strategy trailers.
 func FormatShadowTaskCommit(message, taskMetadataDir, sessionID string) string {
@@ -318,3 +318,43 @@ func TestParseCheckpoint(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAllCheckpoints(t *testing.T) {
+	message := "Squashed work\n\n" +
+		"Entire-Checkpoint: a1b2c3d4e5f6\n" +
+		"Entire-Checkpoint: 0123456789ab\n" +
+		"Entire-Checkpoint: a1b2c3d4e5f6\n" +
+		"Entire-Checkpoint: nothex123456\n"
+
+	got := ParseAllCheckpoints(message)
+	if len(got) != 2 || got[0].String() != "a1b2c3d4e5f6" || got[1].String() != "0123456789ab" {
+		t.Errorf("ParseAllCheckpoints() = %v, want [a1b2c3d4e5f6 0123456789ab]", got)
+	}
+	if got := ParseAllCheckpoints("No trailers"); got != nil {
+		t.Errorf("ParseAllCheckpoints() = %v, want nil", got)
+	}
+}
+
+func TestAppendShadowTurn(t *testing.T) {
+	base := FormatShadowCommit("Edit: main.go", ".entire/metadata/sess-1", "sess-1")
+
+	msg := AppendShadowTurn(base, "a3b2c4d5e6f7", "Edit")
+	if turnID, found := ParseTurn(msg); !found || turnID != "a3b2c4d5e6f7" {
+		t.Errorf("ParseTurn() = %q, %v, want %q, true", turnID, found, "a3b2c4d5e6f7")
+	}
+	if tool, found := ParseTool(msg); !found || tool != "Edit" {
+		t.Errorf("ParseTool() = %q, %v, want %q, true", tool, found, "Edit")
+	}
+	if sessionID, found := ParseSession(msg); !found || sessionID != "sess-1" {
+		t.Errorf("ParseSession() = %q, %v, want %q, true", sessionID, found, "sess-1")
+	}
+
+	// Turn-end checkpoints carry the turn but no tool
+	msg = AppendShadowTurn(base, "a3b2c4d5e6f7", "")
+	if _, found := ParseTool(msg); found {
+		t.Error("ParseTool() found a tool on a turn-end checkpoint")
+	}
+	if got := AppendShadowTurn(base, "", ""); got != base {
+		t.Errorf("AppendShadowTurn() with no turn = %q, want %q", got, base)
+	}
+}
@@ -524,8 +524,14 @@ func GetTranscriptPosition(path string) (TranscriptPosition, error) {
 	}
 	defer func() { _ = file.Close() }()
 
+	return transcriptPositionFromReader(file)
+}
+
+// transcriptPositionFromReader returns the last UUID and line count of a
+// transcript read from r.
+func transcriptPositionFromReader(r io.Reader) (TranscriptPosition, error) {
 	var pos TranscriptPosition
-	reader := bufio.NewReader(file)
+	reader := bufio.NewReader(r)
 
 	for {
 		lineBytes, err := reader.ReadBytes('\n')
@@ -0,0 +1,158 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/logging"
+	"github.com/entireio/cli/cmd/entire/cli/paths"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+	"github.com/spf13/cobra"
+)
+
+// errVerificationFailed is returned (silently) when verify finds problems,
+// so the process exits non-zero.
+var errVerificationFailed = errors.New("verification failed")
+
+func newVerifyCmd() *cobra.Command {
+	var checkpointFlags []string
+
+	cmd := &cobra.Command{
+		Use:   "verify [<revision-range>]",
+		Short: "Check the integrity of committed checkpoints",
+		Long: `Verify checks committed checkpoint data on the entire/checkpoints/v1 branch:
+
+  - transcript chunks reassemble and match the stored content_hash.txt
+  - each checkpoint's root metadata.json lists its session directories
+  - checkpoints_count, files_touched and token_usage in the root
+    metadata.json match the sum of the session metadata
+
+With a revision range (anything 'git rev-list' accepts, e.g. main..HEAD),
+verify also checks that every Entire-Checkpoint trailer on those commits
+resolves to an existing checkpoint. If the local metadata branch does not
+exist, origin/entire/checkpoints/v1 is used, so CI jobs only need to fetch it.
+
+Use --checkpoint to limit the data checks to specific checkpoints.
+
+Exits with a non-zero status if any problem is found. Checkpoints pruned with
+'entire prune' have no transcript and are not reported.
+
+Examples:
+  entire verify
+  entire verify origin/main..HEAD
+  entire verify --checkpoint a1b2c3d4e5f6`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if _, err := paths.RepoRoot(); err != nil {
+				return errors.New("not a git repository")
+			}
+
+			checkpointIDs := make([]id.CheckpointID, 0, len(checkpointFlags))
+			for _, raw := range checkpointFlags {
+				cpID, err := id.NewCheckpointID(raw)
+				if err != nil {
+					return fmt.Errorf("invalid checkpoint ID %q: %w", raw, err)
+				}
+				checkpointIDs = append(checkpointIDs, cpID)
+			}
+
+			var revRange string
+			if len(args) > 0 {
+				revRange = args[0]
+			}
+			return runVerify(cmd.OutOrStdout(), revRange, checkpointIDs)
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&checkpointFlags, "checkpoint", nil, "Only verify these checkpoint IDs (repeatable)")
+
+	return cmd
+}
+
+// unresolvedTrailer is a commit whose Entire-Checkpoint trailer has no checkpoint data.
+type unresolvedTrailer struct {
+	commit       string
+	checkpointID id.CheckpointID
+}
+
+func runVerify(w io.Writer, revRange string, checkpointIDs []id.CheckpointID) error {
+	// Initialize logging so structured logs go to .entire/logs/ instead of stderr.
+	// Error is non-fatal: if logging init fails, logs go to stderr (acceptable fallback).
+	logging.SetLogLevelGetter(GetLogLevel)
+	if err := logging.Init(""); err == nil {
+		defer logging.Close()
+	}
+
+	repo, err := openRepository()
+	if err != nil {
+		return err
+	}
+	store := checkpoint.NewGitStore(repo)
+	ctx := context.Background()
+
+	result, err := store.VerifyCommitted(ctx, checkpointIDs)
+	if err != nil {
+		return fmt.Errorf("failed to verify checkpoints: %w", err)
+	}
+	fmt.Fprintf(w, "Checked %d checkpoints, %d sessions (%d transcript hashes verified)\n",
+		result.CheckpointsChecked, result.SessionsChecked, result.HashesVerified)
+
+	var unresolved []unresolvedTrailer
+	if revRange != "" {
+		commits, err := ListCommitsInRange(revRange)
+		if err != nil {
+			return err
+		}
+		trailerCount := 0
+		for _, hash := range commits {
+			commit, err := repo.CommitObject(hash)
+			if err != nil {
+				return fmt.Errorf("failed to read commit %s: %w", hash, err)
+			}
+			// Squashed commits carry one trailer per checkpoint
+			cpIDs := trailers.ParseAllCheckpoints(commit.Message)
+			if len(cpIDs) == 0 {
+				continue
+			}
+			trailerCount++
+			for _, cpID := range cpIDs {
+				summary, err := store.ReadCommitted(ctx, cpID)
+				if err != nil {
+					return fmt.Errorf("failed to read checkpoint %s: %w", cpID, err)
+				}
+				if summary == nil {
+					unresolved = append(unresolved, unresolvedTrailer{commit: hash.String()[:7], checkpointID: cpID})
+				}
+			}
+		}
+		fmt.Fprintf(w, "Checked %d commits in %s (%d with %s trailers)\n",
+			len(commits), revRange, trailerCount, trailers.CheckpointTrailerKey)
+	}
+
+	if len(result.Problems) == 0 && len(unresolved) == 0 {
+		fmt.Fprintln(w, "\n✓ No problems found")
+		return nil
+	}
+
+	fmt.Fprintln(w, "\nProblems:")
+	for _, p := range result.Problems {
+		marker := "✗"
+		if p.Warning {
+			marker = "!"
+		}
+		fmt.Fprintf(w, "  %s %s\n", marker, p)
+	}
+	for _, u := range unresolved {
+		fmt.Fprintf(w, "  ✗ commit %s: %s %s not found on %s\n",
+			u.commit, trailers.CheckpointTrailerKey, u.checkpointID, paths.MetadataBranchName)
+	}
+
+	if result.HasErrors() || len(unresolved) > 0 {
+		return NewSilentError(errVerificationFailed)
+	}
+	return nil
+}
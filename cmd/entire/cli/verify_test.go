@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint"
+	"github.com/entireio/cli/cmd/entire/cli/checkpoint/id"
+	"github.com/entireio/cli/cmd/entire/cli/strategy"
+	"github.com/entireio/cli/cmd/entire/cli/trailers"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// commitWithMessage creates an empty-tree commit on master with the given message.
+func commitWithMessage(t *testing.T, repo *git.Repository, parent plumbing.Hash, message string) plumbing.Hash {
+	t.Helper()
+
+	parentCommit, err := repo.CommitObject(parent)
+	if err != nil {
+		t.Fatalf("failed to get parent commit: %v", err)
+	}
+	sig := object.Signature{Name: "test", Email: "test@test.com"}
+	commit := &object.Commit{
+		TreeHash:     parentCommit.TreeHash,
+		Author:       sig,
+		Committer:    sig,
+		Message:      message,
+		ParentHashes: []plumbing.Hash{parent},
+	}
+	obj := repo.Storer.NewEncodedObject()
+	if err := commit.Encode(obj); err != nil {
+		t.Fatalf("failed to encode commit: %v", err)
+	}
+	hash, err := repo.Storer.SetEncodedObject(obj)
+	if err != nil {
+		t.Fatalf("failed to store commit: %v", err)
+	}
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.NewBranchReferenceName("master"), hash)); err != nil {
+		t.Fatalf("failed to update master: %v", err)
+	}
+	return hash
+}
+
+func writeVerifyCheckpoint(t *testing.T, repo *git.Repository, cpID id.CheckpointID) {
+	t.Helper()
+	err := checkpoint.NewGitStore(repo).WriteCommitted(context.Background(), checkpoint.WriteCommittedOptions{
+		CheckpointID:     cpID,
+		SessionID:        "session-1",
+		Strategy:         strategy.StrategyNameManualCommit,
+		Transcript:       []byte(`{"type":"user","message":{"content":"hello"}}` + "\n"),
+		CheckpointsCount: 1,
+		AuthorName:       "Test",
+		AuthorEmail:      "test@test.com",
+	})
+	if err != nil {
+		t.Fatalf("WriteCommitted() error = %v", err)
+	}
+}
+
+func TestRunVerify_NoCheckpoints(t *testing.T) {
+	setupCleanTestRepo(t)
+
+	var stdout bytes.Buffer
+	if err := runVerify(&stdout, "", nil); err != nil {
+		t.Fatalf("runVerify() error = %v", err)
+	}
+	if !strings.Contains(stdout.String(), "No problems found") {
+		t.Errorf("Expected 'No problems found', got: %s", stdout.String())
+	}
+}
+
+func TestRunVerify_TrailersResolve(t *testing.T) {
+	repo, initial := setupCleanTestRepo(t)
+	cpID := id.MustCheckpointID("a1b2c3d4e5f6")
+	writeVerifyCheckpoint(t, repo, cpID)
+	commitWithMessage(t, repo, initial, trailers.FormatCheckpoint("Add feature", cpID))
+
+	var stdout bytes.Buffer
+	if err := runVerify(&stdout, initial.String()+"..master", nil); err != nil {
+		t.Fatalf("runVerify() error = %v\n%s", err, stdout.String())
+	}
+	output := stdout.String()
+	if !strings.Contains(output, "Checked 1 checkpoints, 1 sessions (1 transcript hashes verified)") {
+		t.Errorf("unexpected checkpoint summary: %s", output)
+	}
+	if !strings.Contains(output, "Checked 1 commits in") {
+		t.Errorf("unexpected commit summary: %s", output)
+	}
+}
+
+func TestRunVerify_UnresolvedTrailerFails(t *testing.T) {
+	repo, initial := setupCleanTestRepo(t)
+	writeVerifyCheckpoint(t, repo, id.MustCheckpointID("a1b2c3d4e5f6"))
+	missing := id.MustCheckpointID("ffffffffffff")
+	commitWithMessage(t, repo, initial, trailers.FormatCheckpoint("Add feature", missing))
+
+	var stdout bytes.Buffer
+	err := runVerify(&stdout, initial.String()+"..master", nil)
+
+	var silentErr *SilentError
+	if !errors.As(err, &silentErr) {
+		t.Fatalf("runVerify() error = %v, want SilentError", err)
+	}
+	if !strings.Contains(stdout.String(), missing.String()+" not found") {
+		t.Errorf("Expected unresolved trailer to be reported, got: %s", stdout.String())
+	}
+}
+
+func TestRunVerify_InvalidRange(t *testing.T) {
+	setupCleanTestRepo(t)
+
+	var stdout bytes.Buffer
+	if err := runVerify(&stdout, "--all", nil); err == nil {
+		t.Error("runVerify() should reject option-like ranges")
+	}
+}